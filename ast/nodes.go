@@ -5,46 +5,68 @@ type Node interface{}
 type Nodes []Node
 
 type ConstantInt struct {
+	Position
+
 	Value int
 }
 
 type ConstantFloat struct {
+	Position
+
 	Value float64
 }
 
 type SimpleString struct {
+	Position
+
 	Value string
 }
 
 type InterpolatedString struct {
+	Position
+
 	Value string
 }
 
 type CharacterLiteral struct {
+	Position
+
 	Value string
 }
 
 type Symbol struct {
+	Position
+
 	Name string
 }
 
 type BareReference struct {
+	Position
+
 	Name string
 }
 
 type CallExpression struct {
+	Position
+
 	Target        Node
 	Func          BareReference
 	Args          []Node
 	OptionalBlock Block
+
+	// set for calls made with `&.`, which are skipped when the target is nil
+	SafeNavigation bool
 }
 
 type FuncDecl struct {
+	Position
+
 	Target  Node
 	Name    BareReference
 	Args    []Node
 	Body    []Node
 	Rescues []Node
+	Ensure  []Node
 }
 
 func (f FuncDecl) MethodName() string {
@@ -61,6 +83,8 @@ func (f FuncDecl) MethodArgs() []MethodParam {
 }
 
 type ClassDecl struct {
+	Position
+
 	Name       string
 	SuperClass Class
 	Namespace  string
@@ -76,6 +100,8 @@ func (c ClassDecl) FullName() string {
 }
 
 type Class struct {
+	Position
+
 	Name              string
 	Namespace         string
 	IsGlobalNamespace bool
@@ -90,207 +116,411 @@ func (c Class) FullName() string {
 }
 
 type ModuleDecl struct {
+	Position
+
 	Name      string
 	Namespace string
 	Body      []Node
 }
 
 type Assignment struct {
+	Position
+
 	LHS Node
 	RHS Node
 }
 
 type Boolean struct {
+	Position
+
 	Value bool
 }
 
 type Negation struct {
+	Position
+
 	Target Node
 }
 
 type Complement struct {
+	Position
+
 	Target Node
 }
 
 type Positive struct {
+	Position
+
 	Target Node
 }
 
 type Negative struct {
+	Position
+
 	Target Node
 }
 
 type Addition struct {
+	Position
+
 	LHS Node
 	RHS Node
 }
 
 type Subtraction struct {
+	Position
+
 	LHS Node
 	RHS Node
 }
 
 type Multiplication struct {
+	Position
+
 	LHS Node
 	RHS Node
 }
 
 type Array struct {
+	Position
+
 	Nodes []Node
 }
 
 type Hash struct {
+	Position
+
 	Pairs []HashKeyValuePair
 }
 
 type HashKeyValuePair struct {
+	Position
+
 	Key   Node
 	Value Node
 }
 
 type GlobalVariable struct {
+	Position
+
 	Name string
 }
 
 type InstanceVariable struct {
+	Position
+
 	Name string
 }
 
 type ClassVariable struct {
+	Position
+
 	Name string
 }
 
-type FileNameConstReference struct{}
-type LineNumberConstReference struct{}
+type FileNameConstReference struct {
+	Position
+}
+type LineNumberConstReference struct {
+	Position
+}
+type DirNameConstReference struct {
+	Position
+}
+type MethodNameConstReference struct {
+	Position
+}
 
 type Block struct {
+	Position
+
 	Args []Node
 	Body []Node
+
+	// the highest numbered param (_1, _2, ...) referenced by a block
+	// written without |args|
+	ImplicitArgCount int
 }
 
 func (b *Block) Provided() bool {
-	return b.Args != nil && b.Body != nil
+	return b.Args != nil || b.Body != nil
+}
+
+// a block param written in parens, e.g. |(key, value), memo|, which
+// destructures the array passed in its position
+type DestructuredParam struct {
+	Position
+
+	Params []Node
 }
 
 type IfBlock struct {
+	Position
+
 	Condition Node
 	Body      []Node
 	Else      []Node
 }
 
 type Subshell struct {
+	Position
+
 	Command string
 }
 
 type Group struct {
+	Position
+
 	Body []Node
 }
 
 type Begin struct {
+	Position
+
 	Body   []Node
 	Rescue []Node
 	Else   []Node
+	Ensure []Node
 }
 
 type Rescue struct {
+	Position
+
 	Body      []Node
 	Exception RescueException
 }
 
 type RescueException struct {
+	Position
+
 	Var     BareReference
 	Classes []Class
+	Splat   Node // evaluates to an array of further classes, as in `rescue *ERRORS`
 }
 
 type MethodParam struct {
-	Name         BareReference
-	DefaultValue Node
-	IsSplat      bool
-	IsProc       bool
+	Position
+
+	Name           BareReference
+	DefaultValue   Node
+	IsSplat        bool
+	IsKeywordSplat bool
+	IsProc         bool
 }
 
 type Ternary struct {
+	Position
+
 	Condition Node
 	True      Node
 	False     Node
 }
 
 type Yield struct {
+	Position
+
 	Value Node
 }
 
+// defined?(foo), which describes what foo is, or is nil when it isn't defined
+type DefinedExpression struct {
+	Position
+
+	Target Node
+}
+
 type Return struct {
+	Position
+
 	Value Node
 }
 
-type Next struct{}
-type Redo struct{}
-type Break struct{}
-type Retry struct{}
+type Next struct {
+	Position
+}
+type Redo struct {
+	Position
+}
+type Break struct {
+	Position
+}
+type Retry struct {
+	Position
+}
 
 type Loop struct {
+	Position
+
 	Condition Node
 	Body      []Node
+
+	// begin ... end while cond runs its body once before checking cond
+	DoWhile bool
+}
+
+// for a, b in pairs ... end. Unlike block params, the loop's
+// vars are locals of the scope around the loop.
+type ForLoop struct {
+	Position
+
+	Vars       []Node
+	Collection Node
+	Body       []Node
 }
 
 type WeakLogicalAnd struct {
+	Position
+
 	LHS Node
 	RHS Node
 }
 
 type WeakLogicalOr struct {
+	Position
+
 	LHS Node
 	RHS Node
 }
 
 type Lambda struct {
+	Position
+
 	Body Block
 }
 
 type SwitchStatement struct {
+	Position
+
 	Condition Node
 	Cases     []SwitchCase
 	Else      []Node
 }
 
 type SwitchCase struct {
+	Position
+
 	Conditions []Node
 	Body       []Node
 }
 
+type PatternMatch struct {
+	Position
+
+	Condition Node
+	Cases     []PatternCase
+	Else      []Node
+}
+
+type PatternCase struct {
+	Position
+
+	Pattern Node
+	Body    []Node
+}
+
+type ArrayPattern struct {
+	Position
+
+	Elements []Node
+}
+
+type FindPattern struct {
+	Position
+
+	Pre    StarSplat
+	Middle []Node
+	Post   StarSplat
+}
+
+type HashPattern struct {
+	Position
+
+	Pairs []HashPatternPair
+	Rest  Node
+}
+
+type HashPatternPair struct {
+	Position
+
+	Key   Symbol
+	Value Node
+}
+
+type PatternBinding struct {
+	Position
+
+	Pattern Node
+	Name    BareReference
+}
+
 type ConditionalAssignment struct {
+	Position
+
 	LHS Node
 	RHS Node
 }
 
 type Range struct {
-	Start Node
-	End   Node
+	Position
+
+	Start     Node
+	End       Node
+	Exclusive bool
 }
 
 type StarSplat struct {
+	Position
+
+	Value Node
+}
+
+type DoubleStarSplat struct {
+	Position
+
+	Value Node
+}
+
+// an argument passed as the block with &, e.g. each(&printer), whose Value
+// converts it to a proc
+type ProcArg struct {
+	Position
+
 	Value Node
 }
 
 type RescueModifier struct {
+	Position
+
 	Statement Node
 	Rescue    Node
 }
 
 type Regex struct {
-	Value string
+	Position
+
+	Value        string
+	Flags        string
+	Interpolated bool // true when Value contains #{...} templates
 }
 
 type EigenClass struct {
+	Position
+
 	Target Node
 	Body   []Node
 }
 
 type Alias struct {
+	Position
+
 	To   Symbol
 	From Symbol
 }
 
-type Nil struct{}
+type Nil struct {
+	Position
+}
 
-type Self struct{}
+type Self struct {
+	Position
+}
@@ -0,0 +1,19 @@
+package ast
+
+// Position is where a node started in the parsed input. Lines and columns
+// count from 1 and the offset is in bytes from the start of the input. The
+// zero Position means the node was parsed without tracking positions.
+type Position struct {
+	Line   int
+	Column int
+	Offset int
+}
+
+func (p Position) Pos() Position {
+	return p
+}
+
+// Positioned is implemented by every node, through its embedded Position
+type Positioned interface {
+	Pos() Position
+}
@@ -0,0 +1,13 @@
+package ast_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestAST(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Grubby AST Suite")
+}
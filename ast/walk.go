@@ -0,0 +1,42 @@
+package ast
+
+import "reflect"
+
+// Walk calls visit for the node and then for each node beneath it, depth
+// first and in field order. Returning false from visit skips the children of
+// the node it was called with. A slice of nodes may be walked as well, in
+// which case visit is only called for its members.
+//
+// Struct fields that hold their zero value, such as the OptionalBlock of a
+// call made without one, are not visited.
+func Walk(node Node, visit func(Node) bool) {
+	walkValue(reflect.ValueOf(node), visit)
+}
+
+var positionType = reflect.TypeOf(Position{})
+
+func walkValue(value reflect.Value, visit func(Node) bool) {
+	switch value.Kind() {
+	case reflect.Interface, reflect.Ptr:
+		if !value.IsNil() {
+			walkValue(value.Elem(), visit)
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < value.Len(); i++ {
+			walkValue(value.Index(i), visit)
+		}
+	case reflect.Struct:
+		if !value.CanInterface() || !visit(value.Interface()) {
+			return
+		}
+
+		for i := 0; i < value.NumField(); i++ {
+			field := value.Field(i)
+			if !field.CanInterface() || field.Type() == positionType || (field.Kind() == reflect.Struct && field.IsZero()) {
+				continue
+			}
+
+			walkValue(field, visit)
+		}
+	}
+}
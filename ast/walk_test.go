@@ -0,0 +1,94 @@
+package ast_test
+
+import (
+	. "github.com/grubby/grubby/ast"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Walk", func() {
+	var tree Node
+
+	BeforeEach(func() {
+		// if x.empty?
+		//   puts('a') { |i| 1 }
+		// else
+		//   begin
+		//     next
+		//   ensure
+		//     break
+		//   end
+		// end
+		tree = IfBlock{
+			Condition: CallExpression{
+				Target: BareReference{Name: "x"},
+				Func:   BareReference{Name: "empty?"},
+			},
+			Body: []Node{
+				CallExpression{
+					Func: BareReference{Name: "puts"},
+					Args: []Node{SimpleString{Value: "a"}},
+					OptionalBlock: Block{
+						Args: []Node{BareReference{Name: "i"}},
+						Body: []Node{ConstantInt{Value: 1}},
+					},
+				},
+			},
+			Else: []Node{
+				Begin{
+					Body:   []Node{Next{}},
+					Ensure: []Node{Break{}},
+				},
+			},
+		}
+	})
+
+	It("visits every node in the tree", func() {
+		count := 0
+		Walk(tree, func(node Node) bool {
+			count++
+			return true
+		})
+
+		Expect(count).To(Equal(13))
+	})
+
+	It("visits parents before their children", func() {
+		visited := []Node{}
+		Walk(tree.(IfBlock).Else, func(node Node) bool {
+			visited = append(visited, node)
+			return true
+		})
+
+		Expect(visited).To(Equal([]Node{
+			Begin{Body: []Node{Next{}}, Ensure: []Node{Break{}}},
+			Next{},
+			Break{},
+		}))
+	})
+
+	It("skips the children of a node when visit returns false", func() {
+		references := []string{}
+		Walk(tree, func(node Node) bool {
+			if ref, ok := node.(BareReference); ok {
+				references = append(references, ref.Name)
+			}
+
+			_, isBlock := node.(Block)
+			return !isBlock
+		})
+
+		Expect(references).To(Equal([]string{"x", "empty?", "puts"}))
+	})
+
+	It("does not visit the positions of nodes", func() {
+		visited := []Node{}
+		Walk(Negation{Position: Position{Line: 2, Column: 1}, Target: Nil{Position: Position{Line: 2, Column: 2}}}, func(node Node) bool {
+			visited = append(visited, node)
+			return true
+		})
+
+		Expect(visited).To(HaveLen(2))
+		Expect(visited[1]).To(Equal(Nil{Position: Position{Line: 2, Column: 2}}))
+	})
+})
@@ -6,6 +6,7 @@ import (
 
 	. "github.com/grubby/grubby/interpreter/vm"
 	. "github.com/grubby/grubby/interpreter/vm/builtins"
+	. "github.com/grubby/grubby/testhelpers"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 )
@@ -42,4 +43,528 @@ var _ = Describe("Arrays", func() {
 			Expect(array.Members()).To(ContainElement(vm.Symbols()["hello"]))
 		})
 	})
+
+	Describe("rotate", func() {
+		It("returns a new array rotated by the given count", func() {
+			value, err := vm.Run("[1, 2, 3, 4, 5].rotate(2)")
+			Expect(err).ToNot(HaveOccurred())
+
+			array, ok := value.(*Array)
+			Expect(ok).To(BeTrue())
+			Expect(array.Members()).To(Equal([]Value{
+				NewFixnum(3, vm, vm),
+				NewFixnum(4, vm, vm),
+				NewFixnum(5, vm, vm),
+				NewFixnum(1, vm, vm),
+				NewFixnum(2, vm, vm),
+			}))
+		})
+
+		It("rotates to the right with a negative count", func() {
+			value, err := vm.Run("[1, 2, 3].rotate(-1)")
+			Expect(err).ToNot(HaveOccurred())
+
+			array, ok := value.(*Array)
+			Expect(ok).To(BeTrue())
+			Expect(array.Members()).To(Equal([]Value{
+				NewFixnum(3, vm, vm),
+				NewFixnum(1, vm, vm),
+				NewFixnum(2, vm, vm),
+			}))
+		})
+	})
+
+	Describe("shuffle", func() {
+		It("returns the same ordering when given identically seeded random sources", func() {
+			first, err := vm.Run("[1, 2, 3, 4, 5].shuffle(random: Random.new(42))")
+			Expect(err).ToNot(HaveOccurred())
+
+			second, err := vm.Run("[1, 2, 3, 4, 5].shuffle(random: Random.new(42))")
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(first.(*Array).Members()).To(Equal(second.(*Array).Members()))
+			Expect(first.(*Array).Members()).To(ConsistOf(
+				NewFixnum(1, vm, vm),
+				NewFixnum(2, vm, vm),
+				NewFixnum(3, vm, vm),
+				NewFixnum(4, vm, vm),
+				NewFixnum(5, vm, vm),
+			))
+		})
+	})
+
+	Describe("sample", func() {
+		It("returns the requested number of elements from the array", func() {
+			value, err := vm.Run("[:a, :b, :c].sample(2, random: Random.new(7))")
+			Expect(err).ToNot(HaveOccurred())
+
+			array, ok := value.(*Array)
+			Expect(ok).To(BeTrue())
+			Expect(len(array.Members())).To(Equal(2))
+			for _, member := range array.Members() {
+				Expect([]Value{vm.Symbols()["a"], vm.Symbols()["b"], vm.Symbols()["c"]}).To(ContainElement(member))
+			}
+		})
+	})
+
+	Describe("assoc", func() {
+		It("returns the first pair whose first element matches", func() {
+			value, err := vm.Run(`[[1, "a"], [2, "b"]].assoc(2)`)
+			Expect(err).ToNot(HaveOccurred())
+
+			pair := value.(*Array).Members()
+			Expect(pair[0]).To(Equal(NewFixnum(2, vm, vm)))
+			Expect(pair[1]).To(EqualRubyString("b"))
+		})
+
+		It("returns nil when no pair matches", func() {
+			value, err := vm.Run(`[[1, "a"], [2, "b"]].assoc(3)`)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(value).To(Equal(vm.SingletonWithName("nil")))
+		})
+	})
+
+	Describe("rassoc", func() {
+		It("returns the first pair whose second element matches", func() {
+			value, err := vm.Run(`[[1, "a"], [2, "b"]].rassoc("a")`)
+			Expect(err).ToNot(HaveOccurred())
+
+			pair := value.(*Array).Members()
+			Expect(pair[0]).To(Equal(NewFixnum(1, vm, vm)))
+			Expect(pair[1]).To(EqualRubyString("a"))
+		})
+	})
+
+	Describe("new", func() {
+		It("shares a single default value between every element", func() {
+			value, err := vm.Run("Array.new(3, [])")
+			Expect(err).ToNot(HaveOccurred())
+
+			members := value.(*Array).Members()
+			Expect(members).To(HaveLen(3))
+			Expect(members[0]).To(BeIdenticalTo(members[1]))
+			Expect(members[1]).To(BeIdenticalTo(members[2]))
+		})
+
+		It("calls the block once per element when given one", func() {
+			value, err := vm.Run("Array.new(3) { |i| [] }")
+			Expect(err).ToNot(HaveOccurred())
+
+			members := value.(*Array).Members()
+			Expect(members).To(HaveLen(3))
+			Expect(members[0]).ToNot(BeIdenticalTo(members[1]))
+			Expect(members[1]).ToNot(BeIdenticalTo(members[2]))
+		})
+
+		It("passes the index to the block", func() {
+			value, err := vm.Run("Array.new(3) { |i| i }")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(value.(*Array).Members()).To(Equal([]Value{
+				NewFixnum(0, vm, vm),
+				NewFixnum(1, vm, vm),
+				NewFixnum(2, vm, vm),
+			}))
+		})
+	})
+
+	Describe("fill", func() {
+		It("replaces every element with the value", func() {
+			value, err := vm.Run("[1, 2, 3].fill(0)")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(value.(*Array).Members()).To(Equal([]Value{
+				NewFixnum(0, vm, vm),
+				NewFixnum(0, vm, vm),
+				NewFixnum(0, vm, vm),
+			}))
+		})
+
+		It("fills a slice given a start and length, growing the array if needed", func() {
+			value, err := vm.Run("[1, 2, 3].fill(0, 2, 2)")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(value.(*Array).Members()).To(Equal([]Value{
+				NewFixnum(1, vm, vm),
+				NewFixnum(2, vm, vm),
+				NewFixnum(0, vm, vm),
+				NewFixnum(0, vm, vm),
+			}))
+		})
+
+		It("fills each element with the result of the block", func() {
+			value, err := vm.Run("[:a, :b, :c].fill { |i| i }")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(value.(*Array).Members()).To(Equal([]Value{
+				NewFixnum(0, vm, vm),
+				NewFixnum(1, vm, vm),
+				NewFixnum(2, vm, vm),
+			}))
+		})
+	})
+
+	Describe("combination", func() {
+		It("returns an enumerator of every combination of the given size", func() {
+			value, err := vm.Run("[1, 2, 3].combination(2).to_a")
+			Expect(err).ToNot(HaveOccurred())
+
+			combinations := value.(*Array).Members()
+			Expect(combinations).To(HaveLen(3))
+			Expect(combinations[0].(*Array).Members()).To(Equal([]Value{NewFixnum(1, vm, vm), NewFixnum(2, vm, vm)}))
+			Expect(combinations[1].(*Array).Members()).To(Equal([]Value{NewFixnum(1, vm, vm), NewFixnum(3, vm, vm)}))
+			Expect(combinations[2].(*Array).Members()).To(Equal([]Value{NewFixnum(2, vm, vm), NewFixnum(3, vm, vm)}))
+		})
+
+		It("yields each combination to a block", func() {
+			value, err := vm.Run(`
+seen = []
+[1, 2, 3].combination(2) { |pair| seen << pair }
+seen
+`)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(value.(*Array).Members()).To(HaveLen(3))
+		})
+	})
+
+	Describe("permutation", func() {
+		It("returns an enumerator of every ordering of the given size", func() {
+			value, err := vm.Run("[1, 2, 3].permutation(2).to_a")
+			Expect(err).ToNot(HaveOccurred())
+
+			permutations := value.(*Array).Members()
+			Expect(permutations).To(HaveLen(6))
+			Expect(permutations[0].(*Array).Members()).To(Equal([]Value{NewFixnum(1, vm, vm), NewFixnum(2, vm, vm)}))
+			Expect(permutations[2].(*Array).Members()).To(Equal([]Value{NewFixnum(2, vm, vm), NewFixnum(1, vm, vm)}))
+			Expect(permutations[5].(*Array).Members()).To(Equal([]Value{NewFixnum(3, vm, vm), NewFixnum(2, vm, vm)}))
+		})
+
+		It("permutes every element when no size is given", func() {
+			value, err := vm.Run("[1, 2, 3].permutation.to_a")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(value.(*Array).Members()).To(HaveLen(6))
+		})
+	})
+
+	Describe("product", func() {
+		It("returns the cartesian product of two arrays", func() {
+			value, err := vm.Run("[1, 2].product([3, 4]).inspect")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(value).To(EqualRubyString("[[1, 3], [1, 4], [2, 3], [2, 4]]"))
+		})
+
+		It("takes any number of arrays", func() {
+			value, err := vm.Run("[1, 2].product([3], [5, 6]).inspect")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(value).To(EqualRubyString("[[1, 3, 5], [1, 3, 6], [2, 3, 5], [2, 3, 6]]"))
+		})
+
+		It("is empty when any of the arrays is", func() {
+			value, err := vm.Run("[1, 2].product([]).inspect")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(value).To(EqualRubyString("[]"))
+		})
+	})
+
+	Describe("values_at", func() {
+		It("selects elements at each integer index, with nil when out of range", func() {
+			value, err := vm.Run("[1, 2, 3, 4, 5].values_at(0, 2, 9, -1)")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(value.(*Array).Members()).To(Equal([]Value{
+				NewFixnum(1, vm, vm), NewFixnum(3, vm, vm), vm.SingletonWithName("nil"), NewFixnum(5, vm, vm),
+			}))
+		})
+
+		It("gathers ranges and integers in the order given", func() {
+			value, err := vm.Run("[1, 2, 3, 4, 5].values_at(3..5, 0, 0..1)")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(value.(*Array).Members()).To(Equal([]Value{
+				NewFixnum(4, vm, vm), NewFixnum(5, vm, vm), vm.SingletonWithName("nil"),
+				NewFixnum(1, vm, vm),
+				NewFixnum(1, vm, vm), NewFixnum(2, vm, vm),
+			}))
+		})
+	})
+
+	Describe("slice", func() {
+		It("returns a single element, a start and length, or a range", func() {
+			value, err := vm.Run(`
+array = [1, 2, 3, 4, 5]
+[array[-1], array.slice(1, 2), array[3..9]]
+`)
+			Expect(err).ToNot(HaveOccurred())
+
+			results := value.(*Array).Members()
+			Expect(results[0]).To(Equal(NewFixnum(5, vm, vm)))
+			Expect(results[1].(*Array).Members()).To(Equal([]Value{NewFixnum(2, vm, vm), NewFixnum(3, vm, vm)}))
+			Expect(results[2].(*Array).Members()).To(Equal([]Value{NewFixnum(4, vm, vm), NewFixnum(5, vm, vm)}))
+		})
+	})
+
+	Describe("each_index", func() {
+		It("yields each valid index", func() {
+			value, err := vm.Run(`
+indices = []
+%w(a b c).each_index { |i| indices << i }
+indices
+`)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(value.(*Array).Members()).To(Equal([]Value{
+				NewFixnum(0, vm, vm), NewFixnum(1, vm, vm), NewFixnum(2, vm, vm),
+			}))
+		})
+	})
+
+	Describe("cycle", func() {
+		It("repeats the elements the given number of times", func() {
+			value, err := vm.Run(`
+taken = []
+result = [1, 2].cycle(2) { |x| taken << x }
+[taken, result, [1, 2].cycle(2).to_a]
+`)
+			Expect(err).ToNot(HaveOccurred())
+
+			results := value.(*Array).Members()
+			cycled := []Value{NewFixnum(1, vm, vm), NewFixnum(2, vm, vm), NewFixnum(1, vm, vm), NewFixnum(2, vm, vm)}
+			Expect(results[0].(*Array).Members()).To(Equal(cycled))
+			Expect(results[1]).To(Equal(vm.SingletonWithName("nil")))
+			Expect(results[2].(*Array).Members()).To(Equal(cycled))
+		})
+	})
+
+	Describe("delete", func() {
+		It("removes every equal element and returns it", func() {
+			value, err := vm.Run(`
+array = [1, 2, 3, 2]
+[array.delete(2), array]
+`)
+			Expect(err).ToNot(HaveOccurred())
+
+			results := value.(*Array).Members()
+			Expect(results[0]).To(Equal(NewFixnum(2, vm, vm)))
+			Expect(results[1].(*Array).Members()).To(Equal([]Value{NewFixnum(1, vm, vm), NewFixnum(3, vm, vm)}))
+		})
+
+		It("returns nil, or the result of the block, when the value is absent", func() {
+			value, err := vm.Run(`
+array = [1, 2, 3]
+[array.delete(4), array.delete(4) { |v| :missing }, array]
+`)
+			Expect(err).ToNot(HaveOccurred())
+
+			results := value.(*Array).Members()
+			Expect(results[0]).To(Equal(vm.SingletonWithName("nil")))
+			Expect(results[1]).To(Equal(vm.Symbols()["missing"]))
+			Expect(results[2].(*Array).Members()).To(Equal([]Value{
+				NewFixnum(1, vm, vm), NewFixnum(2, vm, vm), NewFixnum(3, vm, vm),
+			}))
+		})
+	})
+
+	Describe("delete_at", func() {
+		It("removes the element at an index counted back from the end", func() {
+			value, err := vm.Run(`
+array = [1, 2, 3]
+[array.delete_at(-1), array, array.delete_at(5)]
+`)
+			Expect(err).ToNot(HaveOccurred())
+
+			results := value.(*Array).Members()
+			Expect(results[0]).To(Equal(NewFixnum(3, vm, vm)))
+			Expect(results[1].(*Array).Members()).To(Equal([]Value{NewFixnum(1, vm, vm), NewFixnum(2, vm, vm)}))
+			Expect(results[2]).To(Equal(vm.SingletonWithName("nil")))
+		})
+	})
+
+	Describe("delete_if and reject!", func() {
+		It("removes the elements the block is truthy for", func() {
+			value, err := vm.Run(`
+array = [1, 2, 3]
+[array.delete_if { |v| v == 2 }, array.reject! { |v| v == 2 }]
+`)
+			Expect(err).ToNot(HaveOccurred())
+
+			results := value.(*Array).Members()
+			Expect(results[0].(*Array).Members()).To(Equal([]Value{NewFixnum(1, vm, vm), NewFixnum(3, vm, vm)}))
+			Expect(results[1]).To(Equal(vm.SingletonWithName("nil")))
+		})
+	})
+
+	Describe("insert", func() {
+		It("pads the array with nils when inserting past the end", func() {
+			value, err := vm.Run("[1, 2].insert(4, 5, 6)")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(value.(*Array).Members()).To(Equal([]Value{
+				NewFixnum(1, vm, vm), NewFixnum(2, vm, vm),
+				vm.SingletonWithName("nil"), vm.SingletonWithName("nil"),
+				NewFixnum(5, vm, vm), NewFixnum(6, vm, vm),
+			}))
+		})
+
+		It("inserts after the element a negative index counts back to", func() {
+			value, err := vm.Run("[1, 2].insert(-2, 3)")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(value.(*Array).Members()).To(Equal([]Value{
+				NewFixnum(1, vm, vm), NewFixnum(3, vm, vm), NewFixnum(2, vm, vm),
+			}))
+		})
+	})
+
+	Describe("flatten", func() {
+		It("only flattens as many levels as the depth given", func() {
+			value, err := vm.Run("[1, [2, [3, [4]]]].flatten(1)")
+			Expect(err).ToNot(HaveOccurred())
+
+			members := value.(*Array).Members()
+			Expect(members[:2]).To(Equal([]Value{NewFixnum(1, vm, vm), NewFixnum(2, vm, vm)}))
+			Expect(members[2].(*Array).Members()[0]).To(Equal(NewFixnum(3, vm, vm)))
+			Expect(members).To(HaveLen(3))
+		})
+
+		It("flattens every level without a depth", func() {
+			value, err := vm.Run("[1, [2, [3, [4]]]].flatten")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(value.(*Array).Members()).To(Equal([]Value{
+				NewFixnum(1, vm, vm), NewFixnum(2, vm, vm), NewFixnum(3, vm, vm), NewFixnum(4, vm, vm),
+			}))
+		})
+
+		It("raises an ArgumentError for an array that contains itself", func() {
+			_, err := vm.Run(`
+array = [1]
+array << array
+array.flatten
+`)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("ArgumentError: tried to flatten recursive array"))
+		})
+	})
+
+	Describe("map", func() {
+		It("returns the results of the block for each element", func() {
+			value, err := vm.Run("[1, 2, 3].map { |x| x * 2 }")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(value.(*Array).Members()).To(Equal([]Value{
+				NewFixnum(2, vm, vm), NewFixnum(4, vm, vm), NewFixnum(6, vm, vm),
+			}))
+		})
+
+		It("evaluates a block without params that uses an operator", func() {
+			value, err := vm.Run("x = 3; [1].map { x * 2 }")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(value.(*Array).Members()).To(Equal([]Value{NewFixnum(6, vm, vm)}))
+
+			value, err = vm.Run("[1].map { 3 * 2 }")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(value.(*Array).Members()).To(Equal([]Value{NewFixnum(6, vm, vm)}))
+		})
+
+		It("evaluates a block that uses a numbered param with an operator", func() {
+			value, err := vm.Run("[1, 2, 3].map { _1 * 2 }")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(value.(*Array).Members()).To(Equal([]Value{
+				NewFixnum(2, vm, vm), NewFixnum(4, vm, vm), NewFixnum(6, vm, vm),
+			}))
+		})
+
+		It("is also called collect", func() {
+			value, err := vm.Run("[1, 2].collect { |x| x + 10 }")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(value.(*Array).Members()).To(Equal([]Value{NewFixnum(11, vm, vm), NewFixnum(12, vm, vm)}))
+		})
+
+		It("returns an empty array for an empty array", func() {
+			value, err := vm.Run("[].map { |x| x * 2 }")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(value.(*Array).Members()).To(BeEmpty())
+		})
+	})
+
+	Describe("each", func() {
+		It("yields each element and returns the receiver", func() {
+			value, err := vm.Run(`
+array = [1, 2, 3]
+doubled = []
+result = array.each { |x| doubled << x * 2 }
+[result.equal?(array), doubled]
+`)
+			Expect(err).ToNot(HaveOccurred())
+
+			results := value.(*Array).Members()
+			Expect(results[0]).To(Equal(vm.SingletonWithName("true")))
+			Expect(results[1].(*Array).Members()).To(Equal([]Value{
+				NewFixnum(2, vm, vm), NewFixnum(4, vm, vm), NewFixnum(6, vm, vm),
+			}))
+		})
+
+		It("never calls the block for an empty array", func() {
+			value, err := vm.Run(`
+calls = []
+[].each { |x| calls << x }
+calls
+`)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(value.(*Array).Members()).To(BeEmpty())
+		})
+	})
+
+	Describe("select, filter and reject", func() {
+		It("keep or drop the elements the block is truthy for, leaving the receiver alone", func() {
+			value, err := vm.Run(`
+array = [1, 2, 3, 4]
+[array.select { |x| x.even? }, array.filter { |x| x.even? }, array.reject { |x| x.even? }, array]
+`)
+			Expect(err).ToNot(HaveOccurred())
+
+			results := value.(*Array).Members()
+			evens := []Value{NewFixnum(2, vm, vm), NewFixnum(4, vm, vm)}
+			Expect(results[0].(*Array).Members()).To(Equal(evens))
+			Expect(results[1].(*Array).Members()).To(Equal(evens))
+			Expect(results[2].(*Array).Members()).To(Equal([]Value{NewFixnum(1, vm, vm), NewFixnum(3, vm, vm)}))
+			Expect(results[3].(*Array).Members()).To(HaveLen(4))
+		})
+	})
+
+	Describe("inject", func() {
+		It("sums from an initial value", func() {
+			value, err := vm.Run("[1, 2, 3, 4].inject(0) { |sum, x| sum + x }")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(value).To(Equal(NewFixnum(10, vm, vm)))
+		})
+
+		It("starts from the first element without an initial value", func() {
+			value, err := vm.Run("[1, 2, 3, 4].reduce { |product, x| product * x }")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(value).To(Equal(NewFixnum(24, vm, vm)))
+		})
+
+		It("calls the method named by a symbol", func() {
+			value, err := vm.Run("[[2, 3, 2].inject(:pow), [3].inject(2, :pow)]")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(value.(*Array).Members()).To(Equal([]Value{NewFixnum(64, vm, vm), NewFixnum(8, vm, vm)}))
+		})
+
+		It("returns nil for an empty array without an initial value", func() {
+			value, err := vm.Run("[].inject { |sum, x| sum + x }")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(value).To(Equal(vm.SingletonWithName("nil")))
+		})
+	})
+
+	Describe("literals with splats", func() {
+		It("spread the splatted arrays inline", func() {
+			value, err := vm.Run(`
+middle = [1, 2]
+[0, *middle, 99, *[3]]
+`)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(value.(*Array).Members()).To(Equal([]Value{
+				NewFixnum(0, vm, vm), NewFixnum(1, vm, vm), NewFixnum(2, vm, vm), NewFixnum(99, vm, vm), NewFixnum(3, vm, vm),
+			}))
+		})
+
+		It("spread ranges, drop nil and keep other values as they are", func() {
+			value, err := vm.Run("[*(1..2), *nil, *:a]")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(value.(*Array).Members()).To(Equal([]Value{
+				NewFixnum(1, vm, vm), NewFixnum(2, vm, vm), vm.Symbols()["a"],
+			}))
+		})
+	})
 })
@@ -0,0 +1,21 @@
+package builtins
+
+import "fmt"
+
+type argumentError struct {
+	message   string
+	callstack string
+	valueStub
+}
+
+func NewArgumentError(message, callstack string) *argumentError {
+	return &argumentError{message: message, callstack: callstack}
+}
+
+func (err *argumentError) String() string {
+	return "ArgumentError"
+}
+
+func (err *argumentError) Error() string {
+	return fmt.Sprintf("ArgumentError: %s\n%s", err.message, err.callstack)
+}
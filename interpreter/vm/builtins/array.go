@@ -3,6 +3,8 @@ package builtins
 import (
 	"errors"
 	"fmt"
+	"math/rand"
+	"sort"
 )
 
 type ArrayClass struct {
@@ -20,6 +22,191 @@ func NewArrayClass(classProvider ClassProvider, singletonProvider SingletonProvi
 	a.initialize()
 	a.setStringer(a.String)
 
+	// Array.new(size, default) shares a single default object between every
+	// element, while Array.new(size) { |index| ... } calls the block for each one
+	a.AddMethod(NewNativeMethod("initialize", classProvider, singletonProvider, func(self Value, block Block, args ...Value) (Value, error) {
+		if len(args) == 0 {
+			return self, nil
+		}
+
+		if len(args) > 2 {
+			return nil, NewArgumentError(fmt.Sprintf("wrong number of arguments (%d for 0..2)", len(args)), "")
+		}
+
+		size, ok := args[0].(*fixnumInstance)
+		if !ok {
+			return nil, errors.New(fmt.Sprintf("TypeError: no implicit conversion of %s into Integer", args[0].Class().String()))
+		}
+
+		if size.value < 0 {
+			return nil, NewArgumentError("negative array size", "")
+		}
+
+		var defaultValue Value = singletonProvider.SingletonWithName("nil")
+		if len(args) == 2 {
+			defaultValue = args[1]
+		}
+
+		members := make([]Value, size.value)
+		for i := range members {
+			if block == nil {
+				members[i] = defaultValue
+				continue
+			}
+
+			member, err := block.Call(NewFixnum(i, classProvider, singletonProvider))
+			if err != nil {
+				return nil, err
+			}
+
+			members[i] = member
+		}
+
+		self.(*Array).members = members
+		return self, nil
+	}))
+
+	a.AddMethod(NewNativeMethod("fill", classProvider, singletonProvider, func(self Value, block Block, args ...Value) (Value, error) {
+		var fillValue Value
+		if block == nil {
+			if len(args) == 0 {
+				return nil, NewArgumentError("wrong number of arguments (0 for 1..3)", "")
+			}
+
+			fillValue, args = args[0], args[1:]
+		}
+
+		if len(args) > 2 {
+			return nil, NewArgumentError(fmt.Sprintf("wrong number of arguments (%d for 0..2)", len(args)), "")
+		}
+
+		array := self.(*Array)
+		if array.IsFrozen() {
+			return nil, NewFrozenError(array, "")
+		}
+
+		start, end := 0, len(array.members)
+		if len(args) > 0 && args[0] != singletonProvider.SingletonWithName("nil") {
+			startArg, ok := args[0].(*fixnumInstance)
+			if !ok {
+				return nil, errors.New(fmt.Sprintf("TypeError: no implicit conversion of %s into Integer", args[0].Class().String()))
+			}
+
+			start = startArg.value
+			if start < 0 {
+				start += len(array.members)
+				if start < 0 {
+					start = 0
+				}
+			}
+		}
+
+		if len(args) > 1 && args[1] != singletonProvider.SingletonWithName("nil") {
+			lengthArg, ok := args[1].(*fixnumInstance)
+			if !ok {
+				return nil, errors.New(fmt.Sprintf("TypeError: no implicit conversion of %s into Integer", args[1].Class().String()))
+			}
+
+			end = start + lengthArg.value
+		}
+
+		// filling past the end grows the array, padding any gap with nil
+		for len(array.members) < end {
+			array.members = append(array.members, singletonProvider.SingletonWithName("nil"))
+		}
+
+		for i := start; i < end; i++ {
+			if block == nil {
+				array.members[i] = fillValue
+				continue
+			}
+
+			member, err := block.Call(NewFixnum(i, classProvider, singletonProvider))
+			if err != nil {
+				return nil, err
+			}
+
+			array.members[i] = member
+		}
+
+		return array, nil
+	}))
+
+	slice := func(self Value, block Block, args ...Value) (Value, error) {
+		array := self.(*Array)
+		switch len(args) {
+		case 1:
+			if r, ok := args[0].(*RangeValue); ok {
+				start, end, ok := r.indicesWithin(len(array.members))
+				if !ok || start > len(array.members) {
+					return singletonProvider.SingletonWithName("nil"), nil
+				}
+
+				return array.subarray(start, end, classProvider, singletonProvider), nil
+			}
+
+			index, err := arrayIndex(args[0])
+			if err != nil {
+				return nil, err
+			}
+
+			return array.at(index, singletonProvider), nil
+		case 2:
+			start, err := arrayIndex(args[0])
+			if err != nil {
+				return nil, err
+			}
+
+			length, err := arrayIndex(args[1])
+			if err != nil {
+				return nil, err
+			}
+
+			if start < 0 {
+				start += len(array.members)
+			}
+			if start < 0 || start > len(array.members) || length < 0 {
+				return singletonProvider.SingletonWithName("nil"), nil
+			}
+
+			return array.subarray(start, start+length, classProvider, singletonProvider), nil
+		default:
+			return nil, NewArgumentError(fmt.Sprintf("wrong number of arguments (%d for 1..2)", len(args)), "")
+		}
+	}
+	a.AddMethod(NewNativeMethod("[]", classProvider, singletonProvider, slice))
+	a.AddMethod(NewNativeMethod("slice", classProvider, singletonProvider, slice))
+
+	// unlike slice, ranges that run past the end are padded with nil
+	a.AddMethod(NewNativeMethod("values_at", classProvider, singletonProvider, func(self Value, block Block, args ...Value) (Value, error) {
+		array := self.(*Array)
+		result, _ := classProvider.ClassWithName("Array").New(classProvider, singletonProvider)
+		values := result.(*Array)
+
+		for _, arg := range args {
+			if r, ok := arg.(*RangeValue); ok {
+				start, end, ok := r.indicesWithin(len(array.members))
+				if !ok {
+					continue
+				}
+
+				for i := start; i < end; i++ {
+					values.Append(array.at(i, singletonProvider))
+				}
+				continue
+			}
+
+			index, err := arrayIndex(arg)
+			if err != nil {
+				return nil, err
+			}
+
+			values.Append(array.at(index, singletonProvider))
+		}
+
+		return values, nil
+	}))
+
 	a.AddMethod(NewNativeMethod("shift", classProvider, singletonProvider, func(self Value, block Block, args ...Value) (Value, error) {
 		a := self.(*Array)
 		if len(a.members) == 0 {
@@ -37,6 +224,18 @@ func NewArrayClass(classProvider ClassProvider, singletonProvider SingletonProvi
 		return a, nil
 	}))
 
+	push := func(self Value, block Block, args ...Value) (Value, error) {
+		array := self.(*Array)
+		if array.IsFrozen() {
+			return nil, NewFrozenError(array, "")
+		}
+
+		array.members = append(array.members, args...)
+		return array, nil
+	}
+	a.AddMethod(NewNativeMethod("<<", classProvider, singletonProvider, push))
+	a.AddMethod(NewNativeMethod("push", classProvider, singletonProvider, push))
+
 	a.AddMethod(NewNativeMethod("include?", classProvider, singletonProvider, func(self Value, block Block, args ...Value) (Value, error) {
 		a := self.(*Array)
 		for _, m := range a.members {
@@ -48,6 +247,14 @@ func NewArrayClass(classProvider ClassProvider, singletonProvider SingletonProvi
 		return singletonProvider.SingletonWithName("false"), nil
 	}))
 
+	a.AddMethod(NewNativeMethod("assoc", classProvider, singletonProvider, func(self Value, block Block, args ...Value) (Value, error) {
+		return findAssociation(self.(*Array), 0, args[0], singletonProvider)
+	}))
+
+	a.AddMethod(NewNativeMethod("rassoc", classProvider, singletonProvider, func(self Value, block Block, args ...Value) (Value, error) {
+		return findAssociation(self.(*Array), 1, args[0], singletonProvider)
+	}))
+
 	a.AddMethod(NewNativeMethod("-", classProvider, singletonProvider, func(self Value, block Block, args ...Value) (Value, error) {
 		a := self.(*Array)
 		argAsArray, ok := args[0].(*Array)
@@ -81,23 +288,556 @@ func NewArrayClass(classProvider ClassProvider, singletonProvider SingletonProvi
 		return self, nil
 	}))
 
-	a.AddMethod(NewNativeMethod("select", classProvider, singletonProvider, func(self Value, block Block, args ...Value) (Value, error) {
-		arr, _ := classProvider.ClassWithName("Array").New(classProvider, singletonProvider)
-		filteredArray := arr.(*Array)
-		selfAsArray := self.(*Array)
+	a.AddMethod(NewNativeMethod("delete", classProvider, singletonProvider, func(self Value, block Block, args ...Value) (Value, error) {
+		if len(args) != 1 {
+			return nil, NewArgumentError(fmt.Sprintf("wrong number of arguments (%d for 1)", len(args)), "")
+		}
 
-		for _, element := range selfAsArray.members {
-			result, err := block.Call(element)
+		array := self.(*Array)
+		if array.IsFrozen() {
+			return nil, NewFrozenError(array, "")
+		}
+
+		var deleted Value
+		kept := []Value{}
+		for _, member := range array.members {
+			equal, err := valuesEqual(member, args[0])
 			if err != nil {
 				return nil, err
 			}
 
-			if result.IsTruthy() {
-				filteredArray.members = append(filteredArray.members, element)
+			if equal {
+				deleted = member
+			} else {
+				kept = append(kept, member)
 			}
 		}
+		array.members = kept
 
-		return filteredArray, nil
+		switch {
+		case deleted != nil:
+			return deleted, nil
+		case block != nil:
+			return block.Call(args[0])
+		default:
+			return singletonProvider.SingletonWithName("nil"), nil
+		}
+	}))
+
+	a.AddMethod(NewNativeMethod("delete_at", classProvider, singletonProvider, func(self Value, block Block, args ...Value) (Value, error) {
+		if len(args) != 1 {
+			return nil, NewArgumentError(fmt.Sprintf("wrong number of arguments (%d for 1)", len(args)), "")
+		}
+
+		array := self.(*Array)
+		if array.IsFrozen() {
+			return nil, NewFrozenError(array, "")
+		}
+
+		index, err := arrayIndex(args[0])
+		if err != nil {
+			return nil, err
+		}
+
+		if index < 0 {
+			index += len(array.members)
+		}
+		if index < 0 || index >= len(array.members) {
+			return singletonProvider.SingletonWithName("nil"), nil
+		}
+
+		deleted := array.members[index]
+		array.members = append(array.members[:index], array.members[index+1:]...)
+		return deleted, nil
+	}))
+
+	// delete_if always returns the array, while reject! returns nil
+	// when the block didn't reject anything
+	for _, name := range []string{"delete_if", "reject!"} {
+		name := name
+		a.AddMethod(NewNativeMethod(name, classProvider, singletonProvider, func(self Value, block Block, args ...Value) (Value, error) {
+			if block == nil {
+				return NewEnumeratorForMethod(self, name, classProvider), nil
+			}
+
+			array := self.(*Array)
+			if array.IsFrozen() {
+				return nil, NewFrozenError(array, "")
+			}
+
+			kept := []Value{}
+			for _, member := range array.members {
+				rejected, err := block.Call(member)
+				if err != nil {
+					return nil, err
+				}
+
+				if !rejected.IsTruthy() {
+					kept = append(kept, member)
+				}
+			}
+
+			changed := len(kept) != len(array.members)
+			array.members = kept
+			if name == "reject!" && !changed {
+				return singletonProvider.SingletonWithName("nil"), nil
+			}
+
+			return array, nil
+		}))
+	}
+
+	a.AddMethod(NewNativeMethod("insert", classProvider, singletonProvider, func(self Value, block Block, args ...Value) (Value, error) {
+		if len(args) == 0 {
+			return nil, NewArgumentError("wrong number of arguments (0 for 1+)", "")
+		}
+
+		array := self.(*Array)
+		if array.IsFrozen() {
+			return nil, NewFrozenError(array, "")
+		}
+
+		index, err := arrayIndex(args[0])
+		if err != nil {
+			return nil, err
+		}
+
+		values := args[1:]
+		if len(values) == 0 {
+			return array, nil
+		}
+
+		// a negative index inserts after the element it counts back to
+		if index < 0 {
+			index += len(array.members) + 1
+			if index < 0 {
+				return nil, errors.New(fmt.Sprintf("IndexError: index %d too small for array; minimum: -%d", index-len(array.members)-1, len(array.members)+1))
+			}
+		}
+
+		for len(array.members) < index {
+			array.members = append(array.members, singletonProvider.SingletonWithName("nil"))
+		}
+
+		inserted := append([]Value{}, array.members[:index]...)
+		inserted = append(inserted, values...)
+		array.members = append(inserted, array.members[index:]...)
+		return array, nil
+	}))
+
+	// flatten! returns nil when there were no nested arrays to flatten
+	for _, name := range []string{"flatten", "flatten!"} {
+		inPlace := name == "flatten!"
+		a.AddMethod(NewNativeMethod(name, classProvider, singletonProvider, func(self Value, block Block, args ...Value) (Value, error) {
+			if len(args) > 1 {
+				return nil, NewArgumentError(fmt.Sprintf("wrong number of arguments (%d for 0..1)", len(args)), "")
+			}
+
+			depth := -1
+			if len(args) == 1 && args[0] != singletonProvider.SingletonWithName("nil") {
+				var err error
+				depth, err = arrayIndex(args[0])
+				if err != nil {
+					return nil, err
+				}
+			}
+
+			array := self.(*Array)
+			if inPlace && array.IsFrozen() {
+				return nil, NewFrozenError(array, "")
+			}
+
+			flattened, err := flattenMembers(array, depth, map[*Array]bool{})
+			if err != nil {
+				return nil, err
+			}
+
+			if !inPlace {
+				result, _ := classProvider.ClassWithName("Array").New(classProvider, singletonProvider)
+				result.(*Array).members = flattened
+				return result, nil
+			}
+
+			changed := false
+			for _, member := range array.members {
+				if _, ok := member.(*Array); ok && depth != 0 {
+					changed = true
+				}
+			}
+
+			array.members = flattened
+			if !changed {
+				return singletonProvider.SingletonWithName("nil"), nil
+			}
+
+			return array, nil
+		}))
+	}
+
+	a.AddMethod(NewNativeMethod("each", classProvider, singletonProvider, func(self Value, block Block, args ...Value) (Value, error) {
+		if block == nil {
+			return NewEnumeratorForMethod(self, "each", classProvider), nil
+		}
+
+		for _, element := range self.(*Array).members {
+			_, err := block.Call(element)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		return self, nil
+	}))
+
+	a.AddMethod(NewNativeMethod("each_index", classProvider, singletonProvider, func(self Value, block Block, args ...Value) (Value, error) {
+		if block == nil {
+			return NewEnumeratorForMethod(self, "each_index", classProvider), nil
+		}
+
+		for i := 0; i < len(self.(*Array).members); i++ {
+			_, err := block.Call(NewFixnum(i, classProvider, singletonProvider))
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		return self, nil
+	}))
+
+	// without a count (or with nil) the array is cycled through forever,
+	// so only an error raised by the block will stop it
+	a.AddMethod(NewNativeMethod("cycle", classProvider, singletonProvider, func(self Value, block Block, args ...Value) (Value, error) {
+		if len(args) > 1 {
+			return nil, NewArgumentError(fmt.Sprintf("wrong number of arguments (%d for 0..1)", len(args)), "")
+		}
+
+		if block == nil {
+			return NewEnumeratorForMethod(self, "cycle", classProvider, args...), nil
+		}
+
+		times := -1
+		if len(args) == 1 && args[0] != singletonProvider.SingletonWithName("nil") {
+			count, ok := args[0].(*fixnumInstance)
+			if !ok {
+				return nil, errors.New(fmt.Sprintf("TypeError: no implicit conversion of %s into Integer", args[0].Class().String()))
+			}
+
+			times = count.value
+		}
+
+		array := self.(*Array)
+		for i := 0; (times < 0 || i < times) && len(array.members) > 0; i++ {
+			for _, element := range array.members {
+				_, err := block.Call(element)
+				if err != nil {
+					return nil, err
+				}
+			}
+		}
+
+		return singletonProvider.SingletonWithName("nil"), nil
+	}))
+
+	for _, name := range []string{"map", "collect"} {
+		name := name
+		a.AddMethod(NewNativeMethod(name, classProvider, singletonProvider, func(self Value, block Block, args ...Value) (Value, error) {
+			if block == nil {
+				return NewEnumeratorForMethod(self, name, classProvider), nil
+			}
+
+			arr, _ := classProvider.ClassWithName("Array").New(classProvider, singletonProvider)
+			mapped := arr.(*Array)
+			for _, element := range self.(*Array).members {
+				result, err := block.Call(element)
+				if err != nil {
+					return nil, err
+				}
+
+				mapped.Append(result)
+			}
+
+			return mapped, nil
+		}))
+	}
+
+	// select keeps the elements the block is truthy for, reject the rest
+	for name, keep := range map[string]bool{"select": true, "filter": true, "reject": false} {
+		name, keep := name, keep
+		a.AddMethod(NewNativeMethod(name, classProvider, singletonProvider, func(self Value, block Block, args ...Value) (Value, error) {
+			if block == nil {
+				return NewEnumeratorForMethod(self, name, classProvider), nil
+			}
+
+			arr, _ := classProvider.ClassWithName("Array").New(classProvider, singletonProvider)
+			filteredArray := arr.(*Array)
+			selfAsArray := self.(*Array)
+
+			for _, element := range selfAsArray.members {
+				result, err := block.Call(element)
+				if err != nil {
+					return nil, err
+				}
+
+				if result.IsTruthy() == keep {
+					filteredArray.members = append(filteredArray.members, element)
+				}
+			}
+
+			return filteredArray, nil
+		}))
+	}
+
+	// combines the elements with the block, or the method named by a symbol,
+	// starting from the initial value if one is given and the first element
+	// otherwise
+	inject := func(self Value, block Block, args ...Value) (Value, error) {
+		var operation *SymbolValue
+		if block == nil && len(args) > 0 {
+			if symbol, ok := args[len(args)-1].(*SymbolValue); ok {
+				operation = symbol
+				args = args[:len(args)-1]
+			}
+		}
+
+		if len(args) > 1 || (block == nil && operation == nil) {
+			return nil, NewArgumentError(fmt.Sprintf("wrong number of arguments (%d for 0..2)", len(args)), "")
+		}
+
+		members := self.(*Array).members
+		var accumulator Value
+		if len(args) == 1 {
+			accumulator = args[0]
+		} else if len(members) > 0 {
+			accumulator, members = members[0], members[1:]
+		} else {
+			return singletonProvider.SingletonWithName("nil"), nil
+		}
+
+		for _, element := range members {
+			var err error
+			if operation != nil {
+				var method Method
+				method, err = accumulator.Method(operation.Name())
+				if err == nil {
+					accumulator, err = method.Execute(accumulator, nil, element)
+				}
+			} else {
+				accumulator, err = block.Call(accumulator, element)
+			}
+
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		return accumulator, nil
+	}
+	for _, name := range []string{"inject", "reduce"} {
+		a.AddMethod(NewNativeMethod(name, classProvider, singletonProvider, inject))
+	}
+
+	a.AddMethod(NewNativeMethod("rotate", classProvider, singletonProvider, func(self Value, block Block, args ...Value) (Value, error) {
+		count := 1
+		if len(args) > 0 {
+			countArg, ok := args[0].(*fixnumInstance)
+			if !ok {
+				return nil, errors.New(fmt.Sprintf("TypeError: no implicit conversion of %s into Integer", args[0].Class().String()))
+			}
+
+			count = countArg.value
+		}
+
+		arr, _ := classProvider.ClassWithName("Array").New(classProvider, singletonProvider)
+		rotated := arr.(*Array)
+		members := self.(*Array).members
+		if len(members) == 0 {
+			return rotated, nil
+		}
+
+		start := count % len(members)
+		if start < 0 {
+			start += len(members)
+		}
+
+		rotated.members = append(rotated.members, members[start:]...)
+		rotated.members = append(rotated.members, members[:start]...)
+		return rotated, nil
+	}))
+
+	a.AddMethod(NewNativeMethod("shuffle", classProvider, singletonProvider, func(self Value, block Block, args ...Value) (Value, error) {
+		source, _, err := randomSourceFromArgs(args)
+		if err != nil {
+			return nil, err
+		}
+
+		arr, _ := classProvider.ClassWithName("Array").New(classProvider, singletonProvider)
+		shuffled := arr.(*Array)
+		shuffled.members = append(shuffled.members, self.(*Array).members...)
+
+		swap := func(i, j int) {
+			shuffled.members[i], shuffled.members[j] = shuffled.members[j], shuffled.members[i]
+		}
+		if source != nil {
+			source.Shuffle(len(shuffled.members), swap)
+		} else {
+			rand.Shuffle(len(shuffled.members), swap)
+		}
+
+		return shuffled, nil
+	}))
+
+	a.AddMethod(NewNativeMethod("sample", classProvider, singletonProvider, func(self Value, block Block, args ...Value) (Value, error) {
+		source, args, err := randomSourceFromArgs(args)
+		if err != nil {
+			return nil, err
+		}
+
+		members := self.(*Array).members
+		var permutation []int
+		if source != nil {
+			permutation = source.Perm(len(members))
+		} else {
+			permutation = rand.Perm(len(members))
+		}
+
+		if len(args) == 0 {
+			if len(members) == 0 {
+				return singletonProvider.SingletonWithName("nil"), nil
+			}
+
+			return members[permutation[0]], nil
+		}
+
+		countArg, ok := args[0].(*fixnumInstance)
+		if !ok {
+			return nil, errors.New(fmt.Sprintf("TypeError: no implicit conversion of %s into Integer", args[0].Class().String()))
+		}
+
+		count := countArg.value
+		if count < 0 {
+			return nil, NewArgumentError("negative sample number", "")
+		}
+		if count > len(members) {
+			count = len(members)
+		}
+
+		arr, _ := classProvider.ClassWithName("Array").New(classProvider, singletonProvider)
+		sampled := arr.(*Array)
+		for _, index := range permutation[:count] {
+			sampled.Append(members[index])
+		}
+
+		return sampled, nil
+	}))
+
+	a.AddMethod(NewNativeMethod("combination", classProvider, singletonProvider, func(self Value, block Block, args ...Value) (Value, error) {
+		if len(args) != 1 {
+			return nil, NewArgumentError(fmt.Sprintf("wrong number of arguments (%d for 1)", len(args)), "")
+		}
+
+		size, ok := args[0].(*fixnumInstance)
+		if !ok {
+			return nil, errors.New(fmt.Sprintf("TypeError: no implicit conversion of %s into Integer", args[0].Class().String()))
+		}
+
+		if block == nil {
+			return NewEnumeratorForMethod(self, "combination", classProvider, args...), nil
+		}
+
+		members := append([]Value{}, self.(*Array).members...)
+		err := eachCombination(members, size.value, func(combination []Value) error {
+			array, _ := classProvider.ClassWithName("Array").New(classProvider, singletonProvider)
+			array.(*Array).members = combination
+			_, err := block.Call(array)
+			return err
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		return self, nil
+	}))
+
+	a.AddMethod(NewNativeMethod("permutation", classProvider, singletonProvider, func(self Value, block Block, args ...Value) (Value, error) {
+		if len(args) > 1 {
+			return nil, NewArgumentError(fmt.Sprintf("wrong number of arguments (%d for 0..1)", len(args)), "")
+		}
+
+		members := append([]Value{}, self.(*Array).members...)
+		size := len(members)
+		if len(args) == 1 {
+			sizeArg, ok := args[0].(*fixnumInstance)
+			if !ok {
+				return nil, errors.New(fmt.Sprintf("TypeError: no implicit conversion of %s into Integer", args[0].Class().String()))
+			}
+
+			size = sizeArg.value
+		}
+
+		if block == nil {
+			return NewEnumeratorForMethod(self, "permutation", classProvider, args...), nil
+		}
+
+		err := eachPermutation(members, size, func(permutation []Value) error {
+			array, _ := classProvider.ClassWithName("Array").New(classProvider, singletonProvider)
+			array.(*Array).members = permutation
+			_, err := block.Call(array)
+			return err
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		return self, nil
+	}))
+
+	a.AddMethod(NewNativeMethod("product", classProvider, singletonProvider, func(self Value, block Block, args ...Value) (Value, error) {
+		lists := [][]Value{self.(*Array).members}
+		for _, arg := range args {
+			list, ok := arg.(*Array)
+			if !ok {
+				return nil, errors.New(fmt.Sprintf("TypeError: no implicit conversion of %s into Array", arg.Class().String()))
+			}
+
+			lists = append(lists, list.members)
+		}
+
+		result, _ := classProvider.ClassWithName("Array").New(classProvider, singletonProvider)
+		for _, tuple := range cartesianProduct(lists) {
+			array, _ := classProvider.ClassWithName("Array").New(classProvider, singletonProvider)
+			array.(*Array).members = tuple
+
+			if block == nil {
+				result.(*Array).Append(array)
+			} else if _, err := block.Call(array); err != nil {
+				return nil, err
+			}
+		}
+
+		if block != nil {
+			return self, nil
+		}
+
+		return result, nil
+	}))
+
+	a.AddMethod(NewNativeMethod("sort", classProvider, singletonProvider, func(self Value, block Block, args ...Value) (Value, error) {
+		sorted, err := sortValues(self.(*Array).members, block)
+		if err != nil {
+			return nil, err
+		}
+
+		result, _ := classProvider.ClassWithName("Array").New(classProvider, singletonProvider)
+		result.(*Array).members = sorted
+		return result, nil
+	}))
+
+	a.AddMethod(NewNativeMethod("min", classProvider, singletonProvider, func(self Value, block Block, args ...Value) (Value, error) {
+		return extremeValue(self.(*Array).members, block, -1, singletonProvider)
+	}))
+
+	a.AddMethod(NewNativeMethod("max", classProvider, singletonProvider, func(self Value, block Block, args ...Value) (Value, error) {
+		return extremeValue(self.(*Array).members, block, 1, singletonProvider)
 	}))
 
 	return a
@@ -133,6 +873,16 @@ func (array *Array) Append(v Value) {
 	array.members = append(array.members, v)
 }
 
+// removes the first member that is the very value given
+func (array *Array) Remove(v Value) {
+	for index, member := range array.members {
+		if member == v {
+			array.members = append(array.members[:index], array.members[index+1:]...)
+			return
+		}
+	}
+}
+
 func (array *Array) Members() []Value {
 	return array.members
 }
@@ -140,3 +890,268 @@ func (array *Array) Members() []Value {
 func (array *Array) String() string {
 	return "Array"
 }
+
+// returns the member at the index, counting back from the end when it is
+// negative, or nil when the index is out of bounds
+func (array *Array) at(index int, singletonProvider SingletonProvider) Value {
+	if index < 0 {
+		index += len(array.members)
+	}
+
+	if index < 0 || index >= len(array.members) {
+		return singletonProvider.SingletonWithName("nil")
+	}
+
+	return array.members[index]
+}
+
+// copies the members from start up to (but not including) end into a new
+// array, stopping early at the end of this one
+func (array *Array) subarray(start, end int, classProvider ClassProvider, singletonProvider SingletonProvider) Value {
+	if end > len(array.members) {
+		end = len(array.members)
+	}
+
+	result, _ := classProvider.ClassWithName("Array").New(classProvider, singletonProvider)
+	if start < end {
+		result.(*Array).members = append([]Value{}, array.members[start:end]...)
+	}
+
+	return result
+}
+
+func arrayIndex(value Value) (int, error) {
+	index, ok := value.(*fixnumInstance)
+	if !ok {
+		return 0, errors.New(fmt.Sprintf("TypeError: no implicit conversion of %s into Integer", value.Class().String()))
+	}
+
+	return index.value, nil
+}
+
+// the members of the array with nested arrays spliced in, up to the given
+// depth (or all the way down when it is negative). When flattening all the way
+// down, the arrays currently being flattened are tracked so that an array
+// containing itself is an error rather than an endless loop.
+func flattenMembers(array *Array, depth int, flattening map[*Array]bool) ([]Value, error) {
+	if depth < 0 {
+		if flattening[array] {
+			return nil, NewArgumentError("tried to flatten recursive array", "")
+		}
+
+		flattening[array] = true
+		defer delete(flattening, array)
+	}
+
+	flattened := []Value{}
+	for _, member := range array.members {
+		nested, ok := member.(*Array)
+		if !ok || depth == 0 {
+			flattened = append(flattened, member)
+			continue
+		}
+
+		members, err := flattenMembers(nested, depth-1, flattening)
+		if err != nil {
+			return nil, err
+		}
+
+		flattened = append(flattened, members...)
+	}
+
+	return flattened, nil
+}
+
+// compares two values with the == method of the first
+func valuesEqual(left, right Value) (bool, error) {
+	equalMethod, err := left.Method("==")
+	if err != nil {
+		return false, err
+	}
+
+	equal, err := equalMethod.Execute(left, nil, right)
+	if err != nil {
+		return false, err
+	}
+
+	return equal.IsTruthy(), nil
+}
+
+// finds the first member of an association list (an array of arrays)
+// whose element at the given index is == to the key
+func findAssociation(list *Array, index int, key Value, singletonProvider SingletonProvider) (Value, error) {
+	for _, member := range list.members {
+		pair, ok := member.(*Array)
+		if !ok || len(pair.members) <= index {
+			continue
+		}
+
+		equalMethod, err := pair.members[index].Method("==")
+		if err != nil {
+			return nil, err
+		}
+
+		equal, err := equalMethod.Execute(pair.members[index], nil, key)
+		if err != nil {
+			return nil, err
+		}
+
+		if equal.IsTruthy() {
+			return pair, nil
+		}
+	}
+
+	return singletonProvider.SingletonWithName("nil"), nil
+}
+
+// orders two values with the block when one is given, and with <=> otherwise
+func compareWithBlock(block Block, left, right Value) (int, error) {
+	if block == nil {
+		return compareValues(left, right)
+	}
+
+	result, err := block.Call(left, right)
+	if err != nil {
+		return 0, err
+	}
+
+	order, ok := result.(*fixnumInstance)
+	if !ok {
+		return 0, NewArgumentError(fmt.Sprintf("comparison of %s with %s failed", left.Class().String(), right.String()), "")
+	}
+
+	return order.value, nil
+}
+
+// returns a sorted copy of the values, stopping at the first comparison that fails
+func sortValues(values []Value, block Block) ([]Value, error) {
+	sorted := make([]Value, len(values))
+	copy(sorted, values)
+
+	var sortErr error
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sortErr != nil {
+			return false
+		}
+
+		order, err := compareWithBlock(block, sorted[i], sorted[j])
+		if err != nil {
+			sortErr = err
+			return false
+		}
+
+		return order < 0
+	})
+
+	return sorted, sortErr
+}
+
+// finds the smallest (direction -1) or largest (direction 1) of the values
+func extremeValue(values []Value, block Block, direction int, singletonProvider SingletonProvider) (Value, error) {
+	if len(values) == 0 {
+		return singletonProvider.SingletonWithName("nil"), nil
+	}
+
+	extreme := values[0]
+	for _, value := range values[1:] {
+		order, err := compareWithBlock(block, value, extreme)
+		if err != nil {
+			return nil, err
+		}
+
+		if order*direction > 0 {
+			extreme = value
+		}
+	}
+
+	return extreme, nil
+}
+
+// calls yield with every way of choosing size values, in the order ruby does
+func eachCombination(values []Value, size int, yield func([]Value) error) error {
+	if size < 0 || size > len(values) {
+		return nil
+	}
+
+	indices := make([]int, size)
+	for i := range indices {
+		indices[i] = i
+	}
+
+	for {
+		combination := make([]Value, size)
+		for i, index := range indices {
+			combination[i] = values[index]
+		}
+
+		if err := yield(combination); err != nil {
+			return err
+		}
+
+		// advance the rightmost index that still has room to move
+		i := size - 1
+		for i >= 0 && indices[i] == len(values)-size+i {
+			i--
+		}
+		if i < 0 {
+			return nil
+		}
+
+		indices[i]++
+		for j := i + 1; j < size; j++ {
+			indices[j] = indices[j-1] + 1
+		}
+	}
+}
+
+// every tuple taking one value from each list, varying the last list fastest
+func cartesianProduct(lists [][]Value) [][]Value {
+	tuples := [][]Value{{}}
+	for _, list := range lists {
+		extended := make([][]Value, 0, len(tuples)*len(list))
+		for _, tuple := range tuples {
+			for _, value := range list {
+				extended = append(extended, append(append([]Value{}, tuple...), value))
+			}
+		}
+
+		tuples = extended
+	}
+
+	return tuples
+}
+
+// calls yield with every ordering of size distinct values, in the order ruby does
+func eachPermutation(values []Value, size int, yield func([]Value) error) error {
+	if size < 0 || size > len(values) {
+		return nil
+	}
+
+	used := make([]bool, len(values))
+	permutation := make([]Value, 0, size)
+
+	var permute func() error
+	permute = func() error {
+		if len(permutation) == size {
+			return yield(append([]Value{}, permutation...))
+		}
+
+		for i, value := range values {
+			if used[i] {
+				continue
+			}
+
+			used[i] = true
+			permutation = append(permutation, value)
+			if err := permute(); err != nil {
+				return err
+			}
+			permutation = permutation[:len(permutation)-1]
+			used[i] = false
+		}
+
+		return nil
+	}
+
+	return permute()
+}
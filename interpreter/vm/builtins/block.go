@@ -25,16 +25,76 @@ type blockImpl struct {
 }
 
 func (b *blockImpl) Call(args ...Value) (Value, error) {
-	invocationArgs := make([]BlockArg, 0, len(args))
-	for index, providedArg := range args {
-		blockArg := BlockArg{
-			Name:  b.args[index].(ast.BareReference).Name,
-			Value: providedArg,
+	return b.callWithSelf(b.Context, args...)
+}
+
+func (b *blockImpl) callWithSelf(self Value, args ...Value) (Value, error) {
+	// a single array yielded to a block with several params is destructured
+	if len(args) == 1 && len(b.args) > 1 {
+		if array, ok := args[0].(*Array); ok {
+			args = array.members
 		}
-		invocationArgs = append(invocationArgs, blockArg)
 	}
 
-	return b.evaluator.EvaluateBlockWithArgsInContext(b.Context, invocationArgs, b.body)
+	// extra args are ignored, as with procs in ruby
+	if len(args) > len(b.args) {
+		args = args[:len(b.args)]
+	}
+
+	// params without a matching arg are bound with no value, which the
+	// evaluator takes as nil
+	invocationArgs := make([]BlockArg, 0, len(b.args))
+	for index, param := range b.args {
+		var providedArg Value
+		if index < len(args) {
+			providedArg = args[index]
+		}
+
+		invocationArgs = append(invocationArgs, bindBlockParam(param, providedArg)...)
+	}
+
+	return b.evaluator.EvaluateBlockWithArgsInContext(self, invocationArgs, b.body)
+}
+
+// calls the block with self as its receiver rather than the receiver of
+// the scope it was written in, as instance_eval does. Blocks that aren't
+// written in ruby are called as they are.
+func CallBlockWithSelf(block Block, self Value, args ...Value) (Value, error) {
+	switch block := block.(type) {
+	case *blockImpl:
+		return block.callWithSelf(self, args...)
+	case *ProcValue:
+		return CallBlockWithSelf(block.block, self, args...)
+	default:
+		return block.Call(args...)
+	}
+}
+
+// binds a value to a block param, splitting an array passed to a
+// destructured param such as (key, value) across its names. A nil value
+// leaves every name it binds without a value.
+func bindBlockParam(param ast.Node, value Value) []BlockArg {
+	destructured, ok := param.(ast.DestructuredParam)
+	if !ok {
+		return []BlockArg{{Name: param.(ast.BareReference).Name, Value: value}}
+	}
+
+	values := []Value{value}
+	if array, ok := value.(*Array); ok {
+		values = array.members
+	}
+
+	bound := []BlockArg{}
+	for index, nested := range destructured.Params {
+		var nestedValue Value
+		if index < len(values) {
+			nestedValue = values[index]
+		}
+
+		bound = append(bound, bindBlockParam(nested, nestedValue)...)
+	}
+
+	return bound
 }
 
 func NewBlock(Context Value, args []ast.Node, body []ast.Node, evaluator BlockEvaluator) Block {
@@ -0,0 +1,91 @@
+package builtins
+
+import (
+	"errors"
+	"fmt"
+)
+
+// a characterSet is the set of characters described by the arguments to
+// String#count, #squeeze and friends, e.g. "a-z" or "^aeiou"
+type characterSet struct {
+	runes   map[rune]bool
+	ranges  [][2]rune
+	negated bool
+}
+
+func newCharacterSet(spec string) (*characterSet, error) {
+	set := &characterSet{runes: map[rune]bool{}}
+
+	runes := []rune(spec)
+	if len(runes) > 1 && runes[0] == '^' {
+		set.negated = true
+		runes = runes[1:]
+	}
+
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		if r == '\\' && i+1 < len(runes) {
+			i++
+			set.runes[runes[i]] = true
+			continue
+		}
+
+		// a dash at either end of the spec is a literal dash
+		if i+2 < len(runes) && runes[i+1] == '-' {
+			last := runes[i+2]
+			if last < r {
+				return nil, NewArgumentError(fmt.Sprintf(`invalid range "%c-%c" in string transliteration`, r, last), "")
+			}
+
+			set.ranges = append(set.ranges, [2]rune{r, last})
+			i += 2
+			continue
+		}
+
+		set.runes[r] = true
+	}
+
+	return set, nil
+}
+
+func (set *characterSet) contains(r rune) bool {
+	found := set.runes[r]
+	for _, bounds := range set.ranges {
+		if r >= bounds[0] && r <= bounds[1] {
+			found = true
+			break
+		}
+	}
+
+	return found != set.negated
+}
+
+// builds the intersection of the sets given as string arguments
+func newCharacterSets(args []Value) ([]*characterSet, error) {
+	sets := []*characterSet{}
+	for _, arg := range args {
+		spec, ok := arg.(*StringValue)
+		if !ok {
+			return nil, errors.New(fmt.Sprintf("TypeError: no implicit conversion of %s into String", arg.Class().String()))
+		}
+
+		set, err := newCharacterSet(spec.value)
+		if err != nil {
+			return nil, err
+		}
+
+		sets = append(sets, set)
+	}
+
+	return sets, nil
+}
+
+func inEveryCharacterSet(sets []*characterSet, r rune) bool {
+	for _, set := range sets {
+		if !set.contains(r) {
+			return false
+		}
+	}
+
+	return true
+}
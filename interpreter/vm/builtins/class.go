@@ -43,7 +43,7 @@ func NewClassClass(provider ClassProvider, singletonProvider SingletonProvider)
 		if err == nil {
 			_, err = method.Execute(instance, block, args...)
 			if err != nil {
-				panic(err)
+				return nil, err
 			}
 		}
 
@@ -81,29 +81,31 @@ type UserDefinedClass struct {
 
 	attr_readers []string
 	attr_writers []string
+
+	// the names of instance methods that can only be called without an
+	// explicit receiver, and whether methods defined from now on join them
+	private_instance_methods []string
+	private_by_default       bool
 }
 
 type UserDefinedClassInstance struct {
 	valueStub
 
 	provider ClassProvider
-	attrs    map[string]Value
 }
 
 func (i *UserDefinedClassInstance) String() string {
 	return fmt.Sprintf("%s:%p", i.Class().String(), i)
 }
 
-func NewUserDefinedClass(name string, provider ClassProvider, singletonProvider SingletonProvider) Class {
+func NewUserDefinedClass(name string, superClass Class, provider ClassProvider, singletonProvider SingletonProvider) Class {
 	c := &UserDefinedClass{
 		name: name,
 	}
 	c.initialize()
 	c.setStringer(c.String)
 	c.class = provider.ClassWithName("Class")
-
-	// FIXME: should be provided as an argument
-	c.superClass = provider.ClassWithName("Object")
+	c.superClass = superClass
 
 	c.AddMethod(NewNativeMethod("include", provider, singletonProvider, func(self Value, block Block, args ...Value) (Value, error) {
 		for _, arg := range args {
@@ -164,33 +166,113 @@ func NewUserDefinedClass(name string, provider ClassProvider, singletonProvider
 		return nil, nil
 	}))
 
+	c.AddPrivateMethod(newDefineMethod(provider, singletonProvider))
+
+	// without any names, makes the methods defined after it private
+	c.AddMethod(NewNativeMethod("private", provider, singletonProvider, func(self Value, block Block, args ...Value) (Value, error) {
+		class := self.(*UserDefinedClass)
+		if len(args) == 0 {
+			class.private_by_default = true
+			return singletonProvider.SingletonWithName("nil"), nil
+		}
+
+		for _, arg := range args {
+			switch name := arg.(type) {
+			case *SymbolValue:
+				class.private_instance_methods = append(class.private_instance_methods, name.Name())
+			case *StringValue:
+				class.private_instance_methods = append(class.private_instance_methods, name.value)
+			default:
+				return nil, errors.New(fmt.Sprintf("TypeError: %s is not a symbol nor a string", arg.String()))
+			}
+		}
+
+		if len(args) == 1 {
+			return args[0], nil
+		}
+
+		array, _ := provider.ClassWithName("Array").New(provider, singletonProvider)
+		array.(*Array).members = args
+		return array, nil
+	}))
+
 	return c
 }
 
+// defines an instance method whose body is the given block, called with
+// the instance as self and the method's arguments bound to its params
+func newDefineMethod(provider ClassProvider, singletonProvider SingletonProvider) Method {
+	return NewNativeMethod("define_method", provider, singletonProvider, func(self Value, block Block, args ...Value) (Value, error) {
+		if len(args) != 1 {
+			return nil, NewArgumentError(fmt.Sprintf("wrong number of arguments (given %d, expected 1)", len(args)), "")
+		}
+
+		if block == nil {
+			return nil, NewArgumentError("tried to create Proc object without a block", "")
+		}
+
+		var name string
+		switch arg := args[0].(type) {
+		case *SymbolValue:
+			name = arg.Name()
+		case *StringValue:
+			name = arg.value
+		default:
+			return nil, errors.New(fmt.Sprintf("TypeError: %s is not a symbol nor a string", arg.String()))
+		}
+
+		self.(Module).AddInstanceMethod(NewNativeMethod(name, provider, singletonProvider, func(instance Value, _ Block, args ...Value) (Value, error) {
+			return CallBlockWithSelf(block, instance, args...)
+		}))
+
+		return singletonProvider.SymbolWithName(name), nil
+	})
+}
+
+func (c *UserDefinedClass) AddInstanceMethod(method Method) {
+	c.classStub.AddInstanceMethod(method)
+
+	if c.private_by_default {
+		c.private_instance_methods = append(c.private_instance_methods, method.Name())
+	}
+}
+
+func (c *UserDefinedClass) isPrivateInstanceMethod(name string) bool {
+	for _, private := range c.private_instance_methods {
+		if private == name {
+			return true
+		}
+	}
+
+	return false
+}
+
 func (c *UserDefinedClass) New(provider ClassProvider, singletonProvider SingletonProvider, args ...Value) (Value, error) {
 	instance := &UserDefinedClassInstance{}
 	instance.initialize()
 	instance.setStringer(instance.String)
 	instance.provider = provider
-	instance.attrs = make(map[string]Value)
 	instance.class = c
 
-	for _, m := range c.instanceMethods {
-		instance.AddMethod(m)
-	}
-
-	for _, module := range c.includedModules() {
-		for _, method := range module.(Module).InstanceMethods() {
-			instance.AddMethod(method)
+	// copy in the instance methods of every ancestor, farthest first, so
+	// that the class's own methods win over those of its modules, and the
+	// modules' over those of its superclass
+	chain := ancestors(c)
+	for i := len(chain) - 1; i >= 0; i-- {
+		for _, m := range chain[i].(Module).InstanceMethods() {
+			if owner, ok := chain[i].(*UserDefinedClass); ok && owner.isPrivateInstanceMethod(m.Name()) {
+				instance.AddPrivateMethod(m)
+			} else {
+				instance.AddMethod(m)
+			}
 		}
 	}
 
 	// FIXME: these should be defined on Module
 	for _, attr := range c.attr_readers {
 		instance.AddMethod(NewNativeMethod(attr, provider, singletonProvider, func(self Value, block Block, args ...Value) (Value, error) {
-			this := self.(*UserDefinedClassInstance)
-			value, ok := this.attrs[attr]
-			if !ok {
+			value := self.GetInstanceVariable(attr)
+			if value == nil {
 				return singletonProvider.SingletonWithName("nil"), nil
 			}
 
@@ -200,9 +282,8 @@ func (c *UserDefinedClass) New(provider ClassProvider, singletonProvider Singlet
 
 	for _, attr := range c.attr_writers {
 		instance.AddMethod(NewNativeMethod(attr+"=", provider, singletonProvider, func(self Value, block Block, args ...Value) (Value, error) {
-			this := self.(*UserDefinedClassInstance)
-			this.attrs[attr] = args[0]
-			return nil, nil
+			self.SetInstanceVariable(attr, args[0])
+			return args[0], nil
 		}))
 	}
 
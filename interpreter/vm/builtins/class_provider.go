@@ -19,3 +19,7 @@ type SingletonProvider interface {
 	SymbolWithName(string) Value
 	AddSymbol(Value)
 }
+
+type GlobalProvider interface {
+	Globals() map[string]Value
+}
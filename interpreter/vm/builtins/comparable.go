@@ -1,25 +1,183 @@
 package builtins
 
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
 func NewComparableModule(provider ClassProvider, singletonProvider SingletonProvider) Module {
 	m := NewModule("Comparable", provider, singletonProvider)
+	boolean := func(value bool) Value {
+		if value {
+			return singletonProvider.SingletonWithName("true")
+		} else {
+			return singletonProvider.SingletonWithName("false")
+		}
+	}
+
 	m.AddMethod(NewNativeMethod("<", provider, singletonProvider, func(self Value, block Block, args ...Value) (Value, error) {
-		return nil, nil
+		order, err := compareValues(self, args[0])
+		if err != nil {
+			return nil, err
+		}
+
+		return boolean(order < 0), nil
 	}))
 	m.AddMethod(NewNativeMethod("<=", provider, singletonProvider, func(self Value, block Block, args ...Value) (Value, error) {
-		return nil, nil
+		order, err := compareValues(self, args[0])
+		if err != nil {
+			return nil, err
+		}
+
+		return boolean(order <= 0), nil
 	}))
 	m.AddMethod(NewNativeMethod("==", provider, singletonProvider, func(self Value, block Block, args ...Value) (Value, error) {
-		return nil, nil
+		if self == args[0] {
+			return boolean(true), nil
+		}
+
+		// objects that cannot be compared are simply not equal
+		order, err := compareValues(self, args[0])
+		return boolean(err == nil && order == 0), nil
 	}))
 	m.AddMethod(NewNativeMethod(">=", provider, singletonProvider, func(self Value, block Block, args ...Value) (Value, error) {
-		return nil, nil
+		order, err := compareValues(self, args[0])
+		if err != nil {
+			return nil, err
+		}
+
+		return boolean(order >= 0), nil
 	}))
 	m.AddMethod(NewNativeMethod(">", provider, singletonProvider, func(self Value, block Block, args ...Value) (Value, error) {
-		return nil, nil
+		order, err := compareValues(self, args[0])
+		if err != nil {
+			return nil, err
+		}
+
+		return boolean(order > 0), nil
 	}))
 	m.AddMethod(NewNativeMethod("between?", provider, singletonProvider, func(self Value, block Block, args ...Value) (Value, error) {
-		return nil, nil
+		if len(args) != 2 {
+			return nil, NewArgumentError(fmt.Sprintf("wrong number of arguments (%d for 2)", len(args)), "")
+		}
+
+		fromMin, err := compareValues(self, args[0])
+		if err != nil {
+			return nil, err
+		}
+
+		toMax, err := compareValues(self, args[1])
+		if err != nil {
+			return nil, err
+		}
+
+		return boolean(fromMin >= 0 && toMax <= 0), nil
+	}))
+	// either bound may be left off by clamping to a beginless or endless range
+	m.AddMethod(NewNativeMethod("clamp", provider, singletonProvider, func(self Value, block Block, args ...Value) (Value, error) {
+		var min, max Value
+		switch len(args) {
+		case 1:
+			bounds, ok := args[0].(*RangeValue)
+			if !ok {
+				return nil, errors.New(fmt.Sprintf("TypeError: wrong argument type %s (expected Range)", args[0].Class().String()))
+			}
+			if bounds.exclusive && !isNilValue(bounds.end) {
+				return nil, NewArgumentError("cannot clamp with an exclusive range", "")
+			}
+
+			if !isNilValue(bounds.start) {
+				min = bounds.start
+			}
+			if !isNilValue(bounds.end) {
+				max = bounds.end
+			}
+		case 2:
+			min, max = args[0], args[1]
+		default:
+			return nil, NewArgumentError(fmt.Sprintf("wrong number of arguments (%d for 1..2)", len(args)), "")
+		}
+
+		if min != nil && max != nil {
+			order, err := compareValues(min, max)
+			if err != nil {
+				return nil, err
+			}
+			if order > 0 {
+				return nil, NewArgumentError("min argument must be smaller than max argument", "")
+			}
+		}
+
+		if min != nil {
+			if order, err := compareValues(self, min); err != nil {
+				return nil, err
+			} else if order < 0 {
+				return min, nil
+			}
+		}
+
+		if max != nil {
+			if order, err := compareValues(self, max); err != nil {
+				return nil, err
+			} else if order > 0 {
+				return max, nil
+			}
+		}
+
+		return self, nil
 	}))
 
 	return m
 }
+
+// compares two values, returning -1, 0 or 1 like <=>
+// numbers are compared directly, anything else is asked via its <=> method
+func compareValues(left, right Value) (int, error) {
+	leftNumber, leftIsNumber := numericValue(left)
+	rightNumber, rightIsNumber := numericValue(right)
+	if leftIsNumber && rightIsNumber {
+		switch {
+		case leftNumber < rightNumber:
+			return -1, nil
+		case leftNumber > rightNumber:
+			return 1, nil
+		default:
+			return 0, nil
+		}
+	}
+
+	if leftString, ok := left.(*StringValue); ok {
+		if rightString, ok := right.(*StringValue); ok {
+			return strings.Compare(leftString.value, rightString.value), nil
+		}
+	}
+
+	spaceship, err := left.Method("<=>")
+	if err != nil {
+		return 0, err
+	}
+
+	result, err := spaceship.Execute(left, nil, right)
+	if err != nil {
+		return 0, err
+	}
+
+	order, ok := result.(*fixnumInstance)
+	if !ok {
+		return 0, NewArgumentError(fmt.Sprintf("comparison of %s with %s failed", left.Class().String(), right.String()), "")
+	}
+
+	return order.value, nil
+}
+
+func numericValue(value Value) (float64, bool) {
+	switch value := value.(type) {
+	case *fixnumInstance:
+		return float64(value.value), true
+	case *FloatValue:
+		return value.value, true
+	default:
+		return 0, false
+	}
+}
@@ -0,0 +1,21 @@
+package builtins
+
+import "fmt"
+
+type domainError struct {
+	message   string
+	callstack string
+	valueStub
+}
+
+func NewDomainError(message, callstack string) *domainError {
+	return &domainError{message: message, callstack: callstack}
+}
+
+func (err *domainError) String() string {
+	return "Math::DomainError"
+}
+
+func (err *domainError) Error() string {
+	return fmt.Sprintf("Math::DomainError: %s\n%s", err.message, err.callstack)
+}
@@ -0,0 +1,70 @@
+package builtins
+
+import (
+	"errors"
+	"fmt"
+)
+
+type encodingClass struct {
+	valueStub
+	classStub
+}
+
+func NewEncodingClass(provider ClassProvider, singletonProvider SingletonProvider) Class {
+	class := &encodingClass{}
+	class.initialize()
+	class.setStringer(class.String)
+	class.class = provider.ClassWithName("Class")
+	class.superClass = provider.ClassWithName("Object")
+
+	class.AddMethod(NewNativeMethod("name", provider, singletonProvider, func(self Value, block Block, args ...Value) (Value, error) {
+		return NewString(self.(*EncodingValue).name, provider, singletonProvider), nil
+	}))
+
+	class.AddMethod(NewNativeMethod("to_s", provider, singletonProvider, func(self Value, block Block, args ...Value) (Value, error) {
+		return NewString(self.(*EncodingValue).name, provider, singletonProvider), nil
+	}))
+
+	utf8 := class.newEncoding("UTF-8")
+	binary := class.newEncoding("ASCII-8BIT")
+	class.SetConstant("UTF_8", utf8)
+	class.SetConstant("ASCII_8BIT", binary)
+	class.SetConstant("BINARY", binary)
+	class.SetConstant("US_ASCII", class.newEncoding("US-ASCII"))
+
+	return class
+}
+
+// the Encoding class is not registered yet while its constants are defined
+func (c *encodingClass) newEncoding(name string) *EncodingValue {
+	e := &EncodingValue{name: name}
+	e.class = c
+	e.initialize()
+	e.setStringer(e.String)
+	return e
+}
+
+func (c *encodingClass) String() string {
+	return "Encoding"
+}
+
+func (c *encodingClass) Name() string {
+	return "Encoding"
+}
+
+func (c *encodingClass) New(provider ClassProvider, singletonProvider SingletonProvider, args ...Value) (Value, error) {
+	return nil, errors.New("NoMethodError: undefined method 'new' for Encoding:Class")
+}
+
+type EncodingValue struct {
+	name string
+	valueStub
+}
+
+func (e *EncodingValue) String() string {
+	return fmt.Sprintf("#<Encoding:%s>", e.name)
+}
+
+func (e *EncodingValue) Name() string {
+	return e.name
+}
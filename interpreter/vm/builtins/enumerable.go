@@ -0,0 +1,260 @@
+package builtins
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+)
+
+func NewEnumerableModule(provider ClassProvider, singletonProvider SingletonProvider) Module {
+	m := NewModule("Enumerable", provider, singletonProvider)
+
+	m.AddMethod(NewNativeMethod("min_by", provider, singletonProvider, func(self Value, block Block, args ...Value) (Value, error) {
+		if block == nil {
+			return NewEnumeratorForMethod(self, "min_by", provider, args...), nil
+		}
+
+		return extremesBy(self, block, -1, provider, singletonProvider, args...)
+	}))
+
+	m.AddMethod(NewNativeMethod("max_by", provider, singletonProvider, func(self Value, block Block, args ...Value) (Value, error) {
+		if block == nil {
+			return NewEnumeratorForMethod(self, "max_by", provider, args...), nil
+		}
+
+		return extremesBy(self, block, 1, provider, singletonProvider, args...)
+	}))
+
+	m.AddMethod(NewNativeMethod("each_with_object", provider, singletonProvider, func(self Value, block Block, args ...Value) (Value, error) {
+		if len(args) != 1 {
+			return nil, NewArgumentError(fmt.Sprintf("wrong number of arguments (%d for 1)", len(args)), "")
+		}
+
+		if block == nil {
+			return NewEnumeratorForMethod(self, "each_with_object", provider, args...), nil
+		}
+
+		values, err := enumerableValues(self, provider, singletonProvider)
+		if err != nil {
+			return nil, err
+		}
+
+		memo := args[0]
+		for _, value := range values {
+			if _, err := block.Call(value, memo); err != nil {
+				return nil, err
+			}
+		}
+
+		return memo, nil
+	}))
+
+	// other collections are zipped by their own #each, so they need not be arrays
+	m.AddMethod(NewNativeMethod("zip", provider, singletonProvider, func(self Value, block Block, args ...Value) (Value, error) {
+		values, err := enumerableValues(self, provider, singletonProvider)
+		if err != nil {
+			return nil, err
+		}
+
+		others := make([][]Value, len(args))
+		for i, arg := range args {
+			if array, ok := arg.(*Array); ok {
+				others[i] = array.members
+				continue
+			}
+
+			if _, err := arg.Method("each"); err != nil {
+				return nil, errors.New(fmt.Sprintf("TypeError: wrong argument type %s (must respond to :each)", arg.Class().String()))
+			}
+
+			others[i], err = enumerableValues(arg, provider, singletonProvider)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		zipped, _ := provider.ClassWithName("Array").New(provider, singletonProvider)
+		for index, value := range values {
+			tuple, _ := provider.ClassWithName("Array").New(provider, singletonProvider)
+			tuple.(*Array).Append(value)
+			for _, other := range others {
+				if index < len(other) {
+					tuple.(*Array).Append(other[index])
+				} else {
+					tuple.(*Array).Append(singletonProvider.SingletonWithName("nil"))
+				}
+			}
+
+			if block == nil {
+				zipped.(*Array).Append(tuple)
+			} else if _, err := block.Call(tuple); err != nil {
+				return nil, err
+			}
+		}
+
+		if block != nil {
+			return singletonProvider.SingletonWithName("nil"), nil
+		}
+
+		return zipped, nil
+	}))
+
+	// like #each, except that several values yielded at once are
+	// passed to the block as a single array
+	m.AddMethod(NewNativeMethod("each_entry", provider, singletonProvider, func(self Value, block Block, args ...Value) (Value, error) {
+		if block == nil {
+			return NewEnumeratorForMethod(self, "each_entry", provider, args...), nil
+		}
+
+		values, err := enumerableValues(self, provider, singletonProvider)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, value := range values {
+			if _, err := block.Call(value); err != nil {
+				return nil, err
+			}
+		}
+
+		return self, nil
+	}))
+
+	// groups keep the order in which their keys first appear
+	m.AddMethod(NewNativeMethod("group_by", provider, singletonProvider, func(self Value, block Block, args ...Value) (Value, error) {
+		if block == nil {
+			return NewEnumeratorForMethod(self, "group_by", provider, args...), nil
+		}
+
+		values, err := enumerableValues(self, provider, singletonProvider)
+		if err != nil {
+			return nil, err
+		}
+
+		h, _ := provider.ClassWithName("Hash").New(provider, singletonProvider)
+		groups := h.(*Hash)
+		for _, value := range values {
+			key, err := block.Call(value)
+			if err != nil {
+				return nil, err
+			}
+
+			group, ok := groups.Get(key)
+			if !ok {
+				group, _ = provider.ClassWithName("Array").New(provider, singletonProvider)
+				groups.Add(key, group)
+			}
+
+			group.(*Array).Append(value)
+		}
+
+		return groups, nil
+	}))
+
+	// maps each value through the block, keeping only the truthy results
+	m.AddMethod(NewNativeMethod("filter_map", provider, singletonProvider, func(self Value, block Block, args ...Value) (Value, error) {
+		if block == nil {
+			return NewEnumeratorForMethod(self, "filter_map", provider, args...), nil
+		}
+
+		values, err := enumerableValues(self, provider, singletonProvider)
+		if err != nil {
+			return nil, err
+		}
+
+		mapped, _ := provider.ClassWithName("Array").New(provider, singletonProvider)
+		for _, value := range values {
+			result, err := block.Call(value)
+			if err != nil {
+				return nil, err
+			}
+
+			if result.IsTruthy() {
+				mapped.(*Array).Append(result)
+			}
+		}
+
+		return mapped, nil
+	}))
+
+	return m
+}
+
+// collects every value yielded by the receiver's #each
+func enumerableValues(self Value, provider ClassProvider, singletonProvider SingletonProvider) ([]Value, error) {
+	enumerator := NewEnumeratorForMethod(self, "each", provider).(*EnumeratorValue)
+	values, err := enumerator.toArray(provider, singletonProvider)
+	if err != nil {
+		return nil, err
+	}
+
+	return values.members, nil
+}
+
+// finds the value whose key from the block is the smallest (direction -1)
+// or largest (direction 1). When a count is given, an array of that many
+// values is returned instead, ordered from the most extreme key.
+func extremesBy(self Value, block Block, direction int, provider ClassProvider, singletonProvider SingletonProvider, args ...Value) (Value, error) {
+	if len(args) > 1 {
+		return nil, NewArgumentError(fmt.Sprintf("wrong number of arguments (%d for 0..1)", len(args)), "")
+	}
+
+	values, err := enumerableValues(self, provider, singletonProvider)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make([]Value, len(values))
+	for i, value := range values {
+		keys[i], err = block.Call(value)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	indices := make([]int, len(values))
+	for i := range indices {
+		indices[i] = i
+	}
+
+	var sortErr error
+	sort.SliceStable(indices, func(i, j int) bool {
+		if sortErr != nil {
+			return false
+		}
+
+		order, err := compareValues(keys[indices[i]], keys[indices[j]])
+		if err != nil {
+			sortErr = err
+			return false
+		}
+
+		return order*direction > 0
+	})
+	if sortErr != nil {
+		return nil, sortErr
+	}
+
+	if len(args) == 0 {
+		if len(indices) == 0 {
+			return singletonProvider.SingletonWithName("nil"), nil
+		}
+
+		return values[indices[0]], nil
+	}
+
+	count, ok := args[0].(*fixnumInstance)
+	if !ok {
+		return nil, errors.New(fmt.Sprintf("TypeError: no implicit conversion of %s into Integer", args[0].Class().String()))
+	}
+	if count.value < 0 {
+		return nil, NewArgumentError(fmt.Sprintf("negative size (%d)", count.value), "")
+	}
+
+	result, _ := provider.ClassWithName("Array").New(provider, singletonProvider)
+	for i := 0; i < count.value && i < len(indices); i++ {
+		result.(*Array).Append(values[indices[i]])
+	}
+
+	return result, nil
+}
@@ -0,0 +1,167 @@
+package builtins
+
+import (
+	"errors"
+	"fmt"
+)
+
+type enumeratorClass struct {
+	valueStub
+	classStub
+}
+
+func NewEnumeratorClass(provider ClassProvider, singletonProvider SingletonProvider) Class {
+	class := &enumeratorClass{}
+	class.initialize()
+	class.setStringer(class.String)
+	class.class = provider.ClassWithName("Class")
+	class.superClass = provider.ClassWithName("Object")
+
+	class.AddMethod(NewNativeMethod("each", provider, singletonProvider, func(self Value, block Block, args ...Value) (Value, error) {
+		if block == nil {
+			return self, nil
+		}
+
+		return self.(*EnumeratorValue).each(block)
+	}))
+
+	class.AddMethod(NewNativeMethod("with_index", provider, singletonProvider, func(self Value, block Block, args ...Value) (Value, error) {
+		offset := 0
+		if len(args) > 0 {
+			offsetArg, ok := args[0].(*fixnumInstance)
+			if !ok {
+				return nil, errors.New(fmt.Sprintf("TypeError: no implicit conversion of %s into Integer", args[0].Class().String()))
+			}
+
+			offset = offsetArg.value
+		}
+
+		enumerator := self.(*EnumeratorValue)
+		withIndex := func(block Block) (Value, error) {
+			index := offset
+			return enumerator.each(blockFunc(func(args ...Value) (Value, error) {
+				args = append(args, NewFixnum(index, provider, singletonProvider))
+				index++
+				return block.Call(args...)
+			}))
+		}
+
+		if block == nil {
+			return NewEnumerator(withIndex, provider), nil
+		}
+
+		return withIndex(block)
+	}))
+
+	class.AddMethod(NewNativeMethod("to_a", provider, singletonProvider, func(self Value, block Block, args ...Value) (Value, error) {
+		return self.(*EnumeratorValue).toArray(provider, singletonProvider)
+	}))
+
+	class.AddMethod(NewNativeMethod("next", provider, singletonProvider, func(self Value, block Block, args ...Value) (Value, error) {
+		enumerator := self.(*EnumeratorValue)
+		if enumerator.buffered == nil {
+			buffered, err := enumerator.toArray(provider, singletonProvider)
+			if err != nil {
+				return nil, err
+			}
+
+			enumerator.buffered = buffered.members
+		}
+
+		if enumerator.position >= len(enumerator.buffered) {
+			return nil, NewStopIteration("iteration reached an end", "")
+		}
+
+		value := enumerator.buffered[enumerator.position]
+		enumerator.position++
+		return value, nil
+	}))
+
+	class.AddMethod(NewNativeMethod("rewind", provider, singletonProvider, func(self Value, block Block, args ...Value) (Value, error) {
+		self.(*EnumeratorValue).position = 0
+		return self, nil
+	}))
+
+	return class
+}
+
+func (c *enumeratorClass) String() string {
+	return "Enumerator"
+}
+
+func (c *enumeratorClass) Name() string {
+	return "Enumerator"
+}
+
+func (c *enumeratorClass) New(provider ClassProvider, singletonProvider SingletonProvider, args ...Value) (Value, error) {
+	return nil, NewArgumentError("no block given", "")
+}
+
+// an EnumeratorValue wraps an iteration that has not been given a block yet,
+// e.g. the result of calling Array#each without a block
+type EnumeratorValue struct {
+	valueStub
+
+	each func(Block) (Value, error)
+
+	// the values yielded so far by #next
+	buffered []Value
+	position int
+}
+
+func NewEnumerator(each func(Block) (Value, error), provider ClassProvider) Value {
+	e := &EnumeratorValue{each: each}
+	e.initialize()
+	e.setStringer(e.String)
+	e.class = provider.ClassWithName("Enumerator")
+	return e
+}
+
+// returns an enumerator that will call the named method on the receiver
+// with whichever block the enumerator is eventually given
+func NewEnumeratorForMethod(receiver Value, methodName string, provider ClassProvider, args ...Value) Value {
+	return NewEnumerator(func(block Block) (Value, error) {
+		method, err := receiver.Method(methodName)
+		if err != nil {
+			return nil, err
+		}
+
+		return method.Execute(receiver, block, args...)
+	}, provider)
+}
+
+func (e *EnumeratorValue) String() string {
+	return "#<Enumerator: ...>"
+}
+
+func (e *EnumeratorValue) toArray(provider ClassProvider, singletonProvider SingletonProvider) (*Array, error) {
+	arr, _ := provider.ClassWithName("Array").New(provider, singletonProvider)
+	values := arr.(*Array)
+
+	_, err := e.each(blockFunc(func(args ...Value) (Value, error) {
+		if len(args) == 1 {
+			values.Append(args[0])
+		} else {
+			yielded, _ := provider.ClassWithName("Array").New(provider, singletonProvider)
+			for _, arg := range args {
+				yielded.(*Array).Append(arg)
+			}
+			values.Append(yielded)
+		}
+
+		return singletonProvider.SingletonWithName("nil"), nil
+	}))
+
+	if err != nil {
+		return nil, err
+	}
+
+	return values, nil
+}
+
+// blockFunc allows a go function to be passed wherever ruby expects a block
+type blockFunc func(args ...Value) (Value, error)
+
+func (f blockFunc) Call(args ...Value) (Value, error) {
+	return f(args...)
+}
@@ -0,0 +1,46 @@
+package builtins
+
+// the classes of ruby's builtin exception hierarchy
+// errors raised by the VM are matched against these by name
+type exceptionClass struct {
+	name string
+	valueStub
+	classStub
+}
+
+func NewExceptionClass(name string, superClass Class, provider ClassProvider) Class {
+	class := &exceptionClass{name: name}
+	class.initialize()
+	class.setStringer(class.String)
+	class.class = provider.ClassWithName("Class")
+	class.superClass = superClass
+
+	return class
+}
+
+func (c *exceptionClass) String() string {
+	return c.name
+}
+
+func (c *exceptionClass) Name() string {
+	return c.name
+}
+
+func (c *exceptionClass) New(provider ClassProvider, singletonProvider SingletonProvider, args ...Value) (Value, error) {
+	e := &exceptionInstance{message: c.name}
+	if len(args) > 0 {
+		e.message = outputString(args[0])
+	}
+
+	e.initialize()
+	e.setStringer(e.String)
+	e.class = c
+
+	return e, nil
+}
+
+// an exception created with new, which keeps its message until it's raised
+type exceptionInstance struct {
+	object
+	message string
+}
@@ -3,6 +3,8 @@ package builtins
 import (
 	"errors"
 	"fmt"
+	"math"
+	"strconv"
 )
 
 type floatClass struct {
@@ -10,16 +12,65 @@ type floatClass struct {
 	classStub
 }
 
-func NewFloatClass(provider ClassProvider) Class {
+func NewFloatClass(provider ClassProvider, singletonProvider SingletonProvider) Class {
 	class := &floatClass{}
 	class.initialize()
 	class.setStringer(class.String)
 	class.class = provider.ClassWithName("Class")
 	class.superClass = provider.ClassWithName("Numeric")
 
+	class.SetConstant("INFINITY", class.newFloat(math.Inf(1)))
+	class.SetConstant("NAN", class.newFloat(math.NaN()))
+	class.SetConstant("EPSILON", class.newFloat(math.Nextafter(1, 2)-1))
+	class.SetConstant("MAX", class.newFloat(math.MaxFloat64))
+	class.SetConstant("MIN", class.newFloat(2.2250738585072014e-308))
+
+	class.AddMethod(NewNativeMethod("-@", provider, singletonProvider, func(self Value, block Block, args ...Value) (Value, error) {
+		return NewFloat(-self.(*FloatValue).value, provider), nil
+	}))
+
+	class.AddMethod(NewNativeMethod("nan?", provider, singletonProvider, func(self Value, block Block, args ...Value) (Value, error) {
+		if math.IsNaN(self.(*FloatValue).value) {
+			return singletonProvider.SingletonWithName("true"), nil
+		} else {
+			return singletonProvider.SingletonWithName("false"), nil
+		}
+	}))
+
+	class.AddMethod(NewNativeMethod("infinite?", provider, singletonProvider, func(self Value, block Block, args ...Value) (Value, error) {
+		value := self.(*FloatValue).value
+		switch {
+		case math.IsInf(value, 1):
+			return NewFixnum(1, provider, singletonProvider), nil
+		case math.IsInf(value, -1):
+			return NewFixnum(-1, provider, singletonProvider), nil
+		default:
+			return singletonProvider.SingletonWithName("nil"), nil
+		}
+	}))
+
+	class.AddMethod(NewNativeMethod("finite?", provider, singletonProvider, func(self Value, block Block, args ...Value) (Value, error) {
+		value := self.(*FloatValue).value
+		if math.IsNaN(value) || math.IsInf(value, 0) {
+			return singletonProvider.SingletonWithName("false"), nil
+		} else {
+			return singletonProvider.SingletonWithName("true"), nil
+		}
+	}))
+
 	return class
 }
 
+// the Float class is not registered yet while its constants are defined,
+// so they cannot be created with NewFloat
+func (c *floatClass) newFloat(val float64) Value {
+	f := &FloatValue{value: val}
+	f.class = c
+	f.initialize()
+	f.setStringer(f.String)
+	return f
+}
+
 func (c *floatClass) String() string {
 	return "Float"
 }
@@ -50,5 +101,17 @@ func (FloatValue *FloatValue) ValueAsFloat() float64 {
 }
 
 func (FloatValue *FloatValue) String() string {
-	return fmt.Sprintf("%d", FloatValue.value)
+	value := FloatValue.value
+	switch {
+	case math.IsNaN(value):
+		return "NaN"
+	case math.IsInf(value, 1):
+		return "Infinity"
+	case math.IsInf(value, -1):
+		return "-Infinity"
+	case value == math.Trunc(value) && math.Abs(value) < 1e16:
+		return fmt.Sprintf("%.1f", value)
+	default:
+		return strconv.FormatFloat(value, 'g', -1, 64)
+	}
 }
@@ -0,0 +1,210 @@
+package builtins
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// expands a printf-style format string as String#% does. Go's verbs share
+// their flags, width and precision syntax with ruby's, so each directive is
+// handed to fmt once its argument has been converted to the matching go type.
+func formatString(format string, args []Value) (string, error) {
+	var result strings.Builder
+	nextArg := func() (Value, error) {
+		if len(args) == 0 {
+			return nil, NewArgumentError("too few arguments", "")
+		}
+
+		arg := args[0]
+		args = args[1:]
+		return arg, nil
+	}
+
+	for i := 0; i < len(format); i++ {
+		if format[i] != '%' {
+			result.WriteByte(format[i])
+			continue
+		}
+
+		// %<name>s and %{name} take their argument from a hash of names,
+		// which may be given before or after the flags
+		var named Value
+		braced := false
+		takeName := func() error {
+			if named != nil || i >= len(format) || (format[i] != '<' && format[i] != '{') {
+				return nil
+			}
+
+			closing := byte('>')
+			if format[i] == '{' {
+				closing, braced = '}', true
+			}
+
+			end := strings.IndexByte(format[i+1:], closing)
+			if end < 0 {
+				return NewArgumentError("malformed name - unmatched parenthesis", "")
+			}
+
+			name := format[i+1 : i+1+end]
+			i += end + 2
+
+			var err error
+			named, err = namedFormatArg(name, args)
+			return err
+		}
+
+		// a * in place of the width or precision takes it from the next argument
+		spec := "%"
+		i++
+		if err := takeName(); err != nil {
+			return "", err
+		}
+		for !braced && i < len(format) && strings.IndexByte("-+ 0#.123456789*", format[i]) >= 0 {
+			if format[i] != '*' {
+				spec += string(format[i])
+				i++
+				continue
+			}
+
+			arg, err := nextArg()
+			if err != nil {
+				return "", err
+			}
+
+			size, ok := arg.(*fixnumInstance)
+			if !ok {
+				return "", errors.New(fmt.Sprintf("TypeError: no implicit conversion of %s into Integer", arg.Class().String()))
+			}
+
+			if strings.HasSuffix(spec, ".") && size.value < 0 {
+				spec = strings.TrimSuffix(spec, ".")
+			} else {
+				spec += fmt.Sprintf("%d", size.value)
+			}
+			i++
+		}
+
+		if err := takeName(); err != nil {
+			return "", err
+		}
+
+		if braced {
+			result.WriteString(fmt.Sprintf(spec+"s", stringForFormat(named)))
+			i--
+			continue
+		}
+
+		if i >= len(format) {
+			return "", NewArgumentError("incomplete format specifier; use %% (double %) instead", "")
+		}
+
+		verb := format[i]
+		if verb == '%' && named == nil {
+			result.WriteByte('%')
+			continue
+		}
+
+		arg := named
+		if arg == nil {
+			var err error
+			arg, err = nextArg()
+			if err != nil {
+				return "", err
+			}
+		}
+
+		var piece string
+		switch verb {
+		case 's':
+			piece = fmt.Sprintf(spec+"s", stringForFormat(arg))
+		case 'p':
+			inspected, err := inspectValue(arg, map[Value]bool{})
+			if err != nil {
+				return "", err
+			}
+
+			piece = fmt.Sprintf(spec+"s", inspected)
+		case 'd', 'i', 'u', 'x', 'X', 'o', 'b', 'B':
+			number, ok := numericValue(arg)
+			if !ok {
+				return "", errors.New(fmt.Sprintf("TypeError: can't convert %s into Integer", arg.Class().String()))
+			}
+
+			goVerb := string(verb)
+			switch verb {
+			case 'i', 'u':
+				goVerb = "d"
+			case 'B':
+				goVerb = "b"
+			}
+
+			piece = fmt.Sprintf(spec+goVerb, int(number))
+		case 'f', 'e', 'E', 'g', 'G':
+			number, ok := numericValue(arg)
+			if !ok {
+				return "", errors.New(fmt.Sprintf("TypeError: can't convert %s into Float", arg.Class().String()))
+			}
+
+			piece = fmt.Sprintf(spec+string(verb), number)
+		case 'c':
+			switch arg := arg.(type) {
+			case *fixnumInstance:
+				piece = fmt.Sprintf(spec+"c", rune(arg.value))
+			case *StringValue:
+				char, _ := utf8.DecodeRuneInString(arg.value)
+				piece = fmt.Sprintf(spec+"c", char)
+			default:
+				return "", errors.New(fmt.Sprintf("TypeError: no implicit conversion of %s into Integer", arg.Class().String()))
+			}
+		default:
+			return "", NewArgumentError(fmt.Sprintf("malformed format string - %%%c", verb), "")
+		}
+
+		result.WriteString(piece)
+	}
+
+	return result.String(), nil
+}
+
+// finds the value for a %<name> or %{name} reference in the hash that must
+// be the only argument
+func namedFormatArg(name string, args []Value) (Value, error) {
+	if len(args) != 1 {
+		return nil, NewArgumentError("one hash required", "")
+	}
+
+	hash, ok := args[0].(*Hash)
+	if !ok {
+		return nil, NewArgumentError("one hash required", "")
+	}
+
+	for _, key := range hash.keys {
+		if symbol, ok := key.(*SymbolValue); ok && symbol.value == name {
+			return hash.hash[key], nil
+		}
+	}
+
+	return nil, errors.New(fmt.Sprintf("KeyError: key<%s> not found", name))
+}
+
+// converts a value to the string %s would print, using its #to_s when it has one
+func stringForFormat(value Value) string {
+	switch value := value.(type) {
+	case *StringValue:
+		return value.value
+	case *SymbolValue:
+		return value.value
+	}
+
+	if toS, err := value.Method("to_s"); err == nil {
+		if str, err := toS.Execute(value, nil); err == nil {
+			if asStr, ok := str.(*StringValue); ok {
+				return asStr.value
+			}
+		}
+	}
+
+	return value.String()
+}
@@ -0,0 +1,24 @@
+package builtins
+
+import "fmt"
+
+type frozenError struct {
+	message   string
+	callstack string
+	valueStub
+}
+
+func NewFrozenError(receiver Value, callstack string) *frozenError {
+	return &frozenError{
+		message:   fmt.Sprintf("can't modify frozen %s: %s", receiver.Class().String(), receiver.String()),
+		callstack: callstack,
+	}
+}
+
+func (err *frozenError) String() string {
+	return "FrozenError"
+}
+
+func (err *frozenError) Error() string {
+	return fmt.Sprintf("FrozenError: %s\n%s", err.message, err.callstack)
+}
@@ -1,6 +1,7 @@
 package builtins
 
 import (
+	"errors"
 	"fmt"
 	"strings"
 )
@@ -25,7 +26,7 @@ func NewHashClass(provider ClassProvider, singletonProvider SingletonProvider) C
 	class.AddMethod(NewNativeMethod("keys", provider, singletonProvider, func(self Value, block Block, args ...Value) (Value, error) {
 		o, _ := provider.ClassWithName("Array").New(provider, singletonProvider)
 		keys := o.(*Array)
-		for key := range self.(*Hash).hash {
+		for _, key := range self.(*Hash).keys {
 			keys.Append(key)
 		}
 
@@ -34,22 +35,43 @@ func NewHashClass(provider ClassProvider, singletonProvider SingletonProvider) C
 	class.AddMethod(NewNativeMethod("values", provider, singletonProvider, func(self Value, block Block, args ...Value) (Value, error) {
 		o, _ := provider.ClassWithName("Array").New(provider, singletonProvider)
 		values := o.(*Array)
-		for key := range self.(*Hash).hash {
+		for _, key := range self.(*Hash).keys {
 			values.Append(self.(*Hash).hash[key])
 		}
 
 		return values, nil
 	}))
 
+	// each pair is yielded as a two element array, which blocks
+	// with |key, value| params destructure
+	each := func(self Value, block Block, args ...Value) (Value, error) {
+		if block == nil {
+			return NewEnumeratorForMethod(self, "each", provider), nil
+		}
+
+		selfAsHash := self.(*Hash)
+		for _, key := range append([]Value{}, selfAsHash.keys...) {
+			pair, _ := provider.ClassWithName("Array").New(provider, singletonProvider)
+			pair.(*Array).Append(key)
+			pair.(*Array).Append(selfAsHash.hash[key])
+
+			if _, err := block.Call(pair); err != nil {
+				return nil, err
+			}
+		}
+
+		return self, nil
+	}
+	class.AddMethod(NewNativeMethod("each", provider, singletonProvider, each))
+	class.AddMethod(NewNativeMethod("each_pair", provider, singletonProvider, each))
+
 	class.AddMethod(NewNativeMethod("[]=", provider, singletonProvider, func(self Value, block Block, args ...Value) (Value, error) {
-		self.(*Hash).hash[args[0]] = args[1]
+		self.(*Hash).Add(args[0], args[1])
 		return args[1], nil
 	}))
 
 	class.AddMethod(NewNativeMethod("[]", provider, singletonProvider, func(self Value, block Block, args ...Value) (Value, error) {
-		selfAsHash := self.(*Hash)
-		value, ok := selfAsHash.hash[args[0]]
-
+		value, ok := self.(*Hash).Get(args[0])
 		if !ok {
 			return singletonProvider.SingletonWithName("nil"), nil
 		} else {
@@ -57,6 +79,165 @@ func NewHashClass(provider ClassProvider, singletonProvider SingletonProvider) C
 		}
 	}))
 
+	class.AddMethod(NewNativeMethod("fetch", provider, singletonProvider, func(self Value, block Block, args ...Value) (Value, error) {
+		if len(args) == 0 || len(args) > 2 {
+			return nil, NewArgumentError(fmt.Sprintf("wrong number of arguments (given %d, expected 1..2)", len(args)), "")
+		}
+
+		if value, ok := self.(*Hash).Get(args[0]); ok {
+			return value, nil
+		}
+
+		switch {
+		case block != nil:
+			return block.Call(args[0])
+		case len(args) == 2:
+			return args[1], nil
+		default:
+			inspected, err := inspectValue(args[0], map[Value]bool{})
+			if err != nil {
+				return nil, err
+			}
+
+			return nil, errors.New(fmt.Sprintf("KeyError: key not found: %s", inspected))
+		}
+	}))
+
+	boolean := func(value bool) Value {
+		if value {
+			return singletonProvider.SingletonWithName("true")
+		} else {
+			return singletonProvider.SingletonWithName("false")
+		}
+	}
+
+	class.AddMethod(NewNativeMethod("==", provider, singletonProvider, func(self Value, block Block, args ...Value) (Value, error) {
+		other, ok := args[0].(*Hash)
+		if !ok || len(other.keys) != len(self.(*Hash).keys) {
+			return singletonProvider.SingletonWithName("false"), nil
+		}
+
+		equal, err := other.containsPairsOf(self.(*Hash))
+		if err != nil {
+			return nil, err
+		}
+
+		return boolean(equal), nil
+	}))
+
+	// each comparison asks whether one hash is a subset of the other,
+	// with the strict ones also requiring the subset to be smaller
+	for name, comparison := range map[string]struct{ superset, strict bool }{
+		"<=": {false, false},
+		"<":  {false, true},
+		">=": {true, false},
+		">":  {true, true},
+	} {
+		comparison := comparison
+		class.AddMethod(NewNativeMethod(name, provider, singletonProvider, func(self Value, block Block, args ...Value) (Value, error) {
+			other, ok := args[0].(*Hash)
+			if !ok {
+				return nil, errors.New(fmt.Sprintf("TypeError: no implicit conversion of %s into Hash", args[0].Class().String()))
+			}
+
+			subset, superset := self.(*Hash), other
+			if comparison.superset {
+				subset, superset = superset, subset
+			}
+
+			if comparison.strict && len(subset.keys) >= len(superset.keys) {
+				return singletonProvider.SingletonWithName("false"), nil
+			}
+
+			contained, err := superset.containsPairsOf(subset)
+			if err != nil {
+				return nil, err
+			}
+
+			return boolean(contained), nil
+		}))
+	}
+
+	// inverting a hash with duplicate values keeps the last key for each
+	class.AddMethod(NewNativeMethod("invert", provider, singletonProvider, func(self Value, block Block, args ...Value) (Value, error) {
+		selfAsHash := self.(*Hash)
+		h, _ := provider.ClassWithName("Hash").New(provider, singletonProvider)
+		inverted := h.(*Hash)
+		for _, key := range selfAsHash.keys {
+			inverted.Add(selfAsHash.hash[key], key)
+		}
+
+		return inverted, nil
+	}))
+
+	class.AddMethod(NewNativeMethod("key", provider, singletonProvider, func(self Value, block Block, args ...Value) (Value, error) {
+		selfAsHash := self.(*Hash)
+		for _, key := range selfAsHash.keys {
+			value := selfAsHash.hash[key]
+			equal, err := value.Method("==")
+			if err != nil {
+				return nil, err
+			}
+
+			result, err := equal.Execute(value, nil, args[0])
+			if err != nil {
+				return nil, err
+			}
+
+			if result.IsTruthy() {
+				return key, nil
+			}
+		}
+
+		return singletonProvider.SingletonWithName("nil"), nil
+	}))
+
+	class.AddMethod(NewNativeMethod("values_at", provider, singletonProvider, func(self Value, block Block, args ...Value) (Value, error) {
+		o, _ := provider.ClassWithName("Array").New(provider, singletonProvider)
+		values := o.(*Array)
+		for _, key := range args {
+			value, ok := self.(*Hash).Get(key)
+			if !ok {
+				value = singletonProvider.SingletonWithName("nil")
+			}
+
+			values.Append(value)
+		}
+
+		return values, nil
+	}))
+
+	class.AddMethod(NewNativeMethod("slice", provider, singletonProvider, func(self Value, block Block, args ...Value) (Value, error) {
+		selfAsHash := self.(*Hash)
+		h, _ := provider.ClassWithName("Hash").New(provider, singletonProvider)
+		sliced := h.(*Hash)
+		for _, key := range args {
+			if value, ok := selfAsHash.Get(key); ok {
+				sliced.Add(key, value)
+			}
+		}
+
+		return sliced, nil
+	}))
+
+	class.AddMethod(NewNativeMethod("except", provider, singletonProvider, func(self Value, block Block, args ...Value) (Value, error) {
+		excluded := make(map[Value]bool)
+		for _, key := range args {
+			excluded[self.(*Hash).storedKey(key)] = true
+		}
+
+		selfAsHash := self.(*Hash)
+		h, _ := provider.ClassWithName("Hash").New(provider, singletonProvider)
+		remaining := h.(*Hash)
+		for _, key := range selfAsHash.keys {
+			if !excluded[key] {
+				remaining.Add(key, selfAsHash.hash[key])
+			}
+		}
+
+		return remaining, nil
+	}))
+
 	return class
 }
 
@@ -70,6 +251,7 @@ func (klass *HashClass) New(provider ClassProvider, singletonProvider SingletonP
 	hash.setStringer(hash.String)
 	hash.class = klass
 	hash.hash = make(map[Value]Value)
+	hash.stringKeys = make(map[string]Value)
 
 	return hash, nil
 }
@@ -83,19 +265,66 @@ func (hash *HashClass) String() string {
 }
 
 type Hash struct {
-	hash map[Value]Value
+	hash       map[Value]Value
+	keys       []Value          // in insertion order, which ruby preserves when iterating
+	stringKeys map[string]Value // the stored key for the contents of each string key
 	valueStub
 }
 
 func (hash *Hash) String() string {
 	pieces := []string{}
-	for key, value := range hash.hash {
-		pieces = append(pieces, fmt.Sprintf("%s => %s", key.String(), value.String()))
+	for _, key := range hash.keys {
+		pieces = append(pieces, fmt.Sprintf("%s => %s", key.String(), hash.hash[key].String()))
 	}
 
 	return fmt.Sprintf("{%s}", strings.Join(pieces, ", "))
 }
 
+func (hash *Hash) Get(key Value) (Value, bool) {
+	value, ok := hash.hash[hash.storedKey(key)]
+	return value, ok
+}
+
 func (hash *Hash) Add(key, value Value) {
+	key = hash.storedKey(key)
+	if _, ok := hash.hash[key]; !ok {
+		hash.keys = append(hash.keys, key)
+		if str, ok := key.(*StringValue); ok {
+			hash.stringKeys[str.RawString()] = key
+		}
+	}
+
 	hash.hash[key] = value
 }
+
+// string keys are distinct values that ruby compares by their contents,
+// so they are looked up as whichever equal key was stored first
+func (hash *Hash) storedKey(key Value) Value {
+	str, ok := key.(*StringValue)
+	if !ok {
+		return key
+	}
+
+	if stored, ok := hash.stringKeys[str.RawString()]; ok {
+		return stored
+	}
+
+	return key
+}
+
+// whether every key of the other hash is in this one, with an == value
+func (hash *Hash) containsPairsOf(other *Hash) (bool, error) {
+	for _, key := range other.keys {
+		value, ok := hash.Get(key)
+		if !ok {
+			return false, nil
+		}
+
+		equal, err := valuesEqual(other.hash[key], value)
+		if err != nil || !equal {
+			return false, err
+		}
+	}
+
+	return true, nil
+}
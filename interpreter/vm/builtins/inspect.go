@@ -0,0 +1,83 @@
+package builtins
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// builds the #inspect representation of a value, recursing into arrays
+// and hashes. Collections that are already being inspected further up
+// are rendered as [...] or {...}, so self-referential structures terminate.
+func inspectValue(value Value, inProgress map[Value]bool) (string, error) {
+	switch value := value.(type) {
+	case *Array:
+		if inProgress[value] {
+			return "[...]", nil
+		}
+
+		inProgress[value] = true
+		defer delete(inProgress, value)
+
+		pieces := make([]string, 0, len(value.members))
+		for _, member := range value.members {
+			piece, err := inspectValue(member, inProgress)
+			if err != nil {
+				return "", err
+			}
+
+			pieces = append(pieces, piece)
+		}
+
+		return fmt.Sprintf("[%s]", strings.Join(pieces, ", ")), nil
+	case *Hash:
+		if inProgress[value] {
+			return "{...}", nil
+		}
+
+		inProgress[value] = true
+		defer delete(inProgress, value)
+
+		pieces := make([]string, 0, len(value.hash))
+		for _, key := range value.keys {
+			inspectedKey, err := inspectValue(key, inProgress)
+			if err != nil {
+				return "", err
+			}
+
+			inspectedValue, err := inspectValue(value.hash[key], inProgress)
+			if err != nil {
+				return "", err
+			}
+
+			pieces = append(pieces, fmt.Sprintf("%s=>%s", inspectedKey, inspectedValue))
+		}
+
+		return fmt.Sprintf("{%s}", strings.Join(pieces, ", ")), nil
+	case *StringValue:
+		return strconv.Quote(value.value), nil
+	case *nilInstance, nil:
+		// a method written in go may return no value at all, meaning nil
+		return "nil", nil
+	case *RationalValue:
+		return fmt.Sprintf("(%s)", value.String()), nil
+	}
+
+	// respect an #inspect defined in ruby, e.g. on a user defined class
+	if method, err := value.Method("inspect"); err == nil {
+		if _, isRubyMethod := method.(*RubyMethod); isRubyMethod {
+			result, err := method.Execute(value, nil)
+			if err != nil {
+				return "", err
+			}
+
+			if asString, ok := result.(*StringValue); ok {
+				return asString.value, nil
+			}
+
+			return result.String(), nil
+		}
+	}
+
+	return value.String(), nil
+}
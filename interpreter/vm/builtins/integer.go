@@ -1,22 +1,222 @@
 package builtins
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"math/bits"
+	"strings"
+	"unicode/utf8"
+)
 
 type integerClass struct {
 	valueStub
 	classStub
 }
 
-func NewIntegerClass(provider ClassProvider) Class {
+func NewIntegerClass(provider ClassProvider, singletonProvider SingletonProvider) Class {
 	class := &integerClass{}
 	class.initialize()
 	class.setStringer(class.String)
 	class.class = provider.ClassWithName("Class")
 	class.superClass = provider.ClassWithName("Numeric")
 
+	class.AddMethod(NewNativeMethod("-@", provider, singletonProvider, func(self Value, block Block, args ...Value) (Value, error) {
+		return NewFixnum(-self.(*fixnumInstance).value, provider, singletonProvider), nil
+	}))
+	class.AddMethod(NewNativeMethod("abs", provider, singletonProvider, func(self Value, block Block, args ...Value) (Value, error) {
+		value := self.(*fixnumInstance).value
+		if value < 0 {
+			value = -value
+		}
+
+		return NewFixnum(value, provider, singletonProvider), nil
+	}))
+
+	class.AddMethod(NewNativeMethod("to_r", provider, singletonProvider, func(self Value, block Block, args ...Value) (Value, error) {
+		return NewRational(big.NewRat(int64(self.(*fixnumInstance).value), 1), provider), nil
+	}))
+
+	class.AddMethod(NewNativeMethod("digits", provider, singletonProvider, func(self Value, block Block, args ...Value) (Value, error) {
+		value := self.(*fixnumInstance).value
+		if value < 0 {
+			return nil, NewDomainError("out of domain", "")
+		}
+
+		base := 10
+		if len(args) > 0 {
+			baseArg, ok := args[0].(*fixnumInstance)
+			if !ok {
+				return nil, errors.New(fmt.Sprintf("TypeError: no implicit conversion of %s into Integer", args[0].Class().String()))
+			}
+
+			base = baseArg.value
+			if base < 2 {
+				return nil, NewArgumentError(fmt.Sprintf("invalid radix %d", base), "")
+			}
+		}
+
+		arr, _ := provider.ClassWithName("Array").New(provider, singletonProvider)
+		digits := arr.(*Array)
+		digits.Append(NewFixnum(value%base, provider, singletonProvider))
+		for value /= base; value > 0; value /= base {
+			digits.Append(NewFixnum(value%base, provider, singletonProvider))
+		}
+
+		return digits, nil
+	}))
+
+	class.AddMethod(NewNativeMethod("bit_length", provider, singletonProvider, func(self Value, block Block, args ...Value) (Value, error) {
+		value := self.(*fixnumInstance).value
+		if value < 0 {
+			value = ^value
+		}
+
+		return NewFixnum(bits.Len(uint(value)), provider, singletonProvider), nil
+	}))
+
+	class.AddMethod(NewNativeMethod("chr", provider, singletonProvider, func(self Value, block Block, args ...Value) (Value, error) {
+		value := self.(*fixnumInstance).value
+		if len(args) == 0 {
+			if value < 0 || value > 0xff {
+				return nil, errors.New(fmt.Sprintf("RangeError: %d out of char range", value))
+			}
+
+			return NewString(string([]byte{byte(value)}), provider, singletonProvider), nil
+		}
+
+		var encoding string
+		switch arg := args[0].(type) {
+		case *EncodingValue:
+			encoding = arg.name
+		case *StringValue:
+			encoding = strings.ToUpper(arg.value)
+		default:
+			return nil, errors.New(fmt.Sprintf("TypeError: no implicit conversion of %s into String", arg.Class().String()))
+		}
+
+		switch encoding {
+		case "UTF-8":
+			if value < 0 || value > utf8.MaxRune {
+				return nil, errors.New(fmt.Sprintf("RangeError: %d out of char range", value))
+			}
+			if !utf8.ValidRune(rune(value)) {
+				return nil, errors.New(fmt.Sprintf("RangeError: invalid codepoint 0x%X in UTF-8", value))
+			}
+
+			return NewString(string(rune(value)), provider, singletonProvider), nil
+		case "US-ASCII", "ASCII-8BIT", "BINARY":
+			limit := 0xff
+			if encoding == "US-ASCII" {
+				limit = 0x7f
+			}
+			if value < 0 || value > limit {
+				return nil, errors.New(fmt.Sprintf("RangeError: %d out of char range", value))
+			}
+
+			return NewString(string([]byte{byte(value)}), provider, singletonProvider), nil
+		default:
+			return nil, NewArgumentError(fmt.Sprintf("unknown encoding name - %s", encoding), "")
+		}
+	}))
+
+	// with a modulus, the power is reduced as it is computed, so that
+	// large exponents stay cheap
+	class.AddMethod(NewNativeMethod("pow", provider, singletonProvider, func(self Value, block Block, args ...Value) (Value, error) {
+		if len(args) < 1 || len(args) > 2 {
+			return nil, NewArgumentError(fmt.Sprintf("wrong number of arguments (%d for 1..2)", len(args)), "")
+		}
+
+		operands := make([]*big.Int, len(args))
+		for i, arg := range args {
+			integer, ok := arg.(*fixnumInstance)
+			if !ok {
+				return nil, errors.New(fmt.Sprintf("TypeError: no implicit conversion of %s into Integer", arg.Class().String()))
+			}
+
+			operands[i] = big.NewInt(int64(integer.value))
+		}
+
+		base := big.NewInt(int64(self.(*fixnumInstance).value))
+		exponent := operands[0]
+
+		if len(operands) == 1 {
+			if exponent.Sign() < 0 {
+				return NewFloat(math.Pow(float64(base.Int64()), float64(exponent.Int64())), provider), nil
+			}
+
+			result := new(big.Int).Exp(base, exponent, nil)
+			if !result.IsInt64() {
+				return nil, errors.New("RangeError: integer overflow in pow")
+			}
+
+			return NewFixnum(int(result.Int64()), provider, singletonProvider), nil
+		}
+
+		modulus := operands[1]
+		if exponent.Sign() < 0 {
+			return nil, errors.New("RangeError: Integer#pow() 2nd argument not allowed to be negative when 3rd argument specified")
+		}
+		if modulus.Sign() == 0 {
+			return nil, errors.New("ZeroDivisionError: divided by 0")
+		}
+
+		// like Integer#%, the result takes the sign of the modulus
+		result := new(big.Int).Exp(base, exponent, new(big.Int).Abs(modulus))
+		result.Mod(result, new(big.Int).Abs(modulus))
+		if modulus.Sign() < 0 && result.Sign() > 0 {
+			result.Add(result, modulus)
+		}
+
+		return NewFixnum(int(result.Int64()), provider, singletonProvider), nil
+	}))
+
+	for name, operation := range integerOperations {
+		name, operation := name, operation
+		class.AddMethod(NewNativeMethod(name, provider, singletonProvider, func(self Value, block Block, args ...Value) (Value, error) {
+			if len(args) != 1 {
+				return nil, NewArgumentError(fmt.Sprintf("wrong number of arguments (%d for 1)", len(args)), "")
+			}
+
+			value := self.(*fixnumInstance).value
+			switch other := args[0].(type) {
+			case *fixnumInstance:
+				return NewFixnum(operation.integers(value, other.value), provider, singletonProvider), nil
+			case *FloatValue:
+				return NewFloat(operation.floats(float64(value), other.value), provider), nil
+			case *RationalValue:
+				result, _ := rationalOperations[name].rationals(big.NewRat(int64(value), 1), other.value)
+				return NewRational(result, provider), nil
+			default:
+				return nil, errors.New(fmt.Sprintf("TypeError: %s can't be coerced into Integer", args[0].Class().String()))
+			}
+		}))
+	}
+
 	return class
 }
 
+// arithmetic on two integers gives an integer, but a Float operand makes
+// the result a Float
+var integerOperations = map[string]struct {
+	integers func(a, b int) int
+	floats   func(a, b float64) float64
+}{
+	"+": {
+		func(a, b int) int { return a + b },
+		func(a, b float64) float64 { return a + b },
+	},
+	"-": {
+		func(a, b int) int { return a - b },
+		func(a, b float64) float64 { return a - b },
+	},
+	"*": {
+		func(a, b int) int { return a * b },
+		func(a, b float64) float64 { return a * b },
+	},
+}
+
 func (c *integerClass) String() string {
 	return "Integer"
 }
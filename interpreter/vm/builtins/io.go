@@ -1,17 +1,70 @@
 package builtins
 
+import (
+	"errors"
+	"io"
+	"os"
+)
+
+// implemented by the VM, so that output can be sent somewhere other than
+// the process's own standard streams
+type IOProvider interface {
+	Stdout() io.Writer
+	Stderr() io.Writer
+	Stdin() io.Reader
+}
+
 type ioClass struct {
 	valueStub
 	classStub
 	instanceMethods []Method
 }
 
-func NewIOClass(provider ClassProvider) Class {
+func NewIOClass(provider ClassProvider, singletonProvider SingletonProvider) Class {
 	i := &ioClass{}
 	i.initialize()
 	i.setStringer(i.String)
 	i.class = provider.ClassWithName("Class")
 	i.superClass = provider.ClassWithName("Object")
+
+	i.AddMethod(NewNativeMethod("puts", provider, singletonProvider, func(self Value, block Block, args ...Value) (Value, error) {
+		writer, err := self.(*IOValue).writer()
+		if err != nil {
+			return nil, err
+		}
+
+		writeLines(writer, args...)
+		return singletonProvider.SingletonWithName("nil"), nil
+	}))
+
+	i.AddMethod(NewNativeMethod("print", provider, singletonProvider, func(self Value, block Block, args ...Value) (Value, error) {
+		writer, err := self.(*IOValue).writer()
+		if err != nil {
+			return nil, err
+		}
+
+		writeValues(writer, args...)
+		return singletonProvider.SingletonWithName("nil"), nil
+	}))
+
+	i.AddMethod(NewNativeMethod("write", provider, singletonProvider, func(self Value, block Block, args ...Value) (Value, error) {
+		writer, err := self.(*IOValue).writer()
+		if err != nil {
+			return nil, err
+		}
+
+		return NewFixnum(writeValues(writer, args...), provider, singletonProvider), nil
+	}))
+
+	i.AddMethod(NewNativeMethod("gets", provider, singletonProvider, func(self Value, block Block, args ...Value) (Value, error) {
+		reader, err := self.(*IOValue).reader()
+		if err != nil {
+			return nil, err
+		}
+
+		return readLine(reader, provider, singletonProvider), nil
+	}))
+
 	return i
 }
 
@@ -30,3 +83,128 @@ func (io *ioClass) AddInstanceMethod(m Method) {
 func (io *ioClass) New(provider ClassProvider, singletonProvider SingletonProvider, args ...Value) (Value, error) {
 	return nil, nil
 }
+
+// one of the standard streams. The underlying reader or writer is looked up
+// on each use, so that it follows the VM when its streams are replaced.
+type IOValue struct {
+	name   string
+	output func() io.Writer
+	input  func() io.Reader
+	valueStub
+}
+
+func NewOutputIO(name string, output func() io.Writer, provider ClassProvider) Value {
+	return newIO(&IOValue{name: name, output: output}, provider)
+}
+
+func NewInputIO(name string, input func() io.Reader, provider ClassProvider) Value {
+	return newIO(&IOValue{name: name, input: input}, provider)
+}
+
+func newIO(value *IOValue, provider ClassProvider) Value {
+	value.initialize()
+	value.setStringer(value.String)
+	value.class = provider.ClassWithName("IO")
+	return value
+}
+
+func (value *IOValue) String() string {
+	return "#<IO:<" + value.name + ">>"
+}
+
+func (value *IOValue) writer() (io.Writer, error) {
+	if value.output == nil {
+		return nil, errors.New("IOError: not opened for writing")
+	}
+
+	return value.output(), nil
+}
+
+func (value *IOValue) reader() (io.Reader, error) {
+	if value.input == nil {
+		return nil, errors.New("IOError: not opened for reading")
+	}
+
+	return value.input(), nil
+}
+
+func stdout(provider ClassProvider) io.Writer {
+	if streams, ok := provider.(IOProvider); ok {
+		return streams.Stdout()
+	}
+
+	return os.Stdout
+}
+
+func stderr(provider ClassProvider) io.Writer {
+	if streams, ok := provider.(IOProvider); ok {
+		return streams.Stderr()
+	}
+
+	return os.Stderr
+}
+
+func stdin(provider ClassProvider) io.Reader {
+	if streams, ok := provider.(IOProvider); ok {
+		return streams.Stdin()
+	}
+
+	return os.Stdin
+}
+
+// writes each value on a line of its own, or a lone newline when there are none
+func writeLines(writer io.Writer, values ...Value) {
+	if len(values) == 0 {
+		writer.Write([]byte("\n"))
+	}
+
+	for _, value := range values {
+		writer.Write([]byte(outputString(value) + "\n"))
+	}
+}
+
+// writes the values one after another, returning the number of bytes written
+func writeValues(writer io.Writer, values ...Value) int {
+	written := 0
+	for _, value := range values {
+		n, _ := writer.Write([]byte(outputString(value)))
+		written += n
+	}
+
+	return written
+}
+
+// strings are written without the quotes that String() adds
+func outputString(value Value) string {
+	if str, ok := value.(*StringValue); ok {
+		return str.RawString()
+	}
+
+	return value.String()
+}
+
+// reads up to and including the next newline, or nil at the end of input.
+// This reads a byte at a time so nothing past the line is consumed.
+func readLine(reader io.Reader, provider ClassProvider, singletonProvider SingletonProvider) Value {
+	line := []byte{}
+	b := make([]byte, 1)
+	for {
+		n, err := reader.Read(b)
+		if n > 0 {
+			line = append(line, b[0])
+			if b[0] == '\n' {
+				break
+			}
+		}
+
+		if err != nil {
+			break
+		}
+	}
+
+	if len(line) == 0 {
+		return singletonProvider.SingletonWithName("nil")
+	}
+
+	return NewString(string(line), provider, singletonProvider)
+}
@@ -1,6 +1,9 @@
 package builtins
 
-import "os"
+import (
+	"errors"
+	"fmt"
+)
 
 type kernel struct {
 	valueStub
@@ -15,11 +18,169 @@ func NewGlobalKernelModule(provider ClassProvider, singletonProvider SingletonPr
 	k.class = provider.ClassWithName("Module")
 
 	k.AddMethod(NewNativeMethod("puts", provider, singletonProvider, func(self Value, block Block, args ...Value) (Value, error) {
+		writeLines(stdout(provider), args...)
+		return singletonProvider.SingletonWithName("nil"), nil
+	}))
+
+	k.AddMethod(NewNativeMethod("print", provider, singletonProvider, func(self Value, block Block, args ...Value) (Value, error) {
+		writeValues(stdout(provider), args...)
+		return singletonProvider.SingletonWithName("nil"), nil
+	}))
+
+	k.AddMethod(NewNativeMethod("warn", provider, singletonProvider, func(self Value, block Block, args ...Value) (Value, error) {
+		if len(args) > 0 {
+			writeLines(stderr(provider), args...)
+		}
+
+		return singletonProvider.SingletonWithName("nil"), nil
+	}))
+
+	for _, name := range []string{"raise", "fail"} {
+		k.AddMethod(NewNativeMethod(name, provider, singletonProvider, raise))
+	}
+
+	k.AddMethod(NewNativeMethod("abort", provider, singletonProvider, func(self Value, block Block, args ...Value) (Value, error) {
+		message := "exit"
+		if len(args) > 0 {
+			message = outputString(args[0])
+			writeLines(stderr(provider), args[0])
+		}
+
+		return nil, NewRaisedError("SystemExit", message)
+	}))
+
+	k.AddMethod(NewNativeMethod("gets", provider, singletonProvider, func(self Value, block Block, args ...Value) (Value, error) {
+		return readLine(stdin(provider), provider, singletonProvider), nil
+	}))
+
+	sprintf := func(self Value, block Block, args ...Value) (Value, error) {
+		if len(args) == 0 {
+			return nil, NewArgumentError("too few arguments", "")
+		}
+
+		format, ok := args[0].(*StringValue)
+		if !ok {
+			return nil, errors.New(fmt.Sprintf("TypeError: no implicit conversion of %s into String", args[0].Class().String()))
+		}
+
+		formatted, err := formatString(format.value, args[1:])
+		if err != nil {
+			return nil, err
+		}
+
+		return NewString(formatted, provider, singletonProvider), nil
+	}
+	k.AddMethod(NewNativeMethod("format", provider, singletonProvider, sprintf))
+	k.AddMethod(NewNativeMethod("sprintf", provider, singletonProvider, sprintf))
+
+	k.AddMethod(NewNativeMethod("Array", provider, singletonProvider, func(self Value, block Block, args ...Value) (Value, error) {
+		if len(args) != 1 {
+			return nil, NewArgumentError(fmt.Sprintf("wrong number of arguments (%d for 1)", len(args)), "")
+		}
+
+		arg := args[0]
+		if _, ok := arg.(*Array); ok {
+			return arg, nil
+		}
+
+		array, _ := provider.ClassWithName("Array").New(provider, singletonProvider)
+		if arg == singletonProvider.SingletonWithName("nil") {
+			return array, nil
+		}
+
+		for _, conversion := range []string{"to_ary", "to_a"} {
+			converted, ok, err := convertWith(arg, conversion)
+			if err != nil {
+				return nil, err
+			}
+			if !ok || converted == singletonProvider.SingletonWithName("nil") {
+				continue
+			}
+
+			if _, isArray := converted.(*Array); !isArray {
+				return nil, errors.New(fmt.Sprintf("TypeError: can't convert %s to Array (%s#%s gives %s)", arg.Class().String(), arg.Class().String(), conversion, converted.Class().String()))
+			}
+			return converted, nil
+		}
+
+		array.(*Array).Append(arg)
+		return array, nil
+	}))
+
+	k.AddMethod(NewNativeMethod("Hash", provider, singletonProvider, func(self Value, block Block, args ...Value) (Value, error) {
+		if len(args) != 1 {
+			return nil, NewArgumentError(fmt.Sprintf("wrong number of arguments (%d for 1)", len(args)), "")
+		}
+
+		arg := args[0]
+		if _, ok := arg.(*Hash); ok {
+			return arg, nil
+		}
+
+		// nil and [] both convert to an empty hash
+		if array, ok := arg.(*Array); arg == singletonProvider.SingletonWithName("nil") || (ok && len(array.members) == 0) {
+			return provider.ClassWithName("Hash").New(provider, singletonProvider)
+		}
+
+		converted, ok, err := convertWith(arg, "to_hash")
+		if err != nil {
+			return nil, err
+		}
+		if _, isHash := converted.(*Hash); !ok || !isHash {
+			return nil, errors.New(fmt.Sprintf("TypeError: can't convert %s into Hash", arg.Class().String()))
+		}
+
+		return converted, nil
+	}))
+
+	k.AddMethod(NewNativeMethod("String", provider, singletonProvider, func(self Value, block Block, args ...Value) (Value, error) {
+		if len(args) != 1 {
+			return nil, NewArgumentError(fmt.Sprintf("wrong number of arguments (%d for 1)", len(args)), "")
+		}
+
+		arg := args[0]
+		if _, ok := arg.(*StringValue); ok {
+			return arg, nil
+		}
+
+		for _, conversion := range []string{"to_str", "to_s"} {
+			converted, ok, err := convertWith(arg, conversion)
+			if err != nil {
+				return nil, err
+			}
+			if !ok {
+				continue
+			}
+
+			if _, isString := converted.(*StringValue); !isString {
+				return nil, errors.New(fmt.Sprintf("TypeError: can't convert %s to String (%s#%s gives %s)", arg.Class().String(), arg.Class().String(), conversion, converted.Class().String()))
+			}
+			return converted, nil
+		}
+
+		return nil, errors.New(fmt.Sprintf("TypeError: can't convert %s into String", arg.Class().String()))
+	}))
+
+	k.AddMethod(NewNativeMethod("p", provider, singletonProvider, func(self Value, block Block, args ...Value) (Value, error) {
 		for _, arg := range args {
-			os.Stdout.Write([]byte(arg.String() + "\n"))
+			inspected, err := inspectValue(arg, map[Value]bool{})
+			if err != nil {
+				return nil, err
+			}
+
+			stdout(provider).Write([]byte(inspected + "\n"))
 		}
 
-		return nil, nil
+		switch len(args) {
+		case 0:
+			return singletonProvider.SingletonWithName("nil"), nil
+		case 1:
+			return args[0], nil
+		default:
+			array, _ := provider.ClassWithName("Array").New(provider, singletonProvider)
+			array.(*Array).members = args
+			return array, nil
+		}
 	}))
 
 	k.AddMethod(NewNativeMethod("singleton_methods", provider, singletonProvider, func(self Value, block Block, args ...Value) (Value, error) {
@@ -52,3 +213,40 @@ func (kernel *kernel) String() string {
 func (kernel *kernel) Name() string {
 	return "Kernel"
 }
+
+// calls the conversion method (e.g. to_ary) on the value,
+// reporting whether the value responds to it at all
+func convertWith(value Value, conversion string) (Value, bool, error) {
+	method, err := value.Method(conversion)
+	if err != nil {
+		return nil, false, nil
+	}
+
+	converted, err := method.Execute(value, nil)
+	return converted, true, err
+}
+
+func raise(self Value, block Block, args ...Value) (Value, error) {
+	switch len(args) {
+	case 0:
+		return nil, NewRaisedError("RuntimeError", "unhandled exception")
+	case 1:
+		switch exception := args[0].(type) {
+		case Class:
+			return nil, NewRaisedError(exception.Name(), exception.Name())
+		case *exceptionInstance:
+			return nil, NewRaisedError(exception.Class().Name(), exception.message)
+		}
+
+		return nil, NewRaisedError("RuntimeError", outputString(args[0]))
+	default:
+		switch exception := args[0].(type) {
+		case Class:
+			return nil, NewRaisedError(exception.Name(), outputString(args[1]))
+		case *exceptionInstance:
+			return nil, NewRaisedError(exception.Class().Name(), outputString(args[1]))
+		}
+
+		return nil, errors.New("TypeError: exception class/object expected")
+	}
+}
@@ -0,0 +1,21 @@
+package builtins
+
+import "fmt"
+
+type localJumpError struct {
+	message   string
+	callstack string
+	valueStub
+}
+
+func NewLocalJumpError(message, callstack string) *localJumpError {
+	return &localJumpError{message: message, callstack: callstack}
+}
+
+func (err *localJumpError) String() string {
+	return "LocalJumpError"
+}
+
+func (err *localJumpError) Error() string {
+	return fmt.Sprintf("LocalJumpError: %s\n%s", err.message, err.callstack)
+}
@@ -0,0 +1,150 @@
+package builtins
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+)
+
+type matchDataClass struct {
+	valueStub
+	classStub
+}
+
+func NewMatchDataClass(provider ClassProvider, singletonProvider SingletonProvider) Class {
+	class := &matchDataClass{}
+	class.initialize()
+	class.setStringer(class.String)
+	class.class = provider.ClassWithName("Class")
+	class.superClass = provider.ClassWithName("Object")
+
+	class.AddMethod(NewNativeMethod("[]", provider, singletonProvider, func(self Value, block Block, args ...Value) (Value, error) {
+		if len(args) != 1 {
+			return nil, NewArgumentError(fmt.Sprintf("wrong number of arguments (%d for 1)", len(args)), "")
+		}
+
+		match := self.(*MatchDataValue)
+		switch arg := args[0].(type) {
+		case *fixnumInstance:
+			return match.group(arg.value, provider, singletonProvider), nil
+		case *StringValue:
+			return match.namedGroup(arg.value, provider, singletonProvider)
+		case *SymbolValue:
+			return match.namedGroup(arg.value, provider, singletonProvider)
+		default:
+			return nil, errors.New(fmt.Sprintf("TypeError: no implicit conversion of %s into Integer", arg.Class().String()))
+		}
+	}))
+
+	class.AddMethod(NewNativeMethod("to_s", provider, singletonProvider, func(self Value, block Block, args ...Value) (Value, error) {
+		return self.(*MatchDataValue).group(0, provider, singletonProvider), nil
+	}))
+
+	class.AddMethod(NewNativeMethod("captures", provider, singletonProvider, func(self Value, block Block, args ...Value) (Value, error) {
+		return self.(*MatchDataValue).captures(provider, singletonProvider), nil
+	}))
+
+	return class
+}
+
+func (c *matchDataClass) String() string {
+	return "MatchData"
+}
+
+func (c *matchDataClass) Name() string {
+	return "MatchData"
+}
+
+func (c *matchDataClass) New(provider ClassProvider, singletonProvider SingletonProvider, args ...Value) (Value, error) {
+	return nil, errors.New("NoMethodError: undefined method 'new' for MatchData:Class")
+}
+
+// a single match of a pattern against a string, as exposed to ruby by $~
+type MatchDataValue struct {
+	pattern *regexp.Regexp
+	input   string
+	indices []int // pairs of start and end offsets, as from FindStringSubmatchIndex
+	valueStub
+}
+
+func NewMatchData(pattern *regexp.Regexp, input string, indices []int, provider ClassProvider) Value {
+	match := &MatchDataValue{pattern: pattern, input: input, indices: indices}
+	match.initialize()
+	match.setStringer(match.String)
+	match.class = provider.ClassWithName("MatchData")
+	return match
+}
+
+func (match *MatchDataValue) String() string {
+	return fmt.Sprintf("#<MatchData %s>", strconv.Quote(match.input[match.indices[0]:match.indices[1]]))
+}
+
+// the text of the numbered group, or nil when the group did not participate
+func (match *MatchDataValue) group(index int, provider ClassProvider, singletonProvider SingletonProvider) Value {
+	if index < 0 {
+		index += len(match.indices) / 2
+	}
+
+	if index < 0 || 2*index+1 >= len(match.indices) || match.indices[2*index] < 0 {
+		return singletonProvider.SingletonWithName("nil")
+	}
+
+	return NewString(match.input[match.indices[2*index]:match.indices[2*index+1]], provider, singletonProvider)
+}
+
+// the text of every group, without the whole match
+func (match *MatchDataValue) captures(provider ClassProvider, singletonProvider SingletonProvider) Value {
+	captures, _ := provider.ClassWithName("Array").New(provider, singletonProvider)
+	for i := 1; i < len(match.indices)/2; i++ {
+		captures.(*Array).Append(match.group(i, provider, singletonProvider))
+	}
+
+	return captures
+}
+
+func (match *MatchDataValue) namedGroup(name string, provider ClassProvider, singletonProvider SingletonProvider) (Value, error) {
+	index := match.pattern.SubexpIndex(name)
+	if index < 0 {
+		return nil, errors.New(fmt.Sprintf("IndexError: undefined group name reference: %s", name))
+	}
+
+	return match.group(index, provider, singletonProvider), nil
+}
+
+// the globals that describe the most recent match
+var matchGlobals = []string{"~", "1", "2", "3", "4", "5", "6", "7", "8", "9"}
+
+// sets $~ and $1 through $9 to describe the given match while the callback
+// runs, then puts back whatever they held before
+func withMatchGlobals(match *MatchDataValue, provider ClassProvider, singletonProvider SingletonProvider, callback func() (Value, error)) (Value, error) {
+	globalProvider, ok := provider.(GlobalProvider)
+	if !ok {
+		return callback()
+	}
+
+	globals := globalProvider.Globals()
+	previous := make(map[string]Value)
+	for _, name := range matchGlobals {
+		if value, ok := globals[name]; ok {
+			previous[name] = value
+		}
+	}
+
+	globals["~"] = match
+	for i := 1; i <= 9; i++ {
+		globals[strconv.Itoa(i)] = match.group(i, provider, singletonProvider)
+	}
+
+	defer func() {
+		for _, name := range matchGlobals {
+			if value, ok := previous[name]; ok {
+				globals[name] = value
+			} else {
+				delete(globals, name)
+			}
+		}
+	}()
+
+	return callback()
+}
@@ -12,6 +12,9 @@ type Module interface {
 	InstanceMethods() []Method
 	InstanceMethod(string) (Method, error)
 
+	Constant(string) (Value, bool)
+	SetConstant(string, Value)
+
 	Value
 }
 
@@ -46,6 +49,23 @@ func NewModuleClass(classProvider ClassProvider, singletonProvider SingletonProv
 		return methodName, nil
 	}))
 
+	c.AddMethod(NewNativeMethod("ancestors", classProvider, singletonProvider, func(self Value, block Block, args ...Value) (Value, error) {
+		chain, _ := classProvider.ClassWithName("Array").New(classProvider, singletonProvider)
+		for _, ancestor := range ancestors(self) {
+			chain.(*Array).Append(ancestor)
+		}
+
+		return chain, nil
+	}))
+
+	c.AddMethod(NewNativeMethod("===", classProvider, singletonProvider, func(self Value, block Block, args ...Value) (Value, error) {
+		if len(args) > 0 && isKindOf(args[0], self) {
+			return singletonProvider.SingletonWithName("true"), nil
+		} else {
+			return singletonProvider.SingletonWithName("false"), nil
+		}
+	}))
+
 	return c
 }
 
@@ -97,6 +117,8 @@ func NewModule(name string, provider ClassProvider, singletonProvider SingletonP
 		return c, nil
 	}))
 
+	c.AddPrivateMethod(newDefineMethod(provider, singletonProvider))
+
 	c.AddMethod(NewNativeMethod("module_function", provider, singletonProvider, func(self Value, block Block, args ...Value) (Value, error) {
 		if len(args) != 1 {
 			return nil, errors.New("expected exactly one arg")
@@ -127,3 +149,59 @@ func (m RubyModule) Name() string {
 func (m *RubyModule) String() string {
 	return fmt.Sprintf("%s:Module", m.name)
 }
+
+// reports whether the value's class, one of its superclasses,
+// or a module included into any of them is the given module
+func isKindOf(value Value, module Value) bool {
+	for _, ancestor := range ancestors(value.Class()) {
+		if ancestor == module {
+			return true
+		}
+	}
+
+	return false
+}
+
+// the method resolution order of a class or module: itself, then the
+// modules it includes, most recently included first, then the same for
+// its superclass
+func ancestors(module Value) []Value {
+	var included []Value
+	switch module := module.(type) {
+	case Class:
+		for _, m := range module.includedModules() {
+			included = append(included, m)
+		}
+	case *RubyModule:
+		included = module.includedModules
+	}
+
+	chain := []Value{module}
+	for i := len(included) - 1; i >= 0; i-- {
+		for _, ancestor := range ancestors(included[i]) {
+			if !containsValue(chain, ancestor) {
+				chain = append(chain, ancestor)
+			}
+		}
+	}
+
+	if class, ok := module.(Class); ok && class.SuperClass() != nil {
+		for _, ancestor := range ancestors(class.SuperClass()) {
+			if !containsValue(chain, ancestor) {
+				chain = append(chain, ancestor)
+			}
+		}
+	}
+
+	return chain
+}
+
+func containsValue(values []Value, value Value) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
@@ -7,6 +7,7 @@ import (
 
 type moduleStub struct {
 	instanceMethods map[string]Method
+	constants       map[string]Value
 }
 
 func (m *moduleStub) InstanceMethod(name string) (Method, error) {
@@ -34,3 +35,16 @@ func (m *moduleStub) InstanceMethods() []Method {
 
 	return methods
 }
+
+func (m *moduleStub) Constant(name string) (Value, bool) {
+	value, ok := m.constants[name]
+	return value, ok
+}
+
+func (m *moduleStub) SetConstant(name string, value Value) {
+	if m.constants == nil {
+		m.constants = make(map[string]Value)
+	}
+
+	m.constants[name] = value
+}
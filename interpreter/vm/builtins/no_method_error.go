@@ -7,6 +7,7 @@ type noMethodError struct {
 	context   string
 	className string
 	callstack string
+	private   bool
 	valueStub
 }
 
@@ -19,6 +20,17 @@ func NewNoMethodError(name, context, className, callstack string) *noMethodError
 	}
 }
 
+// the error raised when a private method is called with an explicit receiver
+func NewPrivateMethodError(name, context, className, callstack string) *noMethodError {
+	err := NewNoMethodError(name, context, className, callstack)
+	err.private = true
+	return err
+}
+
 func (err *noMethodError) Error() string {
+	if err.private {
+		return fmt.Sprintf("NoMethodError: private method '%s' called for %s:%s\n%s", err.method, err.context, err.className, err.callstack)
+	}
+
 	return fmt.Sprintf("NoMethodError: undefined method '%s' for %s:%s\n%s", err.method, err.context, err.className, err.callstack)
 }
@@ -7,13 +7,28 @@ type numericClass struct {
 	classStub
 }
 
-func NewNumericClass(provider ClassProvider) Class {
+func NewNumericClass(provider ClassProvider, singletonProvider SingletonProvider) Class {
 	class := &numericClass{}
 	class.initialize()
 	class.setStringer(class.String)
 	class.class = provider.ClassWithName("Class")
 	class.superClass = provider.ClassWithName("Object")
 
+	class.AddMethod(NewNativeMethod("<=>", provider, singletonProvider, func(self Value, block Block, args ...Value) (Value, error) {
+		selfNumber, _ := numericValue(self)
+		other, ok := numericValue(args[0])
+		switch {
+		case !ok:
+			return singletonProvider.SingletonWithName("nil"), nil
+		case selfNumber < other:
+			return NewFixnum(-1, provider, singletonProvider), nil
+		case selfNumber > other:
+			return NewFixnum(1, provider, singletonProvider), nil
+		default:
+			return NewFixnum(0, provider, singletonProvider), nil
+		}
+	}))
+
 	return class
 }
 
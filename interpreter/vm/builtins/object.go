@@ -1,6 +1,9 @@
 package builtins
 
-import "fmt"
+import (
+	"errors"
+	"fmt"
+)
 
 type ObjectClass struct {
 	valueStub
@@ -23,6 +26,185 @@ func NewGlobalObjectClass(provider ClassProvider, singletonProvider SingletonPro
 		}
 	}))
 
+	o.AddMethod(NewNativeMethod("!=", provider, singletonProvider, func(self Value, block Block, args ...Value) (Value, error) {
+		equal, err := self.Method("==")
+		if err != nil {
+			return nil, err
+		}
+
+		result, err := equal.Execute(self, nil, args...)
+		if err != nil {
+			return nil, err
+		}
+
+		if result.IsTruthy() {
+			return singletonProvider.SingletonWithName("false"), nil
+		} else {
+			return singletonProvider.SingletonWithName("true"), nil
+		}
+	}))
+
+	// case equality defaults to ==, which subclasses may have overridden
+	o.AddMethod(NewNativeMethod("===", provider, singletonProvider, func(self Value, block Block, args ...Value) (Value, error) {
+		equal, err := self.Method("==")
+		if err != nil {
+			return nil, err
+		}
+
+		return equal.Execute(self, nil, args...)
+	}))
+
+	o.AddMethod(NewNativeMethod("itself", provider, singletonProvider, func(self Value, block Block, args ...Value) (Value, error) {
+		return self, nil
+	}))
+
+	o.AddMethod(NewNativeMethod("inspect", provider, singletonProvider, func(self Value, block Block, args ...Value) (Value, error) {
+		inspected, err := inspectValue(self, map[Value]bool{})
+		if err != nil {
+			return nil, err
+		}
+
+		return NewString(inspected, provider, singletonProvider), nil
+	}))
+
+	o.AddMethod(NewNativeMethod("tap", provider, singletonProvider, func(self Value, block Block, args ...Value) (Value, error) {
+		if block == nil {
+			return nil, NewLocalJumpError("no block given (yield)", "")
+		}
+
+		if _, err := block.Call(self); err != nil {
+			return nil, err
+		}
+
+		return self, nil
+	}))
+
+	// unlike tap, gives back whatever the block returns, even nil
+	for _, name := range []string{"then", "yield_self"} {
+		o.AddMethod(NewNativeMethod(name, provider, singletonProvider, func(self Value, block Block, args ...Value) (Value, error) {
+			if block == nil {
+				return NewEnumeratorForMethod(self, name, provider), nil
+			}
+
+			return block.Call(self)
+		}))
+	}
+
+	// __send__ is kept apart from send so that classes
+	// which define their own send can still be sent messages
+	for _, name := range []string{"send", "__send__", "public_send"} {
+		includePrivate := name != "public_send"
+		o.AddMethod(NewNativeMethod(name, provider, singletonProvider, func(self Value, block Block, args ...Value) (Value, error) {
+			if len(args) == 0 {
+				return nil, NewArgumentError("no method name given", "")
+			}
+
+			var methodName string
+			switch name := args[0].(type) {
+			case *SymbolValue:
+				methodName = name.Name()
+			case *StringValue:
+				methodName = name.value
+			default:
+				return nil, errors.New(fmt.Sprintf("TypeError: %s is not a symbol nor a string", args[0].String()))
+			}
+
+			method, err := LookupMethod(self, methodName, includePrivate)
+			if err == nil {
+				return method.Execute(self, block, args[1:]...)
+			}
+
+			// as with any other call, a method_missing gets a chance to answer
+			missing, missingErr := LookupMethod(self, "method_missing", true)
+			if missingErr != nil {
+				return nil, err
+			}
+
+			missingArgs := append([]Value{singletonProvider.SymbolWithName(methodName)}, args[1:]...)
+			return missing.Execute(self, block, missingArgs...)
+		}))
+	}
+
+	o.AddMethod(NewNativeMethod("instance_eval", provider, singletonProvider, func(self Value, block Block, args ...Value) (Value, error) {
+		if block == nil {
+			return nil, NewArgumentError("wrong number of arguments (given 0, expected 1..3)", "")
+		}
+
+		return CallBlockWithSelf(block, self, self)
+	}))
+
+	o.AddMethod(NewNativeMethod("display", provider, singletonProvider, func(self Value, block Block, args ...Value) (Value, error) {
+		var output string
+		toS, err := self.Method("to_s")
+		if err == nil {
+			str, err := toS.Execute(self, nil)
+			if err != nil {
+				return nil, err
+			}
+
+			output = str.String()
+			if asStr, ok := str.(*StringValue); ok {
+				output = asStr.RawString()
+			}
+		} else if asStr, ok := self.(*StringValue); ok {
+			output = asStr.RawString()
+		} else {
+			output = self.String()
+		}
+
+		stdout(provider).Write([]byte(output))
+		return singletonProvider.SingletonWithName("nil"), nil
+	}))
+
+	o.AddMethod(NewNativeMethod("freeze", provider, singletonProvider, func(self Value, block Block, args ...Value) (Value, error) {
+		self.Freeze()
+		return self, nil
+	}))
+
+	o.AddMethod(NewNativeMethod("frozen?", provider, singletonProvider, func(self Value, block Block, args ...Value) (Value, error) {
+		if self.IsFrozen() {
+			return singletonProvider.SingletonWithName("true"), nil
+		} else {
+			return singletonProvider.SingletonWithName("false"), nil
+		}
+	}))
+
+	// private methods only count when the second argument is truthy
+	o.AddMethod(NewNativeMethod("respond_to?", provider, singletonProvider, func(self Value, block Block, args ...Value) (Value, error) {
+		if len(args) == 0 || len(args) > 2 {
+			return nil, NewArgumentError(fmt.Sprintf("wrong number of arguments (given %d, expected 1..2)", len(args)), "")
+		}
+
+		var name string
+		switch arg := args[0].(type) {
+		case *SymbolValue:
+			name = arg.Name()
+		case *StringValue:
+			name = arg.value
+		default:
+			return nil, errors.New(fmt.Sprintf("TypeError: %s is not a symbol nor a string", arg.String()))
+		}
+
+		includePrivate := len(args) == 2 && args[1].IsTruthy()
+		if _, err := LookupMethod(self, name, includePrivate); err == nil {
+			return singletonProvider.SingletonWithName("true"), nil
+		}
+
+		return singletonProvider.SingletonWithName("false"), nil
+	}))
+
+	o.AddMethod(NewNativeMethod("equal?", provider, singletonProvider, func(self Value, block Block, args ...Value) (Value, error) {
+		if len(args) != 1 {
+			return nil, NewArgumentError(fmt.Sprintf("wrong number of arguments (%d for 1)", len(args)), "")
+		}
+
+		if self == args[0] {
+			return singletonProvider.SingletonWithName("true"), nil
+		} else {
+			return singletonProvider.SingletonWithName("false"), nil
+		}
+	}))
+
 	return o
 }
 
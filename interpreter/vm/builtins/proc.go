@@ -0,0 +1,75 @@
+package builtins
+
+import "errors"
+
+type procClass struct {
+	valueStub
+	classStub
+}
+
+func NewProcClass(provider ClassProvider, singletonProvider SingletonProvider) Class {
+	class := &procClass{}
+	class.initialize()
+	class.setStringer(class.String)
+	class.class = provider.ClassWithName("Class")
+	class.superClass = provider.ClassWithName("Object")
+
+	class.AddMethod(NewNativeMethod("call", provider, singletonProvider, func(self Value, block Block, args ...Value) (Value, error) {
+		return self.(*ProcValue).Call(args...)
+	}))
+
+	return class
+}
+
+func (c *procClass) String() string {
+	return "Proc"
+}
+
+func (c *procClass) Name() string {
+	return "Proc"
+}
+
+func (c *procClass) New(provider ClassProvider, singletonProvider SingletonProvider, args ...Value) (Value, error) {
+	return nil, errors.New("ArgumentError: tried to create Proc object without a block")
+}
+
+// a ProcValue is a Block that can be passed around as a ruby value,
+// e.g. as the `&proc_arg` of a method call
+type ProcValue struct {
+	valueStub
+	block Block
+}
+
+func NewProc(block Block, provider ClassProvider) Value {
+	p := &ProcValue{block: block}
+	p.initialize()
+	p.setStringer(p.String)
+	p.class = provider.ClassWithName("Proc")
+	return p
+}
+
+func (p *ProcValue) Call(args ...Value) (Value, error) {
+	return p.block.Call(args...)
+}
+
+func (p *ProcValue) String() string {
+	return "Proc"
+}
+
+// the block created by Symbol#to_proc, which sends the symbol as a message to its first argument
+type symbolBlock struct {
+	name string
+}
+
+func (b *symbolBlock) Call(args ...Value) (Value, error) {
+	if len(args) == 0 {
+		return nil, NewArgumentError("no receiver given", "")
+	}
+
+	method, err := args[0].Method(b.name)
+	if err != nil {
+		return nil, err
+	}
+
+	return method.Execute(args[0], nil, args[1:]...)
+}
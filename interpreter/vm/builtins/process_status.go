@@ -0,0 +1,72 @@
+package builtins
+
+import (
+	"errors"
+	"fmt"
+)
+
+type processStatusClass struct {
+	valueStub
+	classStub
+}
+
+func NewProcessStatusClass(provider ClassProvider, singletonProvider SingletonProvider) Class {
+	class := &processStatusClass{}
+	class.initialize()
+	class.setStringer(class.String)
+	class.class = provider.ClassWithName("Class")
+	class.superClass = provider.ClassWithName("Object")
+
+	class.AddMethod(NewNativeMethod("exitstatus", provider, singletonProvider, func(self Value, block Block, args ...Value) (Value, error) {
+		return NewFixnum(self.(*ProcessStatusValue).exitStatus, provider, singletonProvider), nil
+	}))
+
+	class.AddMethod(NewNativeMethod("to_i", provider, singletonProvider, func(self Value, block Block, args ...Value) (Value, error) {
+		return NewFixnum(self.(*ProcessStatusValue).exitStatus<<8, provider, singletonProvider), nil
+	}))
+
+	class.AddMethod(NewNativeMethod("pid", provider, singletonProvider, func(self Value, block Block, args ...Value) (Value, error) {
+		return NewFixnum(self.(*ProcessStatusValue).pid, provider, singletonProvider), nil
+	}))
+
+	class.AddMethod(NewNativeMethod("success?", provider, singletonProvider, func(self Value, block Block, args ...Value) (Value, error) {
+		if self.(*ProcessStatusValue).exitStatus == 0 {
+			return singletonProvider.SingletonWithName("true"), nil
+		} else {
+			return singletonProvider.SingletonWithName("false"), nil
+		}
+	}))
+
+	return class
+}
+
+func (c *processStatusClass) String() string {
+	return "Process::Status"
+}
+
+func (c *processStatusClass) Name() string {
+	return "Process::Status"
+}
+
+func (c *processStatusClass) New(provider ClassProvider, singletonProvider SingletonProvider, args ...Value) (Value, error) {
+	return nil, errors.New("NoMethodError: undefined method 'new' for Process::Status:Class")
+}
+
+// the result of a child process, as exposed to ruby by $?
+type ProcessStatusValue struct {
+	pid        int
+	exitStatus int
+	valueStub
+}
+
+func NewProcessStatus(pid, exitStatus int, provider ClassProvider) Value {
+	status := &ProcessStatusValue{pid: pid, exitStatus: exitStatus}
+	status.initialize()
+	status.setStringer(status.String)
+	status.class = provider.ClassWithName("Process::Status")
+	return status
+}
+
+func (status *ProcessStatusValue) String() string {
+	return fmt.Sprintf("pid %d exit %d", status.pid, status.exitStatus)
+}
@@ -0,0 +1,22 @@
+package builtins
+
+import "fmt"
+
+// an exception raised from ruby code with Kernel#raise
+type raisedError struct {
+	className string
+	message   string
+	valueStub
+}
+
+func NewRaisedError(className, message string) *raisedError {
+	return &raisedError{className: className, message: message}
+}
+
+func (err *raisedError) String() string {
+	return err.className
+}
+
+func (err *raisedError) Error() string {
+	return fmt.Sprintf("%s: %s", err.className, err.message)
+}
@@ -0,0 +1,115 @@
+package builtins
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"time"
+)
+
+type randomClass struct {
+	valueStub
+	classStub
+}
+
+func NewRandomClass(provider ClassProvider, singletonProvider SingletonProvider) Class {
+	class := &randomClass{}
+	class.initialize()
+	class.setStringer(class.String)
+	class.class = provider.ClassWithName("Class")
+	class.superClass = provider.ClassWithName("Object")
+
+	class.AddMethod(NewNativeMethod("rand", provider, singletonProvider, func(self Value, block Block, args ...Value) (Value, error) {
+		random := self.(*RandomValue)
+		if len(args) == 0 {
+			return NewFloat(random.source.Float64(), provider), nil
+		}
+
+		max, ok := args[0].(*fixnumInstance)
+		if !ok {
+			return nil, errors.New(fmt.Sprintf("TypeError: no implicit conversion of %s into Integer", args[0].Class().String()))
+		}
+
+		if max.value <= 0 {
+			return nil, NewArgumentError(fmt.Sprintf("invalid argument - %d", max.value), "")
+		}
+
+		return NewFixnum(random.source.Intn(max.value), provider, singletonProvider), nil
+	}))
+
+	class.AddMethod(NewNativeMethod("seed", provider, singletonProvider, func(self Value, block Block, args ...Value) (Value, error) {
+		return NewFixnum(int(self.(*RandomValue).seed), provider, singletonProvider), nil
+	}))
+
+	return class
+}
+
+func (c *randomClass) String() string {
+	return "Random"
+}
+
+func (c *randomClass) Name() string {
+	return "Random"
+}
+
+func (c *randomClass) New(provider ClassProvider, singletonProvider SingletonProvider, args ...Value) (Value, error) {
+	seed := time.Now().UnixNano()
+	if len(args) > 0 {
+		seedArg, ok := args[0].(*fixnumInstance)
+		if !ok {
+			return nil, errors.New(fmt.Sprintf("TypeError: no implicit conversion of %s into Integer", args[0].Class().String()))
+		}
+
+		seed = int64(seedArg.value)
+	}
+
+	r := &RandomValue{
+		seed:   seed,
+		source: rand.New(rand.NewSource(seed)),
+	}
+	r.initialize()
+	r.setStringer(r.String)
+	r.class = c
+
+	return r, nil
+}
+
+type RandomValue struct {
+	seed   int64
+	source *rand.Rand
+	valueStub
+}
+
+func (r *RandomValue) String() string {
+	return fmt.Sprintf("Random:%p", r)
+}
+
+// returns the random number source given as the `random:` keyword argument,
+// along with the remaining positional arguments
+func randomSourceFromArgs(args []Value) (*rand.Rand, []Value, error) {
+	if len(args) == 0 {
+		return nil, args, nil
+	}
+
+	options, ok := args[len(args)-1].(*Hash)
+	if !ok {
+		return nil, args, nil
+	}
+
+	for _, key := range options.keys {
+		value := options.hash[key]
+		symbol, ok := key.(*SymbolValue)
+		if !ok || symbol.Name() != "random" {
+			continue
+		}
+
+		random, ok := value.(*RandomValue)
+		if !ok {
+			return nil, nil, errors.New(fmt.Sprintf("NoMethodError: undefined method 'rand' for %s", value.String()))
+		}
+
+		return random.source, args[:len(args)-1], nil
+	}
+
+	return nil, args, nil
+}
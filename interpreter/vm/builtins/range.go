@@ -0,0 +1,251 @@
+package builtins
+
+import (
+	"errors"
+	"fmt"
+	"unicode/utf8"
+)
+
+type rangeClass struct {
+	valueStub
+	classStub
+}
+
+func NewRangeClass(provider ClassProvider, singletonProvider SingletonProvider) Class {
+	class := &rangeClass{}
+	class.initialize()
+	class.setStringer(class.String)
+	class.class = provider.ClassWithName("Class")
+	class.superClass = provider.ClassWithName("Object")
+
+	class.AddMethod(NewNativeMethod("begin", provider, singletonProvider, func(self Value, block Block, args ...Value) (Value, error) {
+		return self.(*RangeValue).start, nil
+	}))
+
+	class.AddMethod(NewNativeMethod("end", provider, singletonProvider, func(self Value, block Block, args ...Value) (Value, error) {
+		return self.(*RangeValue).end, nil
+	}))
+
+	class.AddMethod(NewNativeMethod("exclude_end?", provider, singletonProvider, func(self Value, block Block, args ...Value) (Value, error) {
+		if self.(*RangeValue).exclusive {
+			return singletonProvider.SingletonWithName("true"), nil
+		} else {
+			return singletonProvider.SingletonWithName("false"), nil
+		}
+	}))
+
+	cover := func(self Value, block Block, args ...Value) (Value, error) {
+		if len(args) != 1 {
+			return nil, NewArgumentError(fmt.Sprintf("wrong number of arguments (%d for 1)", len(args)), "")
+		}
+
+		if self.(*RangeValue).covers(args[0]) {
+			return singletonProvider.SingletonWithName("true"), nil
+		} else {
+			return singletonProvider.SingletonWithName("false"), nil
+		}
+	}
+	for _, name := range []string{"===", "cover?"} {
+		class.AddMethod(NewNativeMethod(name, provider, singletonProvider, cover))
+	}
+
+	include := func(self Value, block Block, args ...Value) (Value, error) {
+		if len(args) != 1 {
+			return nil, NewArgumentError(fmt.Sprintf("wrong number of arguments (%d for 1)", len(args)), "")
+		}
+
+		if self.(*RangeValue).includes(args[0]) {
+			return singletonProvider.SingletonWithName("true"), nil
+		} else {
+			return singletonProvider.SingletonWithName("false"), nil
+		}
+	}
+	for _, name := range []string{"include?", "member?"} {
+		class.AddMethod(NewNativeMethod(name, provider, singletonProvider, include))
+	}
+
+	class.AddMethod(NewNativeMethod("to_a", provider, singletonProvider, func(self Value, block Block, args ...Value) (Value, error) {
+		r := self.(*RangeValue)
+		if isNilValue(r.end) && !isNilValue(r.start) {
+			return nil, errors.New("RangeError: cannot convert endless range to an array")
+		}
+
+		return r.toArray(provider, singletonProvider)
+	}))
+
+	class.AddMethod(NewNativeMethod("each", provider, singletonProvider, func(self Value, block Block, args ...Value) (Value, error) {
+		if block == nil {
+			return NewEnumeratorForMethod(self, "each", provider), nil
+		}
+
+		array, err := self.(*RangeValue).toArray(provider, singletonProvider)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, member := range array.(*Array).members {
+			if _, err := block.Call(member); err != nil {
+				return nil, err
+			}
+		}
+
+		return self, nil
+	}))
+
+	return class
+}
+
+// the integers in the range, which must have integer endpoints
+func (r *RangeValue) toArray(provider ClassProvider, singletonProvider SingletonProvider) (Value, error) {
+	start, startOk := r.start.(*fixnumInstance)
+	end, endOk := r.end.(*fixnumInstance)
+	if !startOk || !endOk {
+		return nil, errors.New(fmt.Sprintf("TypeError: can't iterate from %s", r.start.Class().String()))
+	}
+
+	last := end.value
+	if r.exclusive {
+		last--
+	}
+
+	arrayValue, _ := provider.ClassWithName("Array").New(provider, singletonProvider)
+	array := arrayValue.(*Array)
+	for i := start.value; i <= last; i++ {
+		array.Append(NewFixnum(i, provider, singletonProvider))
+	}
+
+	return array, nil
+}
+
+func (c *rangeClass) String() string {
+	return "Range"
+}
+
+func (c *rangeClass) Name() string {
+	return "Range"
+}
+
+func (c *rangeClass) New(provider ClassProvider, singletonProvider SingletonProvider, args ...Value) (Value, error) {
+	if len(args) < 2 || len(args) > 3 {
+		return nil, NewArgumentError(fmt.Sprintf("wrong number of arguments (%d for 2..3)", len(args)), "")
+	}
+
+	exclusive := len(args) == 3 && args[2].IsTruthy()
+	return NewRange(args[0], args[1], exclusive, provider)
+}
+
+type RangeValue struct {
+	start     Value
+	end       Value
+	exclusive bool
+	valueStub
+}
+
+// either endpoint may be nil, making the range beginless or endless
+func NewRange(start, end Value, exclusive bool, provider ClassProvider) (Value, error) {
+	if !isNilValue(start) && !isNilValue(end) {
+		if _, err := compareValues(start, end); err != nil {
+			return nil, NewArgumentError("bad value for range", "")
+		}
+	}
+
+	r := &RangeValue{start: start, end: end, exclusive: exclusive}
+	r.initialize()
+	r.setStringer(r.String)
+	r.class = provider.ClassWithName("Range")
+	return r, nil
+}
+
+func (r *RangeValue) String() string {
+	if r.exclusive {
+		return fmt.Sprintf("%s...%s", r.start.String(), r.end.String())
+	}
+
+	return fmt.Sprintf("%s..%s", r.start.String(), r.end.String())
+}
+
+// reports whether the value lies between the beginning and end of the range
+// values that cannot be compared to the endpoints are not covered, and a
+// missing endpoint doesn't bound the range on that side
+func (r *RangeValue) covers(value Value) bool {
+	if !isNilValue(r.start) {
+		fromStart, err := compareValues(r.start, value)
+		if err != nil || fromStart > 0 {
+			return false
+		}
+	}
+
+	if !isNilValue(r.end) {
+		toEnd, err := compareValues(value, r.end)
+		if err != nil || toEnd > 0 || (r.exclusive && toEnd == 0) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// like covers, except that a range between two single characters
+// only includes single characters, so ("a".."z") does not include "bb"
+func (r *RangeValue) includes(value Value) bool {
+	start, startOk := r.start.(*StringValue)
+	end, endOk := r.end.(*StringValue)
+	str, strOk := value.(*StringValue)
+	if startOk && endOk && strOk && isSingleCharacter(start.value) && isSingleCharacter(end.value) && !isSingleCharacter(str.value) {
+		return false
+	}
+
+	return r.covers(value)
+}
+
+func isNilValue(value Value) bool {
+	_, ok := value.(*nilInstance)
+	return ok
+}
+
+func isSingleCharacter(str string) bool {
+	return utf8.RuneCountInString(str) == 1
+}
+
+// resolves the range into the start and (exclusive) end indices it selects
+// from a sequence of the given length, counting negative endpoints back from
+// the end. The end is not clamped to the length. A beginless range starts
+// at the first element and an endless range runs through the last.
+func (r *RangeValue) indicesWithin(length int) (int, int, bool) {
+	first, last := 0, length
+	if !isNilValue(r.start) {
+		start, ok := r.start.(*fixnumInstance)
+		if !ok {
+			return 0, 0, false
+		}
+
+		first = start.value
+		if first < 0 {
+			first += length
+		}
+	}
+
+	if !isNilValue(r.end) {
+		end, ok := r.end.(*fixnumInstance)
+		if !ok {
+			return 0, 0, false
+		}
+
+		last = end.value
+		if last < 0 {
+			last += length
+		}
+		if !r.exclusive {
+			last++
+		}
+	}
+
+	if first < 0 {
+		return 0, 0, false
+	}
+	if last < first {
+		last = first
+	}
+
+	return first, last, true
+}
@@ -0,0 +1,176 @@
+package builtins
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"regexp"
+)
+
+type rationalClass struct {
+	valueStub
+	classStub
+}
+
+func NewRationalClass(provider ClassProvider, singletonProvider SingletonProvider) Class {
+	class := &rationalClass{}
+	class.initialize()
+	class.setStringer(class.String)
+	class.class = provider.ClassWithName("Class")
+	class.superClass = provider.ClassWithName("Numeric")
+
+	class.AddMethod(NewNativeMethod("numerator", provider, singletonProvider, func(self Value, block Block, args ...Value) (Value, error) {
+		return NewFixnum(int(self.(*RationalValue).value.Num().Int64()), provider, singletonProvider), nil
+	}))
+	class.AddMethod(NewNativeMethod("denominator", provider, singletonProvider, func(self Value, block Block, args ...Value) (Value, error) {
+		return NewFixnum(int(self.(*RationalValue).value.Denom().Int64()), provider, singletonProvider), nil
+	}))
+
+	class.AddMethod(NewNativeMethod("to_r", provider, singletonProvider, func(self Value, block Block, args ...Value) (Value, error) {
+		return self, nil
+	}))
+	class.AddMethod(NewNativeMethod("to_i", provider, singletonProvider, func(self Value, block Block, args ...Value) (Value, error) {
+		value := self.(*RationalValue).value
+		return NewFixnum(int(new(big.Int).Quo(value.Num(), value.Denom()).Int64()), provider, singletonProvider), nil
+	}))
+	class.AddMethod(NewNativeMethod("to_f", provider, singletonProvider, func(self Value, block Block, args ...Value) (Value, error) {
+		float, _ := self.(*RationalValue).value.Float64()
+		return NewFloat(float, provider), nil
+	}))
+	class.AddMethod(NewNativeMethod("to_s", provider, singletonProvider, func(self Value, block Block, args ...Value) (Value, error) {
+		return NewString(self.String(), provider, singletonProvider), nil
+	}))
+
+	class.AddMethod(NewNativeMethod("==", provider, singletonProvider, func(self Value, block Block, args ...Value) (Value, error) {
+		other, ok := toRational(args[0])
+		if ok && self.(*RationalValue).value.Cmp(other) == 0 {
+			return singletonProvider.SingletonWithName("true"), nil
+		}
+
+		return singletonProvider.SingletonWithName("false"), nil
+	}))
+
+	for name, operation := range rationalOperations {
+		operation := operation
+		class.AddMethod(NewNativeMethod(name, provider, singletonProvider, func(self Value, block Block, args ...Value) (Value, error) {
+			if len(args) != 1 {
+				return nil, NewArgumentError(fmt.Sprintf("wrong number of arguments (%d for 1)", len(args)), "")
+			}
+
+			value := self.(*RationalValue).value
+			if float, ok := args[0].(*FloatValue); ok {
+				asFloat, _ := value.Float64()
+				return NewFloat(operation.floats(asFloat, float.value), provider), nil
+			}
+
+			other, ok := toRational(args[0])
+			if !ok {
+				return nil, errors.New(fmt.Sprintf("TypeError: %s can't be coerced into Rational", args[0].Class().String()))
+			}
+
+			result, err := operation.rationals(value, other)
+			if err != nil {
+				return nil, err
+			}
+
+			return NewRational(result, provider), nil
+		}))
+	}
+
+	return class
+}
+
+// arithmetic between rationals, or integers taken as rationals, stays
+// exact, but a Float operand makes the result a Float
+var rationalOperations = map[string]struct {
+	rationals func(a, b *big.Rat) (*big.Rat, error)
+	floats    func(a, b float64) float64
+}{
+	"+": {
+		func(a, b *big.Rat) (*big.Rat, error) { return new(big.Rat).Add(a, b), nil },
+		func(a, b float64) float64 { return a + b },
+	},
+	"-": {
+		func(a, b *big.Rat) (*big.Rat, error) { return new(big.Rat).Sub(a, b), nil },
+		func(a, b float64) float64 { return a - b },
+	},
+	"*": {
+		func(a, b *big.Rat) (*big.Rat, error) { return new(big.Rat).Mul(a, b), nil },
+		func(a, b float64) float64 { return a * b },
+	},
+	"/": {
+		func(a, b *big.Rat) (*big.Rat, error) {
+			if b.Sign() == 0 {
+				return nil, errors.New("ZeroDivisionError: divided by 0")
+			}
+
+			return new(big.Rat).Quo(a, b), nil
+		},
+		func(a, b float64) float64 { return a / b },
+	},
+}
+
+// the exact value of a Rational or an Integer
+func toRational(value Value) (*big.Rat, bool) {
+	switch value := value.(type) {
+	case *RationalValue:
+		return value.value, true
+	case *fixnumInstance:
+		return big.NewRat(int64(value.value), 1), true
+	default:
+		return nil, false
+	}
+}
+
+// the leading number of a string, optionally a decimal, over an optional
+// integer denominator, as String#to_r reads it
+var rationalPrefix = regexp.MustCompile(`^\s*([-+]?\d+(?:\.\d+)?)(?:/(\d+))?`)
+
+// parses the rational at the start of the string, or zero when there isn't one
+func parseRational(str string) *big.Rat {
+	match := rationalPrefix.FindStringSubmatch(str)
+	if match == nil {
+		return new(big.Rat)
+	}
+
+	numerator, _ := new(big.Rat).SetString(match[1])
+	if match[2] == "" {
+		return numerator
+	}
+
+	denominator, _ := new(big.Rat).SetString(match[2])
+	if denominator.Sign() == 0 {
+		return new(big.Rat)
+	}
+
+	return numerator.Quo(numerator, denominator)
+}
+
+func (c *rationalClass) String() string {
+	return "Rational"
+}
+
+func (c *rationalClass) Name() string {
+	return "Rational"
+}
+
+func (c *rationalClass) New(provider ClassProvider, singletonProvider SingletonProvider, args ...Value) (Value, error) {
+	return nil, errors.New("undefined method 'new' for Rational:Class")
+}
+
+type RationalValue struct {
+	value *big.Rat
+	valueStub
+}
+
+func NewRational(val *big.Rat, provider ClassProvider) Value {
+	r := &RationalValue{value: val}
+	r.class = provider.ClassWithName("Rational")
+	r.initialize()
+	r.setStringer(r.String)
+	return r
+}
+
+func (r *RationalValue) String() string {
+	return r.value.String()
+}
@@ -0,0 +1,154 @@
+package builtins
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+type regexpClass struct {
+	valueStub
+	classStub
+}
+
+func NewRegexpClass(provider ClassProvider, singletonProvider SingletonProvider) Class {
+	class := &regexpClass{}
+	class.initialize()
+	class.setStringer(class.String)
+	class.class = provider.ClassWithName("Class")
+	class.superClass = provider.ClassWithName("Object")
+
+	class.AddMethod(NewNativeMethod("source", provider, singletonProvider, func(self Value, block Block, args ...Value) (Value, error) {
+		return NewString(self.(*RegexpValue).source, provider, singletonProvider), nil
+	}))
+
+	// case equality matches strings (and symbols) against the pattern
+	class.AddMethod(NewNativeMethod("===", provider, singletonProvider, func(self Value, block Block, args ...Value) (Value, error) {
+		var input string
+		switch arg := args[0].(type) {
+		case *StringValue:
+			input = arg.value
+		case *SymbolValue:
+			input = arg.value
+		default:
+			return singletonProvider.SingletonWithName("false"), nil
+		}
+
+		if self.(*RegexpValue).regexp.MatchString(input) {
+			return singletonProvider.SingletonWithName("true"), nil
+		} else {
+			return singletonProvider.SingletonWithName("false"), nil
+		}
+	}))
+
+	return class
+}
+
+func (c *regexpClass) String() string {
+	return "Regexp"
+}
+
+func (c *regexpClass) Name() string {
+	return "Regexp"
+}
+
+func (c *regexpClass) New(provider ClassProvider, singletonProvider SingletonProvider, args ...Value) (Value, error) {
+	if len(args) == 0 {
+		return nil, NewArgumentError("wrong number of arguments (0 for 1..3)", "")
+	}
+
+	source, ok := args[0].(*StringValue)
+	if !ok {
+		return nil, errors.New(fmt.Sprintf("TypeError: no implicit conversion of %s into String", args[0].Class().String()))
+	}
+
+	return NewRegexp(source.value, provider)
+}
+
+type RegexpValue struct {
+	source string
+	regexp *regexp.Regexp
+	valueStub
+}
+
+// ruby spells named groups (?<name>...), which go's regexp package
+// only understands in the (?P<name>...) form
+var rubyNamedGroup = regexp.MustCompile(`\(\?<([A-Za-z_][A-Za-z0-9_]*)>`)
+
+func NewRegexp(source string, provider ClassProvider) (Value, error) {
+	compiled, err := regexp.Compile(rubyNamedGroup.ReplaceAllString(source, "(?P<$1>"))
+	if err != nil {
+		return nil, errors.New(fmt.Sprintf("RegexpError: %s: /%s/", err.Error(), source))
+	}
+
+	r := &RegexpValue{source: source, regexp: compiled}
+	r.initialize()
+	r.setStringer(r.String)
+	r.class = provider.ClassWithName("Regexp")
+	return r, nil
+}
+
+func (r *RegexpValue) String() string {
+	return fmt.Sprintf("/%s/", r.source)
+}
+
+// converts a String or Regexp argument into a compiled pattern
+func patternFromValue(value Value) (*regexp.Regexp, error) {
+	switch value := value.(type) {
+	case *RegexpValue:
+		return value.regexp, nil
+	case *StringValue:
+		return regexp.MustCompile(regexp.QuoteMeta(value.value)), nil
+	default:
+		return nil, errors.New(fmt.Sprintf("TypeError: wrong argument type %s (expected Regexp)", value.Class().String()))
+	}
+}
+
+// expands the backreferences in a sub/gsub replacement string:
+// \0 and \& for the whole match, \1 through \9 for numbered groups,
+// \k<name> for named groups and \\ for a literal backslash
+func expandReplacement(replacement string, pattern *regexp.Regexp, input string, match []int) string {
+	group := func(index int) string {
+		if index < 0 || 2*index+1 >= len(match) || match[2*index] < 0 {
+			return ""
+		}
+
+		return input[match[2*index]:match[2*index+1]]
+	}
+
+	var expanded strings.Builder
+	for i := 0; i < len(replacement); i++ {
+		if replacement[i] != '\\' || i+1 == len(replacement) {
+			expanded.WriteByte(replacement[i])
+			continue
+		}
+
+		next := replacement[i+1]
+		switch {
+		case next >= '0' && next <= '9':
+			expanded.WriteString(group(int(next - '0')))
+			i++
+		case next == '&':
+			expanded.WriteString(group(0))
+			i++
+		case next == '\\':
+			expanded.WriteByte('\\')
+			i++
+		case next == 'k' && i+2 < len(replacement) && replacement[i+2] == '<':
+			end := strings.IndexByte(replacement[i+3:], '>')
+			if end < 0 {
+				expanded.WriteByte(replacement[i])
+				continue
+			}
+
+			name := replacement[i+3 : i+3+end]
+			expanded.WriteString(group(pattern.SubexpIndex(name)))
+			i += 3 + end
+		default:
+			expanded.WriteByte(replacement[i])
+		}
+	}
+
+	return expanded.String()
+}
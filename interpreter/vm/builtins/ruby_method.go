@@ -20,9 +20,11 @@ type RubyMethod struct {
 	body func(self Value, method *RubyMethod) (Value, error)
 
 	invocationArgs  []methodArg
+	invocationBlock Block
 	unevaluatedBody []ast.Node
 
 	evaluator ArgEvaluator
+	provider  ClassProvider
 }
 
 func NewRubyMethod(
@@ -38,6 +40,7 @@ func NewRubyMethod(
 		body:            body,
 		args:            args,
 		evaluator:       evaluator,
+		provider:        provider,
 		unevaluatedBody: rubyBody,
 	}
 	m.class = provider.ClassWithName("Method")
@@ -58,11 +61,17 @@ func (method *RubyMethod) Args() []methodArg {
 	return method.invocationArgs
 }
 
+// the block passed to the current invocation, if any
+func (method *RubyMethod) Block() Block {
+	return method.invocationBlock
+}
+
 func (method *RubyMethod) Body() []ast.Node {
 	return method.unevaluatedBody
 }
 
 func (method *RubyMethod) Execute(self Value, block Block, args ...Value) (Value, error) {
+	args = method.collectSplat(args)
 	method.invocationArgs = make([]methodArg, 0, len(args))
 	for index, arg := range method.args {
 
@@ -90,13 +99,41 @@ func (method *RubyMethod) Execute(self Value, block Block, args ...Value) (Value
 		}
 		method.invocationArgs = append(method.invocationArgs, argument)
 	}
+	method.invocationBlock = block
 	defer func() {
 		method.invocationArgs = nil
+		method.invocationBlock = nil
 	}()
 
 	return method.body(self, method)
 }
 
+// gathers the args that a splat param takes, which are whichever the params
+// on either side of it don't need, into an array in the splat's position
+func (method *RubyMethod) collectSplat(args []Value) []Value {
+	for index, param := range method.args {
+		if !param.IsSplat || index > len(args) {
+			continue
+		}
+
+		end := len(args) - (len(method.args) - index - 1)
+		if end < index {
+			end = index
+		}
+
+		splatted, _ := method.provider.ClassWithName("Array").New(method.provider, nil)
+		for _, arg := range args[index:end] {
+			splatted.(*Array).Append(arg)
+		}
+
+		collected := append([]Value{}, args[:index]...)
+		collected = append(collected, splatted)
+		return append(collected, args[end:]...)
+	}
+
+	return args
+}
+
 // FIXME: in order to fix this, the method needs to know "self"
 func (method *RubyMethod) String() string {
 	return fmt.Sprintf("#Method: FIXME(ClassNameGoesHere)#%s", method.name)
@@ -0,0 +1,21 @@
+package builtins
+
+import "fmt"
+
+type stopIteration struct {
+	message   string
+	callstack string
+	valueStub
+}
+
+func NewStopIteration(message, callstack string) *stopIteration {
+	return &stopIteration{message: message, callstack: callstack}
+}
+
+func (err *stopIteration) String() string {
+	return "StopIteration"
+}
+
+func (err *stopIteration) Error() string {
+	return fmt.Sprintf("StopIteration: %s\n%s", err.message, err.callstack)
+}
@@ -1,12 +1,22 @@
 package builtins
 
-import "fmt"
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
 
 type StringClass struct {
 	valueStub
 	classStub
 
 	provider ClassProvider
+
+	// frozen strings handed out by -@, so equal strings share one object
+	interned map[string]*StringValue
 }
 
 func NewStringClass(provider ClassProvider, singletonProvider SingletonProvider) Class {
@@ -17,12 +27,42 @@ func NewStringClass(provider ClassProvider, singletonProvider SingletonProvider)
 	s.provider = provider
 	s.class = provider.ClassWithName("Class")
 	s.superClass = provider.ClassWithName("Object")
+	s.interned = make(map[string]*StringValue)
 
 	s.AddMethod(NewNativeMethod("+", provider, singletonProvider, func(self Value, block Block, args ...Value) (Value, error) {
 		arg := args[0].(*StringValue)
 		selfAsStr := self.(*StringValue)
 		return NewString(selfAsStr.value+arg.value, provider, singletonProvider), nil
 	}))
+	s.AddMethod(NewNativeMethod("*", provider, singletonProvider, func(self Value, block Block, args ...Value) (Value, error) {
+		if len(args) != 1 {
+			return nil, NewArgumentError(fmt.Sprintf("wrong number of arguments (%d for 1)", len(args)), "")
+		}
+
+		times, ok := args[0].(*fixnumInstance)
+		if !ok {
+			return nil, errors.New(fmt.Sprintf("TypeError: no implicit conversion of %s into Integer", args[0].Class().String()))
+		}
+
+		if times.value < 0 {
+			return nil, NewArgumentError("negative argument", "")
+		}
+
+		return NewString(strings.Repeat(self.(*StringValue).value, times.value), provider, singletonProvider), nil
+	}))
+	s.AddMethod(NewNativeMethod("%", provider, singletonProvider, func(self Value, block Block, args ...Value) (Value, error) {
+		formatArgs := args
+		if array, ok := args[0].(*Array); ok {
+			formatArgs = array.members
+		}
+
+		formatted, err := formatString(self.(*StringValue).value, formatArgs)
+		if err != nil {
+			return nil, err
+		}
+
+		return NewString(formatted, provider, singletonProvider), nil
+	}))
 	s.AddMethod(NewNativeMethod("==", provider, singletonProvider, func(self Value, block Block, args ...Value) (Value, error) {
 		asStr, ok := args[0].(*StringValue)
 		if !ok {
@@ -37,9 +77,431 @@ func NewStringClass(provider ClassProvider, singletonProvider SingletonProvider)
 		}
 	}))
 
+	s.AddMethod(NewNativeMethod("<=>", provider, singletonProvider, func(self Value, block Block, args ...Value) (Value, error) {
+		asStr, ok := args[0].(*StringValue)
+		if !ok {
+			return singletonProvider.SingletonWithName("nil"), nil
+		}
+
+		return NewFixnum(strings.Compare(self.(*StringValue).value, asStr.value), provider, singletonProvider), nil
+	}))
+
+	s.AddMethod(NewNativeMethod("ord", provider, singletonProvider, func(self Value, block Block, args ...Value) (Value, error) {
+		value := self.(*StringValue).value
+		if value == "" {
+			return nil, NewArgumentError("empty string", "")
+		}
+
+		codepoint, _ := utf8.DecodeRuneInString(value)
+		return NewFixnum(int(codepoint), provider, singletonProvider), nil
+	}))
+
+	// with no arguments every run of a repeated character is squeezed,
+	// otherwise only runs of characters in every one of the given sets
+	s.AddMethod(NewNativeMethod("squeeze", provider, singletonProvider, func(self Value, block Block, args ...Value) (Value, error) {
+		sets, err := newCharacterSets(args)
+		if err != nil {
+			return nil, err
+		}
+
+		squeezed := []rune{}
+		for _, r := range self.(*StringValue).value {
+			if len(squeezed) > 0 && squeezed[len(squeezed)-1] == r && inEveryCharacterSet(sets, r) {
+				continue
+			}
+
+			squeezed = append(squeezed, r)
+		}
+
+		return NewString(string(squeezed), provider, singletonProvider), nil
+	}))
+
+	s.AddMethod(NewNativeMethod("count", provider, singletonProvider, func(self Value, block Block, args ...Value) (Value, error) {
+		if len(args) == 0 {
+			return nil, NewArgumentError("wrong number of arguments (0 for 1+)", "")
+		}
+
+		sets, err := newCharacterSets(args)
+		if err != nil {
+			return nil, err
+		}
+
+		count := 0
+		for _, r := range self.(*StringValue).value {
+			if inEveryCharacterSet(sets, r) {
+				count++
+			}
+		}
+
+		return NewFixnum(count, provider, singletonProvider), nil
+	}))
+
+	// true when the string starts with any of the given prefixes, where a
+	// regexp prefix only counts when it matches at the very start
+	s.AddMethod(NewNativeMethod("start_with?", provider, singletonProvider, func(self Value, block Block, args ...Value) (Value, error) {
+		str := self.(*StringValue).value
+		for _, arg := range args {
+			switch prefix := arg.(type) {
+			case *StringValue:
+				if strings.HasPrefix(str, prefix.value) {
+					return singletonProvider.SingletonWithName("true"), nil
+				}
+			case *RegexpValue:
+				if match := prefix.regexp.FindStringIndex(str); match != nil && match[0] == 0 {
+					return singletonProvider.SingletonWithName("true"), nil
+				}
+			default:
+				return nil, errors.New(fmt.Sprintf("TypeError: no implicit conversion of %s into String", arg.Class().String()))
+			}
+		}
+
+		return singletonProvider.SingletonWithName("false"), nil
+	}))
+
+	s.AddMethod(NewNativeMethod("split", provider, singletonProvider, func(self Value, block Block, args ...Value) (Value, error) {
+		return split(self.(*StringValue), provider, singletonProvider, args...)
+	}))
+
+	s.AddMethod(NewNativeMethod("to_r", provider, singletonProvider, func(self Value, block Block, args ...Value) (Value, error) {
+		return NewRational(parseRational(self.(*StringValue).value), provider), nil
+	}))
+
+	// the fraction of the padding that goes on the left of the string
+	for name, leftShare := range map[string]float64{"ljust": 0, "center": 0.5, "rjust": 1} {
+		leftShare := leftShare
+		s.AddMethod(NewNativeMethod(name, provider, singletonProvider, func(self Value, block Block, args ...Value) (Value, error) {
+			return justify(self.(*StringValue), leftShare, provider, singletonProvider, args...)
+		}))
+	}
+
+	s.AddMethod(NewNativeMethod("sub", provider, singletonProvider, func(self Value, block Block, args ...Value) (Value, error) {
+		return substitute(self.(*StringValue), false, block, provider, singletonProvider, args...)
+	}))
+	s.AddMethod(NewNativeMethod("gsub", provider, singletonProvider, func(self Value, block Block, args ...Value) (Value, error) {
+		return substitute(self.(*StringValue), true, block, provider, singletonProvider, args...)
+	}))
+
+	s.AddMethod(NewNativeMethod("scan", provider, singletonProvider, func(self Value, block Block, args ...Value) (Value, error) {
+		return scan(self.(*StringValue), block, provider, singletonProvider, args...)
+	}))
+
+	s.AddMethod(NewNativeMethod("<<", provider, singletonProvider, func(self Value, block Block, args ...Value) (Value, error) {
+		return self, appendToString(self.(*StringValue), args...)
+	}))
+	s.AddMethod(NewNativeMethod("concat", provider, singletonProvider, func(self Value, block Block, args ...Value) (Value, error) {
+		return self, appendToString(self.(*StringValue), args...)
+	}))
+
+	s.AddMethod(NewNativeMethod("-@", provider, singletonProvider, func(self Value, block Block, args ...Value) (Value, error) {
+		str := self.(*StringValue)
+		if interned, ok := s.interned[str.value]; ok {
+			return interned, nil
+		}
+
+		if !str.IsFrozen() {
+			str = NewString(str.value, provider, singletonProvider).(*StringValue)
+			str.Freeze()
+		}
+
+		s.interned[str.value] = str
+		return str, nil
+	}))
+	s.AddMethod(NewNativeMethod("+@", provider, singletonProvider, func(self Value, block Block, args ...Value) (Value, error) {
+		if self.IsFrozen() {
+			return NewString(self.(*StringValue).value, provider, singletonProvider), nil
+		}
+
+		return self, nil
+	}))
+
 	return s
 }
 
+// splits the string around a separator, which may be a string or a regexp.
+// With no separator (or a single space) it splits around runs of whitespace,
+// ignoring any at the start, and an empty separator splits it into
+// characters. A positive limit caps the number of fields, leaving the rest of
+// the string in the last one. Trailing empty fields are dropped unless a limit
+// is given.
+func split(str *StringValue, provider ClassProvider, singletonProvider SingletonProvider, args ...Value) (Value, error) {
+	if len(args) > 2 {
+		return nil, NewArgumentError(fmt.Sprintf("wrong number of arguments (%d for 0..2)", len(args)), "")
+	}
+
+	limit := 0
+	if len(args) == 2 {
+		limitValue, ok := args[1].(*fixnumInstance)
+		if !ok {
+			return nil, errors.New(fmt.Sprintf("TypeError: no implicit conversion of %s into Integer", args[1].Class().String()))
+		}
+
+		limit = limitValue.value
+	}
+
+	var separator Value = singletonProvider.SingletonWithName("nil")
+	if len(args) > 0 {
+		separator = args[0]
+	}
+
+	var fields []string
+	switch sep := separator.(type) {
+	case *StringValue:
+		if sep.value == " " {
+			fields = splitOnWhitespace(str.value, limit)
+		} else if limit > 0 {
+			fields = strings.SplitN(str.value, sep.value, limit)
+		} else {
+			fields = strings.Split(str.value, sep.value)
+		}
+	case *RegexpValue:
+		fields = splitOnRegexp(str.value, sep.regexp, limit)
+	default:
+		if separator != singletonProvider.SingletonWithName("nil") {
+			return nil, errors.New(fmt.Sprintf("TypeError: wrong argument type %s (expected Regexp)", separator.Class().String()))
+		}
+
+		fields = splitOnWhitespace(str.value, limit)
+	}
+
+	if str.value == "" {
+		fields = nil
+	}
+
+	if limit == 0 {
+		for len(fields) > 0 && fields[len(fields)-1] == "" {
+			fields = fields[:len(fields)-1]
+		}
+	}
+
+	result, _ := provider.ClassWithName("Array").New(provider, singletonProvider)
+	for _, field := range fields {
+		result.(*Array).Append(NewString(field, provider, singletonProvider))
+	}
+
+	return result, nil
+}
+
+func splitOnWhitespace(str string, limit int) []string {
+	fields := []string{}
+	rest := strings.TrimLeftFunc(str, unicode.IsSpace)
+	for rest != "" {
+		end := strings.IndexFunc(rest, unicode.IsSpace)
+		if end == -1 || (limit > 0 && len(fields) == limit-1) {
+			return append(fields, rest)
+		}
+
+		fields = append(fields, rest[:end])
+		rest = strings.TrimLeftFunc(rest[end:], unicode.IsSpace)
+	}
+
+	return fields
+}
+
+// like ruby, the groups captured by each match are included in the result,
+// and empty matches at either end of the string don't split it
+func splitOnRegexp(str string, re *regexp.Regexp, limit int) []string {
+	fields := []string{}
+	start := 0
+	for _, match := range re.FindAllStringSubmatchIndex(str, -1) {
+		if limit > 0 && len(fields) == limit-1 {
+			break
+		}
+
+		if match[0] == match[1] && (match[0] == 0 || match[0] == len(str)) {
+			continue
+		}
+
+		fields = append(fields, str[start:match[0]])
+		for group := 2; group < len(match); group += 2 {
+			if match[group] != -1 {
+				fields = append(fields, str[match[group]:match[group+1]])
+			}
+		}
+
+		start = match[1]
+	}
+
+	return append(fields, str[start:])
+}
+
+// pads the string with copies of the padding (a space by default) until it
+// is the given number of characters wide, splitting the padding between the
+// left and right. Widths are counted in characters, not bytes.
+func justify(str *StringValue, leftShare float64, provider ClassProvider, singletonProvider SingletonProvider, args ...Value) (Value, error) {
+	if len(args) < 1 || len(args) > 2 {
+		return nil, NewArgumentError(fmt.Sprintf("wrong number of arguments (%d for 1..2)", len(args)), "")
+	}
+
+	width, ok := args[0].(*fixnumInstance)
+	if !ok {
+		return nil, errors.New(fmt.Sprintf("TypeError: no implicit conversion of %s into Integer", args[0].Class().String()))
+	}
+
+	padding := []rune(" ")
+	if len(args) == 2 {
+		paddingStr, ok := args[1].(*StringValue)
+		if !ok {
+			return nil, errors.New(fmt.Sprintf("TypeError: no implicit conversion of %s into String", args[1].Class().String()))
+		}
+
+		padding = []rune(paddingStr.value)
+		if len(padding) == 0 {
+			return nil, NewArgumentError("zero width padding", "")
+		}
+	}
+
+	total := width.value - utf8.RuneCountInString(str.value)
+	if total <= 0 {
+		return NewString(str.value, provider, singletonProvider), nil
+	}
+
+	left := int(float64(total) * leftShare)
+	return NewString(pad(padding, left)+str.value+pad(padding, total-left), provider, singletonProvider), nil
+}
+
+// repeats the padding to fill the given number of characters,
+// cutting the last copy short if needed
+func pad(padding []rune, length int) string {
+	padded := make([]rune, length)
+	for i := range padded {
+		padded[i] = padding[i%len(padding)]
+	}
+
+	return string(padded)
+}
+
+// replaces the first (or every, when global) match of the pattern with
+// either the expanded replacement string or the result of the block
+func substitute(str *StringValue, global bool, block Block, provider ClassProvider, singletonProvider SingletonProvider, args ...Value) (Value, error) {
+	if len(args) == 0 || (len(args) == 1 && block == nil) {
+		return nil, NewArgumentError(fmt.Sprintf("wrong number of arguments (%d for 2)", len(args)), "")
+	}
+
+	var replacement *StringValue
+	if len(args) > 1 {
+		var ok bool
+		replacement, ok = args[1].(*StringValue)
+		if !ok {
+			return nil, errors.New(fmt.Sprintf("TypeError: no implicit conversion of %s into String", args[1].Class().String()))
+		}
+	}
+
+	limit := 1
+	if global {
+		limit = -1
+	}
+
+	// a literal pattern with a replacement that has no backreferences to
+	// expand doesn't need a regexp at all, which is much cheaper on long input
+	if literal, ok := args[0].(*StringValue); ok && replacement != nil && !strings.Contains(replacement.value, `\`) {
+		return NewString(strings.Replace(str.value, literal.value, replacement.value, limit), provider, singletonProvider), nil
+	}
+
+	pattern, err := patternFromValue(args[0])
+	if err != nil {
+		return nil, err
+	}
+
+	result := ""
+	previousEnd := 0
+	for _, match := range pattern.FindAllStringSubmatchIndex(str.value, limit) {
+		result += str.value[previousEnd:match[0]]
+		previousEnd = match[1]
+
+		if replacement != nil {
+			result += expandReplacement(replacement.value, pattern, str.value, match)
+			continue
+		}
+
+		matchData := NewMatchData(pattern, str.value, match, provider).(*MatchDataValue)
+		blockResult, err := withMatchGlobals(matchData, provider, singletonProvider, func() (Value, error) {
+			return block.Call(NewString(str.value[match[0]:match[1]], provider, singletonProvider))
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		// a block that returns no value at all gives nil, which is empty
+		if asStr, ok := blockResult.(*StringValue); ok {
+			result += asStr.value
+		} else if blockResult != nil {
+			result += blockResult.String()
+		}
+	}
+	result += str.value[previousEnd:]
+
+	return NewString(result, provider, singletonProvider), nil
+}
+
+// collects every match of the pattern, or yields each to the block. When the
+// pattern has groups, each match is an array of the groups' text instead.
+func scan(str *StringValue, block Block, provider ClassProvider, singletonProvider SingletonProvider, args ...Value) (Value, error) {
+	if len(args) != 1 {
+		return nil, NewArgumentError(fmt.Sprintf("wrong number of arguments (%d for 1)", len(args)), "")
+	}
+
+	pattern, err := patternFromValue(args[0])
+	if err != nil {
+		return nil, err
+	}
+
+	matches, _ := provider.ClassWithName("Array").New(provider, singletonProvider)
+	for _, indices := range pattern.FindAllStringSubmatchIndex(str.value, -1) {
+		matchData := NewMatchData(pattern, str.value, indices, provider).(*MatchDataValue)
+
+		var match Value
+		if pattern.NumSubexp() == 0 {
+			match = matchData.group(0, provider, singletonProvider)
+		} else {
+			match = matchData.captures(provider, singletonProvider)
+		}
+
+		if block == nil {
+			matches.(*Array).Append(match)
+			continue
+		}
+
+		_, err := withMatchGlobals(matchData, provider, singletonProvider, func() (Value, error) {
+			return block.Call(match)
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if block != nil {
+		return str, nil
+	}
+
+	return matches, nil
+}
+
+// appends each of the given strings or integer codepoints to the receiver
+func appendToString(str *StringValue, args ...Value) error {
+	if str.IsFrozen() {
+		return NewFrozenError(str, "")
+	}
+
+	suffix := ""
+	for _, arg := range args {
+		switch arg := arg.(type) {
+		case *StringValue:
+			suffix += arg.value
+		case *fixnumInstance:
+			if arg.value < 0 || arg.value > utf8.MaxRune {
+				return errors.New(fmt.Sprintf("RangeError: %d out of char range", arg.value))
+			}
+
+			suffix += string(rune(arg.value))
+		default:
+			return errors.New(fmt.Sprintf("TypeError: no implicit conversion of %s into String", arg.Class().String()))
+		}
+	}
+
+	str.value += suffix
+	return nil
+}
+
 func (c *StringClass) String() string {
 	return "String"
 }
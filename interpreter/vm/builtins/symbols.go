@@ -10,13 +10,17 @@ type symbolClass struct {
 	classStub
 }
 
-func NewSymbolClass(provider ClassProvider) Class {
+func NewSymbolClass(provider ClassProvider, singletonProvider SingletonProvider) Class {
 	s := &symbolClass{}
 	s.initialize()
 	s.setStringer(s.String)
 	s.class = provider.ClassWithName("Class")
 	s.superClass = provider.ClassWithName("Object")
 
+	s.AddMethod(NewNativeMethod("to_proc", provider, singletonProvider, func(self Value, block Block, args ...Value) (Value, error) {
+		return NewProc(&symbolBlock{name: self.(*SymbolValue).Name()}, provider), nil
+	}))
+
 	return s
 }
 
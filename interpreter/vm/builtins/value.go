@@ -19,4 +19,7 @@ type Value interface {
 	SetInstanceVariable(string, Value)
 
 	IsTruthy() bool
+
+	IsFrozen() bool
+	Freeze()
 }
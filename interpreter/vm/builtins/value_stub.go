@@ -16,6 +16,8 @@ type valueStub struct {
 	stringer func() string
 
 	instance_variables map[string]Value
+
+	frozen bool
 }
 
 func (valueStub *valueStub) initialize() {
@@ -53,9 +55,9 @@ func (valueStub *valueStub) Method(name string) (Method, error) {
 	}
 
 	//		4. Modules included into the object's class in reverse order of inclusion
-	// FIXME: this should be reversed (should be fixed in Include method)
-	for _, module := range valueStub.class.includedModules() {
-		m, ok := module.eigenclassMethods()[name]
+	included := valueStub.class.includedModules()
+	for i := len(included) - 1; i >= 0; i-- {
+		m, ok := included[i].eigenclassMethods()[name]
 		if ok {
 			return m, nil
 		}
@@ -69,12 +71,40 @@ func (valueStub *valueStub) Method(name string) (Method, error) {
 			return m, nil
 		}
 
+		//		6. Modules included into the superclass, e.g. Kernel into Object
+		for _, module := range super.includedModules() {
+			m, ok := module.eigenclassMethods()[name]
+			if ok {
+				return m, nil
+			}
+		}
+
 		super = super.SuperClass()
 	}
 
 	return nil, NewNoMethodError(name, valueStub.String(), valueStub.Class().String(), "")
 }
 
+// finds the method a message sent to the receiver would call. Private
+// methods can only be called without an explicit receiver (or via send),
+// so they are only found when includePrivate is set.
+func LookupMethod(receiver Value, name string, includePrivate bool) (Method, error) {
+	method, err := receiver.Method(name)
+	if err == nil {
+		return method, nil
+	}
+
+	if private, privateErr := receiver.PrivateMethod(name); privateErr == nil {
+		if includePrivate {
+			return private, nil
+		}
+
+		return nil, NewPrivateMethodError(name, receiver.String(), receiver.Class().String(), "")
+	}
+
+	return nil, err
+}
+
 func (valueStub *valueStub) PrivateMethod(name string) (Method, error) {
 	m, ok := valueStub.private_methods[name]
 	if !ok {
@@ -141,3 +171,11 @@ func (valueStub *valueStub) SetInstanceVariable(name string, value Value) {
 func (v *valueStub) IsTruthy() bool {
 	return true
 }
+
+func (v *valueStub) IsFrozen() bool {
+	return v.frozen
+}
+
+func (v *valueStub) Freeze() {
+	v.frozen = true
+}
@@ -162,6 +162,58 @@ end
 		})
 	})
 
+	Describe(".define_method", func() {
+		It("defines an instance method whose body is the block", func() {
+			value, err := vm.Run(`
+class Foo
+  def initialize(name)
+    @name = name
+  end
+
+  define_method(:name) { @name }
+end
+
+Foo.new('mariculture').name
+`)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(value).To(EqualRubyString("mariculture"))
+		})
+
+		It("binds the method's arguments to the block params and closes over the class body", func() {
+			value, err := vm.Run(`
+class Foo
+  suffix = '!'
+  define_method('exclaim') { |word| word + suffix }
+end
+
+Foo.new.exclaim('hey')
+`)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(value).To(EqualRubyString("hey!"))
+		})
+
+		It("returns the name as a symbol", func() {
+			_, err := vm.Run(`
+class Foo
+  $defined = define_method(:bar) { }
+end
+`)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(vm.MustGet("defined")).To(Equal(vm.Symbols()["bar"]))
+		})
+
+		It("is private", func() {
+			_, err := vm.Run(`
+class Foo
+end
+
+Foo.define_method(:bar) { }
+`)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("private method 'define_method'"))
+		})
+	})
+
 	Describe("superclasses", func() {
 		It("defaults to Object", func() {
 			class, err := vm.Run(`
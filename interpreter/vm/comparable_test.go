@@ -0,0 +1,108 @@
+package vm_test
+
+import (
+	"os"
+	"path/filepath"
+
+	. "github.com/grubby/grubby/interpreter/vm"
+	. "github.com/grubby/grubby/interpreter/vm/builtins"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Comparable", func() {
+	var vm VM
+
+	BeforeEach(func() {
+		pathToExecutable, err := filepath.Abs(filepath.Dir(filepath.Dir(filepath.Dir(os.Args[0]))))
+		if err != nil {
+			panic(err)
+		}
+
+		vm = NewVM(pathToExecutable, "fake-irb-under-test")
+
+		_, err = vm.Run(`
+class Money
+  include Comparable
+  attr_reader :cents
+
+  def initialize(cents)
+    @cents = cents
+  end
+
+  def <=>(other)
+    cents <=> other.cents
+  end
+end
+
+$three = Money.new(300)
+$one = Money.new(100)
+$two = Money.new(200)
+`)
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("sorts instances with <=>", func() {
+		value, err := vm.Run("[$three, $one, $two].sort.map(&:cents)")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(value.(*Array).Members()).To(Equal([]Value{
+			NewFixnum(100, vm, vm),
+			NewFixnum(200, vm, vm),
+			NewFixnum(300, vm, vm),
+		}))
+	})
+
+	It("finds the min and max instances", func() {
+		value, err := vm.Run("[$three, $one, $two].min")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(value).To(BeIdenticalTo(vm.Globals()["one"]))
+
+		value, err = vm.Run("[$three, $one, $two].max")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(value).To(BeIdenticalTo(vm.Globals()["three"]))
+	})
+
+	It("clamps an instance between two others", func() {
+		value, err := vm.Run("$three.clamp($one, $two)")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(value).To(BeIdenticalTo(vm.Globals()["two"]))
+
+		value, err = vm.Run("$two.clamp($one, $three)")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(value).To(BeIdenticalTo(vm.Globals()["two"]))
+	})
+
+	It("clamps numbers to a range with only one bound", func() {
+		value, err := vm.Run("15.clamp(..10)")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(value).To(Equal(NewFixnum(10, vm, vm)))
+
+		value, err = vm.Run("(-3).clamp(0..)")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(value).To(Equal(NewFixnum(0, vm, vm)))
+
+		value, err = vm.Run("5.clamp(0..)")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(value).To(Equal(NewFixnum(5, vm, vm)))
+	})
+
+	It("considers distinct instances that compare as 0 to be ==", func() {
+		value, err := vm.Run("Money.new(100) == $one")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(value).To(Equal(vm.SingletonWithName("true")))
+
+		value, err = vm.Run("Money.new(100) == $two")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(value).To(Equal(vm.SingletonWithName("false")))
+	})
+
+	It("provides the comparison operators", func() {
+		value, err := vm.Run("$three > $one")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(value).To(Equal(vm.SingletonWithName("true")))
+
+		value, err = vm.Run("$two.between?($one, $three)")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(value).To(Equal(vm.SingletonWithName("true")))
+	})
+})
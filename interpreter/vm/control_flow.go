@@ -0,0 +1,81 @@
+package vm
+
+import (
+	"github.com/grubby/grubby/ast"
+
+	. "github.com/grubby/grubby/interpreter/vm/builtins"
+)
+
+// break, next and return unwind the statements being executed as errors,
+// so that begin blocks they pass through still run their ensure clauses.
+// Their messages have no exception class prefix, so no rescue matches them.
+
+type breakSignal struct{}
+
+func (signal *breakSignal) Error() string {
+	return "Invalid break"
+}
+
+type nextSignal struct{}
+
+func (signal *nextSignal) Error() string {
+	return "Invalid next"
+}
+
+type returnSignal struct {
+	value Value
+}
+
+func (signal *returnSignal) Error() string {
+	return "unexpected return"
+}
+
+// runs the body for as long as the condition holds, stopping early on break
+// and skipping to the next check of the condition on next. A do-while loop
+// runs its body once before the first check.
+func (vm *vm) runLoop(context Value, loop ast.Loop) (Value, error) {
+	for checkCondition := !loop.DoWhile; ; checkCondition = true {
+		if checkCondition {
+			condition, err := vm.executeWithContext(context, loop.Condition)
+			if err != nil {
+				return nil, err
+			}
+
+			if !condition.IsTruthy() {
+				break
+			}
+		}
+
+		_, err := vm.executeWithContext(context, loop.Body...)
+		switch err.(type) {
+		case nil, *nextSignal:
+			continue
+		case *breakSignal:
+			return vm.singletons["nil"], nil
+		default:
+			return nil, err
+		}
+	}
+
+	return vm.singletons["nil"], nil
+}
+
+// the value a method returns, whether it ran to the end of its body or
+// returned early
+func methodResult(value Value, err error) (Value, error) {
+	if signal, ok := err.(*returnSignal); ok {
+		return signal.value, nil
+	}
+
+	return value, err
+}
+
+// the value a block gives back to the method that called it, which is nil
+// when it ends early with next
+func blockResult(value Value, err error, nilValue Value) (Value, error) {
+	if _, ok := err.(*nextSignal); ok {
+		return nilValue, nil
+	}
+
+	return value, err
+}
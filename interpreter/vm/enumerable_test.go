@@ -20,6 +20,9 @@ var _ = Describe("Enumerable collections", func() {
 		}
 
 		vm = NewVM(pathToExecutable, "fake-irb-under-test")
+
+		_, err = vm.Run("$weights = {:a => 3, :b => 9, :c => 1, :d => 5}")
+		Expect(err).ToNot(HaveOccurred())
 	})
 
 	Describe("select", func() {
@@ -35,4 +38,183 @@ var _ = Describe("Enumerable collections", func() {
 			Expect(value.(*Array).Members()).To(ContainElement(NewFixnum(2, vm, vm)))
 		})
 	})
+
+	Describe("calling an iterator without a block", func() {
+		It("returns an enumerator that can be chained with with_index", func() {
+			value, err := vm.Run("[:a, :b, :c].each.with_index(1).to_a")
+			Expect(err).ToNot(HaveOccurred())
+
+			pairs := value.(*Array).Members()
+			Expect(len(pairs)).To(Equal(3))
+			Expect(pairs[0].(*Array).Members()).To(Equal([]Value{vm.Symbols()["a"], NewFixnum(1, vm, vm)}))
+			Expect(pairs[1].(*Array).Members()).To(Equal([]Value{vm.Symbols()["b"], NewFixnum(2, vm, vm)}))
+			Expect(pairs[2].(*Array).Members()).To(Equal([]Value{vm.Symbols()["c"], NewFixnum(3, vm, vm)}))
+		})
+
+		It("passes the index to a block given to with_index", func() {
+			value, err := vm.Run("[:a, :b].map.with_index(1) { |element, index| index }")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(value.(*Array).Members()).To(Equal([]Value{
+				NewFixnum(1, vm, vm),
+				NewFixnum(2, vm, vm),
+			}))
+		})
+
+		It("can be iterated with next", func() {
+			_, err := vm.Run(`
+enumerator = [1, 2].each
+first = enumerator.next
+second = enumerator.next
+`)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(vm.MustGet("first")).To(Equal(NewFixnum(1, vm, vm)))
+			Expect(vm.MustGet("second")).To(Equal(NewFixnum(2, vm, vm)))
+
+			_, err = vm.Run("enumerator.next")
+			Expect(err).To(BeAssignableToTypeOf(NewStopIteration("", "")))
+		})
+	})
+
+	Describe("max_by", func() {
+		It("returns the value with the largest key", func() {
+			value, err := vm.Run("[:a, :b, :c, :d].max_by { |key| $weights[key] }")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(value).To(Equal(vm.Symbols()["b"]))
+		})
+
+		It("returns the n values with the largest keys, largest first", func() {
+			value, err := vm.Run("[:a, :b, :c, :d].max_by(2) { |key| $weights[key] }")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(value.(*Array).Members()).To(Equal([]Value{
+				vm.Symbols()["b"], vm.Symbols()["d"],
+			}))
+		})
+	})
+
+	Describe("min_by", func() {
+		It("returns the n values with the smallest keys, smallest first", func() {
+			value, err := vm.Run("[:a, :b, :c, :d].min_by(3) { |key| $weights[key] }")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(value.(*Array).Members()).To(Equal([]Value{
+				vm.Symbols()["c"], vm.Symbols()["a"], vm.Symbols()["d"],
+			}))
+		})
+
+		It("returns nil for an empty collection", func() {
+			value, err := vm.Run("[].min_by { |key| key }")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(value).To(Equal(vm.SingletonWithName("nil")))
+		})
+	})
+
+	Describe("group_by", func() {
+		It("groups values by parity, keeping the order keys first appear in", func() {
+			value, err := vm.Run(`
+groups = [1, 2, 3, 4, 5].group_by { |n| n.even? }
+[groups.keys, groups[false], groups[true]]
+`)
+			Expect(err).ToNot(HaveOccurred())
+
+			results := value.(*Array).Members()
+			Expect(results[0].(*Array).Members()).To(Equal([]Value{
+				vm.SingletonWithName("false"), vm.SingletonWithName("true"),
+			}))
+			Expect(results[1].(*Array).Members()).To(Equal([]Value{
+				NewFixnum(1, vm, vm), NewFixnum(3, vm, vm), NewFixnum(5, vm, vm),
+			}))
+			Expect(results[2].(*Array).Members()).To(Equal([]Value{
+				NewFixnum(2, vm, vm), NewFixnum(4, vm, vm),
+			}))
+		})
+	})
+
+	Describe("filter_map", func() {
+		It("keeps only the truthy results of the block", func() {
+			value, err := vm.Run("[1, 2, 3, 4].filter_map { |n| n.pow(2) if n.even? }")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(value.(*Array).Members()).To(Equal([]Value{
+				NewFixnum(4, vm, vm), NewFixnum(16, vm, vm),
+			}))
+		})
+
+		It("works on ranges", func() {
+			value, err := vm.Run("(1..6).filter_map { |n| n.pow(3) if n.even? }")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(value.(*Array).Members()).To(Equal([]Value{
+				NewFixnum(8, vm, vm), NewFixnum(64, vm, vm), NewFixnum(216, vm, vm),
+			}))
+		})
+	})
+
+	Describe("sort", func() {
+		It("sorts in reverse when the block flips the <=> comparison", func() {
+			value, err := vm.Run("[2, 3, 1].sort { |a, b| b <=> a }")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(value.(*Array).Members()).To(Equal([]Value{
+				NewFixnum(3, vm, vm), NewFixnum(2, vm, vm), NewFixnum(1, vm, vm),
+			}))
+
+			value, err = vm.Run("['b', 'c', 'a'].sort { |a, b| b <=> a }")
+			Expect(err).ToNot(HaveOccurred())
+
+			members := value.(*Array).Members()
+			Expect(members).To(HaveLen(3))
+			Expect(members[0].(*StringValue).RawString()).To(Equal("c"))
+			Expect(members[2].(*StringValue).RawString()).To(Equal("a"))
+		})
+	})
+
+	Describe("with a custom #each", func() {
+		BeforeEach(func() {
+			_, err := vm.Run(`
+class Countdown
+  include Enumerable
+
+  def each
+    yield 3
+    yield 2, :two
+    yield 1
+  end
+end
+`)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("zips what each yields with other collections", func() {
+			value, err := vm.Run("Countdown.new.zip([:a, :b])")
+			Expect(err).ToNot(HaveOccurred())
+
+			tuples := value.(*Array).Members()
+			Expect(tuples).To(HaveLen(3))
+			Expect(tuples[0].(*Array).Members()).To(Equal([]Value{NewFixnum(3, vm, vm), vm.Symbols()["a"]}))
+			Expect(tuples[2].(*Array).Members()).To(Equal([]Value{NewFixnum(1, vm, vm), vm.SingletonWithName("nil")}))
+
+			pair := tuples[1].(*Array).Members()
+			Expect(pair[0].(*Array).Members()).To(Equal([]Value{NewFixnum(2, vm, vm), vm.Symbols()["two"]}))
+			Expect(pair[1]).To(Equal(vm.Symbols()["b"]))
+		})
+
+		It("passes several values yielded at once to each_entry as an array", func() {
+			value, err := vm.Run(`
+entries = []
+Countdown.new.each_entry { |entry| entries << entry }
+entries
+`)
+			Expect(err).ToNot(HaveOccurred())
+
+			entries := value.(*Array).Members()
+			Expect(entries).To(HaveLen(3))
+			Expect(entries[1].(*Array).Members()).To(Equal([]Value{NewFixnum(2, vm, vm), vm.Symbols()["two"]}))
+		})
+
+		It("filter_maps the values yielded by each", func() {
+			value, err := vm.Run("Countdown.new.filter_map { |n| n unless n == 3 }")
+			Expect(err).ToNot(HaveOccurred())
+
+			kept := value.(*Array).Members()
+			Expect(kept).To(HaveLen(2))
+			Expect(kept[0].(*Array).Members()).To(Equal([]Value{NewFixnum(2, vm, vm), vm.Symbols()["two"]}))
+			Expect(kept[1]).To(Equal(NewFixnum(1, vm, vm)))
+		})
+	})
 })
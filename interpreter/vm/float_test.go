@@ -0,0 +1,76 @@
+package vm_test
+
+import (
+	"os"
+	"path/filepath"
+
+	. "github.com/grubby/grubby/interpreter/vm"
+	. "github.com/grubby/grubby/interpreter/vm/builtins"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Floats", func() {
+	var vm VM
+
+	BeforeEach(func() {
+		pathToExecutable, err := filepath.Abs(filepath.Dir(filepath.Dir(filepath.Dir(os.Args[0]))))
+		if err != nil {
+			panic(err)
+		}
+
+		vm = NewVM(pathToExecutable, "fake-irb-under-test")
+	})
+
+	Describe("nan?", func() {
+		It("is only true for NaN", func() {
+			value, err := vm.Run("Float::NAN.nan?")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(value).To(Equal(vm.SingletonWithName("true")))
+
+			value, err = vm.Run("Float::INFINITY.nan?")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(value).To(Equal(vm.SingletonWithName("false")))
+
+			value, err = vm.Run("1.5.nan?")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(value).To(Equal(vm.SingletonWithName("false")))
+		})
+	})
+
+	Describe("infinite?", func() {
+		It("returns 1 or -1 for infinities, and nil otherwise", func() {
+			value, err := vm.Run("Float::INFINITY.infinite?")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(value).To(Equal(NewFixnum(1, vm, vm)))
+
+			value, err = vm.Run("negative = -Float::INFINITY; negative.infinite?")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(value).To(Equal(NewFixnum(-1, vm, vm)))
+
+			value, err = vm.Run("Float::NAN.infinite?")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(value).To(Equal(vm.SingletonWithName("nil")))
+
+			value, err = vm.Run("1.5.infinite?")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(value).To(Equal(vm.SingletonWithName("nil")))
+		})
+	})
+
+	Describe("finite?", func() {
+		It("is only true for normal numbers", func() {
+			value, err := vm.Run("Float::NAN.finite?")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(value).To(Equal(vm.SingletonWithName("false")))
+
+			value, err = vm.Run("Float::INFINITY.finite?")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(value).To(Equal(vm.SingletonWithName("false")))
+
+			value, err = vm.Run("1.5.finite?")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(value).To(Equal(vm.SingletonWithName("true")))
+		})
+	})
+})
@@ -6,6 +6,7 @@ import (
 
 	. "github.com/grubby/grubby/interpreter/vm"
 	. "github.com/grubby/grubby/interpreter/vm/builtins"
+	. "github.com/grubby/grubby/testhelpers"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 )
@@ -84,4 +85,186 @@ hash[:hello]
 		Expect(err).ToNot(HaveOccurred())
 		Expect(value).To(Equal(vm.Symbols()["world"]))
 	})
+
+	Describe("#invert", func() {
+		It("swaps keys and values, keeping the last key for duplicate values", func() {
+			value, err := vm.Run(`
+inverted = {:a => 1, :b => 2, :c => 1}.invert
+[inverted.keys, inverted.values]
+`)
+			Expect(err).ToNot(HaveOccurred())
+
+			pair := value.(*Array).Members()
+			Expect(pair[0].(*Array).Members()).To(Equal([]Value{
+				NewFixnum(1, vm, vm), NewFixnum(2, vm, vm),
+			}))
+			Expect(pair[1].(*Array).Members()).To(Equal([]Value{
+				vm.Symbols()["c"], vm.Symbols()["b"],
+			}))
+		})
+	})
+
+	Describe("#key", func() {
+		It("returns the first key whose value matches", func() {
+			value, err := vm.Run("{:a => 1, :b => 2, :c => 1}.key(1)")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(value).To(Equal(vm.Symbols()["a"]))
+		})
+
+		It("returns nil when no value matches", func() {
+			value, err := vm.Run("{:a => 1}.key(5)")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(value).To(Equal(vm.SingletonWithName("nil")))
+		})
+	})
+
+	Describe("#values_at", func() {
+		It("returns the values for each key, with nil for missing keys", func() {
+			value, err := vm.Run("{:a => 1, :b => 2}.values_at(:b, :missing, :a)")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(value.(*Array).Members()).To(Equal([]Value{
+				NewFixnum(2, vm, vm), vm.SingletonWithName("nil"), NewFixnum(1, vm, vm),
+			}))
+		})
+	})
+
+	Describe("#each_with_object", func() {
+		It("yields each destructured pair along with the memo object", func() {
+			value, err := vm.Run(`
+inverted = {:a => 1, :b => 2}.each_with_object({}) { |(key, value), memo| memo[value] = key }
+[inverted.keys, inverted.values]
+`)
+			Expect(err).ToNot(HaveOccurred())
+
+			pair := value.(*Array).Members()
+			Expect(pair[0].(*Array).Members()).To(Equal([]Value{
+				NewFixnum(1, vm, vm), NewFixnum(2, vm, vm),
+			}))
+			Expect(pair[1].(*Array).Members()).To(Equal([]Value{
+				vm.Symbols()["a"], vm.Symbols()["b"],
+			}))
+		})
+	})
+
+	Describe("#slice", func() {
+		It("returns a hash with only the given keys, ignoring missing ones", func() {
+			value, err := vm.Run("{:a => 1, :b => 2, :c => 3}.slice(:c, :missing, :a)")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(value.String()).To(Equal("{:c => 3, :a => 1}"))
+		})
+	})
+
+	Describe("#except", func() {
+		It("returns a hash without the given keys", func() {
+			value, err := vm.Run("{:a => 1, :b => 2, :c => 3}.except(:a, :c)")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(value.String()).To(Equal("{:b => 2}"))
+		})
+	})
+
+	Describe("#[] and #[]=", func() {
+		It("reads and overwrites string keys by their contents", func() {
+			value, err := vm.Run(`
+hash = {'a' => 1}
+hash['a'] = 2
+hash['b'] = 3
+[hash['a'], hash.keys]
+`)
+			Expect(err).ToNot(HaveOccurred())
+
+			pair := value.(*Array).Members()
+			Expect(pair[0]).To(Equal(NewFixnum(2, vm, vm)))
+			keys := pair[1].(*Array).Members()
+			Expect(keys).To(HaveLen(2))
+			Expect(keys[0]).To(EqualRubyString("a"))
+			Expect(keys[1]).To(EqualRubyString("b"))
+		})
+	})
+
+	Describe("#each", func() {
+		It("yields each key and value in insertion order", func() {
+			value, err := vm.Run(`
+hash = {:b => 2}
+hash[:a] = 1
+pairs = []
+hash.each { |key, value| pairs.push(key, value) }
+pairs
+`)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(value.(*Array).Members()).To(Equal([]Value{
+				vm.Symbols()["b"], NewFixnum(2, vm, vm), vm.Symbols()["a"], NewFixnum(1, vm, vm),
+			}))
+		})
+	})
+
+	Describe("#fetch", func() {
+		It("returns the value for a key that is present", func() {
+			value, err := vm.Run("{:a => 1}.fetch(:a, 2)")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(value).To(Equal(NewFixnum(1, vm, vm)))
+		})
+
+		It("returns the default, or the block's value, for a missing key", func() {
+			value, err := vm.Run("[{}.fetch(:a, 2), {}.fetch(:b) { |key| key }]")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(value.(*Array).Members()).To(Equal([]Value{
+				NewFixnum(2, vm, vm), vm.Symbols()["b"],
+			}))
+		})
+
+		It("raises a KeyError for a missing key without a default", func() {
+			_, err := vm.Run("{:a => 1}.fetch(:b)")
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(Equal("KeyError: key not found: :b"))
+		})
+	})
+
+	Describe("comparisons", func() {
+		It("compares hashes by their keys and values with ==", func() {
+			value, err := vm.Run(`
+[
+  {:a => 1, 'b' => 'two'} == {'b' => 'two', :a => 1},
+  {:a => 1} == {:a => 2},
+  {:a => 1} == {:a => 1, :b => 2},
+  {} == []
+]
+`)
+			Expect(err).ToNot(HaveOccurred())
+
+			trueValue, falseValue := vm.SingletonWithName("true"), vm.SingletonWithName("false")
+			Expect(value.(*Array).Members()).To(Equal([]Value{trueValue, falseValue, falseValue, falseValue}))
+		})
+
+		It("tests for subsets with <= and <", func() {
+			value, err := vm.Run(`
+small, big = {:a => 1}, {:a => 1, :b => 2}
+[small <= big, small <= small, small < big, small < small, {:a => 2} <= big, big <= small]
+`)
+			Expect(err).ToNot(HaveOccurred())
+
+			trueValue, falseValue := vm.SingletonWithName("true"), vm.SingletonWithName("false")
+			Expect(value.(*Array).Members()).To(Equal([]Value{
+				trueValue, trueValue, trueValue, falseValue, falseValue, falseValue,
+			}))
+		})
+
+		It("tests for supersets with >= and >", func() {
+			value, err := vm.Run(`
+small, big = {:a => 1}, {:a => 1, :b => 2}
+[big >= small, big >= big, big > small, big > big, small >= big]
+`)
+			Expect(err).ToNot(HaveOccurred())
+
+			trueValue, falseValue := vm.SingletonWithName("true"), vm.SingletonWithName("false")
+			Expect(value.(*Array).Members()).To(Equal([]Value{
+				trueValue, trueValue, trueValue, falseValue, falseValue,
+			}))
+		})
+
+		It("raises a TypeError when compared with something that is not a hash", func() {
+			_, err := vm.Run("{} <= 'a'")
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(Equal("TypeError: no implicit conversion of String into Hash"))
+		})
+	})
 })
@@ -0,0 +1,136 @@
+package vm_test
+
+import (
+	"os"
+	"path/filepath"
+
+	. "github.com/grubby/grubby/interpreter/vm"
+	. "github.com/grubby/grubby/interpreter/vm/builtins"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Integers", func() {
+	var vm VM
+
+	BeforeEach(func() {
+		pathToExecutable, err := filepath.Abs(filepath.Dir(filepath.Dir(filepath.Dir(os.Args[0]))))
+		if err != nil {
+			panic(err)
+		}
+
+		vm = NewVM(pathToExecutable, "fake-irb-under-test")
+	})
+
+	Describe("-@", func() {
+		It("negates an integer variable", func() {
+			value, err := vm.Run("x = 5; -x")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(value).To(Equal(NewFixnum(-5, vm, vm)))
+		})
+
+		It("binds looser than a method call on a variable", func() {
+			value, err := vm.Run("x = -5; -x.abs")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(value).To(Equal(NewFixnum(-5, vm, vm)))
+		})
+	})
+
+	Describe("digits", func() {
+		It("returns the base 10 digits, least significant first", func() {
+			value, err := vm.Run("123.digits")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(value.(*Array).Members()).To(Equal([]Value{
+				NewFixnum(3, vm, vm),
+				NewFixnum(2, vm, vm),
+				NewFixnum(1, vm, vm),
+			}))
+		})
+
+		It("accepts an optional base", func() {
+			value, err := vm.Run("255.digits(16)")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(value.(*Array).Members()).To(Equal([]Value{
+				NewFixnum(15, vm, vm),
+				NewFixnum(15, vm, vm),
+			}))
+		})
+
+		It("raises a Math::DomainError for negative numbers", func() {
+			method, err := vm.MustGetClass("Integer").Method("digits")
+			Expect(err).ToNot(HaveOccurred())
+
+			_, err = method.Execute(NewFixnum(-5, vm, vm), nil)
+			Expect(err).To(BeAssignableToTypeOf(NewDomainError("", "")))
+		})
+	})
+
+	Describe("bit_length", func() {
+		It("returns the number of bits needed to represent the number", func() {
+			value, err := vm.Run("255.bit_length")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(value).To(Equal(NewFixnum(8, vm, vm)))
+		})
+	})
+
+	Describe("chr", func() {
+		It("returns the single byte string for the codepoint", func() {
+			value, err := vm.Run("65.chr")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(value.(*StringValue).RawString()).To(Equal("A"))
+		})
+
+		It("encodes codepoints beyond ASCII when given an encoding", func() {
+			value, err := vm.Run("233.chr(Encoding::UTF_8)")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(value.(*StringValue).RawString()).To(Equal("é"))
+		})
+
+		It("raises a RangeError for codepoints that do not fit in a byte", func() {
+			_, err := vm.Run("300.chr")
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("RangeError: 300 out of char range"))
+		})
+	})
+
+	Describe("pow", func() {
+		It("raises the number to the given power", func() {
+			value, err := vm.Run("2.pow(10)")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(value).To(Equal(NewFixnum(1024, vm, vm)))
+		})
+
+		It("reduces the power by the modulus when one is given", func() {
+			value, err := vm.Run("4.pow(13, 497)")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(value).To(Equal(NewFixnum(445, vm, vm)))
+
+			value, err = vm.Run("3.pow(1000000, 7)")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(value).To(Equal(NewFixnum(4, vm, vm)))
+		})
+
+		It("raises a RangeError for a negative exponent with a modulus", func() {
+			_, err := vm.Run("2.pow(-1, 5)")
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("RangeError"))
+		})
+	})
+
+	Describe("+, - and *", func() {
+		It("give an integer for two integers", func() {
+			value, err := vm.Run("[2 + 3, 2 - 3, 2 * 3]")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(value.(*Array).Members()).To(Equal([]Value{
+				NewFixnum(5, vm, vm), NewFixnum(-1, vm, vm), NewFixnum(6, vm, vm),
+			}))
+		})
+
+		It("give a Float when the other operand is one", func() {
+			value, err := vm.Run("2 * 1.5")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(value).To(BeAssignableToTypeOf(&FloatValue{}))
+			Expect(value.String()).To(Equal("3.0"))
+		})
+	})
+})
@@ -9,37 +9,94 @@ import (
 
 type frame map[string]builtins.Value
 
+// a scope holds the local variables of a method body, a block or the top
+// level of a file. Blocks close over the scopes around them, while method
+// bodies (and the top level) only see their own locals.
+type scope struct {
+	variables frame
+	isBlock   bool
+
+	// the name of the method whose body this is, if any,
+	// and the block that method was called with
+	method string
+	block  builtins.Block
+}
+
+// the innermost scope is always first
 type localVariableStack struct {
-	frames []frame
+	frames []scope
 }
 
 func newLocalVariableStack() *localVariableStack {
 	return &localVariableStack{
-		frames: make([]frame, 0),
+		frames: make([]scope, 0),
 	}
 }
 
-func newEmptyFrame() []frame {
-	return []frame{
-		frame{},
-	}
+// starts a new method scope, which hides the locals of its caller
+func (stack *localVariableStack) unshift(method string, block builtins.Block) {
+	stack.unshiftScope(scope{variables: frame{}, method: method, block: block})
+}
+
+// starts a new scope whose locals are stored in the given frame
+func (stack *localVariableStack) unshiftFrame(variables frame) {
+	stack.unshiftScope(scope{variables: variables})
+}
+
+// starts a new block scope, which can read and assign the locals
+// of the scopes it is nested within
+func (stack *localVariableStack) unshiftBlock() {
+	stack.unshiftScope(scope{variables: frame{}, isBlock: true})
 }
 
-func (stack *localVariableStack) unshift() {
-	stack.frames = append(newEmptyFrame(), stack.frames...)
+func (stack *localVariableStack) unshiftScope(s scope) {
+	stack.frames = append([]scope{s}, stack.frames...)
 }
 
 func (stack *localVariableStack) shift() {
-	stack.frames = stack.frames[0 : len(stack.frames)-1]
+	stack.frames = stack.frames[1:]
 }
 
+// the scopes a block created right now would close over
+func (stack *localVariableStack) capture() []scope {
+	return stack.frames
+}
+
+// runs the function with the stack replaced by a block scope nested within
+// the captured scopes, restoring the current scopes afterwards
+func (stack *localVariableStack) withinBlock(captured []scope, f func() (builtins.Value, error)) (builtins.Value, error) {
+	original := stack.frames
+	defer func() {
+		stack.frames = original
+	}()
+
+	stack.frames = captured
+	stack.unshiftBlock()
+	return f()
+}
+
+// defines a local in the innermost scope, shadowing any outer local of the
+// same name (as block and method parameters do)
 func (stack *localVariableStack) store(key string, value builtins.Value) {
-	stack.frames[0][key] = value
+	stack.frames[0].variables[key] = value
+}
+
+// assigns to an existing local visible from the innermost scope, or defines
+// a new one in the innermost scope when there is none
+func (stack *localVariableStack) assign(key string, value builtins.Value) {
+	for _, s := range stack.visibleScopes() {
+		if _, ok := s.variables[key]; ok {
+			s.variables[key] = value
+			return
+		}
+	}
+
+	stack.store(key, value)
 }
 
 func (stack *localVariableStack) retrieve(key string) (builtins.Value, error) {
-	for _, frame := range stack.frames {
-		val, ok := frame[key]
+	for _, s := range stack.visibleScopes() {
+		val, ok := s.variables[key]
 		if ok {
 			return val, nil
 		}
@@ -47,3 +104,39 @@ func (stack *localVariableStack) retrieve(key string) (builtins.Value, error) {
 
 	return nil, errors.New(fmt.Sprintf("No such key '%s'", key))
 }
+
+// the innermost scope and each block's enclosing scope, up to and
+// including the nearest method scope
+func (stack *localVariableStack) visibleScopes() []scope {
+	for index, s := range stack.frames {
+		if !s.isBlock {
+			return stack.frames[:index+1]
+		}
+	}
+
+	return stack.frames
+}
+
+// the name of the method the innermost scope belongs to. Blocks belong to
+// the method they were written in, and the top level to no method at all.
+func (stack *localVariableStack) currentMethod() (string, bool) {
+	method := stack.methodScope().method
+	return method, method != ""
+}
+
+// the block that yield calls, which belongs to the current method
+// even when yielding from within a block
+func (stack *localVariableStack) currentBlock() (builtins.Block, bool) {
+	block := stack.methodScope().block
+	return block, block != nil
+}
+
+// the scope of the method (or file) the innermost scope belongs to
+func (stack *localVariableStack) methodScope() scope {
+	visible := stack.visibleScopes()
+	if len(visible) == 0 {
+		return scope{}
+	}
+
+	return visible[len(visible)-1]
+}
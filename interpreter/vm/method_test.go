@@ -144,4 +144,318 @@ ace
 			})
 		})
 	})
+
+	Describe("calling a setter with safe navigation", func() {
+		BeforeEach(func() {
+			_, err := vm.Run(`
+class Person
+  def name=(value)
+    @name = value
+  end
+end
+`)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("performs the assignment when the receiver is not nil", func() {
+			_, err := vm.Run(`
+person = Person.new
+person&.name = 'alice'
+`)
+			Expect(err).ToNot(HaveOccurred())
+
+			person := vm.MustGet("person")
+			Expect(person.GetInstanceVariable("name")).To(EqualRubyString("alice"))
+		})
+
+		It("returns nil without evaluating the value when the receiver is nil", func() {
+			value, err := vm.Run(`
+person = nil
+person&.name = this_method_does_not_exist
+`)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(value).To(Equal(vm.SingletonWithName("nil")))
+		})
+
+		It("carries on with the statements after a call on nil", func() {
+			value, err := vm.Run(`
+x = nil
+x&.name
+y = 5
+y
+`)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(value).To(Equal(NewFixnum(5, vm, vm)))
+		})
+	})
+
+	Describe("__method__", func() {
+		It("names the enclosing method, even from within a block", func() {
+			value, err := vm.Run(`
+class Greeter
+  def greet
+    [1].map { |x| __method__ }
+  end
+end
+
+Greeter.new.greet
+`)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(value.(*Array).Members()).To(Equal([]Value{vm.Symbols()["greet"]}))
+		})
+
+		It("is nil outside of a method", func() {
+			_, err := vm.Run(`
+class Greeter
+  $inside_class_body = __method__
+end
+`)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(vm.Globals()["inside_class_body"]).To(Equal(vm.SingletonWithName("nil")))
+
+			value, err := vm.Run("__method__")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(value).To(Equal(vm.SingletonWithName("nil")))
+		})
+	})
+
+	Describe("yield", func() {
+		It("calls the block the method was given, even from within another block", func() {
+			value, err := vm.Run(`
+class Pair
+  def each
+    yield :first
+    [:second].each { |x| yield x }
+  end
+end
+
+yielded = []
+Pair.new.each { |x| yielded << x }
+yielded
+`)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(value.(*Array).Members()).To(Equal([]Value{vm.Symbols()["first"], vm.Symbols()["second"]}))
+		})
+
+		It("raises a LocalJumpError without a block", func() {
+			_, err := vm.Run(`
+class Pair
+  def each
+    yield :first
+  end
+end
+
+Pair.new.each
+`)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("LocalJumpError: no block given (yield)"))
+		})
+	})
+
+	Describe("private methods", func() {
+		BeforeEach(func() {
+			_, err := vm.Run(`
+class Safe
+  def open
+    combination
+  end
+
+  private
+
+  def combination
+    "1234"
+  end
+end
+`)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("can be called without an explicit receiver", func() {
+			value, err := vm.Run("Safe.new.open")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(value).To(EqualRubyString("1234"))
+		})
+
+		It("can be called via __send__ and send", func() {
+			value, err := vm.Run("Safe.new.__send__(:combination) + Safe.new.send('combination')")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(value).To(EqualRubyString("12341234"))
+		})
+
+		It("cannot be called with an explicit receiver", func() {
+			_, err := vm.Run("Safe.new.combination")
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("NoMethodError: private method 'combination' called for"))
+		})
+
+		It("cannot be called via public_send", func() {
+			_, err := vm.Run("Safe.new.public_send(:combination)")
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("NoMethodError: private method 'combination' called for"))
+		})
+
+		It("can be named after they are defined", func() {
+			_, err := vm.Run(`
+class Vault
+  def code
+    "0000"
+  end
+  private :code
+end
+
+Vault.new.code
+`)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("NoMethodError: private method 'code' called for"))
+		})
+	})
+
+	Describe("send", func() {
+		BeforeEach(func() {
+			_, err := vm.Run(`
+class Calculator
+  def add(a, b)
+    a + b
+  end
+
+  def each_operand
+    yield 1
+    yield 2
+  end
+end
+`)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("calls the named method with the remaining args", func() {
+			value, err := vm.Run("[Calculator.new.send(:add, 1, 2), Calculator.new.public_send('add', 3, 4)].inspect")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(value).To(EqualRubyString("[3, 7]"))
+		})
+
+		It("passes its block on to the method", func() {
+			value, err := vm.Run(`
+operands = []
+Calculator.new.send(:each_operand) { |operand| operands << operand }
+operands.inspect
+`)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(value).To(EqualRubyString("[1, 2]"))
+		})
+
+		It("raises a NoMethodError for a method that doesn't exist", func() {
+			_, err := vm.Run("Calculator.new.send(:subtract, 2, 1)")
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("NoMethodError: undefined method 'subtract'"))
+		})
+
+		It("falls back to method_missing", func() {
+			value, err := vm.Run(`
+class Calculator
+  def method_missing(name, *args)
+    [name, args]
+  end
+end
+
+Calculator.new.send(:subtract, 2, 1).inspect
+`)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(value).To(EqualRubyString("[:subtract, [2, 1]]"))
+		})
+	})
+
+	Describe("__send__", func() {
+		It("still sends messages when a class defines its own send", func() {
+			value, err := vm.Run(`
+class Message
+  def send(to)
+    "sent"
+  end
+
+  def body
+    "hello"
+  end
+end
+
+Message.new.__send__(:body)
+`)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(value).To(EqualRubyString("hello"))
+		})
+	})
+
+	Describe("proc args", func() {
+		It("passes a value that was converted to a proc as an argument, not as the block", func() {
+			value, err := vm.Run(`
+class Taker
+  def take(value)
+    value.call(2)
+  end
+end
+
+Taker.new.take(:even?.to_proc)
+`)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(value).To(Equal(vm.SingletonWithName("true")))
+		})
+
+		It("passes an argument given with & as the block", func() {
+			value, err := vm.Run("[1, 2].map(&:even?)")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(value.(*Array).Members()).To(Equal([]Value{
+				vm.SingletonWithName("false"), vm.SingletonWithName("true"),
+			}))
+		})
+	})
+
+	Describe("splat params", func() {
+		It("collect the args that the other params don't take into an array", func() {
+			value, err := vm.Run(`
+class Splatter
+  def around(first, *middle, last)
+    [first, middle, last]
+  end
+end
+
+Splatter.new.around(1, 2, 3, 4).inspect
+`)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(value).To(EqualRubyString("[1, [2, 3], 4]"))
+		})
+	})
+
+	Describe("method_missing", func() {
+		BeforeEach(func() {
+			_, err := vm.Run(`
+class Recorder
+  def method_missing(name, *args)
+    [name, args]
+  end
+
+  def replay
+    forgotten
+  end
+end
+`)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("is called with the name and args of a method that doesn't exist", func() {
+			value, err := vm.Run("Recorder.new.play(1, 2).inspect")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(value).To(EqualRubyString("[:play, [1, 2]]"))
+		})
+
+		It("is called for bare identifiers that aren't locals or methods", func() {
+			value, err := vm.Run("Recorder.new.replay.inspect")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(value).To(EqualRubyString("[:forgotten, []]"))
+		})
+
+		It("leaves the NoMethodError in place for classes that don't define it", func() {
+			_, err := vm.Run("Object.new.play")
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("NoMethodError: undefined method 'play'"))
+		})
+	})
 })
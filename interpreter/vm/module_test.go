@@ -2,6 +2,7 @@ package vm_test
 
 import (
 	. "github.com/grubby/grubby/interpreter/vm"
+	. "github.com/grubby/grubby/interpreter/vm/builtins"
 	. "github.com/grubby/grubby/testhelpers"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -73,4 +74,74 @@ end
 		Expect(module).To(HaveInstanceMethod("from"))
 		Expect(module).To(HaveInstanceMethod("to"))
 	})
+
+	Describe("being included into a class", func() {
+		BeforeEach(func() {
+			_, err := vm.Run(`
+module Greeting
+  def greet
+    :greeting
+  end
+
+  def name
+    :greeting
+  end
+end
+
+module Farewell
+  def name
+    :farewell
+  end
+end
+
+class Person
+  def name
+    :person
+  end
+
+  def species
+    :human
+  end
+end
+
+class Friend < Person
+  include Greeting
+  include Farewell
+
+  def species
+    :friend
+  end
+end
+`)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("gives instances of the class the module's instance methods", func() {
+			value, err := vm.Run("Friend.new.greet")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(value).To(Equal(vm.Symbols()["greeting"]))
+		})
+
+		It("prefers the class's own methods, then the most recently included module, then the superclass", func() {
+			value, err := vm.Run("friend = Friend.new; [friend.species, friend.name, Person.new.name]")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(value.(*Array).Members()).To(Equal([]Value{
+				vm.Symbols()["friend"], vm.Symbols()["farewell"], vm.Symbols()["person"],
+			}))
+		})
+
+		It("lists the modules before the superclass in ancestors", func() {
+			value, err := vm.Run("Friend.ancestors")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(value.(*Array).Members()).To(Equal([]Value{
+				vm.ClassWithName("Friend"),
+				vm.Modules()["Farewell"],
+				vm.Modules()["Greeting"],
+				vm.ClassWithName("Person"),
+				vm.ClassWithName("Object"),
+				vm.Modules()["Kernel"],
+				vm.ClassWithName("BasicObject"),
+			}))
+		})
+	})
 })
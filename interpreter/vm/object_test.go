@@ -0,0 +1,308 @@
+package vm_test
+
+import (
+	"os"
+	"path/filepath"
+
+	. "github.com/grubby/grubby/interpreter/vm"
+	. "github.com/grubby/grubby/interpreter/vm/builtins"
+	. "github.com/grubby/grubby/testhelpers"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Objects", func() {
+	var vm VM
+
+	BeforeEach(func() {
+		pathToExecutable, err := filepath.Abs(filepath.Dir(filepath.Dir(filepath.Dir(os.Args[0]))))
+		if err != nil {
+			panic(err)
+		}
+
+		vm = NewVM(pathToExecutable, "fake-irb-under-test")
+	})
+
+	Describe("itself", func() {
+		It("returns the receiver", func() {
+			value, err := vm.Run(":hello.itself")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(value).To(Equal(vm.Symbols()["hello"]))
+		})
+
+		It("can be used to group elements by their own value", func() {
+			value, err := vm.Run(`
+groups = [1, 2, 1, 3, 1].group_by(&:itself)
+groups[1]
+`)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(value.(*Array).Members()).To(Equal([]Value{
+				NewFixnum(1, vm, vm),
+				NewFixnum(1, vm, vm),
+				NewFixnum(1, vm, vm),
+			}))
+		})
+	})
+
+	Describe("display", func() {
+		It("writes the receiver to stdout without a trailing newline", func() {
+			var (
+				value Value
+				err   error
+			)
+
+			output := SwapStdout(func() {
+				value, err = vm.Run("'hello'.display")
+			})
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(output).To(Equal("hello"))
+			Expect(value).To(Equal(vm.SingletonWithName("nil")))
+		})
+	})
+
+	Describe("!=", func() {
+		It("negates ==", func() {
+			value, err := vm.Run("5 != 6")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(value).To(Equal(vm.SingletonWithName("true")))
+
+			value, err = vm.Run("5 != 5")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(value).To(Equal(vm.SingletonWithName("false")))
+		})
+
+		It("uses an overridden ==", func() {
+			value, err := vm.Run(`
+class AlwaysEqual
+  def ==(other)
+    true
+  end
+end
+
+AlwaysEqual.new != 5
+`)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(value).To(Equal(vm.SingletonWithName("false")))
+		})
+	})
+
+	Describe("===", func() {
+		It("defaults to ==", func() {
+			value, err := vm.Run("5 === 5")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(value).To(Equal(vm.SingletonWithName("true")))
+
+			value, err = vm.Run("'a' === 'b'")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(value).To(Equal(vm.SingletonWithName("false")))
+		})
+
+		It("checks membership for modules", func() {
+			value, err := vm.Run("Integer === 5")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(value).To(Equal(vm.SingletonWithName("true")))
+
+			value, err = vm.Run("String === 5")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(value).To(Equal(vm.SingletonWithName("false")))
+		})
+
+		It("checks inclusion for ranges", func() {
+			value, err := vm.Run("range = 1..3; range === 2")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(value).To(Equal(vm.SingletonWithName("true")))
+
+			value, err = vm.Run("range = 1..3; range === 4")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(value).To(Equal(vm.SingletonWithName("false")))
+		})
+
+		It("matches strings for regexps", func() {
+			value, err := vm.Run("/ab+/ === 'cabbage'")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(value).To(Equal(vm.SingletonWithName("true")))
+		})
+
+		It("is used to pick the branch of a case statement", func() {
+			value, err := vm.Run(`
+size = case 7
+when String
+  'text'
+when 1..3
+  'small'
+when Integer
+  'large'
+end
+size
+`)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(value).To(EqualRubyString("large"))
+		})
+	})
+
+	Describe("inspect", func() {
+		It("marks the point where a self-referential array refers back to itself", func() {
+			value, err := vm.Run(`
+a = []
+a << a
+a.inspect
+`)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(value).To(EqualRubyString("[[...]]"))
+		})
+
+		It("does not mistake a value repeated within a structure for a cycle", func() {
+			value, err := vm.Run(`
+a = [1]
+[a, a].inspect
+`)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(value).To(EqualRubyString("[[1], [1]]"))
+		})
+
+		It("marks the point where a self-referential hash refers back to itself", func() {
+			value, err := vm.Run(`
+h = {}
+h[:me] = h
+h.inspect
+`)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(value).To(EqualRubyString("{:me=>{...}}"))
+		})
+
+		It("uses #inspect methods defined in ruby for nested values", func() {
+			value, err := vm.Run(`
+class Shy
+  def inspect
+    'shy'
+  end
+end
+
+[Shy.new, nil, 'str'].inspect
+`)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(value).To(EqualRubyString(`[shy, nil, "str"]`))
+		})
+	})
+
+	Describe("respond_to?", func() {
+		It("is true for methods the receiver's class or its ancestors define", func() {
+			value, err := vm.Run(`["str".respond_to?(:split), "str".respond_to?('frozen?'), "str".respond_to?(:nonexistent)]`)
+			Expect(err).ToNot(HaveOccurred())
+
+			trueValue, falseValue := vm.SingletonWithName("true"), vm.SingletonWithName("false")
+			Expect(value.(*Array).Members()).To(Equal([]Value{trueValue, trueValue, falseValue}))
+		})
+
+		It("only counts private methods when asked to include them", func() {
+			value, err := vm.Run(`
+class Safe
+  private
+
+  def combination
+  end
+end
+
+safe = Safe.new
+[safe.respond_to?(:combination), safe.respond_to?(:combination, true)]
+`)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(value.(*Array).Members()).To(Equal([]Value{
+				vm.SingletonWithName("false"), vm.SingletonWithName("true"),
+			}))
+		})
+	})
+
+	Describe("then", func() {
+		It("passes the value of each block on to the next in a chain", func() {
+			value, err := vm.Run(`
+'grubby'.then { |s| s + '!' }.then { |s| s * 2 }.yield_self { |s| [s, s.count('!')] }.inspect
+`)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(value).To(EqualRubyString(`["grubby!grubby!", 2]`))
+		})
+
+		It("gives nil when the block does", func() {
+			value, err := vm.Run("5.then { nil }.then { |x| x }")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(value).To(Equal(vm.SingletonWithName("nil")))
+		})
+	})
+
+	Describe("tap", func() {
+		It("yields the receiver to the block and returns the receiver", func() {
+			var (
+				value Value
+				err   error
+			)
+
+			output := SwapStdout(func() {
+				value, err = vm.Run("[1, 2].tap { |a| p a }")
+			})
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(output).To(Equal("[1, 2]\n"))
+			Expect(value.(*Array).Members()).To(Equal([]Value{
+				NewFixnum(1, vm, vm),
+				NewFixnum(2, vm, vm),
+			}))
+		})
+	})
+
+	Describe("self", func() {
+		It("is the receiver within an instance method", func() {
+			value, err := vm.Run(`
+class Foo
+  def me
+    self
+  end
+end
+
+$foo = Foo.new
+$foo.me
+`)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(value).To(Equal(vm.Globals()["foo"]))
+		})
+
+		It("is the class within a class method", func() {
+			value, err := vm.Run(`
+class Foo
+  def self.me
+    self
+  end
+end
+
+Foo.me
+`)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(value).To(Equal(vm.MustGetClass("Foo")))
+		})
+
+		It("is the main object at the top level", func() {
+			value, err := vm.Run("self")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(value).To(Equal(vm.MustGet("main")))
+		})
+
+		It("is the receiver of instance_eval within its block", func() {
+			value, err := vm.Run(`
+class Foo
+  def initialize
+    @name = "foo"
+  end
+end
+
+$foo = Foo.new
+$foo.instance_eval do
+  [self, @name]
+end
+`)
+			Expect(err).ToNot(HaveOccurred())
+			members := value.(*Array).Members()
+			Expect(members[0]).To(Equal(vm.Globals()["foo"]))
+			Expect(members[1]).To(EqualRubyString("foo"))
+		})
+	})
+})
@@ -0,0 +1,50 @@
+package vm_test
+
+import (
+	"os"
+	"path/filepath"
+
+	. "github.com/grubby/grubby/interpreter/vm"
+	. "github.com/grubby/grubby/interpreter/vm/builtins"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Kernel#system", func() {
+	var vm VM
+
+	BeforeEach(func() {
+		pathToExecutable, err := filepath.Abs(filepath.Dir(filepath.Dir(filepath.Dir(os.Args[0]))))
+		if err != nil {
+			panic(err)
+		}
+
+		vm = NewVM(pathToExecutable, "fake-irb-under-test")
+	})
+
+	It("returns true when the command succeeds", func() {
+		value, err := vm.Run("system('true')")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(value).To(Equal(vm.SingletonWithName("true")))
+
+		status, err := vm.Run("$?.exitstatus")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(status).To(Equal(NewFixnum(0, vm, vm)))
+	})
+
+	It("returns false when the command exits with a non-zero status", func() {
+		value, err := vm.Run("system('exit 3')")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(value).To(Equal(vm.SingletonWithName("false")))
+
+		status, err := vm.Run("$?.exitstatus")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(status).To(Equal(NewFixnum(3, vm, vm)))
+	})
+
+	It("returns nil when the command cannot be run", func() {
+		value, err := vm.Run("system('grubby-command-that-does-not-exist')")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(value).To(Equal(vm.SingletonWithName("nil")))
+	})
+})
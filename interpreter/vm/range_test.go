@@ -0,0 +1,129 @@
+package vm_test
+
+import (
+	"os"
+	"path/filepath"
+
+	. "github.com/grubby/grubby/interpreter/vm"
+	. "github.com/grubby/grubby/interpreter/vm/builtins"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Range", func() {
+	var vm VM
+
+	BeforeEach(func() {
+		pathToExecutable, err := filepath.Abs(filepath.Dir(filepath.Dir(filepath.Dir(os.Args[0]))))
+		if err != nil {
+			panic(err)
+		}
+
+		vm = NewVM(pathToExecutable, "fake-irb-under-test")
+	})
+
+	Describe("as a case condition", func() {
+		It("matches numbers within the range", func() {
+			value, err := vm.Run(`
+case 72
+when 0..59
+  'F'
+when 60..79
+  'C'
+else
+  'A'
+end
+`)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(value.(*StringValue).RawString()).To(Equal("C"))
+		})
+
+		It("matches strings within the range", func() {
+			value, err := vm.Run(`
+case 'queue'
+when 'a'..'m'
+  'first half'
+when 'n'..'z'
+  'second half'
+end
+`)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(value.(*StringValue).RawString()).To(Equal("second half"))
+		})
+
+		It("does not match values outside of the range", func() {
+			value, err := vm.Run(`
+case 100
+when 0..59
+  'F'
+end
+`)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(value).To(Equal(vm.SingletonWithName("nil")))
+		})
+	})
+
+	Describe("include? and cover?", func() {
+		It("only includes single characters in a range of single characters", func() {
+			value, err := vm.Run("('a'..'z').include?('bb')")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(value).To(Equal(vm.SingletonWithName("false")))
+
+			value, err = vm.Run("('a'..'z').member?('m')")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(value).To(Equal(vm.SingletonWithName("true")))
+		})
+
+		It("covers any value between the endpoints", func() {
+			value, err := vm.Run("('a'..'z').cover?('bb')")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(value).To(Equal(vm.SingletonWithName("true")))
+
+			value, err = vm.Run("(1..10) === 11")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(value).To(Equal(vm.SingletonWithName("false")))
+		})
+	})
+
+	Describe("excluding the end", func() {
+		It("does not include its end", func() {
+			value, err := vm.Run("(1...10) === 10")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(value).To(Equal(vm.SingletonWithName("false")))
+
+			value, err = vm.Run("(1...10) === 9")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(value).To(Equal(vm.SingletonWithName("true")))
+		})
+	})
+
+	Describe("without a beginning or an end", func() {
+		It("is only bounded on the side it has", func() {
+			value, err := vm.Run("(1..) === 100")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(value).To(Equal(vm.SingletonWithName("true")))
+
+			value, err = vm.Run("(...5) === 5")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(value).To(Equal(vm.SingletonWithName("false")))
+		})
+
+		It("slices arrays from the start or through the end", func() {
+			value, err := vm.Run(`
+numbers = [1, 2, 3, 4]
+[numbers[2..], numbers[..1]]
+`)
+			Expect(err).ToNot(HaveOccurred())
+
+			slices := value.(*Array).Members()
+			Expect(slices[0].(*Array).Members()).To(Equal([]Value{
+				NewFixnum(3, vm, vm),
+				NewFixnum(4, vm, vm),
+			}))
+			Expect(slices[1].(*Array).Members()).To(Equal([]Value{
+				NewFixnum(1, vm, vm),
+				NewFixnum(2, vm, vm),
+			}))
+		})
+	})
+})
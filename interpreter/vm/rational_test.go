@@ -0,0 +1,80 @@
+package vm_test
+
+import (
+	"os"
+	"path/filepath"
+
+	. "github.com/grubby/grubby/interpreter/vm"
+	. "github.com/grubby/grubby/interpreter/vm/builtins"
+	. "github.com/grubby/grubby/testhelpers"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Rationals", func() {
+	var vm VM
+
+	BeforeEach(func() {
+		pathToExecutable, err := filepath.Abs(filepath.Dir(filepath.Dir(filepath.Dir(os.Args[0]))))
+		if err != nil {
+			panic(err)
+		}
+
+		vm = NewVM(pathToExecutable, "fake-irb-under-test")
+	})
+
+	Describe("Integer#to_r", func() {
+		It("gives the integer over one", func() {
+			value, err := vm.Run("3.to_r.inspect")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(value).To(EqualRubyString("(3/1)"))
+		})
+	})
+
+	Describe("String#to_r", func() {
+		It("reads a fraction, in lowest terms", func() {
+			value, err := vm.Run("'3/6'.to_r.to_s")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(value).To(EqualRubyString("1/2"))
+		})
+
+		It("reads a decimal and ignores whatever follows the number", func() {
+			value, err := vm.Run("' -0.75 of a cup'.to_r.inspect")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(value).To(EqualRubyString("(-3/4)"))
+		})
+
+		It("gives zero for a string that doesn't start with a number", func() {
+			value, err := vm.Run("'half'.to_r.inspect")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(value).To(EqualRubyString("(0/1)"))
+		})
+	})
+
+	Describe("arithmetic", func() {
+		It("stays exact with rationals and integers", func() {
+			value, err := vm.Run("['1/2'.to_r + 3.to_r, '1/2'.to_r - 1, 1 + '1/3'.to_r, '2/3'.to_r * '3/4'.to_r, '1/2'.to_r / 2].inspect")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(value).To(EqualRubyString("[(7/2), (-1/2), (4/3), (1/2), (1/4)]"))
+		})
+
+		It("gives a Float when the other operand is a Float", func() {
+			value, err := vm.Run("'1/2'.to_r * 0.5")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(value).To(BeAssignableToTypeOf(&FloatValue{}))
+			Expect(value.String()).To(Equal("0.25"))
+		})
+
+		It("compares equal to integers of the same value", func() {
+			value, err := vm.Run("('1/2'.to_r * 2) == 1")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(value).To(Equal(vm.SingletonWithName("true")))
+		})
+
+		It("raises a ZeroDivisionError when dividing by zero", func() {
+			_, err := vm.Run("'1/2'.to_r / 0")
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(Equal("ZeroDivisionError: divided by 0"))
+		})
+	})
+})
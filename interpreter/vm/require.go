@@ -0,0 +1,93 @@
+package vm
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	. "github.com/grubby/grubby/interpreter/vm/builtins"
+)
+
+// resolves the name given to `require` to the canonical absolute path of
+// the file it refers to, so that a feature is only ever loaded once, no
+// matter which relative path was used to require it
+func (vm *vm) resolveRequirePath(fileName string) (string, bool) {
+	// only ruby files are loaded, so a name without the extension never
+	// refers to the file as it's named
+	candidate := fileName + ".rb"
+	if strings.HasSuffix(fileName, ".rb") {
+		candidate = fileName
+	}
+
+	var directories []string
+	if filepath.IsAbs(fileName) {
+		directories = []string{""}
+	} else if strings.HasPrefix(fileName, "./") || strings.HasPrefix(fileName, "../") {
+		workingDir, err := os.Getwd()
+		if err != nil {
+			return "", false
+		}
+
+		directories = []string{workingDir}
+	} else {
+		for _, path := range vm.CurrentGlobals["LOAD_PATH"].(*Array).Members() {
+			directories = append(directories, path.(*StringValue).RawString())
+		}
+	}
+
+	for _, directory := range directories {
+		fullPath, err := filepath.Abs(filepath.Join(directory, candidate))
+		if err != nil {
+			continue
+		}
+
+		info, err := os.Stat(fullPath)
+		if err != nil || info.IsDir() {
+			continue
+		}
+
+		canonicalPath, err := filepath.EvalSymlinks(fullPath)
+		if err != nil {
+			continue
+		}
+
+		return canonicalPath, true
+	}
+
+	return "", false
+}
+
+// runs the file at the resolved path unless it is already in $", returning
+// true if it was loaded now and false if it had been loaded before
+func (vm *vm) loadFeature(fileName, fullPath string) (Value, error) {
+	loadedFeatures := vm.CurrentGlobals["LOADED_FEATURES"].(*Array)
+	for _, feature := range loadedFeatures.Members() {
+		if feature.(*StringValue).RawString() == fullPath {
+			return vm.singletons["false"], nil
+		}
+	}
+
+	contents, err := ioutil.ReadFile(fullPath)
+	if err != nil {
+		return nil, NewLoadError(fileName, vm.stack.String())
+	}
+
+	// mark the feature as loaded first, so that circular requires terminate
+	feature := NewString(fullPath, vm, vm)
+	loadedFeatures.Append(feature)
+
+	originalName := vm.currentFilename
+	defer func() {
+		vm.currentFilename = originalName
+	}()
+
+	vm.currentFilename = fullPath
+	if _, err := vm.Run(string(contents)); err != nil {
+		// a file that failed to load can be required again
+		loadedFeatures.Remove(feature)
+		return nil, err
+	}
+
+	return vm.singletons["true"], nil
+}
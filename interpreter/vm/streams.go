@@ -0,0 +1,64 @@
+package vm
+
+import (
+	"io"
+	"os"
+
+	. "github.com/grubby/grubby/interpreter/vm/builtins"
+)
+
+// exposes the standard streams to ruby as $stdout, $stderr and $stdin,
+// along with their STDOUT, STDERR and STDIN constants
+func (vm *vm) registerStandardStreams() {
+	streams := map[string]Value{
+		"stdout": NewOutputIO("STDOUT", vm.Stdout, vm),
+		"stderr": NewOutputIO("STDERR", vm.Stderr, vm),
+		"stdin":  NewInputIO("STDIN", vm.Stdin, vm),
+	}
+
+	for name, stream := range streams {
+		vm.CurrentGlobals[name] = stream
+	}
+
+	vm.ObjectSpace["STDOUT"] = streams["stdout"]
+	vm.ObjectSpace["STDERR"] = streams["stderr"]
+	vm.ObjectSpace["STDIN"] = streams["stdin"]
+}
+
+// the process's own streams are used until others are set, and are looked
+// up each time so that swapping os.Stdout is still respected
+func (vm *vm) Stdout() io.Writer {
+	if vm.stdout == nil {
+		return os.Stdout
+	}
+
+	return vm.stdout
+}
+
+func (vm *vm) Stderr() io.Writer {
+	if vm.stderr == nil {
+		return os.Stderr
+	}
+
+	return vm.stderr
+}
+
+func (vm *vm) Stdin() io.Reader {
+	if vm.stdin == nil {
+		return os.Stdin
+	}
+
+	return vm.stdin
+}
+
+func (vm *vm) SetStdout(writer io.Writer) {
+	vm.stdout = writer
+}
+
+func (vm *vm) SetStderr(writer io.Writer) {
+	vm.stderr = writer
+}
+
+func (vm *vm) SetStdin(reader io.Reader) {
+	vm.stdin = reader
+}
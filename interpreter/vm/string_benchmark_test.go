@@ -0,0 +1,38 @@
+package vm_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/grubby/grubby/interpreter/vm"
+)
+
+// compares gsub with a literal pattern, which skips regexps entirely,
+// against the same substitution made with the equivalent regexp
+func BenchmarkGsubLiteralPattern(b *testing.B) {
+	benchmarkGsub(b, "str.gsub('.', '-')")
+}
+
+func BenchmarkGsubRegexpPattern(b *testing.B) {
+	benchmarkGsub(b, `str.gsub(/\./, '-')`)
+}
+
+func benchmarkGsub(b *testing.B, substitution string) {
+	pathToExecutable, err := filepath.Abs(filepath.Dir(filepath.Dir(filepath.Dir(os.Args[0]))))
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	vm := NewVM(pathToExecutable, "fake-irb-under-test")
+	if _, err := vm.Run("str = 'www.example.com ' * 10000"); err != nil {
+		b.Fatal(err)
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := vm.Run(substitution); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,433 @@
+package vm_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"unicode/utf8"
+
+	. "github.com/grubby/grubby/interpreter/vm"
+	. "github.com/grubby/grubby/interpreter/vm/builtins"
+	. "github.com/grubby/grubby/testhelpers"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Strings", func() {
+	var vm VM
+
+	BeforeEach(func() {
+		pathToExecutable, err := filepath.Abs(filepath.Dir(filepath.Dir(filepath.Dir(os.Args[0]))))
+		if err != nil {
+			panic(err)
+		}
+
+		vm = NewVM(pathToExecutable, "fake-irb-under-test")
+	})
+
+	Describe("<<", func() {
+		It("appends the character for an integer codepoint", func() {
+			value, err := vm.Run(`
+str = "hello "
+str << 65
+str
+`)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(value.(*StringValue).RawString()).To(Equal("hello A"))
+		})
+
+		It("appends another string", func() {
+			value, err := vm.Run(`
+str = "a"
+str << "bc"
+str
+`)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(value.(*StringValue).RawString()).To(Equal("abc"))
+		})
+
+		It("raises a FrozenError when the receiver is frozen", func() {
+			_, err := vm.Run(`
+str = "a"
+str.freeze
+str << "bc"
+`)
+			Expect(err).To(HaveOccurred())
+			Expect(err).To(BeAssignableToTypeOf(NewFrozenError(NewString("", vm, vm), "")))
+		})
+	})
+
+	Describe("concat", func() {
+		It("appends each of its arguments and returns the receiver", func() {
+			value, err := vm.Run(`
+str = "a"
+str.concat("b", 67, "d")
+`)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(value.(*StringValue).RawString()).To(Equal("abCd"))
+		})
+	})
+
+	Describe("gsub", func() {
+		It("replaces matches with nothing when the block returns nil", func() {
+			vm.SetStdout(&bytes.Buffer{})
+
+			value, err := vm.Run(`"a1".gsub(/\d/) { puts 1 }`)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(value).To(EqualRubyString("a"))
+		})
+
+		It("replaces every occurrence of a literal string pattern", func() {
+			value, err := vm.Run(`'1.5.12'.gsub('.', '-')`)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(value.(*StringValue).RawString()).To(Equal("1-5-12"))
+		})
+
+		It("gives the same result for a literal pattern as for the equivalent regexp", func() {
+			value, err := vm.Run(`
+str = ('a.b.c ' * 50) + 'a.b'
+[
+  str.gsub('.', '-') == str.gsub(/\./, '-'),
+  str.sub('.', '-') == str.sub(/\./, '-'),
+  'abc'.gsub('', '-') == 'abc'.gsub(//, '-'),
+  str.gsub('a.', '\0\0') == str.gsub(/a\./, '\0\0')
+]
+`)
+			Expect(err).ToNot(HaveOccurred())
+
+			trueValue := vm.SingletonWithName("true")
+			Expect(value.(*Array).Members()).To(Equal([]Value{trueValue, trueValue, trueValue, trueValue}))
+		})
+
+		It("replaces every match of a regexp and leaves the receiver alone", func() {
+			value, err := vm.Run(`
+str = 'foo bar foo'
+[str.gsub(/fo+/, 'baz'), str].inspect
+`)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(value).To(EqualRubyString(`["baz bar baz", "foo bar foo"]`))
+		})
+
+		It("replaces named capture references with \\k<name>", func() {
+			value, err := vm.Run(`'hello world'.gsub(/(?<word>\w+)/, '<\k<word>>')`)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(value.(*StringValue).RawString()).To(Equal("<hello> <world>"))
+		})
+
+		It("replaces numbered capture references", func() {
+			value, err := vm.Run(`'john smith'.gsub(/(\w+) (\w+)/, '\2, \1')`)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(value.(*StringValue).RawString()).To(Equal("smith, john"))
+		})
+
+		It("uses the result of the block when one is given", func() {
+			value, err := vm.Run(`'banana'.gsub(/a/) { |match| 'o' }`)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(value.(*StringValue).RawString()).To(Equal("bonono"))
+		})
+
+		It("sets the match globals while the block runs", func() {
+			value, err := vm.Run(`'john smith'.gsub(/(\w)(\w*)/) { $2 + $1 }`)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(value.(*StringValue).RawString()).To(Equal("ohnj miths"))
+
+			value, err = vm.Run("$1")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(value).To(Equal(vm.SingletonWithName("nil")))
+		})
+	})
+
+	Describe("scan", func() {
+		It("returns every match", func() {
+			value, err := vm.Run(`'a1b22'.scan(/\d+/)`)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(value.(*Array).Members()).To(HaveLen(2))
+			Expect(value.(*Array).Members()[0]).To(EqualRubyString("1"))
+			Expect(value.(*Array).Members()[1]).To(EqualRubyString("22"))
+		})
+
+		It("sets $~ for each match yielded to the block", func() {
+			value, err := vm.Run(`
+keys = []
+'a=1 b=2'.scan(/(\w)=(\w)/) { |pair| keys << $~[1] }
+keys
+`)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(value.(*Array).Members()).To(HaveLen(2))
+			Expect(value.(*Array).Members()[0]).To(EqualRubyString("a"))
+			Expect(value.(*Array).Members()[1]).To(EqualRubyString("b"))
+		})
+	})
+
+	Describe("sub", func() {
+		It("replaces only the first match, expanding \\0 to the whole match", func() {
+			value, err := vm.Run(`'a-b-c'.sub('-', '[\0]')`)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(value.(*StringValue).RawString()).To(Equal("a[-]b-c"))
+		})
+
+		It("replaces only the first match of a regexp", func() {
+			value, err := vm.Run(`'foo bar foo'.sub(/o+/, '0')`)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(value.(*StringValue).RawString()).To(Equal("f0 bar foo"))
+		})
+	})
+
+	Describe("*", func() {
+		It("repeats the string", func() {
+			value, err := vm.Run("'ab' * 3")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(value.(*StringValue).RawString()).To(Equal("ababab"))
+		})
+
+		It("raises an ArgumentError for a negative count", func() {
+			_, err := vm.Run("'ab' * -1")
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("ArgumentError: negative argument"))
+		})
+
+		It("raises a TypeError for a count that is not an integer", func() {
+			_, err := vm.Run("'ab' * 'c'")
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("TypeError"))
+		})
+	})
+
+	Describe("ord", func() {
+		It("returns the first codepoint of the string", func() {
+			value, err := vm.Run("'Z'.ord")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(value).To(Equal(NewFixnum(90, vm, vm)))
+		})
+
+		It("round trips through Integer#chr", func() {
+			value, err := vm.Run("'é'.ord.chr(Encoding::UTF_8)")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(value.(*StringValue).RawString()).To(Equal("é"))
+
+			value, err = vm.Run("65.chr.ord")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(value).To(Equal(NewFixnum(65, vm, vm)))
+		})
+
+		It("raises an ArgumentError for an empty string", func() {
+			_, err := vm.Run("''.ord")
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("ArgumentError: empty string"))
+		})
+	})
+
+	Describe("squeeze", func() {
+		It("collapses every run of a repeated character", func() {
+			value, err := vm.Run("'aaabbb  ccc'.squeeze")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(value.(*StringValue).RawString()).To(Equal("ab c"))
+		})
+
+		It("only collapses runs of characters in the given set", func() {
+			value, err := vm.Run("'aaabbb  ccc'.squeeze('a-b')")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(value.(*StringValue).RawString()).To(Equal("ab  ccc"))
+		})
+	})
+
+	Describe("count", func() {
+		It("counts the characters within a range", func() {
+			value, err := vm.Run("'hello world'.count('a-k')")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(value).To(Equal(NewFixnum(3, vm, vm)))
+		})
+
+		It("counts characters outside a negated set", func() {
+			value, err := vm.Run("'hello world'.count('^l')")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(value).To(Equal(NewFixnum(8, vm, vm)))
+		})
+
+		It("counts the intersection of several sets", func() {
+			value, err := vm.Run("'hello world'.count('lo', 'o')")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(value).To(Equal(NewFixnum(2, vm, vm)))
+		})
+	})
+
+	Describe("%", func() {
+		It("formats a single argument", func() {
+			value, err := vm.Run("'hello %s' % 'world'")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(value.(*StringValue).RawString()).To(Equal("hello world"))
+		})
+
+		It("formats each member of an array with widths and precisions", func() {
+			value, err := vm.Run("'%05.2f|%-3d|%x|%p|%%' % [3.14159, 7, 255, nil]")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(value.(*StringValue).RawString()).To(Equal("03.14|7  |ff|nil|%"))
+		})
+
+		It("raises an ArgumentError when there are too few arguments", func() {
+			_, err := vm.Run("'%s and %s' % ['one']")
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("ArgumentError: too few arguments"))
+		})
+
+		It("takes a width or precision given as * from the arguments", func() {
+			value, err := vm.Run(`"%*d|%.*f" % [5, 42, 2, 3.14159]`)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(value.(*StringValue).RawString()).To(Equal("   42|3.14"))
+		})
+
+		It("left justifies with a negative * width", func() {
+			value, err := vm.Run(`"%*d|" % [-4, 7]`)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(value.(*StringValue).RawString()).To(Equal("7   |"))
+		})
+	})
+
+	Describe("format and sprintf", func() {
+		It("format their arguments like String#%", func() {
+			value, err := vm.Run(`format("%*d", 5, 42) + sprintf("|%.*f", 1, 3.14159)`)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(value.(*StringValue).RawString()).To(Equal("   42|3.1"))
+		})
+
+		It("substitutes %{name} references from a hash", func() {
+			value, err := vm.Run(`format("%{greeting}, %{name}!", greeting: "Hi", name: "X")`)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(value.(*StringValue).RawString()).To(Equal("Hi, X!"))
+		})
+
+		It("formats %<name> references with the spec that follows them", func() {
+			value, err := vm.Run(`format("%<name>-4s|%<pi>.2f", name: "X", pi: 3.14159)`)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(value.(*StringValue).RawString()).To(Equal("X   |3.14"))
+		})
+
+		It("raises a KeyError for a missing name", func() {
+			_, err := vm.Run(`format("%{missing}", present: 1)`)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("KeyError: key<missing> not found"))
+		})
+	})
+
+	Describe("center, ljust and rjust", func() {
+		It("measure the width of multibyte strings in characters", func() {
+			value, err := vm.Run(`"日本".center(7, "é")`)
+			Expect(err).ToNot(HaveOccurred())
+
+			centered := value.(*StringValue).RawString()
+			Expect(centered).To(Equal("éé日本ééé"))
+			Expect(utf8.RuneCountInString(centered)).To(Equal(7))
+		})
+
+		It("repeat the padding, cutting the last copy short", func() {
+			value, err := vm.Run(`"abc".ljust(8, "12") + "|" + "abc".rjust(5)`)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(value.(*StringValue).RawString()).To(Equal("abc12121|  abc"))
+		})
+	})
+
+	Describe("-@ and +@", func() {
+		It("returns a frozen copy of an unfrozen string from -@", func() {
+			value, err := vm.Run(`
+str = "abc"
+deduped = -str
+[deduped.frozen?, str.frozen?, deduped.equal?(str)].inspect
+`)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(value).To(EqualRubyString("[true, false, false]"))
+		})
+
+		It("interns equal strings so -@ returns the same object", func() {
+			value, err := vm.Run(`(-"a").equal?(-"a")`)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(value).To(Equal(vm.SingletonWithName("true")))
+
+			value, err = vm.Run(`(-"a").equal?(-"b")`)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(value).To(Equal(vm.SingletonWithName("false")))
+		})
+
+		It("returns a mutable copy of a frozen string from +@", func() {
+			value, err := vm.Run(`
+frozen = -"abc"
+copy = +frozen
+copy << "d"
+[copy.frozen?, copy.equal?(frozen), copy, frozen].inspect
+`)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(value).To(EqualRubyString(`[false, false, "abcd", "abc"]`))
+		})
+
+		It("returns an unfrozen string itself from +@", func() {
+			value, err := vm.Run(`
+str = "abc"
+(+str).equal?(str)
+`)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(value).To(Equal(vm.SingletonWithName("true")))
+		})
+	})
+
+	Describe("split", func() {
+		It("splits around a string separator", func() {
+			value, err := vm.Run(`'a,b,c'.split(',')`)
+			Expect(err).ToNot(HaveOccurred())
+
+			members := value.(*Array).Members()
+			Expect(members).To(HaveLen(3))
+			Expect(members[0]).To(EqualRubyString("a"))
+			Expect(members[1]).To(EqualRubyString("b"))
+			Expect(members[2]).To(EqualRubyString("c"))
+		})
+
+		It("splits around runs of whitespace without a separator", func() {
+			value, err := vm.Run(`"  one two   three ".split.inspect`)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(value).To(EqualRubyString(`["one", "two", "three"]`))
+		})
+
+		It("splits into characters with an empty separator", func() {
+			value, err := vm.Run(`"abc".split("").inspect`)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(value).To(EqualRubyString(`["a", "b", "c"]`))
+		})
+
+		It("splits around matches of a regexp, keeping captured groups", func() {
+			value, err := vm.Run(`["a1b22c".split(/\d+/), "a,b".split(/(,)/)].inspect`)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(value).To(EqualRubyString(`[["a", "b", "c"], ["a", ",", "b"]]`))
+		})
+
+		It("leaves the rest of the string in the last field when given a limit", func() {
+			value, err := vm.Run(`["a-b-c-d".split("-", 2), "a b c".split(" ", 2)].inspect`)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(value).To(EqualRubyString(`[["a", "b-c-d"], ["a", "b c"]]`))
+		})
+
+		It("drops trailing empty fields unless given a negative limit", func() {
+			value, err := vm.Run(`["a,b,,".split(","), "a,b,,".split(",", -1)].inspect`)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(value).To(EqualRubyString(`[["a", "b"], ["a", "b", "", ""]]`))
+		})
+	})
+
+	Describe("start_with?", func() {
+		It("is true when the string starts with one of the prefixes", func() {
+			value, err := vm.Run(`["hello".start_with?("he"), "hello".start_with?("x", "hel"), "hello".start_with?("lo")].inspect`)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(value).To(EqualRubyString("[true, true, false]"))
+		})
+
+		It("matches a regexp prefix at the start of the string", func() {
+			value, err := vm.Run(`"hello".start_with?(/h.l/)`)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(value).To(Equal(vm.SingletonWithName("true")))
+		})
+
+		It("does not match a regexp that only matches later in the string", func() {
+			value, err := vm.Run(`"hello".start_with?(/l+o/)`)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(value).To(Equal(vm.SingletonWithName("false")))
+		})
+	})
+})
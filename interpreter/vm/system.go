@@ -0,0 +1,72 @@
+package vm
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+
+	. "github.com/grubby/grubby/interpreter/vm/builtins"
+)
+
+const shellMetaCharacters = "*?{}[]<>()~&|\\$;'`\"\n#="
+
+// words that only have a meaning to the shell, and cannot be executed directly
+var shellReservedWords = map[string]bool{
+	"case": true, "do": true, "done": true, "elif": true, "else": true, "esac": true,
+	"fi": true, "for": true, "if": true, "then": true, "until": true, "while": true,
+	"!": true, ".": true, ":": true, "break": true, "cd": true, "continue": true,
+	"eval": true, "exec": true, "exit": true, "export": true, "readonly": true,
+	"return": true, "set": true, "shift": true, "times": true, "trap": true, "unset": true,
+}
+
+// Kernel#system runs the command with the interpreter's stdio,
+// returning true or false based on its exit status, or nil if it could not be run.
+// The status of the command is stored in $?
+func (vm *vm) system(self Value, block Block, args ...Value) (Value, error) {
+	if len(args) == 0 {
+		return nil, NewArgumentError("wrong number of arguments (0 for 1+)", vm.stack.String())
+	}
+
+	commandArgs := []string{}
+	for _, arg := range args {
+		str, ok := arg.(*StringValue)
+		if !ok {
+			return nil, errors.New(fmt.Sprintf("TypeError: no implicit conversion of %s into String", arg.Class().String()))
+		}
+
+		commandArgs = append(commandArgs, str.RawString())
+	}
+
+	// like MRI, a single command string is only handed to the shell when it needs one
+	if len(commandArgs) == 1 {
+		words := strings.Fields(commandArgs[0])
+		if strings.ContainsAny(commandArgs[0], shellMetaCharacters) || (len(words) > 0 && shellReservedWords[words[0]]) {
+			commandArgs = []string{"/bin/sh", "-c", commandArgs[0]}
+		} else {
+			commandArgs = words
+		}
+	}
+
+	if len(commandArgs) == 0 {
+		return vm.singletons["nil"], nil
+	}
+
+	cmd := exec.Command(commandArgs[0], commandArgs[1:]...)
+	cmd.Stdin = vm.Stdin()
+	cmd.Stdout = vm.Stdout()
+	cmd.Stderr = vm.Stderr()
+
+	err := cmd.Run()
+	if cmd.ProcessState == nil {
+		vm.CurrentGlobals["?"] = NewProcessStatus(0, 127, vm)
+		return vm.singletons["nil"], nil
+	}
+
+	vm.CurrentGlobals["?"] = NewProcessStatus(cmd.ProcessState.Pid(), cmd.ProcessState.ExitCode(), vm)
+	if err != nil {
+		return vm.singletons["false"], nil
+	}
+
+	return vm.singletons["true"], nil
+}
@@ -3,9 +3,11 @@ package vm
 import (
 	"errors"
 	"fmt"
-	"io/ioutil"
-	"os"
+	"io"
 	"path/filepath"
+	"regexp"
+	"strings"
+	"unicode"
 
 	"github.com/grubby/grubby/ast"
 	"github.com/grubby/grubby/parser"
@@ -16,6 +18,10 @@ import (
 type vm struct {
 	currentFilename string
 
+	stdout io.Writer
+	stderr io.Writer
+	stdin  io.Reader
+
 	stack          *CallStack
 	ObjectSpace    map[string]Value
 	CurrentGlobals map[string]Value
@@ -37,6 +43,10 @@ type VM interface {
 
 	Set(string, Value)
 
+	SetStdout(io.Writer)
+	SetStderr(io.Writer)
+	SetStdin(io.Reader)
+
 	Symbols() map[string]Value
 	Globals() map[string]Value
 	Classes() map[string]Class
@@ -44,6 +54,7 @@ type VM interface {
 
 	ClassProvider
 	SingletonProvider
+	IOProvider
 }
 
 func NewVM(rubyHome, name string) VM {
@@ -64,7 +75,12 @@ func NewVM(rubyHome, name string) VM {
 
 	vm.CurrentGlobals["LOAD_PATH"] = loadPath
 	vm.CurrentGlobals[":"] = loadPath
+
+	loadedFeatures, _ := vm.CurrentClasses["Array"].New(vm, vm)
+	vm.CurrentGlobals["LOADED_FEATURES"] = loadedFeatures
+	vm.CurrentGlobals[`"`] = loadedFeatures
 	vm.ObjectSpace["ARGV"], _ = vm.CurrentClasses["Array"].New(vm, vm)
+	vm.registerStandardStreams()
 
 	main, _ := vm.CurrentClasses["Object"].New(vm, vm)
 	main.AddMethod(NewNativeMethod("to_s", vm, vm, func(self Value, block Block, args ...Value) (Value, error) {
@@ -91,6 +107,7 @@ func (vm *vm) registerBuiltinClassesAndModules() {
 	moduleClass := NewModuleClass(vm, vm)
 	vm.CurrentClasses["Module"] = moduleClass
 	vm.CurrentModules["Comparable"] = NewComparableModule(vm, vm)
+	vm.CurrentModules["Enumerable"] = NewEnumerableModule(vm, vm)
 	vm.CurrentModules["Kernel"] = NewGlobalKernelModule(vm, vm)
 	vm.CurrentModules["Process"] = NewProcessModule(vm)
 
@@ -102,35 +119,53 @@ func (vm *vm) registerBuiltinClassesAndModules() {
 			return vm.singletons["false"], nil
 		}
 
-		loadPath := vm.CurrentGlobals["LOAD_PATH"]
-		for _, pathStr := range loadPath.(*Array).Members() {
-			path := pathStr.(*StringValue)
-			fullPath := filepath.Join(path.RawString(), fileName+".rb")
-			file, err := os.Open(fullPath)
-			if err != nil {
-				continue
-			}
+		fullPath, ok := vm.resolveRequirePath(fileName)
+		if !ok {
+			return nil, NewLoadError(fileName, vm.stack.String())
+		}
 
-			contents, err := ioutil.ReadAll(file)
+		return vm.loadFeature(fileName, fullPath)
+	}))
 
-			if err == nil {
-				originalName := vm.currentFilename
-				defer func() {
-					vm.currentFilename = originalName
-				}()
-
-				vm.currentFilename = file.Name()
-				_, rubyErr := vm.Run(string(contents))
-				if rubyErr != nil {
-					return nil, rubyErr
-				}
+	// resolves the name against the directory of the file being run,
+	// rather than the load path
+	vm.CurrentModules["Kernel"].AddMethod(NewNativeMethod("require_relative", vm, vm, func(self Value, block Block, args ...Value) (Value, error) {
+		fileName := args[0].(*StringValue).RawString()
+
+		directory, err := filepath.Abs(filepath.Dir(vm.currentFilename))
+		if err != nil {
+			return nil, NewLoadError(fileName, vm.stack.String())
+		}
+
+		fullPath, ok := vm.resolveRequirePath(filepath.Join(directory, fileName))
+		if !ok {
+			return nil, NewLoadError(fileName, vm.stack.String())
+		}
+
+		return vm.loadFeature(fileName, fullPath)
+	}))
+
+	vm.CurrentModules["Kernel"].AddMethod(NewNativeMethod("system", vm, vm, vm.system))
+
+	// calls the block until it breaks or raises StopIteration, which is how
+	// an enumerator's next signals that it has run out
+	vm.CurrentModules["Kernel"].AddMethod(NewNativeMethod("loop", vm, vm, func(self Value, block Block, args ...Value) (Value, error) {
+		if block == nil {
+			return NewEnumeratorForMethod(self, "loop", vm), nil
+		}
 
-				return vm.singletons["true"], nil
+		for {
+			_, err := block.Call()
+			if _, ok := err.(*breakSignal); ok || (err != nil && strings.HasPrefix(err.Error(), "StopIteration:")) {
+				return vm.singletons["nil"], nil
+			} else if err != nil {
+				return nil, err
 			}
 		}
+	}))
 
-		errorMessage := fmt.Sprintf("LoadError: cannot load such file -- %s", fileName)
-		return nil, NewLoadError(errorMessage, vm.stack.String())
+	vm.CurrentModules["Kernel"].AddMethod(NewNativeMethod("__method__", vm, vm, func(self Value, block Block, args ...Value) (Value, error) {
+		return vm.currentMethodName(), nil
 	}))
 
 	/* BEGIN RUNTIME TRICKERY
@@ -145,7 +180,7 @@ func (vm *vm) registerBuiltinClassesAndModules() {
 	basicObjectClass.(*BasicObjectClass).SetSuperClass()
 	// END RUNTIME TRICKERY
 
-	vm.CurrentClasses["IO"] = NewIOClass(vm)
+	vm.CurrentClasses["IO"] = NewIOClass(vm, vm)
 	vm.CurrentClasses["Array"] = NewArrayClass(vm, vm)
 	vm.CurrentClasses["Hash"] = NewHashClass(vm, vm)
 	vm.CurrentClasses["TrueClass"] = NewTrueClass(vm)
@@ -153,11 +188,53 @@ func (vm *vm) registerBuiltinClassesAndModules() {
 	vm.CurrentClasses["FalseClass"] = NewFalseClass(vm)
 	vm.CurrentClasses["NilClass"] = NewNilClass(vm)
 	vm.CurrentClasses["String"] = NewStringClass(vm, vm)
-	vm.CurrentClasses["Numeric"] = NewNumericClass(vm)
-	vm.CurrentClasses["Integer"] = NewIntegerClass(vm)
+	vm.CurrentClasses["Numeric"] = NewNumericClass(vm, vm)
+	vm.CurrentClasses["Integer"] = NewIntegerClass(vm, vm)
 	vm.CurrentClasses["Fixnum"] = NewFixnumClass(vm, vm)
-	vm.CurrentClasses["Float"] = NewFloatClass(vm)
-	vm.CurrentClasses["Symbol"] = NewSymbolClass(vm)
+	vm.CurrentClasses["Float"] = NewFloatClass(vm, vm)
+	vm.CurrentClasses["Rational"] = NewRationalClass(vm, vm)
+	vm.CurrentClasses["Symbol"] = NewSymbolClass(vm, vm)
+	vm.CurrentClasses["Proc"] = NewProcClass(vm, vm)
+	vm.CurrentClasses["Regexp"] = NewRegexpClass(vm, vm)
+	vm.CurrentClasses["MatchData"] = NewMatchDataClass(vm, vm)
+	vm.CurrentClasses["Enumerator"] = NewEnumeratorClass(vm, vm)
+	vm.CurrentClasses["Process::Status"] = NewProcessStatusClass(vm, vm)
+	vm.CurrentClasses["Random"] = NewRandomClass(vm, vm)
+	vm.CurrentClasses["Range"] = NewRangeClass(vm, vm)
+	vm.CurrentClasses["Encoding"] = NewEncodingClass(vm, vm)
+
+	vm.CurrentClasses["Numeric"].Include(vm.CurrentModules["Comparable"])
+	vm.CurrentClasses["Array"].Include(vm.CurrentModules["Enumerable"])
+	vm.CurrentClasses["Enumerator"].Include(vm.CurrentModules["Enumerable"])
+	vm.CurrentClasses["Hash"].Include(vm.CurrentModules["Enumerable"])
+	vm.CurrentClasses["Range"].Include(vm.CurrentModules["Enumerable"])
+
+	for _, exception := range []struct{ name, superClass string }{
+		{"Exception", "Object"},
+		{"SystemExit", "Exception"},
+		{"ScriptError", "Exception"},
+		{"LoadError", "ScriptError"},
+		{"NotImplementedError", "ScriptError"},
+		{"StandardError", "Exception"},
+		{"ArgumentError", "StandardError"},
+		{"Math::DomainError", "ArgumentError"},
+		{"IndexError", "StandardError"},
+		{"IOError", "StandardError"},
+		{"KeyError", "IndexError"},
+		{"StopIteration", "IndexError"},
+		{"LocalJumpError", "StandardError"},
+		{"NameError", "StandardError"},
+		{"NoMethodError", "NameError"},
+		{"RangeError", "StandardError"},
+		{"RegexpError", "StandardError"},
+		{"RuntimeError", "StandardError"},
+		{"FrozenError", "RuntimeError"},
+		{"TypeError", "StandardError"},
+		{"ZeroDivisionError", "StandardError"},
+	} {
+		superClass := vm.CurrentClasses[exception.superClass]
+		vm.CurrentClasses[exception.name] = NewExceptionClass(exception.name, superClass, vm)
+	}
 
 	vm.singletons["nil"], _ = vm.CurrentClasses["NilClass"].New(vm, vm)
 	vm.singletons["true"], _ = vm.CurrentClasses["TrueClass"].New(vm, vm)
@@ -251,7 +328,7 @@ func (err *ParseError) Error() string {
 
 func (vm *vm) Run(input string) (Value, error) {
 	parser.Statements = []ast.Node{}
-	lexer := parser.NewLexer(input)
+	lexer := parser.NewPositionedLexer(input)
 	result := parser.RubyParse(lexer)
 	if result != 0 {
 		return nil, NewParseError(vm.currentFilename)
@@ -261,7 +338,8 @@ func (vm *vm) Run(input string) (Value, error) {
 	vm.stack.Unshift("main", vm.currentFilename)
 	defer vm.stack.Shift()
 
-	vm.localVariableStack.unshift()
+	// top level locals live in the object space, where Get can find them
+	vm.localVariableStack.unshiftFrame(vm.ObjectSpace)
 	defer vm.localVariableStack.shift()
 	return vm.executeWithContext(main, parser.Statements...)
 }
@@ -274,22 +352,22 @@ func (vm *vm) executeWithContext(context Value, statements ...ast.Node) (Value,
 	for _, statement := range statements {
 		switch statement.(type) {
 		case ast.IfBlock:
-			truthy := false
 			ifBlock := statement.(ast.IfBlock)
-			switch ifBlock.Condition.(type) {
-			case ast.Boolean:
-				truthy = ifBlock.Condition.(ast.Boolean).Value
-			case ast.BareReference:
-				truthy = ifBlock.Condition.(ast.BareReference).Name == "nil"
-			default:
-				truthy = true
+			condition, err := vm.executeWithContext(context, ifBlock.Condition)
+			if err != nil {
+				return nil, err
 			}
 
-			if truthy {
+			if condition.IsTruthy() {
 				returnValue, returnErr = vm.executeWithContext(context, ifBlock.Body...)
 			} else {
 				returnValue, returnErr = vm.executeWithContext(context, ifBlock.Else...)
 			}
+
+			// an empty branch, like the missing else of a modifier if, is nil
+			if returnValue == nil && returnErr == nil {
+				returnValue = vm.singletons["nil"]
+			}
 		case ast.Alias:
 			// FIXME: assumes that the context will be a module, but could also be a class
 			aliasNode := statement.(ast.Alias)
@@ -319,7 +397,16 @@ func (vm *vm) executeWithContext(context Value, statements ...ast.Node) (Value,
 
 		case ast.ClassDecl:
 			classNode := statement.(ast.ClassDecl)
-			theClass := NewUserDefinedClass(classNode.Name, vm, vm)
+			superClass := vm.CurrentClasses["Object"]
+			if classNode.SuperClass.Name != "" {
+				var ok bool
+				superClass, ok = vm.CurrentClasses[classNode.SuperClass.FullName()]
+				if !ok {
+					return nil, NewNameError(classNode.SuperClass.FullName(), context.String(), context.Class().String(), vm.stack.String())
+				}
+			}
+
+			theClass := NewUserDefinedClass(classNode.Name, superClass, vm, vm)
 			vm.CurrentClasses[classNode.FullName()] = theClass
 
 			_, err := vm.executeWithContext(theClass, classNode.Body...)
@@ -338,14 +425,14 @@ func (vm *vm) executeWithContext(context Value, statements ...ast.Node) (Value,
 				vm,
 				vm,
 				func(self Value, method *RubyMethod) (Value, error) {
-					vm.localVariableStack.unshift()
+					vm.localVariableStack.unshift(method.Name(), method.Block())
 					defer vm.localVariableStack.shift()
 
 					for _, arg := range method.Args() {
 						vm.localVariableStack.store(arg.Name, arg.Value)
 					}
 
-					return vm.executeWithContext(self, method.Body()...)
+					return methodResult(vm.executeWithContext(self, method.Body()...))
 				})
 			returnValue = method
 
@@ -367,6 +454,8 @@ func (vm *vm) executeWithContext(context Value, statements ...ast.Node) (Value,
 				}
 			}
 
+		case ast.Self:
+			returnValue = context
 		case ast.Nil:
 			returnValue = vm.singletons["nil"]
 		case ast.SimpleString:
@@ -380,28 +469,75 @@ func (vm *vm) executeWithContext(context Value, statements ...ast.Node) (Value,
 				returnValue = vm.singletons["false"]
 			}
 		case ast.GlobalVariable:
-			returnValue = vm.CurrentGlobals[statement.(ast.GlobalVariable).Name]
+			var ok bool
+			returnValue, ok = vm.CurrentGlobals[statement.(ast.GlobalVariable).Name]
+			if !ok {
+				returnValue = vm.SingletonWithName("nil")
+			}
+		case ast.InstanceVariable:
+			returnValue = context.GetInstanceVariable(statement.(ast.InstanceVariable).Name)
+			if returnValue == nil {
+				returnValue = vm.singletons["nil"]
+			}
 		case ast.ConstantInt:
 			returnValue = NewFixnum(statement.(ast.ConstantInt).Value, vm, vm)
 		case ast.ConstantFloat:
 			returnValue = NewFloat(statement.(ast.ConstantFloat).Value, vm)
-		case ast.Symbol:
-			name := statement.(ast.Symbol).Name
-			maybe, ok := vm.CurrentSymbols[name]
-			if !ok {
-				returnValue = NewSymbol(name, vm)
-				vm.CurrentSymbols[name] = returnValue
-			} else {
-				returnValue = maybe
+		case ast.Negative:
+			switch target := statement.(ast.Negative).Target.(type) {
+			case ast.ConstantInt:
+				returnValue = NewFixnum(-target.Value, vm, vm)
+			case ast.ConstantFloat:
+				returnValue = NewFloat(-target.Value, vm)
+			default:
+				value, err := vm.executeWithContext(context, target)
+				if err != nil {
+					returnErr = err
+					break
+				}
+
+				method, err := value.Method("-@")
+				if err != nil {
+					returnErr = err
+					break
+				}
+
+				returnValue, returnErr = method.Execute(value, nil)
 			}
+		case ast.Positive:
+			switch target := statement.(ast.Positive).Target.(type) {
+			case ast.ConstantInt:
+				returnValue = NewFixnum(target.Value, vm, vm)
+			case ast.ConstantFloat:
+				returnValue = NewFloat(target.Value, vm)
+			default:
+				value, err := vm.executeWithContext(context, target)
+				if err != nil {
+					returnErr = err
+					break
+				}
+
+				method, err := value.Method("+@")
+				if err != nil {
+					returnErr = err
+					break
+				}
+
+				returnValue, returnErr = method.Execute(value, nil)
+			}
+		case ast.Regex:
+			returnValue, returnErr = NewRegexp(statement.(ast.Regex).Value, vm)
+		case ast.Symbol:
+			returnValue = vm.internSymbol(statement.(ast.Symbol).Name)
 		case ast.BareReference:
 			name := statement.(ast.BareReference).Name
 			maybe, err := vm.localVariableStack.retrieve(name)
 			if err == nil {
 				returnValue = maybe
 			} else {
+				// constants such as ARGV are visible everywhere, unlike top level locals
 				maybe, ok := vm.ObjectSpace[name]
-				if ok {
+				if ok && unicode.IsUpper([]rune(name)[0]) {
 					returnValue = maybe
 				} else {
 					maybe, ok := vm.CurrentClasses[name]
@@ -411,6 +547,15 @@ func (vm *vm) executeWithContext(context Value, statements ...ast.Node) (Value,
 						maybe, ok := vm.CurrentModules[name]
 						if ok {
 							returnValue = maybe
+						} else if method, ok := vm.methodForBareReference(context, name); ok {
+							// without a local of the same name, a bare identifier calls a method on self
+							vm.stack.Unshift(method.Name(), vm.currentFilename)
+							returnValue, returnErr = method.Execute(context, nil)
+							vm.stack.Shift()
+						} else if missing, ok := vm.methodMissing(context); ok {
+							vm.stack.Unshift(missing.Name(), vm.currentFilename)
+							returnValue, returnErr = missing.Execute(context, nil, vm.internSymbol(name))
+							vm.stack.Shift()
 						} else {
 							returnValue = nil
 							returnErr = NewNameError(name, context.String(), context.Class().String(), vm.stack.String())
@@ -418,53 +563,81 @@ func (vm *vm) executeWithContext(context Value, statements ...ast.Node) (Value,
 					}
 				}
 			}
+		case ast.WeakLogicalAnd:
+			logical := statement.(ast.WeakLogicalAnd)
+			returnValue, returnErr = vm.shortCircuit(context, logical.LHS, logical.RHS, true)
+		case ast.WeakLogicalOr:
+			logical := statement.(ast.WeakLogicalOr)
+			returnValue, returnErr = vm.shortCircuit(context, logical.LHS, logical.RHS, false)
 		case ast.CallExpression:
 			var method Method
 			callExpr := statement.(ast.CallExpression)
 
-			var (
-				target           Value
-				usePrivateMethod bool // FIXME: this should be unnecessary now
-			)
+			// the parser represents && and || as method calls, but they must
+			// not evaluate their right hand side unless it is needed
+			if callExpr.Target != nil && len(callExpr.Args) == 1 && (callExpr.Func.Name == "&&" || callExpr.Func.Name == "||") {
+				returnValue, returnErr = vm.shortCircuit(context, callExpr.Target, callExpr.Args[0], callExpr.Func.Name == "&&")
+				if returnErr != nil {
+					return nil, returnErr
+				}
+				break
+			}
 
+			var target Value
 			if callExpr.Target != nil {
 				target, returnErr = vm.executeWithContext(context, callExpr.Target)
 				if returnErr != nil {
 					return nil, returnErr
 				}
 			} else {
-				usePrivateMethod = true
 				target = context
 			}
 
+			if callExpr.SafeNavigation && (target == nil || target == vm.singletons["nil"]) {
+				returnValue = vm.singletons["nil"]
+				break
+			}
+
 			if target == nil {
 				nilValue := vm.singletons["nil"]
 				return nil, NewNoMethodError(callExpr.Func.Name, nilValue.String(), nilValue.Class().String(), vm.stack.String())
 			}
 
-			method, err := target.Method(callExpr.Func.Name)
-			if err != nil && usePrivateMethod {
-				method, err = target.PrivateMethod(callExpr.Func.Name)
-			}
-
+			// private methods may only be called without an explicit receiver,
+			// although self.foo is allowed too
+			_, targetIsSelf := callExpr.Target.(ast.Self)
+			method, err := LookupMethod(target, callExpr.Func.Name, callExpr.Target == nil || targetIsSelf)
+			var missingName Value
 			if err != nil {
-				return nil, err
+				missing, ok := vm.methodMissing(target)
+				if !ok {
+					return nil, err
+				}
+
+				method, missingName = missing, vm.internSymbol(callExpr.Func.Name)
 			}
 
+			var block Block
 			args := []Value{}
-			for _, astArgument := range callExpr.Args {
+			for index, astArgument := range callExpr.Args {
 				arg, err := vm.executeWithContext(context, astArgument)
 				if err != nil {
 					return nil, err
 				}
 
+				if _, isProcArg := astArgument.(ast.ProcArg); isProcArg && index == len(callExpr.Args)-1 {
+					if procArg, ok := arg.(Block); ok {
+						block = procArg
+						continue
+					}
+				}
+
 				args = append(args, arg)
 			}
 
 			vm.stack.Unshift(method.Name(), vm.currentFilename)
 			defer vm.stack.Shift()
 
-			var block Block
 			if callExpr.OptionalBlock.Provided() {
 				blockValue, err := vm.executeWithContext(context, callExpr.OptionalBlock)
 
@@ -475,6 +648,11 @@ func (vm *vm) executeWithContext(context Value, statements ...ast.Node) (Value,
 				block = blockValue.(Block)
 			}
 
+			// method_missing is given the name it was called in place of first
+			if missingName != nil {
+				args = append([]Value{missingName}, args...)
+			}
+
 			returnValue, returnErr = method.Execute(target, block, args...)
 			if returnErr != nil {
 				return returnValue, returnErr
@@ -482,7 +660,15 @@ func (vm *vm) executeWithContext(context Value, statements ...ast.Node) (Value,
 
 		case ast.Block:
 			astBlock := statement.(ast.Block)
-			block := NewBlock(context, astBlock.Args, astBlock.Body, vm)
+			args := astBlock.Args
+			if len(args) == 0 && astBlock.ImplicitArgCount > 0 {
+				// numbered params are bound like any other block param
+				for i := 1; i <= astBlock.ImplicitArgCount; i++ {
+					args = append(args, ast.BareReference{Name: fmt.Sprintf("_%d", i)})
+				}
+			}
+
+			block := NewBlock(context, args, astBlock.Body, vm.closure())
 			returnValue = block.(Value)
 
 		case ast.Assignment:
@@ -493,66 +679,82 @@ func (vm *vm) executeWithContext(context Value, statements ...ast.Node) (Value,
 				return nil, err
 			}
 
-			switch assignment.LHS.(type) {
-			case ast.BareReference:
-				ref := assignment.LHS.(ast.BareReference)
-				vm.ObjectSpace[ref.Name] = returnValue
-			case ast.GlobalVariable:
-				globalVar := assignment.LHS.(ast.GlobalVariable)
-				vm.CurrentGlobals[globalVar.Name] = returnValue
-			case ast.InstanceVariable:
-				iVar := assignment.LHS.(ast.InstanceVariable)
-				context.SetInstanceVariable(iVar.Name, returnValue)
-			default:
-				panic(fmt.Sprintf("unimplemented assignment failure: %#v", assignment.LHS))
-			}
+			vm.assign(context, assignment.LHS, returnValue)
 
 		case ast.FileNameConstReference:
 			returnValue = NewString(vm.currentFilename, vm, vm)
+		case ast.DirNameConstReference:
+			dir, err := filepath.Abs(filepath.Dir(vm.currentFilename))
+			if err != nil {
+				return nil, err
+			}
+			returnValue = NewString(dir, vm, vm)
+		case ast.MethodNameConstReference:
+			returnValue = vm.currentMethodName()
 		case ast.Begin:
+			// a begin block evaluates to its body, the rescue that handled
+			// an error from its body, or its else when nothing was raised
 			begin := statement.(ast.Begin)
-			_, err := vm.executeWithContext(context, begin.Body...)
+			value, err := vm.executeWithContext(context, begin.Body...)
 
 			if err != nil {
-				matchingRescue := false
-
-				rubyErr, ok := err.(Value)
-				if !ok {
-					panic(context)
-					return nil, err
-				}
-
 				for _, rescue := range begin.Rescue {
-					if matchingRescue {
+					r := rescue.(ast.Rescue)
+					matches, rescueErr := vm.rescueMatches(context, r.Exception, err)
+					if rescueErr != nil {
+						err = rescueErr
 						break
 					}
 
-					r := rescue.(ast.Rescue)
-					for _, exceptionClass := range r.Exception.Classes {
-						if exceptionClass.Name == rubyErr.String() {
-							_, err = vm.executeWithContext(context, r.Body...)
-							if err == nil {
-								matchingRescue = true
-								break
-							}
-						}
+					if matches {
+						value, err = vm.executeWithContext(context, r.Body...)
+						break
 					}
 				}
+			} else if begin.Else != nil {
+				value, err = vm.executeWithContext(context, begin.Else...)
+			}
+
+			if begin.Ensure != nil {
+				if _, ensureErr := vm.executeWithContext(context, begin.Ensure...); ensureErr != nil {
+					err = ensureErr
+				}
 			}
 
 			if err != nil {
 				returnErr = err
+			} else if value == nil {
+				returnValue = vm.singletons["nil"]
+			} else {
+				returnValue = value
 			}
 		case ast.Array:
 			arrayValue, _ := vm.CurrentClasses["Array"].New(vm, vm)
 			array := arrayValue.(*Array)
 			for _, node := range statement.(ast.Array).Nodes {
+				splat, isSplat := node.(ast.StarSplat)
+				if isSplat {
+					node = splat.Value
+				}
+
 				value, err := vm.executeWithContext(context, node)
 				if err != nil {
 					return nil, err
 				}
 
-				array.Append(value)
+				if !isSplat {
+					array.Append(value)
+					continue
+				}
+
+				members, err := vm.splatMembers(value)
+				if err != nil {
+					return nil, err
+				}
+
+				for _, member := range members {
+					array.Append(member)
+				}
 			}
 
 			returnValue = array
@@ -577,6 +779,10 @@ func (vm *vm) executeWithContext(context Value, statements ...ast.Node) (Value,
 			}
 
 			returnValue = hash
+		case ast.ProcArg:
+			returnValue, returnErr = vm.executeWithContext(context, statement.(ast.ProcArg).Value)
+		case ast.Group:
+			returnValue, returnErr = vm.executeWithContext(context, statement.(ast.Group).Body...)
 		case ast.Ternary:
 			ternary := statement.(ast.Ternary)
 			value, err := vm.executeWithContext(context, ternary.Condition)
@@ -591,24 +797,273 @@ func (vm *vm) executeWithContext(context Value, statements ...ast.Node) (Value,
 
 			}
 
+		case ast.Range:
+			rangeNode := statement.(ast.Range)
+			start, end := vm.singletons["nil"], vm.singletons["nil"]
+
+			// beginless and endless ranges leave out one of their endpoints
+			var err error
+			if rangeNode.Start != nil {
+				start, err = vm.executeWithContext(context, rangeNode.Start)
+				if err != nil {
+					return nil, err
+				}
+			}
+
+			if rangeNode.End != nil {
+				end, err = vm.executeWithContext(context, rangeNode.End)
+				if err != nil {
+					return nil, err
+				}
+			}
+
+			returnValue, returnErr = NewRange(start, end, rangeNode.Exclusive, vm)
+
+		case ast.SwitchStatement:
+			switchNode := statement.(ast.SwitchStatement)
+			subject, err := vm.executeWithContext(context, switchNode.Condition)
+			if err != nil {
+				return nil, err
+			}
+
+			body, err := vm.matchingSwitchCase(context, subject, switchNode)
+			if err != nil {
+				return nil, err
+			}
+
+			returnValue, returnErr = vm.executeWithContext(context, body...)
+			if returnValue == nil && returnErr == nil {
+				returnValue = vm.singletons["nil"]
+			}
+
+		case ast.Yield:
+			block, ok := vm.localVariableStack.currentBlock()
+			if !ok {
+				return nil, NewLocalJumpError("no block given (yield)", vm.stack.String())
+			}
+
+			args := []Value{}
+			yielded := statement.(ast.Yield).Value
+			if nodes, ok := yielded.(ast.Nodes); ok {
+				for _, node := range nodes {
+					arg, err := vm.executeWithContext(context, node)
+					if err != nil {
+						return nil, err
+					}
+
+					args = append(args, arg)
+				}
+			} else if yielded != nil {
+				arg, err := vm.executeWithContext(context, yielded)
+				if err != nil {
+					return nil, err
+				}
+
+				args = append(args, arg)
+			}
+
+			returnValue, returnErr = block.Call(args...)
+
+		case ast.Negation:
+			value, err := vm.executeWithContext(context, statement.(ast.Negation).Target)
+			if err != nil {
+				return nil, err
+			}
+
+			if value.IsTruthy() {
+				returnValue = vm.singletons["false"]
+			} else {
+				returnValue = vm.singletons["true"]
+			}
+		case ast.Loop:
+			returnValue, returnErr = vm.runLoop(context, statement.(ast.Loop))
+		case ast.Break:
+			returnErr = &breakSignal{}
+		case ast.Next:
+			returnErr = &nextSignal{}
+		case ast.Return:
+			value := Value(vm.singletons["nil"])
+			if ret := statement.(ast.Return); ret.Value != nil {
+				var err error
+				value, err = vm.executeWithContext(context, ret.Value)
+				if err != nil {
+					return nil, err
+				}
+			}
+
+			returnErr = &returnSignal{value: value}
+
 		case ast.Class:
 			class := statement.(ast.Class)
 			className := class.FullName()
 			value, ok := vm.CurrentClasses[className]
-			if !ok {
-				returnErr = NewNameError(className, context.String(), context.Class().String(), vm.stack.String())
-			} else {
+			if ok {
 				returnValue = value
+			} else if constant, ok := vm.namespacedConstant(class); ok {
+				returnValue = constant
+			} else {
+				returnErr = NewNameError(className, context.String(), context.Class().String(), vm.stack.String())
 			}
 
 		default:
 			panic(fmt.Sprintf("handled unknown statement type: %T:\n\t\n => %#v\n", statement, statement))
 		}
+
+		if returnErr != nil {
+			return nil, returnErr
+		}
 	}
 
 	return returnValue, returnErr
 }
 
+// looks up a constant such as Float::INFINITY on the class or module it is namespaced within
+func (vm *vm) namespacedConstant(class ast.Class) (Value, bool) {
+	var namespace Module
+	if namespaceClass, ok := vm.CurrentClasses[class.Namespace]; ok {
+		namespace = namespaceClass
+	} else if namespaceModule, ok := vm.CurrentModules[class.Namespace]; ok {
+		namespace = namespaceModule
+	} else {
+		return nil, false
+	}
+
+	return namespace.Constant(class.Name)
+}
+
+// finds the body of the first `when` whose condition is case-equal (===)
+// to the subject, falling back to the `else` body
+func (vm *vm) matchingSwitchCase(context, subject Value, switchNode ast.SwitchStatement) ([]ast.Node, error) {
+	for _, switchCase := range switchNode.Cases {
+		for _, condition := range switchCase.Conditions {
+			pattern, err := vm.executeWithContext(context, condition)
+			if err != nil {
+				return nil, err
+			}
+
+			caseEqual, err := pattern.Method("===")
+			if err != nil {
+				return nil, err
+			}
+
+			matched, err := caseEqual.Execute(pattern, nil, subject)
+			if err != nil {
+				return nil, err
+			}
+
+			if matched.IsTruthy() {
+				return switchCase.Body, nil
+			}
+		}
+	}
+
+	return switchNode.Else, nil
+}
+
+// the name of the method being run as a symbol, or nil at the top level
+func (vm *vm) currentMethodName() Value {
+	method, ok := vm.localVariableStack.currentMethod()
+	if !ok {
+		return vm.singletons["nil"]
+	}
+
+	return vm.internSymbol(method)
+}
+
+func (vm *vm) methodForBareReference(context Value, name string) (Method, bool) {
+	method, err := LookupMethod(context, name, true)
+	return method, err == nil
+}
+
+// finds the method_missing that the receiver's class or one of its
+// ancestors defines, which is called in place of a method that can't be found
+func (vm *vm) methodMissing(receiver Value) (Method, bool) {
+	method, err := LookupMethod(receiver, "method_missing", true)
+	return method, err == nil
+}
+
+// evaluates the right hand side of a logical and (or) only when
+// the left hand side is truthy (falsey), returning the deciding value
+func (vm *vm) shortCircuit(context Value, lhs, rhs ast.Node, isAnd bool) (Value, error) {
+	left, err := vm.executeWithContext(context, lhs)
+	if err != nil {
+		return nil, err
+	}
+
+	if left == nil {
+		left = vm.singletons["nil"]
+	}
+
+	if left.IsTruthy() != isAnd {
+		return left, nil
+	}
+
+	return vm.executeWithContext(context, rhs)
+}
+
+// errors raised by the VM are described as "ClassName: message"
+var exceptionClassNamePrefix = regexp.MustCompile(`^([A-Z][A-Za-z0-9_]*(?:::[A-Z][A-Za-z0-9_]*)*):`)
+
+// reports whether a rescue clause handles the error, either because one of
+// the rescued classes is an ancestor of the error's class, or because the
+// clause rescues nothing in particular and the error is a StandardError
+func (vm *vm) rescueMatches(context Value, exception ast.RescueException, err error) (bool, error) {
+	match := exceptionClassNamePrefix.FindStringSubmatch(err.Error())
+	if match == nil {
+		return false, nil
+	}
+	errorClassName := match[1]
+
+	rescuedClasses := []Value{}
+	for _, class := range exception.Classes {
+		if value, ok := vm.CurrentClasses[class.FullName()]; ok {
+			rescuedClasses = append(rescuedClasses, value)
+		} else if class.FullName() == errorClassName {
+			return true, nil
+		}
+	}
+
+	if exception.Splat != nil {
+		splatted, err := vm.executeWithContext(context, exception.Splat)
+		if err != nil {
+			return false, err
+		}
+
+		if array, ok := splatted.(*Array); ok {
+			rescuedClasses = append(rescuedClasses, array.Members()...)
+		} else {
+			rescuedClasses = append(rescuedClasses, splatted)
+		}
+	}
+
+	if len(exception.Classes) == 0 && exception.Splat == nil {
+		rescuedClasses = append(rescuedClasses, vm.CurrentClasses["StandardError"])
+	}
+
+	for _, rescued := range rescuedClasses {
+		rescuedClass, ok := rescued.(Class)
+		if !ok {
+			return false, errors.New("TypeError: class or module required for rescue clause")
+		}
+
+		errorClass, ok := vm.CurrentClasses[errorClassName]
+		if !ok {
+			if rescuedClass.Name() == errorClassName {
+				return true, nil
+			}
+			continue
+		}
+
+		for ; errorClass != nil; errorClass = errorClass.SuperClass() {
+			if errorClass == rescuedClass {
+				return true, nil
+			}
+		}
+	}
+
+	return false, nil
+}
+
 // ClassProvider
 func (vm *vm) ClassWithName(name string) Class {
 	return vm.CurrentClasses[name]
@@ -624,14 +1079,36 @@ func (vm *vm) EvaluateBlockWithArgsInContext(
 	context Value,
 	args []BlockArg,
 	statements []ast.Node) (Value, error) {
-	vm.localVariableStack.unshift()
-	defer vm.localVariableStack.shift()
+	return vm.closure().EvaluateBlockWithArgsInContext(context, args, statements)
+}
 
-	for _, arg := range args {
-		vm.localVariableStack.store(arg.Name, arg.Value)
-	}
+// a closure evaluates a block's body within the scopes that were
+// visible where the block was written
+type closure struct {
+	vm     *vm
+	scopes []scope
+}
+
+func (vm *vm) closure() closure {
+	return closure{vm: vm, scopes: vm.localVariableStack.capture()}
+}
+
+func (c closure) EvaluateBlockWithArgsInContext(
+	context Value,
+	args []BlockArg,
+	statements []ast.Node) (Value, error) {
+	return c.vm.localVariableStack.withinBlock(c.scopes, func() (Value, error) {
+		for _, arg := range args {
+			if arg.Value == nil {
+				arg.Value = c.vm.singletons["nil"]
+			}
+
+			c.vm.localVariableStack.store(arg.Name, arg.Value)
+		}
 
-	return vm.executeWithContext(context, statements...)
+		value, err := c.vm.executeWithContext(context, statements...)
+		return blockResult(value, err, c.vm.singletons["nil"])
+	})
 }
 
 // SingletonProvider
@@ -647,7 +1124,103 @@ func (vm *vm) SymbolWithName(name string) Value {
 	return vm.CurrentSymbols[name]
 }
 
+// returns the one symbol with the given name, creating it if need be
+func (vm *vm) internSymbol(name string) Value {
+	symbol, ok := vm.CurrentSymbols[name]
+	if !ok {
+		symbol = NewSymbol(name, vm)
+		vm.CurrentSymbols[name] = symbol
+	}
+
+	return symbol
+}
+
 func (vm *vm) AddSymbol(val Value) {
 	symbol := val.(*SymbolValue)
 	vm.CurrentSymbols[symbol.Name()] = symbol
 }
+
+func (vm *vm) assign(context Value, lhs ast.Node, value Value) {
+	switch lhs.(type) {
+	case ast.BareReference:
+		ref := lhs.(ast.BareReference)
+		vm.localVariableStack.assign(ref.Name, value)
+	case ast.GlobalVariable:
+		globalVar := lhs.(ast.GlobalVariable)
+		vm.CurrentGlobals[globalVar.Name] = value
+	case ast.InstanceVariable:
+		iVar := lhs.(ast.InstanceVariable)
+		context.SetInstanceVariable(iVar.Name, value)
+	case ast.Array:
+		vm.destructure(context, lhs.(ast.Array).Nodes, value)
+	default:
+		panic(fmt.Sprintf("unimplemented assignment failure: %#v", lhs))
+	}
+}
+
+// splatMembers is what a splatted value spreads into: the members of an
+// array, nothing for nil, whatever to_a returns for values such as ranges,
+// and otherwise just the value itself
+func (vm *vm) splatMembers(value Value) ([]Value, error) {
+	if array, ok := value.(*Array); ok {
+		return array.Members(), nil
+	}
+
+	if value == vm.singletons["nil"] {
+		return nil, nil
+	}
+
+	if toA, err := value.Method("to_a"); err == nil {
+		converted, err := toA.Execute(value, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		if array, ok := converted.(*Array); ok {
+			return array.Members(), nil
+		}
+	}
+
+	return []Value{value}, nil
+}
+
+// destructure spreads the members of an array across several targets, as in
+// `a, *b = 1, 2, 3`. A value that is not an array is assigned to the first
+// target and every other target is assigned nil.
+func (vm *vm) destructure(context Value, targets []ast.Node, value Value) {
+	var members []Value
+	if array, ok := value.(*Array); ok {
+		members = array.Members()
+	} else {
+		members = []Value{value}
+	}
+
+	nilValue := vm.SingletonWithName("nil")
+	for index, target := range targets {
+		if splat, ok := target.(ast.StarSplat); ok {
+			remaining := len(targets) - index - 1
+			arrayValue, _ := vm.CurrentClasses["Array"].New(vm, vm)
+			rest := arrayValue.(*Array)
+			for i := index; i < len(members)-remaining; i++ {
+				rest.Append(members[i])
+			}
+			vm.assign(context, splat.Value, rest)
+
+			for offset, trailing := range targets[index+1:] {
+				memberIndex := len(members) - remaining + offset
+				if memberIndex >= index && memberIndex < len(members) {
+					vm.assign(context, trailing, members[memberIndex])
+				} else {
+					vm.assign(context, trailing, nilValue)
+				}
+			}
+			return
+		}
+
+		if index < len(members) {
+			vm.assign(context, target, members[index])
+		} else {
+			vm.assign(context, target, nilValue)
+		}
+	}
+}
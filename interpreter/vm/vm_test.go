@@ -1,10 +1,13 @@
 package vm_test
 
 import (
+	"bytes"
 	"fmt"
+	"io/ioutil"
 	"os"
 	"path/filepath"
 	"reflect"
+	"strings"
 
 	. "github.com/grubby/grubby/interpreter/vm"
 	. "github.com/grubby/grubby/interpreter/vm/builtins"
@@ -213,6 +216,111 @@ end`)
 		})
 	})
 
+	Describe("standard streams", func() {
+		It("writes puts, print and $stdout to the stdout it was given", func() {
+			stdout := &bytes.Buffer{}
+			vm.SetStdout(stdout)
+
+			_, err := vm.Run(`
+puts 'conga', 'oestradiol'
+print 'a', 'b'
+$stdout.puts 'c'
+`)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(stdout.String()).To(Equal("conga\noestradiol\nabc\n"))
+		})
+
+		It("returns nil from puts", func() {
+			vm.SetStdout(&bytes.Buffer{})
+
+			value, err := vm.Run("[1, 2].map { |v| puts v }.inspect")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(value).To(EqualRubyString("[nil, nil]"))
+		})
+
+		It("writes warnings to the stderr it was given", func() {
+			stderr := &bytes.Buffer{}
+			vm.SetStderr(stderr)
+
+			_, err := vm.Run("warn 'careful'")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(stderr.String()).To(Equal("careful\n"))
+		})
+
+		It("reads lines with gets from the stdin it was given", func() {
+			vm.SetStdin(strings.NewReader("first\nsecond\n"))
+
+			value, err := vm.Run("[gets, $stdin.gets, gets]")
+			Expect(err).ToNot(HaveOccurred())
+
+			lines := value.(*Array).Members()
+			Expect(lines[0]).To(EqualRubyString("first\n"))
+			Expect(lines[1]).To(EqualRubyString("second\n"))
+			Expect(lines[2]).To(Equal(vm.SingletonWithName("nil")))
+		})
+	})
+
+	Describe("Kernel#Array", func() {
+		It("converts objects that define to_ary", func() {
+			value, err := vm.Run(`
+class Pair
+  def to_ary
+    [1, 2]
+  end
+end
+
+Array(Pair.new)
+`)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(value.(*Array).Members()).To(Equal([]Value{NewFixnum(1, vm, vm), NewFixnum(2, vm, vm)}))
+		})
+
+		It("wraps objects that cannot be converted, and converts nil to []", func() {
+			value, err := vm.Run("[Array(1), Array(nil)]")
+			Expect(err).ToNot(HaveOccurred())
+
+			results := value.(*Array).Members()
+			Expect(results[0].(*Array).Members()).To(Equal([]Value{NewFixnum(1, vm, vm)}))
+			Expect(results[1].(*Array).Members()).To(BeEmpty())
+		})
+	})
+
+	Describe("Kernel#Hash", func() {
+		It("converts nil and [] to {}, and other objects with to_hash", func() {
+			value, err := vm.Run(`
+class Options
+  def to_hash
+    {verbose: true}
+  end
+end
+
+[Hash(nil), Hash([]), Hash(Options.new)]
+`)
+			Expect(err).ToNot(HaveOccurred())
+
+			results := value.(*Array).Members()
+			Expect(results[0].String()).To(Equal("{}"))
+			Expect(results[1].String()).To(Equal("{}"))
+			Expect(results[2].String()).To(Equal("{:verbose => true}"))
+		})
+	})
+
+	Describe("Kernel#String", func() {
+		It("converts objects with to_str or to_s", func() {
+			value, err := vm.Run(`
+class Name
+  def to_s
+    "grubby"
+  end
+end
+
+String(Name.new)
+`)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(value).To(EqualRubyString("grubby"))
+		})
+	})
+
 	Describe("Kernel#require", func() {
 		It("searches for a file with the given name", func() {
 			_, err := vm.Run("require 'something'")
@@ -237,6 +345,118 @@ end`)
 				Expect(method.Name()).To(Equal("foo"))
 			})
 		})
+
+		Context("when the same file is required through different paths", func() {
+			var directory string
+
+			BeforeEach(func() {
+				directory = SetupLoadPathWithAFileThatCountsLoads(vm)
+			})
+
+			It("only loads the file once", func() {
+				_, err := vm.Run(fmt.Sprintf(`
+$loads = []
+require 'counter'
+require 'counter.rb'
+require '%s/nested/../counter'
+`, directory))
+				Expect(err).ToNot(HaveOccurred())
+
+				loads, err := vm.Get("loads")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(len(loads.(*Array).Members())).To(Equal(1))
+			})
+
+			It("records the absolute path of the file in $\"", func() {
+				_, err := vm.Run(`
+$loads = []
+require 'counter'
+`)
+				Expect(err).ToNot(HaveOccurred())
+
+				expectedPath, err := filepath.EvalSymlinks(filepath.Join(directory, "counter.rb"))
+				Expect(err).ToNot(HaveOccurred())
+
+				loadedFeatures, err := vm.Run(`$"`)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(loadedFeatures.(*Array).Members()).To(ContainElement(EqualRubyString(expectedPath)))
+				Expect(vm.MustGet("LOADED_FEATURES")).To(Equal(loadedFeatures))
+			})
+		})
+
+		Context("with a directory added to $: from ruby", func() {
+			var directory string
+
+			BeforeEach(func() {
+				var err error
+				directory, err = ioutil.TempDir("", "")
+				Expect(err).ToNot(HaveOccurred())
+
+				files := map[string]string{
+					"library.rb":        "require_relative 'support/helper'\n$loaded = true\n",
+					"support/helper.rb": "$helped = true\n",
+					"flaky.rb":          "$attempts = $attempts + 1\nraise 'boom' if $attempts == 1\n",
+					"notes":             "$notes = true\n",
+				}
+				Expect(os.Mkdir(filepath.Join(directory, "support"), 0700)).To(Succeed())
+				for name, contents := range files {
+					Expect(ioutil.WriteFile(filepath.Join(directory, name), []byte(contents), 0600)).To(Succeed())
+				}
+			})
+
+			AfterEach(func() {
+				os.RemoveAll(directory)
+			})
+
+			It("returns true when it loads the file and false once it has been loaded", func() {
+				value, err := vm.Run(fmt.Sprintf(`
+$:.unshift File.expand_path('%s')
+[require('library'), require('library'), $loaded]
+`, directory))
+				Expect(err).ToNot(HaveOccurred())
+
+				trueValue := vm.SingletonWithName("true")
+				Expect(value.(*Array).Members()).To(Equal([]Value{trueValue, vm.SingletonWithName("false"), trueValue}))
+			})
+
+			It("loads files given to require_relative from the requiring file's directory", func() {
+				_, err := vm.Run(fmt.Sprintf(`
+$LOAD_PATH.unshift '%s'
+require 'library'
+`, directory))
+				Expect(err).ToNot(HaveOccurred())
+				Expect(vm.MustGet("helped")).To(Equal(vm.SingletonWithName("true")))
+			})
+
+			It("can require a file again after it failed to load", func() {
+				value, err := vm.Run(fmt.Sprintf(`
+$LOAD_PATH.unshift '%s'
+$attempts = 0
+begin
+  require 'flaky'
+rescue RuntimeError
+end
+[require('flaky'), $attempts]
+`, directory))
+				Expect(err).ToNot(HaveOccurred())
+				Expect(value.(*Array).Members()).To(Equal([]Value{vm.SingletonWithName("true"), NewFixnum(2, vm, vm)}))
+			})
+
+			It("does not load a file without the .rb extension", func() {
+				_, err := vm.Run(fmt.Sprintf(`
+$LOAD_PATH.unshift '%s'
+require 'notes'
+`, directory))
+				Expect(err).To(BeAssignableToTypeOf(NewLoadError("", "")))
+			})
+
+			It("raises a LoadError naming a file that is not on the load path", func() {
+				_, err := vm.Run("require 'support/missing'")
+
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(HavePrefix("LoadError: cannot load such file -- support/missing\n"))
+			})
+		})
 	})
 
 	Describe("the load path", func() {
@@ -276,6 +496,60 @@ end`)
 		})
 	})
 
+	Describe("assignment to multiple variables", func() {
+		It("spreads the values across each variable", func() {
+			_, err := vm.Run("foo, *bar = 1, 2, 3")
+			Expect(err).ToNot(HaveOccurred())
+
+			foo, err := vm.Get("foo")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(foo).To(Equal(NewFixnum(1, vm, vm)))
+
+			bar, err := vm.Get("bar")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(bar.(*Array).Members()).To(Equal([]Value{
+				NewFixnum(2, vm, vm),
+				NewFixnum(3, vm, vm),
+			}))
+		})
+
+		It("spreads a splatted array on the right hand side", func() {
+			_, err := vm.Run(`
+pair = [2, 3]
+foo, *bar = 1, *pair
+`)
+			Expect(err).ToNot(HaveOccurred())
+
+			foo, err := vm.Get("foo")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(foo).To(Equal(NewFixnum(1, vm, vm)))
+
+			bar, err := vm.Get("bar")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(bar.(*Array).Members()).To(Equal([]Value{
+				NewFixnum(2, vm, vm),
+				NewFixnum(3, vm, vm),
+			}))
+		})
+
+		It("skips the whole assignment when a trailing condition is false", func() {
+			_, err := vm.Run(`
+foo = 0
+bar = 0
+foo, bar = 1, 2 if false
+`)
+			Expect(err).ToNot(HaveOccurred())
+
+			foo, err := vm.Get("foo")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(foo).To(Equal(NewFixnum(0, vm, vm)))
+
+			bar, err := vm.Get("bar")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(bar).To(Equal(NewFixnum(0, vm, vm)))
+		})
+	})
+
 	Describe("special global variables", func() {
 		Describe("__FILE__", func() {
 			It("inherits the name given to the vm initially", func() {
@@ -331,6 +605,418 @@ bar = true
 			Expect(vm.MustGet("foo")).To(Equal(trueValue))
 			Expect(vm.MustGet("bar")).To(Equal(trueValue))
 		})
+
+		It("rescues any of the classes in a splatted array", func() {
+			_, err := vm.Run(`
+ERRORS = [TypeError, ArgumentError]
+rescued = false
+begin
+  'ab' * -1
+rescue *ERRORS => e
+  rescued = true
+end
+`)
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(vm.MustGet("rescued")).To(Equal(vm.SingletonWithName("true")))
+		})
+
+		It("lets errors not in the splatted array bubble up", func() {
+			_, err := vm.Run(`
+ERRORS = [TypeError]
+begin
+  'ab' * -1
+rescue *ERRORS
+end
+`)
+
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("ArgumentError"))
+		})
+
+		It("rescues subclasses of the named class", func() {
+			_, err := vm.Run(`
+rescued = false
+begin
+  'hello'.world
+rescue NameError
+  rescued = true
+end
+`)
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(vm.MustGet("rescued")).To(Equal(vm.SingletonWithName("true")))
+		})
+
+		It("evaluates to the value of the rescue that handled an error", func() {
+			value, err := vm.Run(`
+repeated = begin
+  'ab' * -1
+rescue ArgumentError
+  'default'
+end
+`)
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(value).To(EqualRubyString("default"))
+			Expect(vm.MustGet("repeated")).To(EqualRubyString("default"))
+		})
+
+		It("evaluates to its body, or its else, when nothing is raised", func() {
+			_, err := vm.Run(`
+from_body = begin
+  'body'
+rescue
+  'rescue'
+end
+
+from_else = begin
+  'body'
+rescue
+  'rescue'
+else
+  'else'
+ensure
+  'ensure'
+end
+`)
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(vm.MustGet("from_body")).To(EqualRubyString("body"))
+			Expect(vm.MustGet("from_else")).To(EqualRubyString("else"))
+		})
+
+	})
+
+	Describe("raising exceptions", func() {
+		It("raises a RuntimeError with the message given to raise", func() {
+			_, err := vm.Run("raise 'oops'")
+
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(Equal("RuntimeError: oops"))
+		})
+
+		It("raises the same error with fail as it does with raise", func() {
+			_, raiseErr := vm.Run("raise ArgumentError, 'bad value'")
+			_, failErr := vm.Run("fail ArgumentError, 'bad value'")
+
+			Expect(failErr).To(HaveOccurred())
+			Expect(failErr.Error()).To(Equal("ArgumentError: bad value"))
+			Expect(failErr.Error()).To(Equal(raiseErr.Error()))
+		})
+
+		It("can rescue what fail raised", func() {
+			value, err := vm.Run(`
+begin
+  fail ArgumentError
+rescue ArgumentError
+  'rescued'
+end
+`)
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(value).To(EqualRubyString("rescued"))
+		})
+
+		It("raises an exception instance as its own class, with its message", func() {
+			value, err := vm.Run(`
+begin
+  raise ArgumentError.new('bad')
+rescue RuntimeError
+  'runtime error'
+rescue ArgumentError
+  'argument error'
+end
+`)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(value).To(EqualRubyString("argument error"))
+
+			_, err = vm.Run("raise ArgumentError.new('bad')")
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(Equal("ArgumentError: bad"))
+		})
+
+		It("writes the message given to abort to stderr and raises SystemExit", func() {
+			stderr := &bytes.Buffer{}
+			vm.SetStderr(stderr)
+
+			_, err := vm.Run("abort('giving up')")
+
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(Equal("SystemExit: giving up"))
+			Expect(stderr.String()).To(Equal("giving up\n"))
+		})
+
+		It("does not rescue the SystemExit from abort with a bare rescue", func() {
+			vm.SetStderr(&bytes.Buffer{})
+
+			_, err := vm.Run(`
+begin
+  abort('giving up')
+rescue
+  'rescued'
+end
+`)
+
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("SystemExit"))
+		})
+	})
+
+	Describe("loops", func() {
+		It("runs the body of begin ... end until once before checking the condition", func() {
+			value, err := vm.Run(`
+i = 0
+begin
+  i = i + 1
+end until true
+i
+`)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(value).To(Equal(NewFixnum(1, vm, vm)))
+		})
+
+		It("runs begin ... end while until the condition no longer holds", func() {
+			value, err := vm.Run(`
+i = 0
+begin
+  i = i + 1
+end while i < 3
+i
+`)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(value).To(Equal(NewFixnum(3, vm, vm)))
+		})
+
+		It("checks the condition of a while modifier before running its expression", func() {
+			value, err := vm.Run(`
+i = 0
+i = i + 1 while false
+i
+`)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(value).To(Equal(NewFixnum(0, vm, vm)))
+		})
+
+		It("runs the block given to loop until it breaks", func() {
+			value, err := vm.Run(`
+count = 0
+loop do
+  count = count.then { |n| n + 1 }
+  break if count == 3
+end
+count
+`)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(value).To(Equal(NewFixnum(3, vm, vm)))
+		})
+
+		It("stops a loop when the block raises StopIteration", func() {
+			value, err := vm.Run(`
+letters = ['a', 'b'].each
+seen = []
+result = loop do
+  seen << letters.next
+end
+[seen, result].inspect
+`)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(value).To(EqualRubyString(`[["a", "b"], nil]`))
+		})
+
+		It("runs the ensure of a begin block that next or break leaves", func() {
+			value, err := vm.Run(`
+queue = [1, 2, 3, 4, 5]
+log = []
+while queue[0]
+  i = queue.shift
+  begin
+    next if i == 2
+    break if i == 4
+    log << i
+  ensure
+    log << :ensure
+  end
+end
+log
+`)
+			Expect(err).ToNot(HaveOccurred())
+
+			ensure := vm.Symbols()["ensure"]
+			Expect(value.(*Array).Members()).To(Equal([]Value{
+				NewFixnum(1, vm, vm), ensure,
+				ensure,
+				NewFixnum(3, vm, vm), ensure,
+				ensure,
+			}))
+		})
+
+		It("runs the ensure of a begin block that a method returns from", func() {
+			value, err := vm.Run(`
+class Cleanup
+  def run
+    begin
+      return :returned
+    ensure
+      $cleaned_up = true
+    end
+    :fell_through
+  end
+end
+
+Cleanup.new.run
+`)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(value).To(Equal(vm.Symbols()["returned"]))
+			Expect(vm.Globals()["cleaned_up"]).To(Equal(vm.SingletonWithName("true")))
+		})
+	})
+
+	Describe("and / or", func() {
+		It("assigns before evaluating a low precedence or", func() {
+			value, err := vm.Run("x = nil or 5")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(value).To(Equal(NewFixnum(5, vm, vm)))
+			Expect(vm.MustGet("x")).To(Equal(vm.SingletonWithName("nil")))
+		})
+
+		It("does not evaluate the right hand side when the left decides the result", func() {
+			value, err := vm.Run("false and undefined_method")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(value).To(Equal(vm.SingletonWithName("false")))
+
+			value, err = vm.Run("1 or undefined_method")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(value).To(Equal(NewFixnum(1, vm, vm)))
+		})
+
+		It("short circuits && and || too", func() {
+			value, err := vm.Run("nil || 2")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(value).To(Equal(NewFixnum(2, vm, vm)))
+
+			value, err = vm.Run("nil && undefined_method")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(value).To(Equal(vm.SingletonWithName("nil")))
+		})
+	})
+
+	Describe("local variable scope", func() {
+		It("lets blocks read and assign the locals around them", func() {
+			value, err := vm.Run(`
+x = 1
+[1].each { x = 2 }
+x
+`)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(value).To(Equal(NewFixnum(2, vm, vm)))
+		})
+
+		It("keeps locals first assigned in a block inside the block", func() {
+			_, err := vm.Run(`
+[1].each { |i| inner = i }
+inner
+`)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("NameError: undefined local variable or method 'inner'"))
+		})
+
+		It("closes blocks over the locals of the method they are written in", func() {
+			value, err := vm.Run(`
+class Counter
+  def last(items)
+    seen = nil
+    items.each { |item| seen = item }
+    seen
+  end
+end
+
+Counter.new.last([1, 2, 3])
+`)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(value).To(Equal(NewFixnum(3, vm, vm)))
+		})
+
+		It("does not let methods see the locals of their caller", func() {
+			_, err := vm.Run(`
+class Reader
+  def read
+    x
+  end
+end
+
+x = 1
+Reader.new.read
+`)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("NameError: undefined local variable or method 'x'"))
+		})
+	})
+
+	Describe("block params", func() {
+		It("binds nil to params that get no arg", func() {
+			value, err := vm.Run("[[1]].map { |a, b| b }.inspect")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(value).To(EqualRubyString("[nil]"))
+		})
+
+		It("binds nil to destructured params that get no value", func() {
+			value, err := vm.Run("[[1]].map { |(a, b), c| [a, b, c] }.inspect")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(value).To(EqualRubyString("[[1, nil, nil]]"))
+		})
+	})
+
+	Describe("numbered block params", func() {
+		It("binds the args of a block written without |args| to _1, _2, ...", func() {
+			value, err := vm.Run(`[["a", "b"], ["c", "d"]].map { _2 + _1 }`)
+			Expect(err).ToNot(HaveOccurred())
+
+			results := value.(*Array).Members()
+			Expect(results[0]).To(EqualRubyString("ba"))
+			Expect(results[1]).To(EqualRubyString("dc"))
+		})
+
+		It("binds nil to numbered params that get no arg", func() {
+			value, err := vm.Run("[3, 4].map { [_1, _2] }.inspect")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(value).To(EqualRubyString("[[3, nil], [4, nil]]"))
+		})
+	})
+
+	Describe("grouped expressions", func() {
+		It("evaluates to the last statement in the group", func() {
+			value, err := vm.Run("(1; 2; 3)")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(value).To(Equal(NewFixnum(3, vm, vm)))
+		})
+
+		It("evaluates each of several groups in a row once", func() {
+			value, err := vm.Run(`
+$calls = 0
+a = ($calls = $calls + 1)
+b = (7)
+[$calls, b]
+`)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(value.(*Array).Members()).To(Equal([]Value{NewFixnum(1, vm, vm), NewFixnum(7, vm, vm)}))
+		})
+
+		It("assigns to variables in the surrounding scope", func() {
+			_, err := vm.Run("(x = 1; y = 2)")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(vm.MustGet("x")).To(Equal(NewFixnum(1, vm, vm)))
+			Expect(vm.MustGet("y")).To(Equal(NewFixnum(2, vm, vm)))
+		})
+
+		It("can be used as the receiver of a method call", func() {
+			value, err := vm.Run(`
+x = 'world'
+('hello %s' % x).inspect
+`)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(value).To(EqualRubyString(`"hello world"`))
+		})
 	})
 
 	Describe("calling a method that does not exist", func() {
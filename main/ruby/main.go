@@ -6,6 +6,7 @@ import (
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/grubby/grubby/interpreter/vm"
 	"github.com/grubby/grubby/parser"
@@ -63,6 +64,11 @@ func main() {
 		os.Exit(1)
 	case nil:
 	case error:
+		// abort has already written its message to stderr
+		if strings.HasPrefix(err.Error(), "SystemExit:") {
+			os.Exit(1)
+		}
+
 		panic(err.Error())
 	default:
 		panic(fmt.Sprintf("%#v", err))
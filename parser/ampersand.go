@@ -8,6 +8,17 @@ func lexAmpersand(l StatefulRubyLexer) stateFn {
 		return lexSomething
 	}
 
+	if l.accept(".") {
+		l.emit(tokenTypeSafeNavigation)
+
+		if l.accept(validMethodNameRunes) {
+			l.acceptRun(validMethodNameRunes)
+			l.emit(tokenTypeReference)
+		}
+
+		return lexSomething
+	}
+
 	switch l.lastToken().typ {
 	case tokenTypeInteger:
 		parseAsBinaryBitwiseOperator(l)
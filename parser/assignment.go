@@ -0,0 +1,16 @@
+package parser
+
+import "github.com/grubby/grubby/ast"
+
+// `and` and `or` bind more loosely than assignment, so `x = a or b`
+// assigns a to x and only then evaluates `or b`
+func newAssignment(lhs, rhs ast.Node) ast.Node {
+	switch rhs := rhs.(type) {
+	case ast.WeakLogicalAnd:
+		return ast.WeakLogicalAnd{LHS: newAssignment(lhs, rhs.LHS), RHS: rhs.RHS}
+	case ast.WeakLogicalOr:
+		return ast.WeakLogicalOr{LHS: newAssignment(lhs, rhs.LHS), RHS: rhs.RHS}
+	default:
+		return ast.Assignment{LHS: lhs, RHS: rhs}
+	}
+}
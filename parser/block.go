@@ -0,0 +1,47 @@
+package parser
+
+import (
+	"strconv"
+
+	"github.com/grubby/grubby/ast"
+)
+
+// a block written without |args| may refer to its args by number instead,
+// e.g. { _1 + _2 }, which gives it as many args as the highest number used
+func newBlockWithoutArgs(body []ast.Node) ast.Block {
+	return ast.Block{Body: body, ImplicitArgCount: highestNumberedParam(body)}
+}
+
+// searches the nodes for references to numbered params, without descending
+// into nested blocks (whose numbered params are their own)
+func highestNumberedParam(nodes []ast.Node) int {
+	highest := 0
+	ast.Walk(nodes, func(node ast.Node) bool {
+		switch node := node.(type) {
+		case ast.Block:
+			return false
+		case ast.BareReference:
+			if n := numberedParam(node.Name); n > highest {
+				highest = n
+			}
+		}
+
+		return true
+	})
+
+	return highest
+}
+
+// the number of a numbered param such as _1, which range from _1 to _9
+func numberedParam(name string) int {
+	if len(name) != 2 || name[0] != '_' {
+		return 0
+	}
+
+	n, err := strconv.Atoi(name[1:])
+	if err != nil || n < 1 {
+		return 0
+	}
+
+	return n
+}
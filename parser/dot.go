@@ -2,7 +2,11 @@ package parser
 
 func lexDot(l StatefulRubyLexer) stateFn {
 	if l.accept(".") {
-		l.emit(tokenTypeRange)
+		if l.accept(".") {
+			l.emit(tokenTypeExclusiveRange)
+		} else {
+			l.emit(tokenTypeRange)
+		}
 		return lexSomething
 	}
 
@@ -2,6 +2,8 @@ package parser
 
 import "fmt"
 
+const regexFlags = "imxouesn"
+
 func lexSlash(l StatefulRubyLexer) stateFn {
 	switch l.lastToken().typ {
 	case tokenTypeInteger:
@@ -152,11 +154,13 @@ func parseAsRegex(l StatefulRubyLexer) {
 	for {
 		prev = r
 		switch r = l.next(); {
+		case r == '#' && prev != '\\':
+			if l.accept("{") {
+				lexUntilClosingMatchingBraces('{', '}')(l)
+			}
 		case r == '/' && prev != '\\':
 			l.backup()
-			l.emit(tokenTypeRegex)
-			l.accept("/")
-			l.ignore() // ignore closing slash
+			emitRegex(l)
 			shouldBreak = true
 		case r == eof:
 			l.emit(tokenTypeError)
@@ -169,6 +173,16 @@ func parseAsRegex(l StatefulRubyLexer) {
 	}
 }
 
+// emitRegex emits the body of a regex literal, which ends just before the
+// closing delimiter, along with any option flags that follow the delimiter.
+func emitRegex(l StatefulRubyLexer) {
+	body := l.currentSlice()
+	l.next()
+	l.ignore() // ignore closing delimiter
+	l.acceptRun(regexFlags)
+	l.emitToken(token{typ: tokenTypeRegex, value: body, flags: l.currentSlice()})
+}
+
 func parseAsOperator(l StatefulRubyLexer) {
 	if l.accept("=") {
 		l.emit(tokenTypeOperator)
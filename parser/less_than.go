@@ -1,7 +1,10 @@
 package parser
 
+import "strings"
+
 func lexLessThan(l StatefulRubyLexer) stateFn {
 	if l.accept("<") {
+		afterOperator := l.currentIndex()
 		heredocEndsAtFirstColumn := true
 		if l.accept("-") {
 			l.moveCurrentTokenStartIndex(3)
@@ -10,9 +13,28 @@ func lexLessThan(l StatefulRubyLexer) stateFn {
 			l.moveCurrentTokenStartIndex(2)
 		}
 
+		// a quoted identifier controls interpolation of the body:
+		// <<-'EOS' is literal, while <<-"EOS" and <<-EOS are interpolated
+		stringType := tokenTypeDoubleQuoteString
+		quote := ""
+		if l.accept(`'"`) {
+			quote = l.currentSlice()[len(l.currentSlice())-1:]
+			l.moveCurrentTokenStartIndex(1)
+			if quote == "'" {
+				stringType = tokenTypeString
+			}
+		}
+
 		if l.accept(alphaNumericUnderscore) {
 			l.acceptRun(alphaNumericUnderscore)
 			heredocIdentifier := l.currentSlice()
+			if quote != "" && (!l.accept(quote) || !heredocTerminated(l, heredocIdentifier, heredocEndsAtFirstColumn)) {
+				// without a terminating line, x <<'b' appends a string to x
+				l.moveCurrentTokenStartIndex(afterOperator - 2 - l.startIndex())
+				l.setCurrentPositionIndex(afterOperator)
+				l.emit(tokenTypeOperator)
+				return lexSomething
+			}
 			l.ignore()
 
 			//            Were You Aware???
@@ -64,7 +86,7 @@ func lexLessThan(l StatefulRubyLexer) stateFn {
 						l.acceptRun(alphaNumericUnderscore)
 						if l.slice(beginningOfHeredoc, l.currentIndex()) == heredocIdentifier {
 							l.setCurrentPositionIndex(beginningOfLine - 1)
-							l.emit(tokenTypeDoubleQuoteString)
+							l.emit(stringType)
 							l.accept("\n")
 							l.acceptRun(whitespace)
 							l.acceptRun(alphaNumericUnderscore)
@@ -82,6 +104,12 @@ func lexLessThan(l StatefulRubyLexer) stateFn {
 				}
 			}
 		} else {
+			if quote != "" {
+				// the quote starts a string, rather than a heredoc
+				l.setCurrentPositionIndex(l.currentIndex() - 1)
+				l.moveCurrentTokenStartIndex(-1)
+			}
+
 			l.moveCurrentTokenStartIndex(-2)
 			l.emit(tokenTypeOperator)
 		}
@@ -94,3 +122,20 @@ func lexLessThan(l StatefulRubyLexer) stateFn {
 
 	return lexSomething
 }
+
+// reports whether a line after the current one closes the heredoc
+func heredocTerminated(l StatefulRubyLexer, identifier string, endsAtFirstColumn bool) bool {
+	rest := l.slice(l.currentIndex(), l.lengthOfInput())
+	lines := strings.Split(rest, "\n")
+	for _, line := range lines[1:] {
+		if !endsAtFirstColumn {
+			line = strings.TrimLeft(line, whitespace)
+		}
+
+		if strings.TrimRight(line, whitespace) == identifier {
+			return true
+		}
+	}
+
+	return false
+}
@@ -28,61 +28,61 @@ func lexMinus(l StatefulRubyLexer) stateFn {
 	case tokenTypeGlobal:
 		l.emit(tokenTypeBinaryMinus)
 	case tokenTypeLParen:
-		l.emit(tokenTypeUnaryMinus)
+		emitUnaryMinus(l)
 	case tokenTypeRParen:
 		l.emit(tokenTypeBinaryMinus)
 	case tokenTypeComma:
-		l.emit(tokenTypeUnaryMinus)
+		emitUnaryMinus(l)
 	case tokenTypeNewline:
-		l.emit(tokenTypeUnaryMinus)
+		emitUnaryMinus(l)
 	case tokenTypeDEF:
-		l.emit(tokenTypeUnaryMinus)
+		emitUnaryMinus(l)
 	case tokenTypeDO:
-		l.emit(tokenTypeUnaryMinus)
+		emitUnaryMinus(l)
 	case tokenTypeEND:
-		l.emit(tokenTypeUnaryMinus)
+		emitUnaryMinus(l)
 	case tokenTypeIF:
-		l.emit(tokenTypeUnaryMinus)
+		emitUnaryMinus(l)
 	case tokenTypeELSE:
-		l.emit(tokenTypeUnaryMinus)
+		emitUnaryMinus(l)
 	case tokenTypeELSIF:
-		l.emit(tokenTypeUnaryMinus)
+		emitUnaryMinus(l)
 	case tokenTypeUNLESS:
-		l.emit(tokenTypeUnaryMinus)
+		emitUnaryMinus(l)
 	case tokenTypeTRUE:
 		l.emit(tokenTypeBinaryMinus)
 	case tokenTypeFALSE:
 		l.emit(tokenTypeBinaryMinus)
 	case tokenTypeLessThan:
-		l.emit(tokenTypeUnaryMinus)
+		emitUnaryMinus(l)
 	case tokenTypeGreaterThan:
-		l.emit(tokenTypeUnaryMinus)
+		emitUnaryMinus(l)
 	case tokenTypeColon:
-		l.emit(tokenTypeUnaryMinus)
+		emitUnaryMinus(l)
 	case tokenTypeSemicolon:
-		l.emit(tokenTypeUnaryMinus)
+		emitUnaryMinus(l)
 	case tokenTypeEqual:
-		l.emit(tokenTypeUnaryMinus)
+		emitUnaryMinus(l)
 	case tokenTypeBang:
-		l.emit(tokenTypeUnaryMinus)
+		emitUnaryMinus(l)
 	case tokenTypeTilde:
-		l.emit(tokenTypeUnaryMinus)
+		emitUnaryMinus(l)
 	case tokenTypeUnaryMinus:
-		l.emit(tokenTypeUnaryMinus)
+		emitUnaryMinus(l)
 	case tokenTypeBinaryMinus:
-		l.emit(tokenTypeUnaryMinus)
+		emitUnaryMinus(l)
 	case tokenTypeBinaryPlus:
-		l.emit(tokenTypeUnaryMinus)
+		emitUnaryMinus(l)
 	case tokenTypeUnaryPlus:
-		l.emit(tokenTypeUnaryMinus)
+		emitUnaryMinus(l)
 	case tokenTypeStar:
-		l.emit(tokenTypeUnaryMinus)
+		emitUnaryMinus(l)
 	case tokenTypeLBracket:
-		l.emit(tokenTypeUnaryMinus)
+		emitUnaryMinus(l)
 	case tokenTypeRBracket:
 		l.emit(tokenTypeBinaryMinus)
 	case tokenTypeLBrace:
-		l.emit(tokenTypeUnaryMinus)
+		emitUnaryMinus(l)
 	case tokenTypeRBrace:
 		l.emit(tokenTypeBinaryMinus)
 	case tokenType__FILE__:
@@ -92,51 +92,51 @@ func lexMinus(l StatefulRubyLexer) stateFn {
 	case tokenTypeDot:
 		l.emit(tokenTypeBinaryMinus)
 	case tokenTypePipe:
-		l.emit(tokenTypeUnaryMinus)
+		emitUnaryMinus(l)
 	case tokenTypeSubshell:
 		l.emit(tokenTypeBinaryMinus)
 	case tokenTypeOperator:
-		l.emit(tokenTypeUnaryMinus)
+		emitUnaryMinus(l)
 	case tokenTypeBEGIN:
-		l.emit(tokenTypeUnaryMinus)
+		emitUnaryMinus(l)
 	case tokenTypeRESCUE:
-		l.emit(tokenTypeUnaryMinus)
+		emitUnaryMinus(l)
 	case tokenTypeENSURE:
-		l.emit(tokenTypeUnaryMinus)
+		emitUnaryMinus(l)
 	case tokenTypeBREAK:
-		l.emit(tokenTypeUnaryMinus)
+		emitUnaryMinus(l)
 	case tokenTypeNEXT:
-		l.emit(tokenTypeUnaryMinus)
+		emitUnaryMinus(l)
 	case tokenTypeREDO:
-		l.emit(tokenTypeUnaryMinus)
+		emitUnaryMinus(l)
 	case tokenTypeRETRY:
-		l.emit(tokenTypeUnaryMinus)
+		emitUnaryMinus(l)
 	case tokenTypeRETURN:
-		l.emit(tokenTypeUnaryMinus)
+		emitUnaryMinus(l)
 	case tokenTypeYIELD:
-		l.emit(tokenTypeUnaryMinus)
+		emitUnaryMinus(l)
 	case tokenTypeQuestionMark:
-		l.emit(tokenTypeUnaryMinus)
+		emitUnaryMinus(l)
 	case tokenTypeMethodName:
-		l.emit(tokenTypeUnaryMinus)
+		emitUnaryMinus(l)
 	case tokenTypeWHILE:
-		l.emit(tokenTypeUnaryMinus)
+		emitUnaryMinus(l)
 	case tokenTypeAND:
-		l.emit(tokenTypeUnaryMinus)
+		emitUnaryMinus(l)
 	case tokenTypeOR:
-		l.emit(tokenTypeUnaryMinus)
+		emitUnaryMinus(l)
 	case tokenTypeLAMBDA:
-		l.emit(tokenTypeUnaryMinus)
+		emitUnaryMinus(l)
 	case tokenTypeCASE:
-		l.emit(tokenTypeUnaryMinus)
+		emitUnaryMinus(l)
 	case tokenTypeWHEN:
-		l.emit(tokenTypeUnaryMinus)
+		emitUnaryMinus(l)
 	case tokenTypeOrEquals:
-		l.emit(tokenTypeUnaryMinus)
+		emitUnaryMinus(l)
 	case tokenTypeRange:
-		l.emit(tokenTypeUnaryMinus)
+		emitUnaryMinus(l)
 	case tokenTypeError:
-		l.emit(tokenTypeUnaryMinus)
+		emitUnaryMinus(l)
 	case tokenTypeSELF:
 		l.emit(tokenTypeBinaryMinus)
 	case tokenTypeNIL:
@@ -147,3 +147,13 @@ func lexMinus(l StatefulRubyLexer) stateFn {
 
 	return lexSomething
 }
+
+// a unary minus written right before a number belongs to the literal, so
+// -5.abs is (-5).abs while -x.abs is -(x.abs)
+func emitUnaryMinus(l StatefulRubyLexer) {
+	if r := l.peek(); '0' <= r && r <= '9' {
+		l.emit(tokenTypeUnaryMinusNumber)
+	} else {
+		l.emit(tokenTypeUnaryMinus)
+	}
+}
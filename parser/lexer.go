@@ -3,7 +3,6 @@ package parser
 import (
 	"errors"
 	"fmt"
-	"strconv"
 	"strings"
 	"unicode/utf8"
 
@@ -20,6 +19,8 @@ const eof = -1
 type token struct {
 	typ   tokenType
 	value string
+	flags string // trailing options of a regex literal, such as "im"
+	pos   ast.Position
 }
 
 type tokenType int
@@ -32,6 +33,9 @@ const (
 	tokenTypeString
 	tokenTypeDoubleQuoteString
 	tokenTypeRegex
+	tokenTypeWordArray
+	tokenTypeInterpolatedWordArray
+	tokenTypeSymbolArray
 	tokenTypeCharacter
 	tokenTypeSymbol
 	tokenTypeReference
@@ -67,7 +71,9 @@ const (
 	tokenTypeBinaryPlus
 	tokenTypeBinaryMinus
 	tokenTypeUnaryMinus
+	tokenTypeUnaryMinusNumber
 	tokenTypeStar
+	tokenTypeDoubleStar
 	tokenTypeLBracket
 	tokenTypeRBracket
 	tokenTypeLBrace
@@ -76,6 +82,7 @@ const (
 	tokenTypeAtSign
 	tokenTypeDot
 	tokenTypeRange
+	tokenTypeExclusiveRange
 	tokenTypePipe
 	tokenTypeOrEquals
 	tokenTypeForwardSlash
@@ -96,15 +103,20 @@ const (
 	tokenTypeRETRY
 	tokenTypeRETURN
 	tokenTypeYIELD
+	tokenTypeDEFINED
 	tokenTypeAND
 	tokenTypeOR
 	tokenTypeLAMBDA
 	tokenTypeCASE
 	tokenTypeWHEN
+	tokenTypeIN
 	tokenTypeALIAS
 	tokenType__FILE__
 	tokenType__LINE__
+	tokenType__dir__
+	tokenType__method__
 	tokenType__ENCODING__
+	tokenTypeSafeNavigation
 )
 
 type StatefulRubyLexer interface {
@@ -122,14 +134,18 @@ type StatefulRubyLexer interface {
 	acceptRun(string)
 
 	emit(tokenType)
+	emitToken(token)
 
 	lastToken() token
 
+	newlinesAreSignificant() bool
+
 	slice(int, int) string
 	currentSlice() string
 
 	currentIndex() int
 	startIndex() int
+	startPosition() ast.Position
 
 	lengthOfInput() int
 
@@ -146,6 +162,16 @@ type ConcreteStatefulRubyLexer struct {
 
 	lastTokenEmitted token
 	LastError        error
+
+	nesting []nestingFrame
+
+	// positions are only handed to the parser when this is set
+	trackPositions bool
+
+	// how far into the input lines have been counted, for startPosition
+	scanned   int
+	newlines  int
+	lineStart int
 }
 
 type stateFn func(StatefulRubyLexer) stateFn
@@ -160,6 +186,19 @@ func NewLexer(input string) StatefulRubyLexer {
 	return lexer
 }
 
+// NewPositionedLexer is like NewLexer, but the nodes parsed from it record
+// the Position where they started in the input
+func NewPositionedLexer(input string) StatefulRubyLexer {
+	lexer := &ConcreteStatefulRubyLexer{
+		input:          input,
+		tokens:         make(chan token),
+		trackPositions: true,
+	}
+
+	go lexer.run()
+	return lexer
+}
+
 func (lexer *ConcreteStatefulRubyLexer) run() {
 	for state := lexSomething; state != nil; {
 		state = state(lexer)
@@ -172,8 +211,26 @@ func (lexer *ConcreteStatefulRubyLexer) run() {
 	close(lexer.tokens)
 }
 
+// single punctuation characters that name a special global, e.g. $" or $!
+var specialGlobalNameRunes = "\"!@&~/\\,;.<>*$?"
+
 var validCharRunes = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ01234567789`!@#$%^&*()-_=+\\|][{}/?;:'\",.<>~"
 
+// the characters written as a backslash escape in a ?-literal, like ?\n
+var characterEscapes = map[rune]string{
+	'n':  "\n",
+	't':  "\t",
+	's':  " ",
+	'r':  "\r",
+	'0':  "\x00",
+	'a':  "\a",
+	'b':  "\b",
+	'e':  "\x1b",
+	'f':  "\f",
+	'v':  "\v",
+	'\\': "\\",
+}
+
 func lexSomething(l StatefulRubyLexer) stateFn {
 	switch r := l.next(); {
 	case '0' <= r && r <= '9':
@@ -187,7 +244,15 @@ func lexSomething(l StatefulRubyLexer) stateFn {
 		return lexDoubleQuoteString
 	case r == '?':
 		// FIXME: this is not an exhaustive list of character literals
-		if l.accept(validCharRunes) {
+		if l.accept("\\") {
+			l.moveCurrentTokenStartIndex(1)
+			if escaped, ok := characterEscapes[l.peek()]; ok {
+				l.next()
+				l.emitToken(token{typ: tokenTypeCharacter, value: escaped})
+			} else {
+				l.emit(tokenTypeCharacter)
+			}
+		} else if l.accept(validCharRunes) {
 			// skip past the ? rune
 			l.moveCurrentTokenStartIndex(1)
 			l.emit(tokenTypeCharacter)
@@ -251,6 +316,16 @@ func lexSomething(l StatefulRubyLexer) stateFn {
 		if l.accept("=") {
 			l.emit(tokenTypeOperator)
 		} else if l.accept("*") {
+			// ** starts a keyword splat where an argument or param is expected,
+			// otherwise it is exponentiation
+			switch l.lastToken().typ {
+			case tokenTypeLParen, tokenTypeComma, tokenTypePipe:
+				l.emit(tokenTypeDoubleStar)
+			default:
+				l.emit(tokenTypeOperator)
+			}
+		} else if r := l.peek(); r == ' ' || r == '\t' {
+			// like ruby, x * 2 multiplies while x *2 passes a splat to x
 			l.emit(tokenTypeOperator)
 		} else {
 			l.emit(tokenTypeStar)
@@ -270,6 +345,11 @@ func lexSomething(l StatefulRubyLexer) stateFn {
 			l.ignore()
 			l.acceptRun(validGlobalNameRunes)
 			l.emit(tokenTypeGlobal)
+		} else if l.accept(specialGlobalNameRunes) {
+			l.backup()
+			l.ignore()
+			l.next()
+			l.emit(tokenTypeGlobal)
 		} else {
 			l.emit(tokenTypeDollarSign)
 		}
@@ -404,6 +484,16 @@ func (l *ConcreteStatefulRubyLexer) emit(t tokenType) {
 }
 
 func (l *ConcreteStatefulRubyLexer) emitToken(t token) {
+	if t.typ == tokenTypeDO && l.loopAwaitingDo() {
+		t.typ = tokenTypeNewline
+	}
+
+	// tokens read ahead of a heredoc body already know where they started
+	if t.pos == (ast.Position{}) {
+		t.pos = l.startPosition()
+	}
+
+	l.trackNesting(t)
 	l.tokens <- t
 	l.lastTokenEmitted = t
 	l.start = l.pos
@@ -417,26 +507,66 @@ func (l *ConcreteStatefulRubyLexer) moveCurrentPositionIndex(val int) {
 	l.pos += val
 }
 
+// no rule accepts the parser's $unk token, so returning it from Lex
+// halts parsing with a syntax error
+const unknownToken = RubyPrivate + 1
+
+// startPosition is where the token currently being lexed starts. Lines are
+// counted incrementally, since the start of a token rarely moves backwards.
+func (l *ConcreteStatefulRubyLexer) startPosition() ast.Position {
+	if l.start < l.scanned {
+		l.scanned, l.newlines, l.lineStart = 0, 0, 0
+	}
+
+	for ; l.scanned < l.start; l.scanned++ {
+		if l.input[l.scanned] == '\n' {
+			l.newlines++
+			l.lineStart = l.scanned + 1
+		}
+	}
+
+	return ast.Position{
+		Line:   l.newlines + 1,
+		Column: l.start - l.lineStart + 1,
+		Offset: l.start,
+	}
+}
+
 func (lexer *ConcreteStatefulRubyLexer) Lex(lval *RubySymType) int {
+	tokenType := lexer.lex(lval)
+	if lexer.trackPositions {
+		lval.genericValue = withPosition(lval.genericValue, lval.pos)
+	}
+
+	return tokenType
+}
+
+func (lexer *ConcreteStatefulRubyLexer) lex(lval *RubySymType) int {
 	debug("Called Lex()")
 	defer func() { debug("") }()
 
 	for token := range lexer.tokens {
+		if lexer.trackPositions {
+			lval.pos = token.pos
+		}
+
 		switch token.typ {
 		case tokenTypeInteger:
 			debug("integer: %s", token.value)
-			intVal, err := strconv.Atoi(token.value)
+			intVal, err := parseIntegerLiteral(token.value)
 			if err != nil {
-				panic(err)
+				lexer.Error(err.Error())
+				return unknownToken
 			}
 
 			lval.genericValue = ast.ConstantInt{Value: intVal}
 			return NODE
 		case tokenTypeFloat:
 			debug("float: %s", token.value)
-			floatval, err := strconv.ParseFloat(token.value, 64)
+			floatval, err := parseFloatLiteral(token.value)
 			if err != nil {
-				panic(err)
+				lexer.Error(err.Error())
+				return unknownToken
 			}
 
 			lval.genericValue = ast.ConstantFloat{Value: floatval}
@@ -449,6 +579,31 @@ func (lexer *ConcreteStatefulRubyLexer) Lex(lval *RubySymType) int {
 			debug("string: '%s'", token.value)
 			lval.genericValue = ast.InterpolatedString{Value: token.value}
 			return NODE
+		case tokenTypeWordArray:
+			debug("word array: '%s'", token.value)
+			words := ast.Array{Nodes: []ast.Node{}}
+			for _, word := range splitWords(token.value) {
+				words.Nodes = append(words.Nodes, ast.SimpleString{Value: word})
+			}
+			lval.genericValue = words
+			return NODE
+		case tokenTypeInterpolatedWordArray:
+			debug("interpolated word array: '%s'", token.value)
+			words := ast.Array{Nodes: []ast.Node{}}
+			for _, word := range splitWords(token.value) {
+				words.Nodes = append(words.Nodes, ast.InterpolatedString{Value: word})
+			}
+			lval.genericValue = words
+			return NODE
+		case tokenTypeSymbolArray:
+			// like :"@#{foo}", interpolation templates in %I stay in the name
+			debug("symbol array: '%s'", token.value)
+			symbols := ast.Array{Nodes: []ast.Node{}}
+			for _, word := range splitWords(token.value) {
+				symbols.Nodes = append(symbols.Nodes, ast.Symbol{Name: word})
+			}
+			lval.genericValue = symbols
+			return NODE
 		case tokenTypeCharacter:
 			debug("char: '%s'", token.value)
 			lval.genericValue = ast.CharacterLiteral{Value: token.value}
@@ -550,9 +705,15 @@ func (lexer *ConcreteStatefulRubyLexer) Lex(lval *RubySymType) int {
 		case tokenTypeUnaryMinus:
 			debug("(unary) -")
 			return UNARY_MINUS
+		case tokenTypeUnaryMinusNumber:
+			debug("(unary) - before a number")
+			return UNARY_MINUS_NUM
 		case tokenTypeStar:
 			debug("*")
 			return STAR
+		case tokenTypeDoubleStar:
+			debug("**")
+			return DOUBLESTAR
 		case tokenTypeLBracket:
 			debug("[")
 			return LBRACKET
@@ -579,9 +740,20 @@ func (lexer *ConcreteStatefulRubyLexer) Lex(lval *RubySymType) int {
 			debug("__LINE__")
 			lval.genericValue = ast.LineNumberConstReference{}
 			return LINE_CONST_REF
+		case tokenType__dir__:
+			debug("__dir__")
+			lval.genericValue = ast.DirNameConstReference{}
+			return DIR_CONST_REF
+		case tokenType__method__:
+			debug("__method__")
+			lval.genericValue = ast.MethodNameConstReference{}
+			return METHOD_CONST_REF
 		case tokenTypeDot:
 			debug(".")
 			return DOT
+		case tokenTypeSafeNavigation:
+			debug("&.")
+			return SAFE_NAV
 		case tokenTypePipe:
 			debug("|")
 			return PIPE
@@ -598,6 +770,13 @@ func (lexer *ConcreteStatefulRubyLexer) Lex(lval *RubySymType) int {
 		case tokenTypeOperator:
 			debug("Operator: %s", token.value)
 			lval.operator = token.value
+			if token.value == "**" {
+				return POW
+			}
+			if token.value == "=>" {
+				return HASHROCKET
+			}
+
 			return OPERATOR
 		case tokenTypeBEGIN:
 			debug("BEGIN")
@@ -626,6 +805,9 @@ func (lexer *ConcreteStatefulRubyLexer) Lex(lval *RubySymType) int {
 		case tokenTypeYIELD:
 			debug("YIELD")
 			return YIELD
+		case tokenTypeDEFINED:
+			debug("DEFINED")
+			return DEFINED
 		case tokenTypeQuestionMark:
 			debug("?")
 			return QUESTIONMARK
@@ -651,6 +833,9 @@ func (lexer *ConcreteStatefulRubyLexer) Lex(lval *RubySymType) int {
 		case tokenTypeWHEN:
 			debug("WHEN")
 			return WHEN
+		case tokenTypeIN:
+			debug("IN")
+			return IN
 		case tokenTypeSELF:
 			debug("SELF")
 			return SELF
@@ -666,13 +851,23 @@ func (lexer *ConcreteStatefulRubyLexer) Lex(lval *RubySymType) int {
 		case tokenTypeRange:
 			debug(".. (range)")
 			return RANGE
+		case tokenTypeExclusiveRange:
+			debug("... (exclusive range)")
+			return EXCLUSIVE_RANGE
 		case tokenTypeRegex:
 			debug("regex: '%s'", token.value)
-			lval.genericValue = ast.Regex{Value: token.value}
+			lval.genericValue = ast.Regex{
+				Value:        token.value,
+				Flags:        token.flags,
+				Interpolated: strings.Contains(token.value, "#{"),
+			}
 			return NODE
 		case tokenTypeUNTIL:
 			debug("UNTIL")
 			return UNTIL
+		case tokenTypeFOR:
+			debug("FOR")
+			return FOR
 		case tokenTypeNamespaceResolvedModule:
 			debug("NamespacedModule '%s'", token.value)
 			lval.genericValue = token.value
@@ -690,7 +885,13 @@ func (lexer *ConcreteStatefulRubyLexer) Lex(lval *RubySymType) int {
 	return 0
 }
 
+// the first error is the most useful one, as the parser reports any
+// invalid token the lexer returns as a syntax error of its own
 func (lexer *ConcreteStatefulRubyLexer) Error(error string) {
+	if lexer.LastError != nil {
+		return
+	}
+
 	lexer.LastError = errors.New(fmt.Sprintf("syntax error: %s\n", error))
 }
 
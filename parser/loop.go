@@ -0,0 +1,10 @@
+package parser
+
+import "github.com/grubby/grubby/ast"
+
+// a while or until modifier checks its condition before running the
+// expression, unless the expression is a begin block, which runs first
+func newModifierLoop(body, condition ast.Node) ast.Loop {
+	_, isBegin := body.(ast.Begin)
+	return ast.Loop{Condition: condition, Body: []ast.Node{body}, DoWhile: isBegin}
+}
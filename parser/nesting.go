@@ -0,0 +1,143 @@
+package parser
+
+// a nestingFrame records an open bracket or keyword that is waiting
+// on its closing token
+type nestingFrame struct {
+	opener tokenType
+
+	// while, until and for may be followed by an optional `do` on the same line
+	awaitingDo bool
+
+	// a def is still in its signature until the token after its name or
+	// parameter list, where an `=` makes it an endless def with no `end`
+	inSignature bool
+	nameSeen    bool
+}
+
+// tokens after which `if`, `unless`, `while` and `until` act as modifiers
+// of the preceding expression rather than opening a new block
+var expressionEndingTokens = map[tokenType]bool{
+	tokenTypeInteger:                 true,
+	tokenTypeFloat:                   true,
+	tokenTypeString:                  true,
+	tokenTypeDoubleQuoteString:       true,
+	tokenTypeRegex:                   true,
+	tokenTypeWordArray:               true,
+	tokenTypeInterpolatedWordArray:   true,
+	tokenTypeSymbolArray:             true,
+	tokenTypeCharacter:               true,
+	tokenTypeSymbol:                  true,
+	tokenTypeReference:               true,
+	tokenTypeNamespaceResolvedModule: true,
+	tokenTypeMethodName:              true,
+	tokenTypeGlobal:                  true,
+	tokenTypeCapitalizedReference:    true,
+	tokenTypeRParen:                  true,
+	tokenTypeRBracket:                true,
+	tokenTypeRBrace:                  true,
+	tokenTypeEND:                     true,
+	tokenTypeTRUE:                    true,
+	tokenTypeFALSE:                   true,
+	tokenTypeSELF:                    true,
+	tokenTypeNIL:                     true,
+	tokenTypeSubshell:                true,
+	tokenTypeBREAK:                   true,
+	tokenTypeNEXT:                    true,
+	tokenTypeREDO:                    true,
+	tokenTypeRETRY:                   true,
+	tokenTypeRETURN:                  true,
+	tokenTypeYIELD:                   true,
+	tokenType__FILE__:                true,
+	tokenType__LINE__:                true,
+	tokenType__ENCODING__:            true,
+}
+
+func (l *ConcreteStatefulRubyLexer) trackNesting(t token) {
+	if top := l.topOfNesting(); top != nil && top.opener == tokenTypeDEF && top.inSignature {
+		switch {
+		case t.typ == tokenTypeSELF:
+		case t.typ == tokenTypeDot:
+			top.nameSeen = false
+		case !top.nameSeen:
+			top.nameSeen = true
+		case t.typ == tokenTypeEqual:
+			l.popNesting()
+			return
+		case t.typ == tokenTypeLParen && l.lastTokenEmitted.typ != tokenTypeRParen:
+		default:
+			top.inSignature = false
+		}
+	}
+
+	switch t.typ {
+	case tokenTypeLParen, tokenTypeLBracket, tokenTypeLBrace:
+		l.pushNesting(nestingFrame{opener: t.typ})
+	case tokenTypeRParen, tokenTypeRBracket, tokenTypeRBrace:
+		l.popNesting()
+	case tokenTypeDEF:
+		l.pushNesting(nestingFrame{opener: t.typ, inSignature: true})
+	case tokenTypeCLASS, tokenTypeMODULE, tokenTypeBEGIN, tokenTypeCASE:
+		l.pushNesting(nestingFrame{opener: t.typ})
+	case tokenTypeIF, tokenTypeUNLESS:
+		if !expressionEndingTokens[l.lastTokenEmitted.typ] {
+			l.pushNesting(nestingFrame{opener: t.typ})
+		}
+	case tokenTypeWHILE, tokenTypeUNTIL:
+		if !expressionEndingTokens[l.lastTokenEmitted.typ] {
+			l.pushNesting(nestingFrame{opener: t.typ, awaitingDo: true})
+		}
+	case tokenTypeFOR:
+		l.pushNesting(nestingFrame{opener: t.typ, awaitingDo: true})
+	case tokenTypeDO:
+		if top := l.topOfNesting(); top != nil && top.awaitingDo {
+			top.awaitingDo = false
+		} else {
+			l.pushNesting(nestingFrame{opener: t.typ})
+		}
+	case tokenTypeNewline, tokenTypeSemicolon:
+		if top := l.topOfNesting(); top != nil {
+			top.awaitingDo = false
+		}
+	case tokenTypeEND:
+		if top := l.topOfNesting(); top != nil && !isBracket(top.opener) {
+			l.popNesting()
+		}
+	}
+}
+
+// the optional `do` of a while, until or for loop separates
+// its condition from its body, just as a newline would
+func (l *ConcreteStatefulRubyLexer) loopAwaitingDo() bool {
+	top := l.topOfNesting()
+	return top != nil && top.awaitingDo
+}
+
+func (l *ConcreteStatefulRubyLexer) pushNesting(frame nestingFrame) {
+	l.nesting = append(l.nesting, frame)
+}
+
+func (l *ConcreteStatefulRubyLexer) popNesting() {
+	if len(l.nesting) > 0 {
+		l.nesting = l.nesting[:len(l.nesting)-1]
+	}
+}
+
+func (l *ConcreteStatefulRubyLexer) topOfNesting() *nestingFrame {
+	if len(l.nesting) == 0 {
+		return nil
+	}
+
+	return &l.nesting[len(l.nesting)-1]
+}
+
+// newlines are insignificant while directly inside parens or brackets,
+// which lets arguments and array literals span several lines.
+// Braces and keyword blocks contain statements, so newlines still separate them.
+func (l *ConcreteStatefulRubyLexer) newlinesAreSignificant() bool {
+	top := l.topOfNesting()
+	return top == nil || (top.opener != tokenTypeLParen && top.opener != tokenTypeLBracket)
+}
+
+func isBracket(t tokenType) bool {
+	return t == tokenTypeLParen || t == tokenTypeLBracket || t == tokenTypeLBrace
+}
@@ -1,6 +1,10 @@
 package parser
 
-import "strings"
+import (
+	"strings"
+
+	"github.com/grubby/grubby/ast"
+)
 
 type nonEmitingLexer struct {
 	lexer  StatefulRubyLexer
@@ -48,7 +52,12 @@ func (l *nonEmitingLexer) acceptRun(valid string) {
 }
 
 func (l *nonEmitingLexer) emit(t tokenType) {
-	l.Tokens = append(l.Tokens, token{typ: t, value: l.lexer.currentSlice()})
+	l.emitToken(token{typ: t, value: l.lexer.currentSlice()})
+}
+
+func (l *nonEmitingLexer) emitToken(t token) {
+	t.pos = l.lexer.startPosition()
+	l.Tokens = append(l.Tokens, t)
 	l.lexer.ignore()
 }
 
@@ -56,6 +65,12 @@ func (l *nonEmitingLexer) lastToken() token {
 	return l.Tokens[len(l.Tokens)-1]
 }
 
+// the non-emiting lexer reads ahead to the end of the current line,
+// so it always needs to see the newline that ends it
+func (l *nonEmitingLexer) newlinesAreSignificant() bool {
+	return true
+}
+
 func (l *nonEmitingLexer) slice(start, end int) string {
 	return l.lexer.slice(start, end)
 }
@@ -80,6 +95,10 @@ func (l *nonEmitingLexer) startIndex() int {
 	return l.lexer.startIndex()
 }
 
+func (l *nonEmitingLexer) startPosition() ast.Position {
+	return l.lexer.startPosition()
+}
+
 func (l *nonEmitingLexer) currentIndex() int {
 	return l.lexer.currentIndex()
 }
@@ -1,21 +1,120 @@
 package parser
 
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
 const digits = "0123456789"
 
+// the digits of every radix a prefixed integer literal can use. Whether the
+// digits are valid for the literal's radix is checked when it is converted.
+const radixDigits = "0123456789abcdefABCDEF_"
+
 func lexNumber(l StatefulRubyLexer) stateFn {
-	l.acceptRun(digits)
+	if l.accept("0") && l.accept("xXoObBdD") {
+		l.acceptRun(radixDigits)
+		l.emit(tokenTypeInteger)
+		return lexSomething
+	}
+
+	l.acceptRun(digits + "_")
+
+	// a dot only begins a fraction when a digit follows it, as in 1.5,
+	// otherwise it is a method call like 5.times
+	isFloat := false
 	if l.accept(".") {
 		if l.accept(digits) {
-			l.acceptRun(digits)
-			l.emit(tokenTypeFloat)
-			return lexSomething
+			l.acceptRun(digits + "_")
+			isFloat = true
 		} else {
 			l.backup()
-			l.emit(tokenTypeInteger)
-			return lexSomething
 		}
 	}
 
-	l.emit(tokenTypeInteger)
+	if acceptExponent(l) {
+		isFloat = true
+	}
+
+	if isFloat {
+		l.emit(tokenTypeFloat)
+	} else {
+		l.emit(tokenTypeInteger)
+	}
+
 	return lexSomething
 }
+
+// accepts the exponent of a float like 1e10 or 2.5e-3, leaving the
+// position untouched when there is no exponent
+func acceptExponent(l StatefulRubyLexer) bool {
+	start := l.currentIndex()
+	if !l.accept("eE") {
+		return false
+	}
+
+	l.accept("+-")
+	if !l.accept(digits) {
+		l.setCurrentPositionIndex(start)
+		return false
+	}
+
+	l.acceptRun(digits + "_")
+	return true
+}
+
+// converts an integer literal such as 1_000, 0xff, 0o755, 0b1010 or 0d99
+// to its value. Underscores may only appear between two digits.
+func parseIntegerLiteral(literal string) (int, error) {
+	base, body := 10, literal
+	if len(literal) > 1 && literal[0] == '0' {
+		switch literal[1] {
+		case 'x', 'X':
+			base, body = 16, literal[2:]
+		case 'o', 'O':
+			base, body = 8, literal[2:]
+		case 'b', 'B':
+			base, body = 2, literal[2:]
+		case 'd', 'D':
+			base, body = 10, literal[2:]
+		}
+	}
+
+	if body == "" {
+		return 0, errors.New(fmt.Sprintf("numeric literal without digits: '%s'", literal))
+	}
+
+	if strings.HasPrefix(body, "_") || strings.HasSuffix(body, "_") || strings.Contains(body, "__") {
+		return 0, errors.New(fmt.Sprintf("misplaced '_' in number: '%s'", literal))
+	}
+
+	value, err := strconv.ParseInt(strings.Replace(body, "_", "", -1), base, 64)
+	if err != nil {
+		return 0, errors.New(fmt.Sprintf("invalid numeric literal: '%s'", literal))
+	}
+
+	return int(value), nil
+}
+
+// converts a float literal such as 1_234.567_8 or 6.022E23 to its value.
+// Underscores may only appear between two digits.
+func parseFloatLiteral(literal string) (float64, error) {
+	for i, r := range literal {
+		if r != '_' {
+			continue
+		}
+
+		if i == 0 || i == len(literal)-1 || !strings.ContainsRune(digits, rune(literal[i-1])) || !strings.ContainsRune(digits, rune(literal[i+1])) {
+			return 0, errors.New(fmt.Sprintf("misplaced '_' in number: '%s'", literal))
+		}
+	}
+
+	value, err := strconv.ParseFloat(strings.Replace(literal, "_", "", -1), 64)
+	if err != nil {
+		return 0, errors.New(fmt.Sprintf("invalid numeric literal: '%s'", literal))
+	}
+
+	return value, nil
+}
@@ -1,9 +1,13 @@
+// Code generated by goyacc -o parser.go -p Ruby parser.y. DO NOT EDIT.
+
 //line parser.y:2
+
 package parser
 
 import __yyfmt__ "fmt"
 
 //line parser.y:3
+
 import (
 	"github.com/grubby/grubby/ast"
 	"strings"
@@ -13,91 +17,112 @@ var Statements []ast.Node
 
 //line parser.y:16
 type RubySymType struct {
-	yys             int
-	operator        string
-	genericBlock    ast.Block
-	genericValue    ast.Node
-	genericSlice    ast.Nodes
-	stringSlice     []string
-	switchCaseSlice []ast.SwitchCase
+	yys              int
+	operator         string
+	genericBlock     ast.Block
+	genericValue     ast.Node
+	genericSlice     ast.Nodes
+	stringSlice      []string
+	switchCaseSlice  []ast.SwitchCase
+	patternCaseSlice []ast.PatternCase
+	hashPatternPairs []ast.HashPatternPair
+
+	// where the first token of a symbol started, which goyacc carries over
+	// from $1 to $$ before running each action
+	pos ast.Position
 }
 
 const OPERATOR = 57346
-const NODE = 57347
-const REF = 57348
-const SYMBOL = 57349
-const SPECIAL_CHAR_REF = 57350
-const CAPITAL_REF = 57351
-const LPAREN = 57352
-const RPAREN = 57353
-const COMMA = 57354
-const NamespacedModule = 57355
-const ProcArg = 57356
-const DO = 57357
-const DEF = 57358
-const END = 57359
-const IF = 57360
-const ELSE = 57361
-const ELSIF = 57362
-const UNLESS = 57363
-const CLASS = 57364
-const MODULE = 57365
-const FOR = 57366
-const WHILE = 57367
-const UNTIL = 57368
-const BEGIN = 57369
-const RESCUE = 57370
-const ENSURE = 57371
-const BREAK = 57372
-const NEXT = 57373
-const REDO = 57374
-const RETRY = 57375
-const RETURN = 57376
-const YIELD = 57377
-const AND = 57378
-const OR = 57379
-const LAMBDA = 57380
-const CASE = 57381
-const WHEN = 57382
-const ALIAS = 57383
-const SELF = 57384
-const NIL = 57385
-const TRUE = 57386
-const FALSE = 57387
-const LESSTHAN = 57388
-const GREATERTHAN = 57389
-const EQUALTO = 57390
-const BANG = 57391
-const COMPLEMENT = 57392
-const BINARY_PLUS = 57393
-const UNARY_PLUS = 57394
-const BINARY_MINUS = 57395
-const UNARY_MINUS = 57396
-const STAR = 57397
-const RANGE = 57398
-const OR_EQUALS = 57399
-const WHITESPACE = 57400
-const NEWLINE = 57401
-const SEMICOLON = 57402
-const COLON = 57403
-const DOT = 57404
-const PIPE = 57405
-const SLASH = 57406
-const AMPERSAND = 57407
-const QUESTIONMARK = 57408
-const CARET = 57409
-const LBRACKET = 57410
-const RBRACKET = 57411
-const LBRACE = 57412
-const RBRACE = 57413
-const DOLLARSIGN = 57414
-const ATSIGN = 57415
-const FILE_CONST_REF = 57416
-const LINE_CONST_REF = 57417
-const EOF = 57418
-
-var RubyToknames = []string{
+const POW = 57347
+const HASHROCKET = 57348
+const NODE = 57349
+const REF = 57350
+const SYMBOL = 57351
+const SPECIAL_CHAR_REF = 57352
+const CAPITAL_REF = 57353
+const LPAREN = 57354
+const RPAREN = 57355
+const COMMA = 57356
+const NamespacedModule = 57357
+const ProcArg = 57358
+const DO = 57359
+const DEF = 57360
+const END = 57361
+const IF = 57362
+const ELSE = 57363
+const ELSIF = 57364
+const UNLESS = 57365
+const CLASS = 57366
+const MODULE = 57367
+const FOR = 57368
+const WHILE = 57369
+const UNTIL = 57370
+const BEGIN = 57371
+const RESCUE = 57372
+const ENSURE = 57373
+const BREAK = 57374
+const NEXT = 57375
+const REDO = 57376
+const RETRY = 57377
+const RETURN = 57378
+const YIELD = 57379
+const DEFINED = 57380
+const AND = 57381
+const OR = 57382
+const LAMBDA = 57383
+const CASE = 57384
+const WHEN = 57385
+const IN = 57386
+const ALIAS = 57387
+const SELF = 57388
+const NIL = 57389
+const TRUE = 57390
+const FALSE = 57391
+const LESSTHAN = 57392
+const GREATERTHAN = 57393
+const EQUALTO = 57394
+const BANG = 57395
+const COMPLEMENT = 57396
+const BINARY_PLUS = 57397
+const UNARY_PLUS = 57398
+const BINARY_MINUS = 57399
+const UNARY_MINUS = 57400
+const UNARY_MINUS_NUM = 57401
+const STAR = 57402
+const DOUBLESTAR = 57403
+const RANGE = 57404
+const EXCLUSIVE_RANGE = 57405
+const OR_EQUALS = 57406
+const WHITESPACE = 57407
+const NEWLINE = 57408
+const SEMICOLON = 57409
+const COLON = 57410
+const DOT = 57411
+const SAFE_NAV = 57412
+const PIPE = 57413
+const SLASH = 57414
+const AMPERSAND = 57415
+const QUESTIONMARK = 57416
+const CARET = 57417
+const LBRACKET = 57418
+const RBRACKET = 57419
+const LBRACE = 57420
+const RBRACE = 57421
+const DOLLARSIGN = 57422
+const ATSIGN = 57423
+const FILE_CONST_REF = 57424
+const LINE_CONST_REF = 57425
+const DIR_CONST_REF = 57426
+const METHOD_CONST_REF = 57427
+const EOF = 57428
+
+var RubyToknames = [...]string{
+	"$end",
+	"error",
+	"$unk",
 	"OPERATOR",
+	"POW",
+	"HASHROCKET",
 	"NODE",
 	"REF",
 	"SYMBOL",
@@ -129,11 +154,13 @@ var RubyToknames = []string{
 	"RETRY",
 	"RETURN",
 	"YIELD",
+	"DEFINED",
 	"AND",
 	"OR",
 	"LAMBDA",
 	"CASE",
 	"WHEN",
+	"IN",
 	"ALIAS",
 	"SELF",
 	"NIL",
@@ -148,14 +175,18 @@ var RubyToknames = []string{
 	"UNARY_PLUS",
 	"BINARY_MINUS",
 	"UNARY_MINUS",
+	"UNARY_MINUS_NUM",
 	"STAR",
+	"DOUBLESTAR",
 	"RANGE",
+	"EXCLUSIVE_RANGE",
 	"OR_EQUALS",
 	"WHITESPACE",
 	"NEWLINE",
 	"SEMICOLON",
 	"COLON",
 	"DOT",
+	"SAFE_NAV",
 	"PIPE",
 	"SLASH",
 	"AMPERSAND",
@@ -169,780 +200,1107 @@ var RubyToknames = []string{
 	"ATSIGN",
 	"FILE_CONST_REF",
 	"LINE_CONST_REF",
+	"DIR_CONST_REF",
+	"METHOD_CONST_REF",
 	"EOF",
 }
-var RubyStatenames = []string{}
+
+var RubyStatenames = [...]string{}
 
 const RubyEofCode = 1
 const RubyErrCode = 2
-const RubyMaxDepth = 200
+const RubyInitialStackSize = 16
 
-//line parser.y:1459
+//line parser.y:1903
 
 //line yacctab:1
-var RubyExca = []int{
+var RubyExca = [...]int16{
 	-1, 1,
 	1, -1,
 	-2, 0,
-	-1, 135,
-	11, 117,
-	12, 117,
-	-2, 246,
-	-1, 331,
-	4, 21,
-	36, 21,
-	37, 21,
-	46, 21,
-	47, 21,
-	51, 21,
-	53, 21,
-	62, 21,
-	63, 21,
-	64, 21,
-	65, 21,
-	-2, 117,
-	-1, 342,
-	11, 117,
-	12, 117,
-	-2, 246,
-	-1, 380,
-	4, 36,
-	36, 36,
-	37, 36,
-	47, 36,
-	51, 36,
-	53, 36,
-	59, 13,
-	62, 36,
-	63, 36,
-	64, 36,
-	65, 36,
-	71, 13,
-	-2, 15,
+	-1, 150,
+	13, 135,
+	14, 135,
+	-2, 300,
+	-1, 387,
+	4, 19,
+	5, 19,
+	14, 19,
+	39, 19,
+	40, 19,
+	50, 19,
+	51, 19,
+	55, 19,
+	57, 19,
+	66, 19,
+	69, 19,
+	70, 19,
+	71, 19,
+	72, 19,
+	73, 19,
+	77, 19,
+	79, 19,
+	-2, 135,
+	-1, 392,
+	14, 135,
+	-2, 19,
+	-1, 402,
+	13, 135,
+	14, 135,
+	-2, 300,
+	-1, 448,
+	4, 37,
+	5, 37,
+	6, 37,
+	39, 37,
+	40, 37,
+	51, 37,
+	55, 37,
+	57, 37,
+	66, 11,
+	69, 37,
+	70, 37,
+	71, 37,
+	72, 37,
+	73, 37,
+	79, 11,
+	-2, 13,
 }
 
-const RubyNprod = 302
 const RubyPrivate = 57344
 
-var RubyTokenNames []string
-var RubyStates []string
-
-const RubyLast = 4384
-
-var RubyAct = []int{
-
-	309, 33, 5, 561, 426, 393, 148, 177, 241, 137,
-	239, 138, 427, 316, 136, 55, 25, 315, 102, 194,
-	403, 103, 2, 3, 144, 104, 297, 237, 379, 537,
-	315, 290, 284, 28, 205, 315, 26, 206, 143, 4,
-	264, 315, 505, 315, 315, 122, 14, 503, 255, 131,
-	134, 144, 384, 487, 483, 485, 369, 174, 175, 100,
-	99, 184, 185, 156, 164, 536, 158, 123, 271, 391,
-	156, 188, 162, 158, 125, 300, 101, 126, 349, 199,
-	293, 287, 187, 200, 201, 156, 390, 161, 158, 267,
-	447, 198, 161, 93, 349, 349, 147, 195, 93, 93,
-	162, 207, 122, 210, 211, 212, 199, 93, 159, 163,
-	245, 127, 219, 124, 155, 549, 315, 224, 443, 530,
-	161, 157, 229, 159, 123, 233, 234, 235, 157, 217,
-	74, 317, 160, 168, 442, 385, 454, 242, 226, 227,
-	453, 446, 169, 157, 424, 244, 253, 251, 254, 51,
-	315, 370, 348, 356, 442, 168, 165, 260, 246, 315,
-	279, 280, 231, 282, 283, 272, 288, 289, 275, 294,
-	295, 296, 315, 270, 261, 263, 257, 129, 258, 173,
-	102, 242, 147, 103, 248, 240, 243, 104, 301, 244,
-	318, 319, 320, 321, 277, 167, 278, 147, 333, 152,
-	121, 326, 171, 147, 491, 366, 314, 102, 332, 181,
-	103, 158, 181, 181, 104, 172, 130, 443, 128, 336,
-	102, 544, 545, 103, 339, 102, 147, 104, 103, 340,
-	243, 165, 104, 311, 181, 181, 181, 238, 170, 415,
-	166, 325, 398, 147, 399, 478, 351, 479, 305, 306,
-	362, 355, 413, 401, 97, 181, 191, 181, 181, 192,
-	181, 543, 181, 181, 181, 181, 313, 181, 408, 551,
-	181, 535, 181, 181, 102, 367, 406, 103, 407, 322,
-	401, 104, 181, 550, 497, 152, 493, 196, 372, 181,
-	181, 181, 265, 189, 401, 401, 190, 401, 133, 408,
-	152, 102, 78, 411, 103, 181, 152, 181, 104, 173,
-	257, 181, 258, 354, 285, 312, 268, 291, 568, 430,
-	354, 298, 242, 400, 102, 409, 240, 103, 405, 152,
-	244, 104, 517, 337, 404, 576, 338, 573, 572, 193,
-	518, 540, 421, 410, 152, 181, 152, 519, 412, 414,
-	420, 502, 501, 571, 422, 573, 572, 514, 389, 459,
-	458, 418, 255, 429, 181, 434, 457, 181, 459, 458,
-	388, 243, 382, 255, 441, 437, 181, 181, 147, 444,
-	387, 428, 377, 468, 371, 132, 438, 432, 133, 147,
-	133, 567, 78, 359, 78, 460, 365, 366, 448, 358,
-	450, 357, 463, 469, 473, 473, 208, 353, 303, 209,
-	302, 236, 214, 323, 440, 467, 481, 489, 181, 329,
-	376, 310, 181, 181, 328, 492, 1, 197, 92, 91,
-	90, 484, 89, 486, 88, 488, 217, 87, 41, 494,
-	40, 39, 38, 500, 54, 499, 474, 494, 20, 43,
-	44, 21, 16, 12, 13, 508, 11, 45, 24, 511,
-	23, 181, 22, 27, 19, 10, 35, 181, 30, 18,
-	15, 506, 42, 507, 17, 37, 520, 521, 36, 31,
-	29, 152, 71, 32, 70, 440, 181, 75, 0, 0,
-	0, 528, 152, 0, 0, 181, 0, 0, 0, 181,
-	532, 534, 525, 0, 0, 0, 181, 0, 538, 0,
-	0, 0, 0, 0, 0, 0, 0, 152, 0, 0,
-	541, 0, 0, 0, 0, 0, 0, 0, 0, 0,
-	0, 527, 0, 52, 0, 0, 0, 0, 0, 0,
-	181, 181, 0, 0, 547, 494, 0, 494, 0, 557,
-	0, 0, 0, 0, 0, 473, 473, 473, 181, 565,
-	0, 0, 0, 574, 554, 555, 556, 181, 217, 0,
-	0, 0, 0, 578, 0, 0, 473, 0, 569, 0,
-	473, 473, 473, 153, 575, 0, 0, 0, 152, 0,
-	577, 0, 0, 182, 579, 580, 182, 182, 0, 581,
+const RubyLast = 6764
+
+var RubyAct = [...]int16{
+	40, 754, 482, 670, 572, 62, 671, 510, 444, 275,
+	203, 65, 15, 167, 486, 44, 470, 213, 166, 151,
+	163, 276, 126, 131, 168, 2, 3, 512, 271, 722,
+	153, 770, 29, 152, 447, 228, 158, 458, 229, 171,
+	343, 336, 23, 19, 32, 4, 330, 675, 146, 149,
+	208, 162, 432, 369, 208, 208, 693, 136, 137, 208,
+	208, 369, 466, 369, 305, 217, 695, 198, 124, 125,
+	199, 369, 369, 127, 694, 128, 635, 159, 129, 292,
+	138, 139, 159, 164, 632, 630, 719, 122, 123, 133,
+	130, 132, 346, 339, 721, 553, 369, 195, 333, 208,
+	208, 195, 208, 543, 434, 465, 369, 369, 230, 609,
+	208, 197, 185, 78, 78, 312, 308, 605, 673, 78,
+	607, 434, 208, 196, 182, 208, 208, 196, 208, 237,
+	208, 208, 281, 208, 208, 183, 208, 78, 238, 208,
+	208, 369, 227, 238, 761, 541, 723, 434, 208, 718,
+	183, 171, 636, 131, 371, 208, 208, 208, 306, 182,
+	542, 174, 184, 162, 82, 596, 81, 467, 716, 551,
+	171, 730, 282, 745, 182, 208, 208, 369, 171, 208,
+	535, 288, 162, 208, 459, 290, 331, 291, 297, 337,
+	162, 295, 208, 344, 311, 164, 208, 208, 300, 301,
+	298, 304, 540, 112, 113, 110, 111, 171, 321, 347,
+	433, 369, 536, 177, 164, 324, 179, 122, 123, 162,
+	371, 488, 164, 126, 131, 188, 744, 369, 666, 667,
+	617, 370, 189, 351, 171, 171, 208, 79, 78, 86,
+	85, 87, 88, 369, 190, 177, 162, 385, 179, 390,
+	535, 164, 559, 208, 208, 386, 555, 208, 136, 137,
+	389, 194, 208, 186, 382, 554, 417, 208, 208, 124,
+	125, 285, 400, 404, 127, 187, 128, 178, 164, 129,
+	177, 138, 139, 179, 415, 180, 399, 405, 122, 123,
+	133, 130, 132, 423, 148, 192, 457, 425, 93, 193,
+	82, 596, 81, 208, 597, 189, 186, 277, 93, 178,
+	208, 283, 140, 277, 171, 280, 89, 519, 208, 208,
+	180, 280, 587, 179, 588, 148, 449, 390, 439, 93,
+	442, 536, 181, 191, 585, 363, 586, 366, 389, 112,
+	113, 110, 111, 575, 178, 144, 145, 589, 117, 141,
+	316, 118, 142, 672, 295, 119, 120, 518, 491, 278,
+	279, 489, 315, 490, 208, 278, 279, 367, 483, 599,
+	668, 600, 208, 79, 78, 86, 85, 87, 88, 499,
+	208, 726, 492, 691, 416, 491, 41, 112, 171, 497,
+	478, 621, 479, 171, 115, 114, 194, 171, 222, 494,
+	162, 223, 479, 171, 277, 162, 629, 366, 274, 449,
+	208, 651, 280, 493, 116, 162, 727, 573, 208, 652,
+	416, 769, 148, 766, 765, 172, 93, 479, 728, 309,
+	521, 171, 164, 506, 504, 208, 209, 164, 515, 692,
+	209, 209, 513, 528, 533, 209, 209, 164, 273, 532,
+	517, 529, 277, 439, 208, 534, 278, 279, 538, 233,
+	280, 378, 525, 544, 208, 272, 208, 208, 226, 117,
+	612, 575, 118, 243, 556, 530, 119, 120, 611, 429,
+	220, 479, 613, 221, 218, 209, 209, 219, 209, 598,
+	566, 574, 208, 760, 592, 590, 209, 522, 416, 752,
+	593, 720, 602, 208, 278, 279, 714, 764, 209, 766,
+	765, 209, 209, 704, 209, 706, 209, 209, 701, 209,
+	209, 475, 209, 476, 147, 209, 209, 171, 604, 620,
+	655, 148, 479, 477, 209, 93, 625, 172, 614, 528,
+	533, 209, 209, 209, 307, 532, 214, 623, 737, 614,
+	626, 534, 628, 484, 464, 646, 172, 562, 561, 462,
+	117, 209, 209, 118, 172, 209, 627, 119, 120, 209,
+	445, 530, 332, 514, 416, 338, 461, 117, 209, 345,
+	118, 436, 209, 209, 119, 120, 560, 660, 562, 561,
+	598, 117, 421, 172, 118, 592, 663, 665, 119, 120,
+	598, 593, 495, 292, 484, 592, 584, 171, 445, 208,
+	117, 593, 214, 118, 456, 292, 445, 119, 120, 680,
+	172, 172, 209, 365, 686, 420, 689, 681, 117, 428,
+	429, 118, 419, 418, 413, 119, 120, 208, 117, 209,
+	209, 118, 379, 209, 657, 119, 120, 656, 209, 380,
+	349, 503, 381, 209, 209, 348, 364, 702, 117, 231,
+	253, 118, 232, 252, 703, 119, 120, 598, 598, 654,
+	397, 383, 715, 717, 707, 708, 571, 443, 362, 1,
+	236, 108, 107, 106, 357, 358, 105, 104, 103, 209,
+	102, 72, 71, 70, 208, 69, 209, 43, 580, 21,
+	172, 74, 75, 674, 209, 209, 595, 594, 669, 739,
+	740, 741, 591, 487, 614, 24, 17, 614, 13, 14,
+	598, 12, 76, 743, 598, 592, 28, 27, 26, 592,
+	746, 593, 25, 31, 77, 593, 22, 20, 11, 143,
+	66, 34, 758, 16, 73, 18, 68, 67, 63, 33,
+	209, 84, 64, 83, 9, 90, 0, 0, 209, 768,
+	0, 0, 598, 0, 0, 0, 209, 592, 771, 773,
+	774, 0, 42, 593, 172, 775, 0, 0, 0, 172,
+	0, 0, 0, 172, 0, 0, 0, 0, 0, 172,
+	0, 0, 0, 0, 0, 0, 209, 0, 126, 131,
+	314, 0, 0, 0, 209, 0, 0, 0, 0, 0,
+	0, 173, 0, 0, 0, 0, 0, 172, 0, 0,
+	0, 209, 210, 0, 0, 0, 210, 210, 0, 0,
+	0, 210, 210, 136, 137, 0, 0, 0, 0, 0,
+	209, 0, 0, 0, 124, 125, 0, 0, 0, 127,
+	209, 128, 209, 209, 129, 0, 138, 139, 0, 0,
+	0, 0, 0, 122, 123, 133, 130, 132, 135, 0,
+	0, 210, 210, 0, 210, 209, 0, 0, 209, 0,
+	0, 0, 210, 0, 0, 0, 0, 0, 0, 209,
+	0, 0, 0, 0, 210, 0, 0, 210, 210, 0,
+	210, 0, 210, 210, 0, 210, 210, 0, 210, 0,
+	0, 210, 210, 172, 0, 0, 0, 0, 0, 0,
+	210, 0, 30, 173, 0, 0, 0, 210, 210, 210,
 	0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
-	0, 0, 0, 0, 0, 0, 0, 0, 182, 182,
-	182, 0, 0, 0, 0, 0, 0, 0, 0, 0,
-	0, 0, 0, 0, 152, 0, 181, 0, 0, 182,
-	0, 182, 182, 0, 182, 0, 182, 182, 182, 182,
-	0, 182, 0, 0, 182, 0, 182, 182, 0, 34,
-	0, 0, 0, 0, 0, 0, 182, 0, 0, 153,
-	0, 0, 0, 182, 182, 182, 266, 0, 0, 0,
-	0, 0, 0, 0, 153, 0, 181, 0, 0, 182,
-	153, 182, 0, 0, 0, 182, 0, 0, 286, 181,
-	0, 292, 0, 0, 0, 299, 0, 0, 0, 149,
-	0, 0, 0, 153, 0, 0, 0, 0, 0, 149,
-	0, 0, 149, 149, 0, 0, 0, 0, 153, 182,
-	153, 0, 0, 0, 0, 0, 0, 0, 0, 0,
-	0, 0, 0, 0, 149, 149, 149, 0, 182, 0,
-	0, 182, 0, 0, 0, 0, 0, 0, 0, 0,
-	182, 182, 0, 0, 0, 149, 0, 149, 149, 0,
-	149, 109, 149, 149, 149, 149, 0, 149, 0, 0,
-	149, 0, 149, 149, 0, 0, 0, 0, 0, 0,
-	0, 0, 149, 0, 0, 149, 0, 0, 0, 149,
-	149, 149, 182, 118, 119, 0, 182, 182, 0, 0,
-	149, 0, 0, 107, 108, 149, 149, 149, 110, 0,
-	111, 149, 112, 120, 0, 0, 0, 0, 0, 106,
-	115, 113, 114, 0, 0, 0, 452, 0, 0, 149,
-	0, 0, 0, 0, 0, 182, 0, 0, 0, 0,
-	0, 182, 0, 0, 149, 149, 149, 0, 0, 0,
-	0, 109, 0, 0, 0, 153, 0, 0, 0, 0,
-	182, 0, 0, 0, 149, 0, 153, 149, 0, 182,
-	0, 0, 0, 182, 0, 0, 149, 149, 0, 0,
-	182, 0, 0, 118, 119, 0, 0, 0, 0, 0,
-	0, 153, 0, 107, 108, 0, 0, 0, 110, 0,
-	111, 0, 112, 120, 0, 0, 0, 0, 0, 106,
-	115, 113, 114, 0, 182, 182, 383, 0, 149, 0,
-	0, 0, 380, 149, 0, 0, 0, 0, 0, 0,
-	0, 0, 182, 0, 0, 69, 150, 68, 79, 151,
-	135, 182, 142, 78, 155, 144, 0, 0, 0, 0,
+	0, 0, 173, 0, 0, 0, 0, 210, 210, 0,
+	173, 210, 0, 0, 0, 210, 0, 0, 0, 0,
+	0, 165, 0, 0, 210, 0, 0, 0, 210, 210,
+	0, 0, 205, 0, 0, 0, 209, 205, 0, 173,
+	0, 0, 0, 0, 0, 0, 209, 0, 0, 0,
+	0, 0, 0, 172, 0, 209, 361, 0, 5, 0,
+	0, 0, 0, 0, 0, 0, 173, 173, 210, 0,
 	0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
-	0, 149, 153, 9, 0, 0, 0, 149, 81, 0,
-	0, 0, 97, 98, 95, 96, 0, 0, 140, 82,
-	83, 149, 84, 0, 85, 86, 380, 141, 0, 0,
-	0, 0, 149, 0, 0, 149, 109, 0, 139, 149,
-	145, 0, 94, 93, 73, 72, 149, 0, 153, 0,
-	182, 0, 0, 146, 0, 0, 0, 149, 0, 0,
-	0, 0, 0, 178, 0, 0, 186, 178, 118, 119,
-	0, 0, 0, 0, 0, 0, 0, 0, 107, 108,
-	149, 149, 0, 110, 0, 111, 0, 112, 202, 203,
-	204, 0, 0, 0, 106, 115, 113, 114, 149, 0,
-	182, 559, 0, 0, 0, 0, 0, 149, 0, 213,
-	0, 215, 216, 182, 218, 0, 220, 221, 222, 223,
-	0, 225, 0, 0, 228, 0, 230, 232, 149, 53,
-	0, 0, 0, 0, 0, 0, 249, 0, 0, 252,
-	0, 0, 0, 256, 259, 262, 0, 0, 0, 0,
-	0, 0, 0, 0, 146, 0, 0, 0, 0, 274,
-	252, 276, 0, 304, 0, 281, 0, 0, 0, 0,
-	0, 0, 0, 0, 149, 0, 149, 0, 0, 154,
-	0, 0, 0, 146, 0, 0, 0, 0, 0, 183,
-	0, 0, 183, 183, 0, 0, 0, 0, 324, 330,
-	252, 0, 0, 0, 0, 0, 0, 0, 0, 0,
-	0, 0, 0, 0, 183, 183, 183, 0, 343, 0,
-	0, 344, 176, 0, 0, 0, 149, 0, 0, 0,
-	346, 347, 0, 0, 0, 183, 0, 183, 183, 149,
-	183, 109, 183, 183, 183, 183, 0, 183, 0, 0,
-	183, 0, 183, 183, 0, 0, 0, 0, 0, 0,
-	0, 0, 183, 0, 0, 154, 0, 0, 0, 183,
-	183, 183, 373, 118, 119, 0, 330, 381, 0, 0,
-	154, 0, 0, 107, 108, 183, 154, 183, 110, 0,
-	111, 183, 112, 120, 0, 247, 0, 0, 250, 106,
-	115, 113, 114, 0, 0, 0, 368, 0, 269, 154,
-	0, 0, 0, 0, 0, 402, 0, 0, 0, 109,
-	0, 178, 0, 0, 154, 183, 154, 0, 0, 0,
-	0, 0, 0, 0, 0, 146, 0, 0, 0, 0,
-	419, 0, 0, 0, 183, 0, 252, 183, 0, 423,
-	0, 118, 119, 373, 0, 0, 183, 183, 0, 0,
-	431, 107, 108, 0, 0, 0, 110, 0, 111, 0,
-	112, 439, 0, 0, 0, 0, 0, 106, 115, 113,
-	114, 0, 0, 0, 510, 0, 0, 0, 0, 0,
-	0, 0, 0, 0, 455, 456, 0, 0, 183, 0,
-	0, 352, 183, 183, 0, 0, 0, 0, 0, 0,
-	360, 0, 178, 363, 0, 69, 150, 68, 79, 151,
-	135, 490, 0, 78, 155, 144, 0, 0, 0, 0,
-	0, 0, 0, 0, 375, 0, 378, 0, 0, 0,
-	0, 183, 439, 0, 0, 0, 0, 183, 81, 0,
-	0, 0, 97, 98, 95, 96, 0, 0, 0, 82,
-	83, 154, 84, 0, 85, 86, 183, 0, 0, 396,
-	397, 417, 154, 0, 0, 183, 109, 0, 273, 183,
-	145, 0, 94, 93, 73, 72, 183, 0, 526, 0,
-	529, 0, 0, 0, 0, 0, 0, 154, 0, 0,
-	378, 0, 0, 0, 0, 0, 0, 0, 118, 119,
-	0, 0, 0, 0, 0, 0, 0, 0, 107, 108,
-	183, 183, 0, 110, 0, 111, 435, 112, 0, 0,
-	0, 0, 0, 0, 106, 115, 113, 114, 183, 0,
-	552, 509, 109, 449, 451, 0, 0, 183, 0, 0,
-	0, 0, 0, 558, 0, 0, 0, 0, 0, 461,
-	0, 0, 0, 465, 0, 466, 0, 0, 154, 0,
-	0, 480, 0, 482, 118, 119, 0, 0, 0, 109,
-	0, 0, 0, 0, 107, 108, 0, 0, 0, 110,
-	0, 111, 495, 112, 0, 0, 496, 0, 0, 0,
-	106, 115, 113, 114, 0, 0, 0, 386, 0, 0,
-	0, 118, 119, 0, 154, 0, 183, 0, 0, 0,
-	0, 107, 108, 512, 513, 0, 110, 0, 111, 0,
-	112, 516, 0, 0, 0, 0, 0, 106, 115, 113,
-	114, 0, 0, 522, 350, 524, 0, 0, 0, 0,
-	0, 0, 0, 0, 0, 0, 69, 49, 68, 79,
-	50, 80, 0, 0, 78, 0, 183, 46, 564, 475,
-	563, 562, 476, 47, 48, 539, 60, 61, 58, 183,
-	0, 64, 65, 542, 66, 63, 59, 0, 0, 81,
-	62, 0, 67, 97, 98, 95, 96, 0, 0, 0,
-	82, 83, 0, 84, 553, 85, 86, 0, 396, 397,
-	471, 472, 0, 0, 0, 0, 0, 0, 0, 76,
-	0, 77, 0, 94, 93, 73, 72, 69, 49, 68,
-	79, 50, 80, 0, 0, 78, 0, 0, 46, 560,
-	475, 563, 562, 476, 47, 48, 0, 60, 61, 58,
-	0, 0, 64, 65, 0, 66, 63, 59, 0, 0,
-	81, 62, 0, 67, 97, 98, 95, 96, 0, 0,
-	0, 82, 83, 0, 84, 0, 85, 86, 0, 0,
-	0, 471, 472, 0, 0, 0, 0, 0, 0, 0,
-	76, 0, 77, 0, 94, 93, 73, 72, 69, 49,
-	68, 79, 50, 80, 0, 0, 78, 0, 0, 46,
-	462, 56, 395, 394, 57, 47, 48, 0, 60, 61,
-	58, 0, 0, 64, 65, 0, 66, 63, 59, 0,
-	0, 81, 62, 0, 67, 97, 98, 95, 96, 0,
-	0, 0, 82, 83, 0, 84, 0, 85, 86, 0,
-	0, 0, 307, 308, 0, 0, 0, 0, 0, 0,
-	0, 76, 0, 77, 0, 94, 93, 73, 72, 69,
-	49, 68, 79, 50, 80, 0, 0, 78, 0, 0,
-	46, 392, 56, 395, 394, 57, 47, 48, 0, 60,
-	61, 58, 0, 0, 64, 65, 0, 66, 63, 59,
-	0, 0, 81, 62, 0, 67, 97, 98, 95, 96,
-	0, 0, 0, 82, 83, 0, 84, 0, 85, 86,
-	0, 0, 0, 307, 308, 0, 0, 0, 0, 0,
-	0, 0, 76, 0, 77, 0, 94, 93, 73, 72,
-	69, 49, 68, 79, 50, 80, 0, 0, 78, 0,
-	0, 46, 533, 56, 0, 0, 57, 47, 48, 0,
-	60, 61, 58, 401, 0, 64, 65, 0, 66, 63,
-	59, 0, 0, 81, 62, 0, 67, 97, 98, 95,
-	96, 0, 0, 0, 82, 83, 0, 84, 0, 85,
-	86, 0, 0, 0, 307, 308, 0, 0, 0, 0,
-	0, 0, 0, 76, 0, 77, 0, 94, 93, 73,
-	72, 69, 49, 68, 79, 50, 80, 0, 0, 78,
-	0, 0, 46, 531, 56, 0, 0, 57, 47, 48,
-	0, 60, 61, 58, 401, 0, 64, 65, 0, 66,
-	63, 59, 0, 0, 81, 62, 0, 67, 97, 98,
-	95, 96, 0, 0, 0, 82, 83, 0, 84, 0,
-	85, 86, 0, 0, 0, 307, 308, 0, 0, 0,
-	0, 0, 0, 0, 76, 0, 77, 0, 94, 93,
-	73, 72, 69, 49, 68, 79, 50, 80, 0, 0,
-	78, 0, 0, 46, 433, 56, 0, 0, 57, 47,
-	48, 0, 60, 61, 58, 401, 0, 64, 65, 0,
-	66, 63, 59, 0, 0, 81, 62, 0, 67, 97,
-	98, 95, 96, 0, 0, 0, 82, 83, 0, 84,
-	0, 85, 86, 0, 0, 0, 307, 308, 0, 0,
-	0, 0, 0, 0, 0, 76, 0, 77, 0, 94,
-	93, 73, 72, 69, 49, 68, 79, 50, 80, 0,
-	0, 78, 0, 0, 46, 425, 56, 0, 0, 57,
-	47, 48, 0, 60, 61, 58, 401, 0, 64, 65,
-	0, 66, 63, 59, 0, 0, 81, 62, 0, 67,
-	97, 98, 95, 96, 0, 0, 0, 82, 83, 0,
-	84, 0, 85, 86, 0, 0, 0, 307, 308, 0,
-	0, 0, 0, 0, 0, 0, 76, 0, 77, 0,
-	94, 93, 73, 72, 69, 49, 68, 79, 50, 80,
-	0, 0, 78, 0, 0, 46, 0, 56, 0, 0,
-	57, 47, 48, 0, 60, 61, 58, 0, 0, 64,
-	65, 0, 66, 63, 59, 0, 0, 81, 62, 0,
-	67, 97, 98, 95, 96, 0, 0, 0, 82, 83,
-	0, 84, 0, 85, 86, 0, 0, 0, 6, 7,
-	0, 0, 0, 0, 0, 0, 0, 76, 0, 77,
-	0, 94, 93, 73, 72, 8, 69, 49, 68, 79,
-	50, 80, 0, 0, 78, 0, 0, 46, 566, 475,
-	0, 0, 476, 47, 48, 0, 60, 61, 58, 0,
-	0, 64, 65, 0, 66, 63, 59, 0, 0, 81,
-	62, 0, 67, 97, 98, 95, 96, 0, 0, 0,
-	82, 83, 0, 84, 0, 85, 86, 0, 0, 0,
-	471, 472, 0, 0, 0, 0, 0, 0, 0, 76,
-	0, 77, 0, 94, 93, 73, 72, 69, 49, 68,
-	79, 50, 80, 0, 0, 78, 0, 0, 46, 546,
-	56, 0, 0, 57, 47, 48, 0, 60, 61, 58,
-	0, 0, 64, 65, 0, 66, 63, 59, 0, 0,
-	81, 62, 0, 67, 97, 98, 95, 96, 0, 0,
-	0, 82, 83, 0, 84, 0, 85, 86, 0, 0,
-	0, 307, 308, 0, 0, 0, 0, 0, 0, 0,
-	76, 0, 77, 0, 94, 93, 73, 72, 69, 49,
-	68, 79, 50, 80, 0, 0, 78, 0, 0, 46,
-	523, 56, 0, 0, 57, 47, 48, 0, 60, 61,
-	58, 0, 0, 64, 65, 0, 66, 63, 59, 0,
-	0, 81, 62, 0, 67, 97, 98, 95, 96, 0,
-	0, 0, 82, 83, 0, 84, 0, 85, 86, 0,
-	0, 0, 307, 308, 0, 0, 0, 0, 0, 0,
-	0, 76, 0, 77, 0, 94, 93, 73, 72, 69,
-	49, 68, 79, 50, 80, 0, 0, 78, 0, 0,
-	46, 515, 56, 0, 0, 57, 47, 48, 0, 60,
-	61, 58, 0, 0, 64, 65, 0, 66, 63, 59,
-	0, 0, 81, 62, 0, 67, 97, 98, 95, 96,
-	0, 0, 0, 82, 83, 0, 84, 0, 85, 86,
-	0, 0, 0, 307, 308, 0, 0, 0, 0, 0,
-	0, 0, 76, 0, 77, 0, 94, 93, 73, 72,
-	69, 49, 68, 79, 50, 80, 0, 0, 78, 0,
-	0, 46, 0, 56, 0, 0, 57, 47, 48, 0,
-	60, 61, 58, 0, 0, 64, 65, 0, 66, 63,
-	59, 0, 0, 81, 62, 0, 67, 97, 98, 95,
-	96, 0, 0, 0, 82, 83, 0, 84, 0, 85,
-	86, 0, 0, 0, 307, 308, 0, 0, 0, 0,
-	0, 0, 0, 76, 0, 77, 504, 94, 93, 73,
-	72, 69, 49, 68, 79, 50, 80, 0, 0, 78,
-	0, 0, 46, 498, 56, 0, 0, 57, 47, 48,
-	0, 60, 61, 58, 0, 0, 64, 65, 0, 66,
-	63, 59, 0, 0, 81, 62, 0, 67, 97, 98,
-	95, 96, 0, 0, 0, 82, 83, 0, 84, 0,
-	85, 86, 0, 0, 0, 307, 308, 0, 0, 0,
-	0, 0, 0, 0, 76, 0, 77, 0, 94, 93,
-	73, 72, 69, 49, 68, 79, 50, 80, 0, 0,
-	78, 0, 0, 46, 477, 475, 0, 0, 476, 47,
-	48, 0, 60, 61, 58, 0, 0, 64, 65, 0,
-	66, 63, 59, 0, 0, 81, 62, 0, 67, 97,
-	98, 95, 96, 0, 0, 0, 82, 83, 0, 84,
-	0, 85, 86, 0, 0, 0, 471, 472, 0, 0,
-	0, 0, 0, 0, 0, 76, 0, 77, 0, 94,
-	93, 73, 72, 69, 49, 68, 79, 50, 80, 0,
-	0, 78, 0, 0, 46, 470, 475, 0, 0, 476,
-	47, 48, 0, 60, 61, 58, 0, 0, 64, 65,
-	0, 66, 63, 59, 0, 0, 81, 62, 0, 67,
-	97, 98, 95, 96, 0, 0, 0, 82, 83, 0,
-	84, 0, 85, 86, 0, 0, 0, 471, 472, 0,
-	0, 0, 0, 0, 0, 0, 76, 0, 77, 0,
-	94, 93, 73, 72, 69, 49, 68, 79, 50, 80,
-	0, 0, 78, 0, 0, 46, 464, 56, 0, 0,
-	57, 47, 48, 0, 60, 61, 58, 0, 0, 64,
-	65, 0, 66, 63, 59, 0, 0, 81, 62, 0,
-	67, 97, 98, 95, 96, 0, 0, 0, 82, 83,
-	0, 84, 0, 85, 86, 0, 0, 0, 307, 308,
-	0, 0, 0, 0, 0, 0, 0, 76, 0, 77,
-	0, 94, 93, 73, 72, 69, 49, 68, 79, 50,
-	80, 0, 0, 78, 0, 0, 46, 445, 56, 0,
-	0, 57, 47, 48, 0, 60, 61, 58, 0, 0,
-	64, 65, 0, 66, 63, 59, 0, 0, 81, 62,
-	0, 67, 97, 98, 95, 96, 0, 0, 0, 82,
-	83, 0, 84, 0, 85, 86, 0, 0, 0, 307,
-	308, 0, 0, 0, 0, 0, 0, 0, 76, 0,
-	77, 0, 94, 93, 73, 72, 69, 49, 68, 79,
-	50, 80, 0, 0, 78, 0, 0, 46, 436, 56,
-	0, 0, 57, 47, 48, 0, 60, 61, 58, 0,
-	0, 64, 65, 0, 66, 63, 59, 0, 0, 81,
-	62, 0, 67, 97, 98, 95, 96, 0, 0, 0,
-	82, 83, 0, 84, 0, 85, 86, 0, 0, 0,
-	307, 308, 0, 0, 0, 0, 0, 0, 0, 76,
-	0, 77, 0, 94, 93, 73, 72, 69, 49, 68,
-	79, 50, 80, 0, 0, 78, 0, 0, 46, 374,
-	56, 0, 0, 57, 47, 48, 0, 60, 61, 58,
-	0, 0, 64, 65, 0, 66, 63, 59, 0, 0,
-	81, 62, 0, 67, 97, 98, 95, 96, 0, 0,
-	0, 82, 83, 0, 84, 0, 85, 86, 0, 0,
-	0, 307, 308, 0, 0, 0, 0, 0, 0, 0,
-	76, 0, 77, 0, 94, 93, 73, 72, 69, 49,
-	68, 79, 50, 80, 0, 0, 78, 0, 0, 46,
-	364, 56, 0, 0, 57, 47, 48, 0, 60, 61,
-	58, 0, 0, 64, 65, 0, 66, 63, 59, 0,
-	0, 81, 62, 0, 67, 97, 98, 95, 96, 0,
-	0, 0, 82, 83, 0, 84, 0, 85, 86, 0,
-	0, 0, 307, 308, 0, 0, 0, 0, 0, 0,
-	0, 76, 0, 77, 0, 94, 93, 73, 72, 69,
-	49, 68, 79, 50, 80, 0, 0, 78, 0, 0,
-	46, 361, 56, 0, 0, 57, 47, 48, 0, 60,
-	61, 58, 0, 0, 64, 65, 0, 66, 63, 59,
-	0, 0, 81, 62, 0, 67, 97, 98, 95, 96,
-	0, 0, 0, 82, 83, 0, 84, 0, 85, 86,
-	0, 0, 0, 307, 308, 0, 0, 0, 0, 0,
-	0, 0, 76, 0, 77, 0, 94, 93, 73, 72,
-	69, 49, 68, 79, 50, 80, 0, 0, 78, 0,
-	0, 46, 0, 475, 0, 0, 476, 47, 48, 0,
-	60, 61, 58, 0, 0, 64, 65, 0, 66, 63,
-	59, 0, 0, 81, 62, 0, 67, 97, 98, 95,
-	96, 0, 0, 0, 82, 83, 0, 84, 0, 85,
-	86, 0, 0, 0, 471, 472, 0, 0, 0, 0,
-	0, 0, 0, 76, 0, 77, 0, 94, 93, 73,
-	72, 69, 49, 68, 79, 50, 80, 0, 0, 78,
-	0, 0, 46, 0, 56, 0, 0, 57, 47, 48,
-	0, 60, 61, 58, 0, 0, 64, 65, 0, 66,
-	63, 59, 0, 0, 81, 62, 0, 67, 97, 98,
-	95, 96, 0, 0, 0, 82, 83, 0, 84, 0,
-	85, 86, 0, 0, 0, 307, 308, 0, 0, 0,
-	0, 0, 0, 0, 76, 0, 77, 0, 94, 93,
-	73, 72, 69, 49, 68, 79, 50, 80, 335, 0,
-	78, 0, 0, 46, 0, 56, 0, 0, 57, 47,
-	48, 0, 60, 61, 58, 0, 0, 64, 65, 0,
-	66, 63, 59, 0, 0, 81, 62, 0, 67, 97,
-	98, 95, 96, 0, 0, 0, 82, 83, 0, 84,
-	0, 85, 86, 0, 0, 0, 0, 334, 0, 0,
-	0, 0, 0, 0, 0, 76, 0, 77, 0, 94,
-	93, 73, 72, 69, 49, 68, 79, 50, 80, 0,
-	0, 78, 0, 0, 46, 0, 56, 0, 0, 57,
-	47, 48, 0, 60, 61, 58, 0, 0, 64, 65,
-	0, 66, 63, 59, 0, 0, 81, 62, 0, 67,
-	97, 98, 95, 96, 0, 0, 0, 82, 83, 0,
-	84, 0, 85, 86, 0, 0, 0, 315, 0, 0,
-	0, 0, 0, 0, 0, 0, 76, 0, 77, 0,
-	94, 93, 73, 72, 69, 49, 68, 79, 50, 80,
-	0, 0, 78, 0, 0, 46, 0, 56, 0, 0,
-	57, 47, 48, 0, 60, 61, 58, 0, 0, 64,
-	65, 0, 66, 63, 59, 0, 0, 81, 62, 0,
-	67, 97, 98, 95, 96, 0, 0, 0, 82, 83,
-	0, 84, 0, 85, 86, 69, 331, 68, 79, 180,
-	80, 0, 0, 78, 0, 0, 0, 76, 0, 77,
-	0, 94, 93, 73, 72, 0, 0, 0, 0, 0,
-	0, 0, 0, 0, 0, 0, 0, 0, 81, 0,
-	0, 0, 97, 98, 95, 96, 0, 0, 0, 82,
-	83, 0, 84, 0, 85, 86, 0, 0, 0, 315,
-	0, 0, 0, 271, 0, 0, 0, 0, 76, 0,
-	77, 327, 94, 93, 73, 72, 69, 150, 68, 79,
-	151, 135, 0, 0, 78, 155, 144, 0, 0, 0,
+	0, 0, 0, 209, 0, 210, 210, 0, 0, 210,
+	0, 0, 0, 0, 210, 0, 0, 0, 0, 210,
+	210, 0, 0, 200, 201, 0, 0, 211, 212, 0,
+	0, 0, 0, 209, 209, 0, 0, 0, 0, 0,
 	0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
-	0, 0, 0, 0, 0, 0, 0, 0, 0, 81,
-	0, 0, 0, 97, 98, 95, 96, 0, 0, 140,
-	82, 83, 0, 84, 0, 85, 86, 69, 150, 68,
-	79, 151, 80, 0, 0, 78, 155, 0, 0, 273,
-	0, 145, 0, 94, 93, 73, 72, 0, 0, 0,
+	0, 0, 0, 165, 0, 210, 0, 294, 299, 0,
+	209, 0, 210, 0, 0, 0, 173, 0, 0, 0,
+	210, 210, 165, 239, 240, 0, 0, 0, 0, 0,
+	165, 323, 0, 0, 0, 0, 209, 0, 0, 0,
+	209, 0, 356, 0, 247, 248, 249, 250, 0, 0,
+	0, 0, 0, 0, 0, 258, 0, 0, 0, 165,
+	0, 264, 0, 0, 0, 0, 210, 270, 0, 0,
+	0, 0, 0, 0, 210, 0, 0, 0, 209, 0,
+	0, 0, 210, 0, 0, 0, 165, 0, 0, 0,
+	173, 202, 0, 0, 0, 173, 0, 0, 0, 173,
+	0, 0, 0, 0, 0, 173, 0, 325, 326, 0,
+	328, 329, 210, 334, 335, 0, 340, 341, 0, 0,
+	210, 0, 0, 0, 353, 354, 355, 0, 0, 0,
+	0, 0, 0, 173, 0, 0, 0, 210, 0, 234,
+	0, 0, 0, 0, 0, 372, 373, 374, 375, 376,
+	377, 0, 0, 0, 0, 0, 210, 0, 0, 0,
+	0, 0, 0, 393, 0, 0, 210, 0, 210, 210,
+	294, 0, 398, 82, 596, 81, 0, 597, 0, 0,
+	0, 93, 0, 0, 0, 0, 0, 0, 0, 284,
+	0, 210, 287, 216, 210, 0, 0, 0, 0, 0,
+	414, 0, 310, 0, 0, 210, 0, 0, 0, 0,
+	0, 0, 112, 113, 110, 111, 481, 0, 0, 0,
+	0, 0, 126, 131, 205, 0, 672, 0, 0, 173,
+	0, 0, 235, 0, 0, 0, 0, 0, 0, 0,
+	165, 451, 599, 0, 600, 165, 79, 78, 86, 85,
+	87, 88, 0, 0, 0, 165, 0, 136, 137, 0,
+	0, 0, 0, 0, 0, 0, 256, 0, 124, 125,
+	0, 261, 0, 127, 0, 128, 266, 267, 129, 0,
+	138, 139, 0, 531, 126, 131, 0, 122, 123, 133,
+	130, 132, 210, 0, 485, 431, 0, 0, 0, 0,
+	313, 0, 210, 0, 0, 0, 0, 0, 0, 173,
+	0, 210, 0, 0, 412, 0, 205, 0, 0, 136,
+	137, 0, 0, 0, 0, 422, 0, 0, 505, 426,
+	124, 125, 0, 507, 0, 127, 0, 128, 0, 210,
+	129, 0, 0, 0, 205, 0, 126, 131, 0, 122,
+	123, 133, 130, 132, 441, 368, 446, 750, 0, 0,
+	0, 0, 0, 0, 0, 134, 0, 0, 0, 210,
+	210, 0, 121, 0, 0, 0, 0, 0, 396, 531,
+	0, 136, 137, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 124, 125, 0, 0, 210, 127, 563, 128,
+	473, 474, 129, 0, 138, 139, 0, 0, 0, 579,
+	579, 122, 123, 133, 130, 132, 135, 0, 0, 0,
+	0, 0, 210, 0, 0, 0, 210, 0, 0, 0,
+	0, 0, 430, 0, 0, 0, 0, 0, 0, 446,
+	0, 0, 216, 0, 0, 619, 0, 0, 0, 437,
+	0, 0, 0, 0, 0, 452, 453, 0, 0, 0,
+	0, 0, 624, 0, 210, 82, 596, 81, 523, 597,
+	0, 0, 0, 93, 0, 0, 0, 0, 0, 0,
+	0, 0, 639, 0, 0, 0, 642, 0, 643, 0,
+	546, 548, 549, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 112, 113, 110, 111, 658, 659,
+	0, 0, 0, 0, 0, 564, 0, 0, 0, 568,
+	569, 0, 570, 496, 0, 0, 126, 131, 0, 498,
+	500, 0, 0, 601, 599, 603, 600, 502, 79, 78,
+	86, 85, 87, 88, 687, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 615, 0, 616, 0, 0, 0,
+	618, 136, 137, 0, 697, 698, 0, 0, 0, 526,
+	0, 0, 124, 125, 0, 537, 0, 127, 0, 128,
+	0, 0, 129, 0, 0, 0, 545, 579, 547, 0,
+	550, 122, 123, 133, 130, 132, 0, 0, 0, 641,
+	0, 0, 0, 0, 0, 644, 645, 0, 0, 0,
+	0, 0, 0, 0, 650, 653, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 0, 0, 0, 661,
+	0, 662, 0, 664, 0, 0, 0, 606, 0, 608,
+	0, 550, 0, 0, 0, 677, 0, 0, 0, 0,
+	0, 0, 0, 0, 45, 0, 683, 749, 0, 0,
+	0, 0, 0, 0, 0, 0, 579, 579, 579, 0,
 	0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
-	81, 0, 0, 0, 97, 98, 95, 96, 0, 0,
-	0, 82, 83, 0, 84, 0, 85, 86, 0, 0,
-	0, 315, 0, 69, 179, 68, 79, 180, 80, 0,
-	76, 78, 77, 0, 94, 93, 73, 72, 0, 0,
+	0, 0, 767, 0, 0, 0, 699, 0, 633, 634,
+	0, 700, 772, 176, 638, 579, 0, 0, 705, 0,
+	579, 579, 579, 0, 176, 0, 712, 0, 176, 176,
+	0, 0, 0, 176, 176, 0, 0, 0, 0, 0,
 	0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
-	0, 0, 0, 0, 0, 0, 81, 0, 0, 0,
-	97, 98, 95, 96, 0, 0, 0, 82, 83, 0,
-	84, 0, 85, 86, 0, 0, 0, 315, 0, 0,
-	0, 271, 0, 0, 0, 0, 76, 0, 77, 0,
-	94, 93, 73, 72, 69, 179, 68, 79, 180, 342,
-	0, 0, 78, 0, 144, 0, 0, 0, 0, 0,
+	0, 0, 0, 729, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 678, 735, 736, 0, 738, 0, 0,
+	473, 474, 0, 176, 176, 0, 176, 0, 0, 0,
+	0, 0, 0, 0, 176, 0, 0, 0, 0, 0,
+	0, 747, 0, 0, 0, 0, 176, 0, 0, 176,
+	176, 0, 176, 0, 176, 176, 0, 176, 176, 0,
+	176, 0, 0, 176, 176, 763, 0, 0, 0, 0,
+	0, 0, 176, 0, 713, 176, 0, 0, 0, 176,
+	176, 176, 0, 0, 0, 0, 0, 724, 0, 0,
+	0, 0, 0, 0, 176, 0, 0, 0, 0, 176,
+	176, 0, 176, 176, 0, 0, 732, 176, 0, 0,
+	0, 0, 0, 0, 0, 0, 176, 0, 0, 0,
+	176, 176, 742, 0, 0, 0, 0, 0, 0, 0,
+	0, 176, 0, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 751, 0, 0, 0, 0, 82, 169,
+	81, 80, 170, 94, 0, 0, 93, 174, 176, 176,
+	176, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 0, 176, 176, 109,
+	0, 176, 95, 0, 0, 0, 176, 112, 113, 110,
+	111, 176, 176, 0, 96, 97, 0, 98, 0, 99,
+	100, 101, 175, 58, 59, 82, 169, 81, 80, 170,
+	150, 0, 157, 93, 174, 159, 0, 91, 0, 92,
+	0, 79, 78, 86, 85, 87, 88, 176, 0, 0,
+	0, 0, 0, 0, 176, 0, 109, 0, 448, 95,
+	0, 0, 176, 176, 112, 113, 110, 111, 0, 0,
+	155, 96, 97, 0, 98, 0, 99, 100, 101, 175,
+	58, 59, 156, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 126, 131, 154, 0, 160, 0, 79, 78,
+	86, 85, 87, 88, 0, 0, 0, 0, 176, 0,
+	0, 0, 0, 0, 0, 0, 176, 0, 0, 0,
+	0, 0, 0, 0, 176, 0, 0, 136, 137, 0,
+	10, 0, 176, 0, 0, 126, 131, 176, 124, 125,
+	0, 448, 0, 127, 0, 128, 0, 176, 129, 0,
+	0, 0, 0, 0, 176, 0, 0, 122, 123, 133,
+	130, 132, 176, 0, 0, 640, 0, 0, 0, 161,
+	136, 137, 0, 0, 0, 176, 0, 0, 0, 176,
+	204, 124, 125, 0, 215, 204, 127, 0, 128, 224,
+	225, 129, 0, 138, 139, 0, 0, 0, 176, 0,
+	122, 123, 133, 130, 132, 0, 0, 0, 176, 0,
+	176, 176, 0, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 0, 0, 0, 241,
+	242, 0, 244, 0, 0, 0, 176, 0, 0, 0,
+	246, 0, 0, 0, 0, 0, 0, 176, 0, 0,
+	0, 0, 251, 0, 0, 254, 255, 0, 257, 0,
+	259, 260, 0, 262, 263, 0, 265, 0, 0, 268,
+	269, 176, 0, 0, 0, 0, 0, 0, 286, 0,
+	0, 289, 0, 0, 0, 293, 296, 303, 0, 0,
 	0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
-	0, 0, 0, 0, 0, 0, 0, 81, 0, 0,
-	0, 97, 98, 95, 96, 0, 0, 341, 82, 83,
-	0, 84, 0, 85, 86, 69, 331, 68, 79, 180,
-	80, 0, 0, 78, 0, 0, 0, 76, 0, 145,
-	0, 94, 93, 73, 72, 0, 0, 0, 0, 0,
-	0, 0, 0, 0, 0, 0, 0, 0, 81, 0,
-	0, 0, 97, 98, 95, 96, 0, 0, 0, 82,
-	83, 0, 84, 0, 85, 86, 0, 0, 0, 315,
-	0, 0, 0, 0, 0, 0, 0, 0, 76, 0,
-	77, 327, 94, 93, 73, 72, 69, 150, 68, 79,
-	151, 135, 0, 0, 78, 155, 144, 0, 0, 0,
+	161, 0, 0, 0, 0, 319, 320, 0, 289, 322,
+	0, 0, 0, 327, 0, 0, 0, 0, 0, 0,
+	0, 0, 342, 0, 0, 0, 350, 352, 0, 0,
+	0, 0, 0, 0, 0, 0, 0, 161, 0, 0,
 	0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
-	0, 0, 0, 0, 0, 0, 0, 0, 0, 81,
-	0, 0, 0, 97, 98, 95, 96, 0, 0, 0,
-	82, 83, 0, 84, 0, 85, 86, 69, 179, 68,
-	79, 180, 80, 0, 0, 78, 0, 0, 0, 273,
-	0, 145, 0, 94, 93, 73, 72, 0, 0, 0,
+	0, 176, 0, 176, 82, 206, 81, 80, 207, 94,
+	0, 0, 93, 0, 289, 384, 391, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 48, 0, 0, 0,
+	0, 176, 0, 403, 403, 109, 0, 407, 95, 302,
+	0, 0, 408, 112, 113, 110, 111, 410, 411, 0,
+	96, 97, 0, 98, 0, 99, 100, 101, 0, 58,
+	59, 82, 169, 81, 80, 170, 150, 0, 0, 93,
+	174, 159, 0, 91, 0, 92, 0, 79, 78, 86,
+	85, 87, 88, 435, 0, 0, 0, 0, 176, 0,
+	438, 0, 109, 0, 450, 95, 0, 0, 454, 455,
+	112, 113, 110, 111, 0, 0, 155, 96, 97, 0,
+	98, 0, 99, 100, 101, 175, 58, 59, 0, 0,
+	0, 0, 318, 0, 0, 0, 0, 0, 0, 0,
+	317, 0, 160, 0, 79, 78, 86, 85, 87, 88,
+	0, 0, 0, 0, 480, 0, 0, 0, 0, 0,
+	0, 0, 204, 82, 169, 81, 80, 170, 150, 0,
+	403, 93, 174, 159, 0, 0, 0, 0, 161, 0,
+	0, 0, 0, 161, 0, 0, 0, 501, 0, 0,
+	0, 0, 0, 289, 109, 0, 0, 95, 0, 0,
+	508, 0, 112, 113, 110, 111, 0, 0, 516, 96,
+	97, 0, 98, 0, 99, 100, 101, 175, 58, 59,
+	0, 527, 0, 0, 318, 438, 0, 0, 0, 0,
+	0, 0, 317, 0, 160, 0, 79, 78, 86, 85,
+	87, 88, 0, 0, 552, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 204, 0, 557, 558, 0, 0,
 	0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
-	81, 0, 0, 0, 97, 98, 95, 96, 0, 0,
-	0, 82, 83, 0, 84, 0, 85, 86, 0, 0,
-	0, 315, 0, 0, 0, 0, 0, 0, 0, 0,
-	76, 0, 77, 0, 94, 93, 73, 72, 69, 150,
-	68, 79, 151, 80, 0, 0, 78, 155, 0, 0,
 	0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 204, 0, 82, 38, 81, 80, 39, 94,
+	0, 0, 93, 610, 0, 35, 757, 581, 756, 755,
+	582, 36, 37, 52, 50, 51, 48, 0, 0, 55,
+	56, 57, 60, 54, 49, 109, 0, 527, 95, 53,
+	0, 0, 61, 112, 113, 110, 111, 0, 0, 0,
+	96, 97, 0, 98, 0, 99, 100, 101, 0, 58,
+	59, 0, 0, 577, 578, 0, 0, 0, 0, 0,
+	0, 0, 0, 91, 0, 92, 0, 79, 78, 86,
+	85, 87, 88, 0, 0, 0, 0, 0, 0, 0,
 	0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
-	0, 81, 0, 0, 0, 97, 98, 95, 96, 0,
-	0, 0, 82, 83, 0, 84, 0, 85, 86, 69,
-	179, 68, 79, 180, 80, 0, 0, 78, 0, 0,
-	0, 76, 0, 77, 0, 94, 93, 73, 72, 0,
+	0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+	82, 38, 81, 80, 39, 94, 0, 679, 93, 552,
+	0, 35, 753, 581, 756, 755, 582, 36, 37, 52,
+	50, 51, 48, 0, 0, 55, 56, 57, 60, 54,
+	49, 109, 0, 0, 95, 53, 0, 696, 61, 112,
+	113, 110, 111, 0, 0, 0, 96, 97, 0, 98,
+	0, 99, 100, 101, 0, 58, 59, 0, 0, 577,
+	578, 0, 0, 0, 0, 0, 0, 0, 0, 91,
+	0, 92, 0, 79, 78, 86, 85, 87, 88, 82,
+	38, 81, 80, 39, 94, 0, 0, 93, 0, 0,
+	35, 688, 46, 0, 731, 47, 36, 37, 52, 50,
+	51, 48, 479, 690, 55, 56, 57, 60, 54, 49,
+	109, 0, 0, 95, 53, 0, 0, 61, 112, 113,
+	110, 111, 0, 0, 0, 96, 97, 0, 98, 0,
+	99, 100, 101, 0, 58, 59, 0, 0, 359, 360,
+	0, 0, 0, 0, 0, 0, 0, 0, 91, 0,
+	92, 0, 79, 78, 86, 85, 87, 88, 82, 38,
+	81, 80, 39, 94, 0, 0, 93, 0, 0, 35,
+	565, 46, 472, 471, 47, 36, 37, 52, 50, 51,
+	48, 0, 0, 55, 56, 57, 60, 54, 49, 109,
+	0, 0, 95, 53, 0, 0, 61, 112, 113, 110,
+	111, 0, 0, 0, 96, 97, 0, 98, 0, 99,
+	100, 101, 0, 58, 59, 0, 0, 359, 360, 0,
+	0, 0, 0, 0, 0, 0, 0, 91, 0, 92,
+	0, 79, 78, 86, 85, 87, 88, 82, 38, 81,
+	80, 39, 94, 0, 0, 93, 0, 0, 35, 509,
+	46, 0, 0, 47, 36, 37, 52, 50, 51, 48,
+	479, 511, 55, 56, 57, 60, 54, 49, 109, 0,
+	0, 95, 53, 0, 0, 61, 112, 113, 110, 111,
+	0, 0, 0, 96, 97, 0, 98, 0, 99, 100,
+	101, 0, 58, 59, 0, 0, 359, 360, 0, 0,
+	0, 0, 0, 0, 0, 0, 91, 0, 92, 0,
+	79, 78, 86, 85, 87, 88, 82, 38, 81, 80,
+	39, 94, 0, 0, 93, 0, 0, 35, 469, 46,
+	472, 471, 47, 36, 37, 52, 50, 51, 48, 0,
+	0, 55, 56, 57, 60, 54, 49, 109, 0, 0,
+	95, 53, 0, 0, 61, 112, 113, 110, 111, 0,
+	0, 0, 96, 97, 0, 98, 0, 99, 100, 101,
+	0, 58, 59, 0, 0, 359, 360, 0, 0, 0,
+	0, 0, 0, 0, 0, 91, 0, 92, 0, 79,
+	78, 86, 85, 87, 88, 82, 38, 81, 80, 39,
+	94, 0, 0, 93, 0, 0, 35, 685, 46, 0,
+	0, 47, 36, 37, 52, 50, 51, 48, 479, 0,
+	55, 56, 57, 60, 54, 49, 109, 0, 0, 95,
+	53, 0, 0, 61, 112, 113, 110, 111, 0, 0,
+	0, 96, 97, 0, 98, 0, 99, 100, 101, 0,
+	58, 59, 0, 0, 359, 360, 0, 0, 0, 0,
+	0, 0, 0, 0, 91, 0, 92, 0, 79, 78,
+	86, 85, 87, 88, 82, 38, 81, 80, 39, 94,
+	0, 0, 93, 0, 0, 35, 647, 46, 0, 0,
+	47, 36, 37, 52, 50, 51, 48, 0, 648, 55,
+	56, 57, 60, 54, 49, 109, 0, 0, 95, 53,
+	0, 0, 61, 112, 113, 110, 111, 0, 0, 0,
+	96, 97, 0, 98, 0, 99, 100, 101, 0, 58,
+	59, 0, 0, 359, 360, 0, 0, 0, 0, 0,
+	0, 0, 0, 91, 0, 92, 0, 79, 78, 86,
+	85, 87, 88, 82, 38, 81, 80, 39, 94, 0,
+	0, 93, 0, 0, 35, 520, 46, 0, 0, 47,
+	36, 37, 52, 50, 51, 48, 479, 0, 55, 56,
+	57, 60, 54, 49, 109, 0, 0, 95, 53, 0,
+	0, 61, 112, 113, 110, 111, 0, 0, 0, 96,
+	97, 0, 98, 0, 99, 100, 101, 0, 58, 59,
+	0, 0, 359, 360, 0, 0, 0, 0, 0, 0,
+	0, 0, 91, 0, 92, 0, 79, 78, 86, 85,
+	87, 88, 82, 38, 81, 80, 39, 94, 0, 0,
+	93, 0, 0, 35, 0, 46, 0, 0, 47, 36,
+	37, 52, 50, 51, 48, 0, 0, 55, 56, 57,
+	60, 54, 49, 109, 0, 0, 95, 53, 0, 0,
+	61, 112, 113, 110, 111, 0, 0, 0, 96, 97,
+	0, 98, 0, 99, 100, 101, 0, 58, 59, 0,
+	0, 6, 7, 0, 0, 0, 0, 0, 0, 0,
+	0, 91, 0, 92, 0, 79, 78, 86, 85, 87,
+	88, 8, 82, 38, 81, 80, 39, 94, 0, 0,
+	93, 0, 0, 35, 762, 46, 0, 0, 47, 36,
+	37, 52, 50, 51, 48, 0, 0, 55, 56, 57,
+	60, 54, 49, 109, 0, 0, 95, 53, 0, 0,
+	61, 112, 113, 110, 111, 0, 0, 0, 96, 97,
+	0, 98, 0, 99, 100, 101, 0, 58, 59, 0,
+	0, 359, 360, 0, 0, 0, 0, 0, 0, 0,
+	0, 91, 0, 92, 0, 79, 78, 86, 85, 87,
+	88, 82, 38, 81, 80, 39, 94, 0, 0, 93,
+	0, 0, 35, 759, 581, 0, 0, 582, 36, 37,
+	52, 50, 51, 48, 0, 0, 55, 56, 57, 60,
+	54, 49, 109, 0, 0, 95, 53, 0, 0, 61,
+	112, 113, 110, 111, 0, 0, 0, 96, 97, 0,
+	98, 0, 99, 100, 101, 0, 58, 59, 0, 0,
+	577, 578, 0, 0, 0, 0, 0, 0, 0, 0,
+	91, 0, 92, 0, 79, 78, 86, 85, 87, 88,
+	82, 38, 81, 80, 39, 94, 0, 0, 93, 0,
+	0, 35, 748, 46, 0, 0, 47, 36, 37, 52,
+	50, 51, 48, 0, 0, 55, 56, 57, 60, 54,
+	49, 109, 0, 0, 95, 53, 0, 0, 61, 112,
+	113, 110, 111, 0, 0, 0, 96, 97, 0, 98,
+	0, 99, 100, 101, 0, 58, 59, 0, 0, 359,
+	360, 0, 0, 0, 0, 0, 0, 0, 0, 91,
+	0, 92, 0, 79, 78, 86, 85, 87, 88, 82,
+	38, 81, 80, 39, 94, 0, 0, 93, 0, 0,
+	35, 734, 46, 0, 0, 47, 36, 37, 52, 50,
+	51, 48, 0, 0, 55, 56, 57, 60, 54, 49,
+	109, 0, 0, 95, 53, 0, 0, 61, 112, 113,
+	110, 111, 0, 0, 0, 96, 97, 0, 98, 0,
+	99, 100, 101, 0, 58, 59, 0, 0, 359, 360,
+	0, 0, 0, 0, 0, 0, 0, 0, 91, 0,
+	92, 0, 79, 78, 86, 85, 87, 88, 82, 38,
+	81, 80, 39, 94, 0, 0, 93, 0, 0, 35,
+	725, 46, 0, 0, 47, 36, 37, 52, 50, 51,
+	48, 0, 0, 55, 56, 57, 60, 54, 49, 109,
+	0, 0, 95, 53, 0, 0, 61, 112, 113, 110,
+	111, 0, 0, 0, 96, 97, 0, 98, 0, 99,
+	100, 101, 0, 58, 59, 0, 0, 359, 360, 0,
+	0, 0, 0, 0, 0, 0, 0, 91, 0, 92,
+	0, 79, 78, 86, 85, 87, 88, 82, 38, 81,
+	80, 39, 94, 0, 0, 93, 0, 0, 35, 711,
+	46, 0, 0, 47, 36, 37, 52, 50, 51, 48,
+	0, 0, 55, 56, 57, 60, 54, 49, 109, 0,
+	0, 95, 53, 0, 0, 61, 112, 113, 110, 111,
+	0, 0, 0, 96, 97, 0, 98, 0, 99, 100,
+	101, 0, 58, 59, 0, 0, 359, 360, 0, 0,
+	0, 0, 0, 0, 0, 0, 91, 0, 92, 0,
+	79, 78, 86, 85, 87, 88, 82, 38, 81, 80,
+	39, 94, 0, 0, 93, 0, 0, 35, 710, 46,
+	0, 0, 47, 36, 37, 52, 50, 51, 48, 0,
+	0, 55, 56, 57, 60, 54, 49, 109, 0, 0,
+	95, 53, 0, 0, 61, 112, 113, 110, 111, 0,
+	0, 0, 96, 97, 0, 98, 0, 99, 100, 101,
+	0, 58, 59, 0, 0, 359, 360, 0, 0, 0,
+	0, 0, 0, 0, 0, 91, 0, 92, 0, 79,
+	78, 86, 85, 87, 88, 82, 38, 81, 80, 39,
+	94, 0, 0, 93, 0, 0, 35, 709, 581, 0,
+	0, 582, 36, 37, 52, 50, 51, 48, 0, 0,
+	55, 56, 57, 60, 54, 49, 109, 0, 0, 95,
+	53, 0, 0, 61, 112, 113, 110, 111, 0, 0,
+	0, 96, 97, 0, 98, 0, 99, 100, 101, 0,
+	58, 59, 0, 0, 577, 578, 0, 0, 0, 0,
+	0, 0, 0, 0, 91, 0, 92, 0, 79, 78,
+	86, 85, 87, 88, 82, 38, 81, 80, 39, 94,
+	0, 0, 93, 0, 0, 35, 684, 46, 0, 0,
+	47, 36, 37, 52, 50, 51, 48, 0, 0, 55,
+	56, 57, 60, 54, 49, 109, 0, 0, 95, 53,
+	0, 0, 61, 112, 113, 110, 111, 0, 0, 0,
+	96, 97, 0, 98, 0, 99, 100, 101, 0, 58,
+	59, 0, 0, 359, 360, 0, 0, 0, 0, 0,
+	0, 0, 0, 91, 0, 92, 0, 79, 78, 86,
+	85, 87, 88, 82, 38, 81, 80, 39, 94, 0,
+	0, 93, 0, 0, 35, 676, 46, 0, 0, 47,
+	36, 37, 52, 50, 51, 48, 0, 0, 55, 56,
+	57, 60, 54, 49, 109, 0, 0, 95, 53, 0,
+	0, 61, 112, 113, 110, 111, 0, 0, 0, 96,
+	97, 0, 98, 0, 99, 100, 101, 0, 58, 59,
+	0, 0, 359, 360, 0, 0, 0, 0, 0, 0,
+	0, 0, 91, 0, 92, 0, 79, 78, 86, 85,
+	87, 88, 82, 38, 81, 80, 39, 94, 0, 0,
+	93, 0, 0, 35, 649, 46, 0, 0, 47, 36,
+	37, 52, 50, 51, 48, 0, 0, 55, 56, 57,
+	60, 54, 49, 109, 0, 0, 95, 53, 0, 0,
+	61, 112, 113, 110, 111, 0, 0, 0, 96, 97,
+	0, 98, 0, 99, 100, 101, 0, 58, 59, 0,
+	0, 359, 360, 0, 0, 0, 0, 0, 0, 0,
+	0, 91, 0, 92, 0, 79, 78, 86, 85, 87,
+	88, 82, 38, 81, 80, 39, 94, 0, 0, 93,
+	0, 0, 35, 0, 46, 0, 0, 47, 36, 37,
+	52, 50, 51, 48, 0, 0, 55, 56, 57, 60,
+	54, 49, 109, 0, 0, 95, 53, 0, 0, 61,
+	112, 113, 110, 111, 0, 0, 0, 96, 97, 0,
+	98, 0, 99, 100, 101, 0, 58, 59, 0, 0,
+	359, 360, 0, 0, 0, 0, 0, 0, 0, 0,
+	91, 0, 92, 631, 79, 78, 86, 85, 87, 88,
+	82, 38, 81, 80, 39, 94, 0, 0, 93, 0,
+	0, 35, 622, 46, 0, 0, 47, 36, 37, 52,
+	50, 51, 48, 0, 0, 55, 56, 57, 60, 54,
+	49, 109, 0, 0, 95, 53, 0, 0, 61, 112,
+	113, 110, 111, 0, 0, 0, 96, 97, 0, 98,
+	0, 99, 100, 101, 0, 58, 59, 0, 0, 359,
+	360, 0, 0, 0, 0, 0, 0, 0, 0, 91,
+	0, 92, 0, 79, 78, 86, 85, 87, 88, 82,
+	38, 81, 80, 39, 94, 0, 0, 93, 0, 0,
+	35, 583, 581, 0, 0, 582, 36, 37, 52, 50,
+	51, 48, 0, 0, 55, 56, 57, 60, 54, 49,
+	109, 0, 0, 95, 53, 0, 0, 61, 112, 113,
+	110, 111, 0, 0, 0, 96, 97, 0, 98, 0,
+	99, 100, 101, 0, 58, 59, 0, 0, 577, 578,
+	0, 0, 0, 0, 0, 0, 0, 0, 91, 0,
+	92, 0, 79, 78, 86, 85, 87, 88, 82, 38,
+	81, 80, 39, 94, 0, 0, 93, 0, 0, 35,
+	576, 581, 0, 0, 582, 36, 37, 52, 50, 51,
+	48, 0, 0, 55, 56, 57, 60, 54, 49, 109,
+	0, 0, 95, 53, 0, 0, 61, 112, 113, 110,
+	111, 0, 0, 0, 96, 97, 0, 98, 0, 99,
+	100, 101, 0, 58, 59, 0, 0, 577, 578, 0,
+	0, 0, 0, 0, 0, 0, 0, 91, 0, 92,
+	0, 79, 78, 86, 85, 87, 88, 82, 38, 81,
+	80, 39, 94, 0, 0, 93, 0, 0, 35, 567,
+	46, 0, 0, 47, 36, 37, 52, 50, 51, 48,
+	0, 0, 55, 56, 57, 60, 54, 49, 109, 0,
+	0, 95, 53, 0, 0, 61, 112, 113, 110, 111,
+	0, 0, 0, 96, 97, 0, 98, 0, 99, 100,
+	101, 0, 58, 59, 0, 0, 359, 360, 0, 0,
+	0, 0, 0, 0, 0, 0, 91, 0, 92, 0,
+	79, 78, 86, 85, 87, 88, 82, 38, 81, 80,
+	39, 94, 0, 0, 93, 0, 0, 35, 539, 46,
+	0, 0, 47, 36, 37, 52, 50, 51, 48, 0,
+	0, 55, 56, 57, 60, 54, 49, 109, 0, 0,
+	95, 53, 0, 0, 61, 112, 113, 110, 111, 0,
+	0, 0, 96, 97, 0, 98, 0, 99, 100, 101,
+	0, 58, 59, 0, 0, 359, 360, 0, 0, 0,
+	0, 0, 0, 0, 0, 91, 0, 92, 0, 79,
+	78, 86, 85, 87, 88, 82, 38, 81, 80, 39,
+	94, 0, 0, 93, 0, 0, 35, 524, 46, 0,
+	0, 47, 36, 37, 52, 50, 51, 48, 0, 0,
+	55, 56, 57, 60, 54, 49, 109, 0, 0, 95,
+	53, 0, 0, 61, 112, 113, 110, 111, 0, 0,
+	0, 96, 97, 0, 98, 0, 99, 100, 101, 0,
+	58, 59, 0, 0, 359, 360, 0, 0, 0, 0,
+	0, 0, 0, 0, 91, 0, 92, 0, 79, 78,
+	86, 85, 87, 88, 82, 38, 81, 80, 39, 94,
+	0, 0, 93, 0, 0, 35, 440, 46, 0, 0,
+	47, 36, 37, 52, 50, 51, 48, 0, 0, 55,
+	56, 57, 60, 54, 49, 109, 0, 0, 95, 53,
+	0, 0, 61, 112, 113, 110, 111, 0, 0, 0,
+	96, 97, 0, 98, 0, 99, 100, 101, 0, 58,
+	59, 0, 0, 359, 360, 0, 0, 0, 0, 0,
+	0, 0, 0, 91, 0, 92, 0, 79, 78, 86,
+	85, 87, 88, 82, 38, 81, 80, 39, 94, 0,
+	0, 93, 0, 0, 35, 427, 46, 0, 0, 47,
+	36, 37, 52, 50, 51, 48, 0, 0, 55, 56,
+	57, 60, 54, 49, 109, 0, 0, 95, 53, 0,
+	0, 61, 112, 113, 110, 111, 0, 0, 0, 96,
+	97, 0, 98, 0, 99, 100, 101, 0, 58, 59,
+	0, 0, 359, 360, 0, 0, 0, 0, 0, 0,
+	0, 0, 91, 0, 92, 0, 79, 78, 86, 85,
+	87, 88, 82, 38, 81, 80, 39, 94, 0, 0,
+	93, 0, 0, 35, 424, 46, 0, 0, 47, 36,
+	37, 52, 50, 51, 48, 0, 0, 55, 56, 57,
+	60, 54, 49, 109, 0, 0, 95, 53, 0, 0,
+	61, 112, 113, 110, 111, 0, 0, 0, 96, 97,
+	0, 98, 0, 99, 100, 101, 0, 58, 59, 0,
+	0, 359, 360, 0, 0, 0, 0, 0, 0, 0,
+	0, 91, 0, 92, 0, 79, 78, 86, 85, 87,
+	88, 82, 38, 81, 80, 39, 94, 0, 0, 93,
+	0, 0, 35, 0, 581, 0, 0, 582, 36, 37,
+	52, 50, 51, 48, 0, 0, 55, 56, 57, 60,
+	54, 49, 109, 0, 0, 95, 53, 0, 0, 61,
+	112, 113, 110, 111, 0, 0, 0, 96, 97, 0,
+	98, 0, 99, 100, 101, 0, 58, 59, 0, 0,
+	577, 578, 0, 0, 0, 0, 0, 0, 0, 0,
+	91, 0, 92, 0, 79, 78, 86, 85, 87, 88,
+	82, 38, 81, 80, 39, 94, 0, 0, 93, 0,
+	0, 35, 0, 46, 0, 0, 47, 36, 37, 52,
+	50, 51, 48, 0, 0, 55, 56, 57, 60, 54,
+	49, 109, 0, 0, 95, 53, 0, 0, 61, 112,
+	113, 110, 111, 0, 0, 0, 96, 97, 0, 98,
+	0, 99, 100, 101, 0, 58, 59, 0, 0, 359,
+	360, 0, 0, 0, 0, 0, 0, 0, 0, 91,
+	0, 92, 0, 79, 78, 86, 85, 87, 88, 82,
+	38, 81, 80, 39, 94, 395, 0, 93, 0, 0,
+	35, 0, 46, 0, 0, 47, 36, 37, 52, 50,
+	51, 48, 0, 0, 55, 56, 57, 60, 54, 49,
+	109, 0, 0, 95, 53, 0, 0, 61, 112, 113,
+	110, 111, 0, 0, 0, 96, 97, 0, 98, 0,
+	99, 100, 101, 0, 58, 59, 0, 0, 0, 394,
+	0, 0, 0, 0, 0, 0, 0, 0, 91, 0,
+	92, 0, 79, 78, 86, 85, 87, 88, 82, 38,
+	81, 80, 39, 94, 0, 0, 93, 0, 0, 35,
+	0, 46, 0, 0, 47, 36, 37, 52, 50, 51,
+	48, 0, 0, 55, 56, 57, 60, 54, 49, 109,
+	0, 0, 95, 53, 0, 0, 61, 112, 113, 110,
+	111, 0, 0, 0, 96, 97, 0, 98, 0, 99,
+	100, 101, 0, 58, 59, 0, 0, 369, 0, 0,
+	0, 0, 0, 0, 0, 0, 0, 91, 0, 92,
+	0, 79, 78, 86, 85, 87, 88, 82, 38, 81,
+	80, 39, 94, 0, 0, 93, 0, 0, 35, 0,
+	46, 0, 0, 47, 36, 37, 52, 50, 51, 48,
+	0, 0, 55, 56, 57, 60, 54, 49, 109, 0,
+	0, 95, 53, 0, 0, 61, 112, 113, 110, 111,
+	0, 0, 0, 96, 97, 0, 98, 0, 99, 100,
+	101, 0, 58, 59, 82, 169, 81, 80, 170, 150,
+	0, 0, 93, 174, 159, 0, 91, 0, 92, 0,
+	79, 78, 86, 85, 87, 88, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 109, 0, 0, 95, 0,
+	0, 0, 0, 112, 113, 110, 111, 0, 0, 155,
+	96, 97, 0, 98, 0, 99, 100, 101, 175, 58,
+	59, 82, 169, 81, 80, 170, 94, 0, 0, 93,
+	174, 0, 0, 317, 0, 160, 0, 79, 78, 86,
+	85, 87, 88, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 109, 0, 0, 95, 0, 0, 0, 0,
+	112, 113, 110, 111, 0, 0, 0, 96, 97, 0,
+	98, 0, 99, 100, 101, 175, 58, 59, 0, 0,
+	369, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+	91, 0, 92, 0, 79, 78, 86, 85, 87, 88,
+	82, 169, 81, 80, 170, 150, 0, 0, 93, 174,
+	159, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+	0, 109, 0, 0, 95, 0, 0, 0, 0, 112,
+	113, 110, 111, 0, 0, 0, 96, 97, 0, 98,
+	0, 99, 100, 101, 175, 58, 59, 82, 169, 81,
+	80, 170, 94, 0, 0, 93, 0, 0, 0, 317,
+	0, 160, 0, 79, 78, 86, 85, 87, 88, 0,
 	0, 0, 0, 0, 0, 0, 0, 0, 109, 0,
-	0, 0, 81, 0, 0, 0, 97, 98, 95, 96,
-	0, 0, 0, 82, 83, 116, 84, 0, 85, 86,
-	109, 0, 105, 0, 0, 0, 0, 0, 0, 0,
-	118, 119, 76, 0, 77, 0, 94, 93, 73, 72,
-	107, 108, 109, 0, 105, 110, 0, 111, 0, 112,
-	120, 0, 118, 119, 0, 0, 106, 115, 113, 114,
-	117, 0, 107, 108, 109, 0, 0, 110, 0, 111,
-	0, 112, 570, 0, 118, 119, 0, 0, 106, 115,
-	113, 114, 117, 0, 107, 108, 109, 0, 0, 110,
-	0, 111, 0, 112, 0, 0, 118, 119, 0, 0,
-	106, 115, 113, 114, 117, 0, 107, 108, 548, 0,
-	0, 110, 0, 111, 0, 112, 0, 0, 118, 119,
-	0, 0, 106, 115, 113, 114, 0, 0, 107, 108,
-	109, 0, 0, 110, 0, 111, 0, 112, 0, 0,
-	118, 119, 0, 345, 106, 115, 113, 114, 0, 0,
-	107, 108, 416, 0, 0, 110, 0, 111, 0, 112,
-	0, 0, 118, 119, 0, 0, 106, 115, 113, 114,
-	0, 0, 107, 108, 0, 0, 0, 110, 0, 111,
-	0, 112, 0, 0, 118, 119, 0, 0, 106, 115,
-	113, 114, 0, 0, 107, 108, 0, 0, 0, 110,
-	0, 111, 0, 112, 0, 0, 0, 0, 0, 0,
-	106, 115, 113, 114,
+	0, 95, 0, 0, 0, 0, 112, 113, 110, 111,
+	0, 0, 0, 96, 97, 0, 98, 0, 99, 100,
+	101, 0, 0, 0, 0, 0, 369, 0, 0, 0,
+	0, 312, 0, 0, 0, 0, 91, 0, 92, 388,
+	79, 78, 86, 85, 87, 88, 82, 206, 81, 80,
+	207, 94, 0, 0, 93, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 0, 109, 0, 0,
+	95, 0, 0, 0, 0, 112, 113, 110, 111, 0,
+	0, 0, 96, 97, 0, 98, 0, 99, 100, 101,
+	0, 0, 0, 0, 0, 369, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 91, 0, 92, 682, 79,
+	78, 86, 85, 87, 88, 82, 387, 81, 80, 170,
+	94, 0, 0, 93, 174, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 109, 0, 0, 95,
+	0, 0, 0, 0, 112, 113, 110, 111, 0, 0,
+	0, 96, 97, 0, 98, 0, 99, 100, 101, 0,
+	0, 0, 0, 0, 369, 82, 206, 81, 80, 207,
+	94, 0, 0, 93, 91, 0, 92, 0, 79, 78,
+	86, 85, 87, 88, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 109, 0, 0, 95,
+	0, 0, 0, 0, 112, 113, 110, 111, 0, 0,
+	0, 96, 97, 0, 98, 0, 99, 100, 101, 0,
+	58, 59, 82, 387, 81, 80, 170, 94, 0, 0,
+	93, 0, 0, 0, 91, 0, 92, 0, 79, 78,
+	86, 85, 87, 88, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 109, 0, 0, 95, 0, 0, 0,
+	0, 112, 113, 110, 111, 0, 0, 0, 96, 97,
+	0, 98, 0, 99, 100, 101, 0, 0, 0, 0,
+	0, 369, 0, 0, 0, 0, 312, 0, 0, 0,
+	0, 91, 0, 92, 0, 79, 78, 86, 85, 87,
+	88, 82, 206, 81, 80, 207, 402, 0, 0, 93,
+	0, 159, 0, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 109, 0, 0, 95, 0, 0, 0, 0,
+	112, 113, 110, 111, 0, 0, 406, 96, 97, 0,
+	98, 0, 99, 100, 101, 82, 206, 81, 80, 207,
+	402, 0, 0, 93, 0, 159, 0, 0, 0, 0,
+	91, 0, 160, 0, 79, 78, 86, 85, 87, 88,
+	0, 0, 0, 0, 0, 0, 109, 0, 0, 95,
+	0, 0, 0, 0, 112, 113, 110, 111, 0, 0,
+	401, 96, 97, 0, 98, 0, 99, 100, 101, 82,
+	392, 81, 80, 207, 94, 0, 0, 93, 0, 0,
+	0, 0, 0, 0, 91, 0, 160, 0, 79, 78,
+	86, 85, 87, 88, 0, 0, 0, 0, 0, 0,
+	109, 0, 0, 95, 0, 0, 0, 0, 112, 113,
+	110, 111, 0, 0, 0, 96, 97, 0, 98, 0,
+	99, 100, 101, 0, 0, 0, 0, 0, 369, 82,
+	206, 81, 80, 207, 94, 0, 0, 93, 91, 0,
+	92, 388, 79, 78, 86, 85, 87, 88, 0, 0,
+	0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+	109, 0, 0, 95, 0, 0, 0, 0, 112, 113,
+	110, 111, 0, 0, 0, 96, 97, 0, 98, 0,
+	99, 100, 101, 0, 0, 0, 0, 0, 369, 82,
+	206, 81, 80, 207, 94, 0, 0, 93, 91, 0,
+	92, 0, 79, 78, 86, 85, 87, 88, 0, 0,
+	0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+	109, 0, 0, 95, 0, 0, 0, 0, 112, 113,
+	110, 111, 0, 0, 0, 96, 97, 0, 98, 0,
+	99, 100, 101, 175, 82, 206, 81, 80, 207, 402,
+	0, 0, 93, 0, 159, 0, 0, 0, 91, 0,
+	92, 0, 79, 78, 86, 85, 87, 88, 0, 0,
+	0, 0, 0, 0, 0, 109, 0, 0, 95, 0,
+	0, 0, 0, 112, 113, 110, 111, 0, 0, 0,
+	96, 97, 0, 98, 0, 99, 100, 101, 82, 206,
+	81, 80, 207, 94, 0, 0, 93, 0, 0, 0,
+	0, 0, 0, 91, 0, 160, 0, 79, 78, 86,
+	85, 87, 88, 0, 0, 0, 0, 0, 0, 109,
+	0, 0, 95, 0, 0, 0, 0, 112, 113, 110,
+	111, 0, 0, 0, 96, 97, 0, 98, 0, 99,
+	100, 101, 82, 206, 81, 80, 207, 245, 0, 0,
+	93, 0, 0, 0, 0, 0, 0, 91, 0, 92,
+	0, 79, 78, 86, 85, 87, 88, 0, 0, 0,
+	0, 0, 0, 109, 0, 0, 95, 0, 0, 0,
+	0, 112, 113, 110, 111, 0, 126, 131, 96, 97,
+	0, 98, 0, 99, 100, 101, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+	0, 91, 0, 92, 0, 79, 78, 86, 85, 87,
+	88, 136, 137, 0, 0, 126, 131, 0, 0, 0,
+	0, 0, 124, 125, 0, 0, 0, 127, 0, 128,
+	0, 0, 129, 0, 0, 0, 0, 0, 0, 126,
+	131, 122, 123, 133, 130, 132, 0, 0, 0, 468,
+	136, 137, 0, 0, 0, 0, 0, 0, 0, 0,
+	0, 124, 125, 0, 0, 121, 127, 0, 128, 0,
+	0, 129, 0, 0, 136, 137, 126, 131, 0, 0,
+	122, 123, 133, 130, 132, 124, 125, 0, 460, 0,
+	127, 0, 128, 0, 0, 129, 0, 138, 139, 0,
+	0, 0, 0, 0, 122, 123, 133, 130, 132, 135,
+	0, 136, 137, 0, 126, 131, 0, 0, 0, 0,
+	0, 0, 124, 125, 733, 0, 0, 127, 0, 128,
+	0, 0, 129, 0, 0, 0, 0, 0, 126, 131,
+	637, 122, 123, 133, 130, 132, 135, 0, 0, 136,
+	137, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+	124, 125, 126, 131, 314, 127, 0, 128, 0, 0,
+	129, 0, 0, 136, 137, 0, 0, 0, 0, 122,
+	123, 133, 130, 132, 124, 125, 126, 131, 0, 127,
+	0, 128, 0, 0, 129, 0, 463, 136, 137, 0,
+	0, 0, 0, 122, 123, 133, 130, 132, 124, 125,
+	126, 131, 0, 127, 0, 128, 0, 0, 129, 0,
+	0, 136, 137, 0, 0, 0, 0, 122, 123, 133,
+	130, 132, 124, 125, 126, 131, 0, 127, 0, 128,
+	0, 0, 129, 0, 0, 136, 137, 0, 0, 0,
+	0, 122, 123, 133, 130, 132, 124, 125, 0, 0,
+	0, 127, 0, 128, 0, 0, 129, 0, 0, 136,
+	137, 0, 0, 0, 409, 122, 123, 133, 130, 132,
+	124, 125, 0, 0, 0, 127, 0, 128, 0, 0,
+	129, 0, 0, 0, 0, 0, 0, 0, 0, 122,
+	123, 133, 130, 132,
 }
-var RubyPact = []int{
-
-	-37, 2189, -1000, -1000, -1000, 0, -1000, -1000, -1000, 4164,
-	-1000, -1000, -1000, -1000, 179, -1000, -1000, -1000, -1000, -1000,
-	-1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000,
-	-1000, -1000, -1000, -1000, 56, -1000, 49, -1000, -1000, -1000,
-	-1000, -1000, -1000, -1000, -1000, -1000, 212, 381, 289, 940,
-	75, 52, 183, 85, 190, 167, 3539, 3539, -1000, 4134,
-	3539, 3539, 4134, 4134, 275, 238, -1000, 332, -1000, -1000,
-	-1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000, 277,
-	-1000, 9, 3539, 3539, 4134, 4134, 4134, -1000, -1000, -1000,
-	-1000, -1000, -1000, 28, 400, -1000, -1000, -1000, -1000, -1000,
-	-1000, -1000, 3539, 3539, 3539, 4134, 406, 4134, 4134, -1000,
-	4134, 3539, 4134, 4134, 4134, 4134, 3539, 4134, -1000, -1000,
-	4134, 3539, 4134, 4134, 3539, 3539, 3539, 405, 175, 48,
-	316, 138, 4134, 198, -1000, 4083, 9, -1000, 36, 4134,
-	4134, 4134, 34, 304, 5, -1000, 4296, -1000, -1000, -1,
-	3661, 60, 24, 108, 107, 4134, 4083, 4134, -1000, 3539,
-	3539, 4134, 3539, 3539, 26, 3539, 3539, 25, 3539, 3539,
-	3539, 20, 404, 402, 283, 189, 3326, 221, 4296, 3961,
-	53, 19, -1000, -1000, 256, 207, 4296, 91, 221, 3539,
-	3539, 3539, 3539, 272, 3712, 3890, 4083, 3397, -1000, -1000,
-	283, 283, 4296, 4296, 4296, -1000, -1000, 327, -1000, -1000,
-	283, 283, 283, 4296, 3839, 4296, 4296, 4012, 4296, 283,
-	4296, 4296, 4296, 4296, 283, 4252, 4012, 4012, 4296, 283,
-	4296, 83, 1545, 283, 283, 283, 9, -1000, 401, 301,
-	131, -1000, 105, 395, 393, 387, -1000, 3184, 289, 4296,
-	3113, 385, 4296, -1000, -1000, -1000, 1207, -13, 82, 4186,
-	-1000, -1000, 4208, -1000, -1000, -1000, -1000, 378, 4134, 3042,
-	-1000, 376, 3590, 4134, 4296, 361, 857, -17, 66, 283,
-	283, 1508, 283, 283, -1000, -1000, -1000, 374, 283, 283,
-	-1000, -1000, -1000, 364, 283, 283, 283, -1000, -1000, -1000,
-	352, 297, 18, 1, 1834, -1000, -1000, -1000, -1000, 283,
-	225, 4134, -1000, -1000, 91, -1000, 259, 4134, 283, 283,
-	283, 283, -1000, 291, 4296, -1000, -1000, -1000, 240, 227,
-	4318, 1380, 350, 283, -1000, -1000, 3768, -1000, -1000, -1000,
-	9, 3539, 4083, 4296, 4296, 4134, 4296, 4296, -1000, 4134,
-	96, -1000, 2118, 316, 131, 308, 4134, -1000, -1000, 316,
-	2047, -1000, -1000, 2971, -1000, 9, -1000, 3712, 106, -1000,
-	-1000, -1000, 100, 4296, -1000, 2900, 78, -1000, 3326, -1000,
-	-1, 767, -1000, 92, -1000, -1000, 88, -1000, -1000, -1000,
-	4134, 4134, -1000, 349, 3539, -1000, 1763, 2829, -1000, -1000,
-	-1000, 379, 4296, 2758, 2687, 228, -1000, -1000, 4134, 221,
-	-15, -1000, -16, -1000, -18, -1000, 3539, 4134, -1000, 4296,
-	-1000, 283, 193, 4296, 3539, -1000, 269, -1000, -1000, -1000,
-	-1000, 4296, -1000, -1000, 267, 2616, -1000, -1000, 3712, 4296,
-	-1000, -1000, 3539, 346, -1000, -1000, -1000, 345, -24, 2545,
-	-29, 3326, 86, -1000, 3539, 1442, 1285, -1000, 3539, -1000,
-	283, 3326, -1000, 340, -1000, 2474, 3326, 328, 341, -1000,
-	-1000, -1000, -1000, 283, -1000, 3539, 3539, -1000, -1000, -1000,
-	2403, 221, 3326, -1000, 3712, -1000, 4012, -1000, 113, 283,
-	4296, -1000, 283, -1000, -1000, 1976, 1905, -1000, -1000, 260,
-	283, -3, -1000, -1000, -1000, -1000, -42, 3468, 283, 205,
-	-1000, 283, 3326, 3326, -1000, -1000, 3326, 335, 289, -1000,
-	202, 162, 2332, -1000, 3326, 57, 4296, -1000, -1000, 4274,
-	54, -1000, 266, -1000, 252, -1000, 4134, -1000, 283, 3326,
-	-1000, -1000, 3326, -1000, -1000, -1000, -1000, 57, 3539, 4134,
-	-1000, -1000, 1002, 3326, 1692, 1621, 2261, 306, 4230, -1000,
-	-1000, 336, 3539, -1000, -1000, 318, -1000, -1000, -1000, 57,
-	-1000, -1000, 3539, -1000, 283, 3255, -1000, 57, 283, 3255,
-	3255, 3255,
+
+var RubyPact = [...]int16{
+	-41, 3335, -32768, -32768, -32768, 328, -32768, -32768, -32768, -32768,
+	1412, -32768, -32768, -32768, -32768, 289, -32768, -32768, -32768, -32768,
+	-32768, -32768, -32768, -32768, -32768, -32768, -32768, -32768, -32768, -32768,
+	-32768, -32768, -32768, -32768, -32768, 341, 520, 314, 1998, 268,
+	98, 211, 180, 281, 247, 47, 5390, 5390, -32768, 5858,
+	5390, 5390, 538, 6331, 5858, 464, 460, 378, 6331, 6331,
+	-32768, 459, -32768, -32768, -32768, -32768, -32768, 73, -32768, -32768,
+	-32768, -32768, -32768, -32768, -32768, -32768, -32768, -32768, 27, 651,
+	447, -32768, -32768, -32768, -32768, -32768, -32768, -32768, -32768, -32768,
+	-32768, -32768, -32768, -32768, -32768, 60, 5390, 5390, 6331, 6331,
+	466, 6331, -32768, -32768, -32768, -32768, -32768, -32768, -32768, 6385,
+	-32768, -32768, -32768, -32768, -32768, -32768, -32768, 5390, 5390, 5390,
+	5390, 6331, 655, 652, 6331, 6331, -32768, 6331, 5390, 6331,
+	6331, -32768, 6331, 6331, 5390, 6331, -32768, -32768, 6331, 6331,
+	5390, 396, 63, 299, -32768, -32768, 221, 6331, 308, -32768,
+	1941, 60, -32768, 65, 5858, 2327, 6331, 56, 415, 44,
+	-32768, 794, -32768, -32768, -32768, -32768, -32768, 348, 336, 2384,
+	233, 83, 254, 253, 6331, 6331, 51, 1941, 5858, -32768,
+	5390, 5390, 6331, 5390, 5390, 38, 5390, 5390, 33, 5390,
+	5390, 6331, 32, 647, 642, 6331, 6331, 5390, 5390, 5390,
+	571, 618, 5153, 321, 2111, -32768, 5583, 201, 48, -32768,
+	-32768, 590, 557, 323, -32768, 6690, 111, 321, 5390, 5390,
+	5390, 5390, 5390, 5390, 6690, 6690, 452, 634, -32768, -32768,
+	641, -32768, -32768, 1941, 5798, 6102, 5232, -32768, -32768, 571,
+	571, 6690, 6690, 665, 6690, 5390, 6690, 571, 571, 571,
+	571, 6690, 6048, 5994, 6690, 6690, 6162, 6690, 571, 6690,
+	6690, 6162, 6690, 6690, 571, 6666, 6162, 6162, 6690, 6690,
+	571, -32768, 626, 5390, 444, 406, -32768, 214, 625, 624,
+	617, 584, -32768, 444, 4995, 314, 6690, 4916, 616, 794,
+	-32768, -32768, -32768, 1288, -25, 133, 6495, -32768, -32768, -32768,
+	-32768, -32768, 6331, 6532, -32768, -32768, -32768, -32768, 573, 6222,
+	4837, -32768, 604, 5640, 5390, -32768, -32768, 5858, 6331, 6690,
+	6690, 601, 219, -40, 107, 571, 571, 6471, 571, 571,
+	-32768, -32768, -32768, 568, 571, 571, -32768, -32768, -32768, 551,
+	571, 571, 6642, -32768, -32768, -32768, 546, 382, 29, -14,
+	6690, 90, 6432, 571, 571, 571, 3019, -32768, -32768, -32768,
+	-32768, 571, 502, 5858, -32768, -32768, 545, 5390, 177, -32768,
+	342, 5858, 571, 571, 571, 571, 571, 571, -32768, 6277,
+	-32768, -32768, 589, 375, 6690, -32768, -32768, 5447, -32768, 365,
+	348, 6618, 2476, 571, -32768, -32768, 5915, -32768, 638, -32768,
+	60, 5390, 1941, 6690, -32768, -32768, 5390, 6690, 148, 6331,
+	6690, 6690, 2940, 299, 571, 560, 444, 6331, -32768, -32768,
+	-32768, 305, 3256, 484, -32768, -32768, 4758, -32768, 60, -32768,
+	5504, 198, -32768, -32768, 6222, 6690, -32768, 145, 6690, -32768,
+	-32768, 4679, 131, 89, -32768, 538, 5153, -32768, 51, -32768,
+	6618, 571, 161, 6162, 18, 6690, -32768, 213, -32768, -32768,
+	204, -32768, -32768, 5858, -32768, 6331, 6331, -32768, 200, -32768,
+	567, 5390, -32768, 2861, 4600, -32768, -32768, -32768, -32768, 411,
+	2111, -32768, 4521, 4442, -32768, 540, 315, 303, 1528, -32768,
+	-32768, 5858, 321, -32768, 60, -32768, 40, -32768, 41, -32768,
+	30, 6690, 6162, -32768, -32768, 571, 465, 571, 6690, -32768,
+	451, -32768, -32768, -32768, 178, -32768, 6690, -32768, 5390, 444,
+	-32768, 372, -32768, 4363, -32768, -32768, 5504, 794, -32768, -32768,
+	-32768, -32768, -32768, 348, 336, 5390, 528, 111, -32768, -32768,
+	-32768, 596, -32768, 558, 393, 6, 4284, 5, 5153, 5153,
+	-3, 84, 6594, 128, -32768, 5390, 321, 2068, 1592, 5390,
+	-32768, 5390, -32768, 571, 5153, -32768, 536, -32768, 3177, 4205,
+	5153, 405, 663, 522, -32768, 636, -32768, -32768, -32768, 571,
+	-32768, 5390, 5390, -32768, -32768, -32768, -32768, -32768, -32768, 1528,
+	-32768, 591, 166, -32768, -32768, -32768, -32768, 308, -32768, 293,
+	39, 4126, 321, 5153, -32768, -32768, 5798, -32768, 5719, -32768,
+	148, -32768, -32768, -32768, -32768, 4047, 3098, 5390, 2782, 571,
+	370, -32768, -32768, 426, 571, -20, -32768, -32768, -32768, -32768,
+	-32768, -32768, -32768, -5, -13, -32768, 6331, 5390, 5311, 571,
+	317, -32768, 571, 571, 5153, 5153, -32768, -32768, -32768, -32768,
+	5153, 510, 283, 5153, 505, -32768, -32768, -32768, 449, 608,
+	3968, 3889, 3810, -32768, 5153, 498, 157, 157, -32768, 72,
+	-32768, -32768, 493, -32768, 15, 78, -32768, 5153, 75, 6690,
+	-32768, -32768, -32768, 3731, -32768, -32768, 362, 571, -32768, 397,
+	-32768, 119, -32768, 6331, -32768, -32768, 6570, 571, 571, 5153,
+	3652, -32768, 542, -32768, -32768, 5153, -32768, -32768, -32768, -32768,
+	-32768, -32768, 5153, 75, -32768, -32768, -32768, -32768, -32768, 1236,
+	-32768, -32768, 165, 1528, 75, -32768, -32768, -32768, -32768, 3573,
+	5390, 1350, 75, -32768, -32768, 5153, 5153, 491, 5153, 2703,
+	2597, 3494, 75, -32768, 485, 76, -32768, 3415, -32768, 571,
+	-32768, 75, -32768, -32768, 488, 5390, -32768, -32768, 402, -32768,
+	-48, 1528, -32768, 5153, -32768, 5390, -32768, 571, 5074, -32768,
+	-32768, -32768, 571, 5074, 5074, 5074,
 }
-var RubyPgo = []int{
-
-	0, 487, 0, 484, 130, 483, 36, 9, 482, 480,
-	479, 478, 1099, 475, 12, 33, 474, 6, 472, 46,
-	470, 469, 973, 468, 533, 659, 466, 465, 464, 463,
-	462, 460, 458, 457, 456, 454, 8, 149, 453, 452,
-	1, 13, 451, 450, 449, 16, 448, 446, 3, 444,
-	442, 441, 440, 438, 437, 434, 432, 430, 429, 428,
-	1133, 427, 4, 14, 28, 5, 426, 27, 424, 20,
-	421, 11, 420, 7, 419, 38, 15, 10, 415, 413,
-	391, 19,
+
+var RubyPgo = [...]int16{
+	0, 755, 996, 754, 753, 316, 752, 922, 33, 751,
+	749, 748, 747, 772, 746, 27, 44, 745, 20, 744,
+	18, 12, 743, 43, 2110, 741, 5, 386, 1724, 740,
+	739, 738, 737, 736, 734, 733, 732, 728, 727, 726,
+	722, 721, 719, 21, 0, 718, 716, 11, 14, 42,
+	715, 713, 6, 712, 3, 708, 707, 706, 703, 702,
+	701, 32, 699, 698, 1, 697, 695, 693, 692, 691,
+	690, 688, 687, 686, 683, 682, 681, 1112, 680, 7,
+	4, 19, 34, 16, 679, 28, 24, 2, 678, 30,
+	17, 677, 8, 10, 13, 36, 15, 9, 676, 671,
+	1209,
 }
-var RubyR1 = []int{
-
-	0, 66, 66, 66, 66, 66, 66, 66, 66, 66,
-	66, 80, 80, 81, 81, 60, 60, 60, 60, 23,
-	23, 23, 23, 23, 23, 23, 23, 23, 23, 23,
-	23, 23, 22, 22, 22, 22, 22, 22, 22, 22,
-	22, 22, 22, 22, 22, 22, 22, 22, 33, 33,
-	33, 33, 33, 33, 2, 2, 2, 2, 2, 2,
-	2, 2, 2, 2, 2, 2, 2, 2, 2, 2,
-	2, 2, 2, 2, 2, 45, 18, 25, 25, 25,
-	25, 25, 25, 25, 25, 25, 25, 25, 25, 25,
-	25, 25, 25, 25, 25, 25, 25, 25, 25, 25,
+
+var RubyR1 = [...]int8{
+	0, 84, 84, 84, 84, 84, 84, 84, 84, 84,
+	84, 100, 100, 77, 77, 77, 77, 26, 26, 26,
+	26, 26, 26, 26, 26, 26, 26, 26, 26, 26,
+	26, 26, 24, 25, 25, 25, 25, 25, 25, 25,
 	25, 25, 25, 25, 25, 25, 25, 25, 25, 25,
-	26, 63, 63, 63, 63, 73, 73, 71, 71, 71,
-	71, 71, 71, 71, 17, 75, 75, 27, 27, 27,
-	27, 27, 27, 27, 27, 67, 67, 77, 77, 77,
-	36, 36, 36, 36, 34, 34, 35, 38, 40, 40,
-	40, 19, 19, 19, 19, 19, 19, 19, 20, 20,
-	76, 76, 39, 39, 39, 39, 39, 39, 39, 12,
-	12, 37, 37, 24, 24, 49, 49, 49, 49, 49,
-	49, 49, 49, 49, 49, 49, 49, 49, 49, 49,
-	49, 50, 51, 52, 53, 54, 55, 56, 57, 58,
-	59, 3, 8, 10, 4, 1, 79, 79, 79, 79,
-	79, 79, 79, 5, 5, 5, 68, 68, 74, 74,
-	74, 7, 7, 7, 7, 7, 7, 64, 72, 72,
-	72, 16, 16, 16, 16, 16, 16, 16, 16, 16,
-	16, 16, 65, 65, 65, 65, 61, 61, 61, 11,
-	21, 21, 14, 14, 14, 14, 78, 78, 70, 70,
-	62, 62, 28, 28, 29, 30, 30, 32, 32, 32,
-	31, 31, 31, 15, 46, 46, 46, 69, 69, 69,
-	69, 69, 47, 47, 47, 47, 47, 48, 48, 48,
-	48, 44, 43, 13, 42, 42, 42, 42, 41, 41,
-	6, 9,
+	40, 40, 40, 40, 40, 40, 40, 2, 3, 3,
+	3, 3, 3, 3, 3, 3, 3, 3, 3, 3,
+	3, 3, 3, 3, 3, 3, 3, 3, 3, 3,
+	3, 3, 61, 19, 20, 28, 28, 28, 28, 28,
+	28, 28, 28, 28, 28, 28, 28, 28, 28, 28,
+	28, 28, 28, 28, 28, 28, 28, 28, 28, 28,
+	28, 28, 28, 28, 28, 28, 28, 28, 28, 28,
+	28, 28, 28, 28, 30, 30, 29, 81, 81, 81,
+	81, 93, 93, 93, 93, 89, 89, 89, 89, 89,
+	89, 89, 89, 89, 89, 89, 89, 89, 89, 89,
+	89, 89, 18, 95, 95, 95, 31, 31, 31, 31,
+	31, 31, 31, 31, 31, 31, 31, 31, 31, 31,
+	31, 31, 85, 85, 97, 97, 97, 43, 43, 43,
+	43, 43, 41, 41, 42, 45, 47, 47, 47, 21,
+	21, 21, 21, 21, 21, 21, 21, 21, 21, 22,
+	22, 22, 96, 96, 46, 46, 46, 46, 46, 46,
+	46, 13, 13, 44, 44, 27, 27, 65, 65, 65,
+	65, 65, 65, 65, 65, 65, 65, 65, 65, 65,
+	65, 65, 65, 66, 67, 68, 69, 69, 69, 70,
+	71, 72, 73, 74, 75, 76, 4, 9, 11, 5,
+	1, 99, 99, 99, 99, 99, 99, 99, 6, 6,
+	6, 6, 86, 86, 94, 94, 94, 8, 8, 8,
+	8, 8, 8, 8, 8, 82, 82, 91, 91, 91,
+	91, 92, 90, 90, 90, 17, 17, 17, 17, 17,
+	17, 17, 17, 17, 17, 17, 83, 83, 83, 83,
+	78, 78, 78, 12, 23, 23, 23, 23, 15, 15,
+	15, 15, 15, 15, 15, 15, 80, 80, 98, 98,
+	88, 88, 79, 79, 34, 34, 32, 32, 35, 36,
+	36, 38, 38, 38, 39, 39, 39, 37, 37, 37,
+	16, 62, 62, 62, 62, 33, 87, 87, 87, 87,
+	87, 63, 63, 63, 63, 63, 64, 64, 64, 64,
+	60, 59, 14, 49, 49, 49, 49, 48, 48, 50,
+	50, 51, 51, 52, 52, 53, 53, 53, 53, 53,
+	53, 56, 56, 55, 55, 54, 54, 54, 57, 57,
+	57, 58, 58, 58, 58, 7, 7, 7, 7, 7,
+	7, 10,
 }
-var RubyR2 = []int{
 
+var RubyR2 = [...]int8{
 	0, 0, 1, 1, 1, 3, 3, 3, 2, 2,
-	2, 0, 1, 0, 2, 0, 2, 2, 2, 1,
+	2, 0, 2, 0, 2, 2, 2, 1, 1, 1,
+	1, 1, 1, 1, 1, 1, 1, 1, 1, 1,
 	1, 1, 1, 1, 1, 1, 1, 1, 1, 1,
 	1, 1, 1, 1, 1, 1, 1, 1, 1, 1,
 	1, 1, 1, 1, 1, 1, 1, 1, 1, 1,
 	1, 1, 1, 1, 1, 1, 1, 1, 1, 1,
 	1, 1, 1, 1, 1, 1, 1, 1, 1, 1,
-	1, 1, 1, 1, 1, 3, 2, 4, 5, 1,
-	4, 4, 2, 3, 2, 3, 4, 5, 4, 3,
-	4, 5, 2, 3, 3, 3, 3, 4, 4, 4,
-	4, 4, 4, 4, 4, 4, 6, 7, 6, 6,
-	4, 3, 6, 1, 4, 1, 3, 0, 1, 1,
-	1, 4, 4, 4, 2, 1, 3, 5, 6, 7,
-	7, 8, 8, 5, 6, 1, 3, 0, 1, 3,
-	1, 2, 3, 2, 4, 6, 5, 4, 1, 2,
-	1, 3, 3, 3, 3, 3, 3, 3, 3, 3,
-	9, 6, 3, 3, 3, 3, 3, 3, 3, 2,
-	2, 2, 2, 3, 3, 3, 3, 3, 4, 3,
-	3, 3, 4, 3, 3, 3, 4, 3, 3, 3,
-	4, 2, 2, 2, 2, 3, 3, 3, 3, 3,
-	3, 1, 1, 5, 1, 1, 0, 1, 1, 1,
-	4, 4, 4, 3, 5, 5, 3, 7, 3, 7,
-	8, 3, 4, 5, 5, 5, 6, 3, 0, 1,
-	3, 4, 5, 3, 3, 3, 3, 3, 5, 6,
-	5, 3, 4, 3, 3, 2, 0, 2, 2, 3,
-	4, 6, 2, 3, 5, 4, 1, 3, 0, 2,
-	1, 2, 2, 1, 1, 2, 1, 1, 3, 3,
-	1, 3, 3, 5, 5, 5, 3, 0, 2, 2,
-	2, 2, 5, 6, 5, 6, 5, 4, 3, 3,
-	2, 4, 4, 2, 5, 7, 4, 6, 4, 5,
-	3, 3,
+	1, 1, 3, 2, 2, 4, 5, 1, 4, 4,
+	2, 3, 2, 3, 4, 5, 4, 3, 4, 4,
+	5, 5, 3, 4, 4, 5, 2, 3, 3, 3,
+	3, 4, 4, 4, 4, 4, 4, 4, 4, 4,
+	6, 7, 6, 6, 1, 1, 4, 3, 6, 1,
+	4, 1, 1, 3, 3, 0, 1, 1, 1, 1,
+	1, 1, 4, 4, 4, 4, 4, 4, 1, 4,
+	1, 4, 2, 1, 3, 3, 5, 6, 7, 7,
+	8, 8, 7, 8, 9, 10, 5, 6, 4, 7,
+	6, 9, 1, 3, 0, 1, 3, 1, 2, 2,
+	3, 2, 4, 6, 5, 4, 1, 2, 1, 3,
+	3, 3, 3, 3, 3, 3, 3, 3, 3, 3,
+	5, 3, 9, 6, 3, 3, 3, 3, 3, 3,
+	3, 2, 2, 2, 2, 3, 3, 3, 3, 3,
+	4, 3, 3, 3, 4, 3, 3, 3, 4, 3,
+	3, 3, 4, 2, 2, 2, 2, 2, 5, 3,
+	3, 3, 3, 4, 3, 3, 1, 1, 5, 1,
+	1, 0, 1, 1, 1, 4, 4, 4, 3, 5,
+	6, 5, 3, 6, 3, 7, 8, 3, 4, 5,
+	5, 5, 6, 6, 5, 3, 3, 1, 3, 3,
+	3, 3, 0, 1, 3, 4, 5, 3, 3, 3,
+	3, 3, 5, 6, 5, 3, 4, 3, 3, 2,
+	0, 2, 2, 3, 4, 6, 6, 8, 2, 3,
+	5, 3, 5, 5, 7, 4, 2, 2, 1, 3,
+	0, 2, 1, 2, 4, 2, 2, 1, 1, 2,
+	1, 1, 3, 3, 1, 3, 3, 1, 3, 3,
+	5, 5, 5, 3, 3, 7, 0, 2, 2, 2,
+	2, 5, 6, 5, 6, 5, 4, 3, 3, 2,
+	4, 4, 2, 5, 7, 4, 6, 4, 5, 5,
+	7, 4, 5, 1, 3, 1, 1, 1, 1, 3,
+	3, 2, 3, 1, 3, 1, 2, 1, 2, 3,
+	6, 2, 3, 4, 5, 3, 3, 2, 2, 2,
+	2, 3,
 }
-var RubyChk = []int{
-
-	-1000, -66, 59, 60, 76, -2, 59, 60, 76, -22,
-	-27, -34, -38, -35, -19, -20, -39, -16, -21, -28,
-	-46, -42, -30, -31, -32, -45, -6, -29, -15, -9,
-	-23, -10, -5, -40, -25, -26, -11, -13, -50, -51,
-	-52, -53, -18, -44, -43, -33, 16, 22, 23, 6,
-	9, -37, -24, -12, -49, -76, 18, 21, 27, 35,
-	25, 26, 39, 34, 30, 31, 33, 41, 7, 5,
-	-3, -8, 75, 74, -4, -1, 68, 70, 13, 8,
-	10, 38, 49, 50, 52, 54, 55, -54, -55, -56,
-	-57, -58, -59, 73, 72, 44, 45, 42, 43, 60,
-	59, 76, 18, 21, 25, 28, 62, 46, 47, 4,
-	51, 53, 55, 64, 65, 63, 21, 66, 36, 37,
-	56, 21, 46, 68, 57, 18, 21, 62, 6, -4,
-	4, -40, 4, 9, -40, 10, -63, -7, -71, 68,
-	48, 57, 12, -75, 15, 70, -22, -19, -17, -25,
-	6, 9, -37, -24, -12, 14, 10, 68, 13, 48,
-	57, 68, 48, 57, 12, 48, 57, 12, 48, 57,
-	48, 12, 48, 12, -2, -2, -60, -73, -22, 6,
-	9, -37, -24, -12, -2, -2, -22, -81, -73, 18,
-	21, 18, 21, 7, -81, -81, 10, -61, -7, 70,
-	-2, -2, -22, -22, -22, 6, 9, 73, 6, 9,
-	-2, -2, -2, -22, 6, -22, -22, -81, -22, -2,
-	-22, -22, -22, -22, -2, -22, -81, -81, -22, -2,
-	-22, -75, -22, -2, -2, -2, 6, -67, 62, -77,
-	10, -36, 6, 55, 14, 62, -67, -60, 46, -22,
-	-60, -71, -22, -7, -7, 12, -22, -6, -75, -22,
-	-45, -15, -22, -15, 6, -37, -24, 55, 12, -60,
-	-64, 63, -81, 68, -22, -71, -22, -6, -75, -2,
-	-2, -22, -2, -2, 6, -37, -24, 55, -2, -2,
-	6, -37, -24, 55, -2, -2, -2, 6, -37, -24,
-	55, -76, 6, 6, -60, 59, 60, 59, 60, -2,
-	-70, 12, 59, 59, -81, 59, -41, 40, -2, -2,
-	-2, -2, 7, -79, -22, -19, -17, 71, -68, -74,
-	-22, 6, -71, -2, 60, 11, -81, 6, 9, -7,
-	-63, 48, 10, -22, -22, 61, -22, -22, 69, 12,
-	69, -7, -60, 6, 12, -77, 48, 6, 6, 6,
-	-60, 17, -40, -60, 17, 11, 12, -81, 69, 69,
-	69, 6, -81, -22, 17, -60, -72, 6, -60, -64,
-	-25, -22, 11, 69, 69, 69, 69, 6, 6, 6,
-	68, 68, 17, -65, 20, 19, -60, -60, 17, 19,
-	-14, 28, -22, -69, -69, -41, 17, 19, 40, -73,
-	-81, 12, -81, 12, -81, 12, 4, 61, 11, -22,
-	-7, -2, -71, -22, 48, 17, -62, -14, -67, -36,
-	11, -22, -67, 17, -62, -60, 17, -7, -81, -22,
-	-19, -17, 48, 12, -17, 17, 63, 12, -81, -60,
-	-81, -60, 69, 48, 48, -22, -22, 17, 20, 19,
-	-2, -60, 17, -65, 17, -60, -60, -78, 4, -40,
-	17, 59, 60, -2, -47, 18, 21, 17, 17, 19,
-	-60, -73, -60, 69, -81, 71, -81, 71, -81, -2,
-	-22, 11, -2, 17, -14, -60, -60, 17, 17, -17,
-	-2, 6, 6, 71, 71, 71, -81, -81, -2, 69,
-	69, -2, -60, -60, 17, 17, -60, 4, 12, 6,
-	-2, -2, -60, 17, -60, -81, -22, -19, -17, -22,
-	6, 17, -62, 17, -62, 11, 68, 71, -2, -60,
-	6, -40, -60, 59, 59, 60, 17, -81, 4, 61,
-	17, 17, -22, -60, -69, -69, -69, -2, -22, 69,
-	17, -48, 20, 19, 17, -48, 17, -80, 12, -81,
-	12, 17, 20, 19, -2, -69, 17, -81, -2, -69,
-	-69, -69,
+
+var RubyChk = [...]int16{
+	-32768, -84, 66, 67, 86, -2, 66, 67, 86, -3,
+	-24, -31, -41, -45, -42, -21, -22, -46, -17, -23,
+	-32, -62, -33, -49, -50, -36, -37, -38, -39, -61,
+	-7, -35, -16, -10, -25, 18, 24, 25, 8, 11,
+	-44, -27, -13, -65, -96, -28, 20, 23, 29, 37,
+	27, 28, 26, 42, 36, 32, 33, 34, 62, 63,
+	35, 45, -26, -11, -6, -47, -29, -12, -14, -66,
+	-67, -68, -69, -19, -60, -59, -40, -34, 81, 80,
+	10, 9, 7, -4, -9, 83, 82, 84, 85, -5,
+	-1, 76, 78, 15, 12, 41, 53, 54, 56, 58,
+	59, 60, -70, -71, -72, -73, -74, -75, -76, 38,
+	48, 49, 46, 47, 67, 66, 86, 20, 23, 27,
+	28, 30, 69, 70, 50, 51, 4, 55, 57, 60,
+	72, 5, 73, 71, 23, 74, 39, 40, 62, 63,
+	23, 8, -5, -30, 4, 5, -47, 4, 11, -47,
+	12, -81, -8, -89, 76, 52, 64, 14, -95, 17,
+	78, -24, -21, -18, -16, -7, -20, -94, -86, 8,
+	11, -44, -27, -13, 16, 61, -28, 12, 76, 15,
+	52, 64, 76, 52, 64, 14, 52, 64, 14, 52,
+	64, 52, 14, 52, 14, 50, 76, 64, 20, 23,
+	-2, -2, -77, -93, -24, -7, 8, 11, -44, -27,
+	-13, -2, -2, -90, 8, -24, -100, -93, 20, 23,
+	20, 23, 20, 23, -24, -24, 9, 69, 8, 11,
+	81, 8, 11, 12, -100, -100, -78, -8, 78, -2,
+	-2, -24, -24, 7, -24, 12, -24, -2, -2, -2,
+	-2, -24, 8, 8, -24, -24, -100, -24, -2, -24,
+	-24, -100, -24, -24, -2, -24, -100, -100, -24, -24,
+	-2, -85, 69, 52, 12, -97, -43, 8, 60, 61,
+	16, 69, -85, 12, -77, 50, -24, -77, -89, -24,
+	-8, -8, 14, -24, -7, -95, -24, -61, -16, -7,
+	-49, -23, 42, -24, -16, 8, -44, -27, 60, 14,
+	-77, -82, 71, -100, 6, 14, 14, 76, 68, -24,
+	-24, -89, -24, -7, -95, -2, -2, -24, -2, -2,
+	8, -44, -27, 60, -2, -2, 8, -44, -27, 60,
+	-2, -2, -24, 8, -44, -27, 60, -96, 8, 8,
+	-24, -95, -24, -2, -2, -2, -77, 66, 67, 66,
+	67, -2, -88, 14, 66, 66, 14, 44, -100, 66,
+	-48, 43, -2, -2, -2, -2, -2, -2, 9, 8,
+	8, 11, -89, -99, -24, -21, -18, 8, 79, -86,
+	-94, -24, 8, -2, 67, 13, -100, 5, -2, -8,
+	-81, 52, 12, -24, -81, -8, 52, -24, -24, 68,
+	-24, -24, -77, 8, -2, -97, 14, 52, 8, 8,
+	8, 8, -77, -97, 19, -47, -77, 19, 13, 14,
+	-100, 77, 77, 77, 14, -24, 8, -100, -24, -20,
+	19, -77, -90, -91, -92, 12, -77, -82, -28, -21,
+	-24, -2, -100, -100, -24, -24, 13, 77, 77, 77,
+	77, 8, 8, 14, 8, 76, 76, 77, 77, 19,
+	-83, 22, 21, -77, -77, 19, 21, 31, -15, 30,
+	-24, -7, -87, -87, 8, -2, -48, -51, 44, 19,
+	21, 43, -93, -8, -81, 13, -100, 14, -100, 14,
+	-100, -24, -100, 13, -8, -2, -89, -2, -24, 19,
+	-79, 31, -15, -85, 13, -43, -24, -85, 52, 12,
+	19, -79, 13, -77, 19, -8, -100, -24, -21, -18,
+	-16, -7, -20, -94, -86, 52, 14, -100, -18, 19,
+	71, 14, 71, 14, -90, -100, -77, -100, -77, -77,
+	-100, 8, -24, 77, 52, 52, -93, -24, -24, 52,
+	19, 22, 21, -2, -77, 19, -83, 19, -77, -77,
+	-77, -98, -80, 6, -47, 60, 19, 66, 67, -2,
+	-63, 20, 23, 19, 66, 19, 21, 19, 21, 44,
+	-52, -53, -26, -47, -56, -57, 8, 11, -44, 76,
+	78, -77, -93, -77, -8, 77, -100, 79, -100, 79,
+	-24, 13, 19, 31, -15, -77, -77, 52, -77, -2,
+	-97, 19, 19, -18, -2, 8, -92, 8, -92, 13,
+	79, 79, 79, -100, -100, 79, 68, 6, -100, -2,
+	77, 77, -2, -2, -77, -77, 19, 19, 31, 19,
+	-77, 6, 14, -77, 6, 8, 11, 8, -2, -2,
+	-87, -77, -77, -52, -77, 6, 62, 63, 77, -55,
+	-54, -52, 60, 79, -58, 8, 19, -77, -100, -24,
+	-21, -18, 79, -77, 19, 19, -79, -2, 19, -79,
+	31, 13, 13, 76, 79, 79, -24, -2, -2, -77,
+	-77, 8, -80, -47, 8, -77, 66, 66, 67, 19,
+	19, 19, -77, -100, 8, -26, 11, -26, 77, 14,
+	8, 79, 14, 68, -100, 19, 19, 19, 31, -77,
+	52, -24, -100, 14, 19, -77, -77, 6, -77, -87,
+	-87, -87, -100, -54, 61, 8, -52, -77, 19, -2,
+	77, -100, 8, 19, -64, 22, 21, 19, -64, 19,
+	8, 68, 19, -77, 19, 22, 21, -2, -87, 19,
+	79, -52, -2, -87, -87, -87,
 }
-var RubyDef = []int{
 
-	1, -2, 2, 3, 4, 0, 8, 9, 10, 54,
-	55, 56, 57, 58, 59, 60, 61, 62, 63, 64,
-	65, 66, 67, 68, 69, 70, 71, 72, 73, 74,
-	32, 33, 34, 35, 36, 37, 38, 39, 40, 41,
-	42, 43, 44, 45, 46, 47, 0, 0, 0, 21,
-	22, 23, 24, 25, 0, 0, 0, 0, 15, 263,
-	0, 0, 13, 266, 270, 267, 264, 0, 19, 20,
-	26, 27, 28, 29, 30, 31, 13, 13, 150, 79,
-	246, 0, 0, 0, 0, 0, 0, 48, 49, 50,
-	51, 52, 53, 0, 0, 201, 202, 204, 205, 5,
-	6, 7, 0, 0, 0, 0, 0, 0, 0, 13,
-	0, 0, 0, 0, 0, 0, 0, 0, 13, 13,
-	0, 0, 0, 0, 0, 0, 0, 0, 137, 0,
-	137, 15, 0, 148, 15, -2, 82, 84, 92, 13,
-	0, 0, 0, 113, 15, 13, 118, 119, 120, 36,
-	21, 22, 23, 24, 25, 0, 117, 0, 149, 0,
+var RubyDef = [...]int16{
+	1, -2, 2, 3, 4, 0, 8, 9, 10, 57,
+	58, 59, 60, 61, 62, 63, 64, 65, 66, 67,
+	68, 69, 70, 71, 72, 73, 74, 75, 76, 77,
+	78, 79, 80, 81, 32, 0, 0, 0, 19, 20,
+	21, 22, 23, 0, 0, 37, 0, 0, 13, 327,
+	0, 0, 282, 11, 330, 337, 331, 334, 0, 0,
+	328, 0, 33, 34, 35, 36, 38, 39, 40, 41,
+	42, 43, 44, 45, 46, 47, 48, 49, 0, 0,
+	87, 17, 18, 24, 25, 26, 27, 28, 29, 30,
+	31, 11, 11, 188, 300, 0, 0, 0, 0, 0,
+	0, 0, 50, 51, 52, 53, 54, 55, 56, 0,
+	246, 247, 249, 250, 5, 6, 7, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 11, 0, 0, 0,
+	0, 11, 0, 0, 0, 0, 11, 11, 397, 398,
+	0, 174, 0, 174, 124, 125, 13, 0, 186, 13,
+	-2, 90, 92, 106, 11, 0, 0, 0, 129, 13,
+	11, 136, 137, 138, 139, 140, 141, 148, 150, 19,
+	20, 21, 22, 23, 0, 0, 37, 135, 0, 187,
 	0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
-	0, 0, 0, 0, 15, 0, 258, 262, 115, 21,
-	22, 23, 24, 25, 0, 0, 13, 0, 265, 0,
-	0, 0, 0, 0, 206, 0, 117, 0, 293, 13,
-	191, 192, 193, 194, 76, 171, 172, 0, 169, 170,
-	233, 241, 276, 75, 85, 94, 96, 0, 195, 196,
-	197, 198, 199, 200, 235, 0, 0, 0, 300, 237,
-	95, 0, 125, 168, 234, 236, 89, 15, 0, 135,
-	137, 138, 140, 0, 0, 0, 15, 0, 0, 15,
-	0, 0, 118, 83, 93, 13, 125, 0, 0, 151,
-	152, 153, 162, 163, 175, 176, 177, 0, 13, 0,
-	15, 228, 15, 13, 124, 0, 125, 0, 0, 154,
-	164, 0, 155, 165, 179, 180, 181, 0, 156, 166,
-	183, 184, 185, 0, 157, 167, 158, 187, 188, 189,
-	0, 159, 0, 0, 0, 15, 15, 16, 17, 18,
-	0, 0, 277, 277, 0, 14, 0, 0, 271, 272,
-	268, 269, 301, 13, 207, 208, 209, 213, 13, 13,
-	0, -2, 0, 247, 248, 249, 15, 173, 174, 86,
-	88, 0, -2, 125, 110, 0, 291, 292, 104, 0,
-	105, 90, 0, 137, 0, 0, 0, 141, 143, 137,
-	0, 144, 15, 0, 147, 77, 13, 0, 97, 100,
-	102, 178, 0, 126, 221, 0, 0, 229, 13, 15,
-	-2, 125, 81, 98, 101, 103, 99, 182, 186, 190,
-	0, 0, 231, 0, 0, 15, 0, 0, 250, 15,
-	259, 15, 116, 0, 0, 0, 296, 15, 0, 15,
-	0, 13, 0, 13, 0, 13, 13, 0, 80, 0,
-	87, 91, 0, 273, 0, 127, 0, 260, 15, 139,
-	136, 142, 15, 133, 0, 0, 146, 78, 0, 121,
-	122, 123, 0, 0, 114, 222, 227, 0, 0, 0,
-	0, 13, 97, 13, 0, 0, 0, 232, 0, 15,
-	15, 245, 238, 0, 240, 0, 252, 15, 0, 256,
-	274, 278, 279, 280, 281, 0, 0, 275, 294, 15,
-	0, 15, 13, 203, 0, 214, 0, 215, 0, 216,
-	218, 111, 109, 128, 261, 0, 0, 134, 145, 123,
-	106, 0, 230, 223, 224, 225, 0, 0, 108, 0,
-	161, 15, 243, 244, 239, 251, 253, 0, 0, 15,
-	15, 0, 0, 297, 13, 298, 210, 211, 212, 0,
-	0, 129, 0, 130, 0, 112, 0, 226, 107, 242,
-	15, 257, 255, 277, 15, 15, 295, 299, 13, 0,
-	131, 132, 0, 254, 0, 0, 0, 11, 13, 160,
-	282, 0, 0, 277, 284, 0, 286, 217, 12, 219,
-	13, 283, 0, 277, 277, 290, 285, 220, 277, 288,
-	289, 287,
+	0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+	13, 0, 320, 326, 131, 132, 19, 20, 21, 22,
+	23, 0, 0, 0, 283, 11, 0, 329, 0, 0,
+	0, 0, 0, 0, 399, 400, 0, 0, 213, 214,
+	0, 211, 212, 135, 251, 0, 0, 362, 11, 233,
+	234, 235, 236, 237, 83, 300, 325, 287, 295, 343,
+	344, 82, 93, 102, 108, 110, 0, 239, 240, 241,
+	242, 0, 244, 245, 289, 0, 0, 0, 395, 396,
+	291, 13, 0, 0, 174, 172, 175, 177, 0, 0,
+	0, 0, 13, 174, 0, 0, 13, 0, 0, 136,
+	91, 107, 11, 153, 0, 0, 189, 190, 191, 192,
+	193, 194, 11, 204, 205, 217, 218, 219, 0, 11,
+	0, 13, 282, 13, 0, 11, 11, 11, 0, 152,
+	84, 0, 153, 0, 0, 195, 206, 0, 196, 207,
+	221, 222, 223, 0, 197, 208, 225, 226, 227, 0,
+	198, 209, 199, 229, 230, 231, 0, 201, 0, 0,
+	109, 0, 153, 210, 288, 290, 0, 13, 13, 14,
+	15, 16, 0, 0, 346, 346, 0, 0, 0, 12,
+	0, 0, 338, 339, 332, 333, 335, 336, 401, 97,
+	215, 216, 0, 11, 252, 253, 254, -2, 258, 11,
+	11, 0, -2, 301, 302, 303, 13, 11, 0, 94,
+	96, 0, -2, 153, 103, 104, 0, 126, 243, 0,
+	360, 361, 0, 174, 168, 0, 0, 0, 178, 179,
+	181, 174, 0, 0, 182, 13, 0, 185, 85, 11,
+	0, 111, 114, 116, 0, 11, 220, 0, 154, 155,
+	267, 0, 0, 0, 277, 282, 11, 13, -2, 13,
+	11, 262, 0, 0, 153, 264, 89, 112, 115, 117,
+	113, 224, 228, 0, 232, 0, 0, 118, 119, 285,
+	0, 0, 13, 0, 0, 304, 13, 13, 321, 13,
+	133, 134, 0, 0, 284, 0, 0, 0, 0, 365,
+	13, 0, 13, 98, 99, 88, 0, 11, 0, 11,
+	0, 11, 0, 324, 95, 101, 0, 105, 340, 156,
+	0, 13, 322, 13, 173, 176, 180, 13, 0, 174,
+	166, 0, 173, 0, 184, 86, 0, 142, 143, 144,
+	145, 146, 147, 149, 151, 0, 0, 0, 130, 268,
+	275, 0, 276, 0, 0, 0, 0, 0, 11, 11,
+	0, 0, 0, 111, 11, 0, 200, 0, 0, 0,
+	286, 0, 13, 13, 299, 292, 0, 294, 0, 0,
+	308, 13, 13, 0, 318, 0, 341, 347, 348, 349,
+	350, 0, 0, 342, 346, 363, 13, 369, 13, 0,
+	13, 373, 375, 376, 377, 378, 19, 20, 21, 0,
+	0, 0, 13, 11, 100, 248, 0, 259, 0, 261,
+	238, 127, 157, 13, 323, 0, 0, 0, 0, 170,
+	0, 167, 183, 144, 120, 0, 278, 279, 280, 281,
+	269, 270, 271, 0, 0, 274, 0, 0, 0, 122,
+	0, 203, 123, 13, 297, 298, 293, 305, 13, 306,
+	309, 0, 0, 311, 0, 13, 316, 317, 13, 0,
+	0, 0, 0, 13, 11, 0, 0, 0, 381, 0,
+	383, 385, 387, 388, 0, 0, 366, 11, 367, 255,
+	256, 257, 260, 0, 162, 158, 0, 169, 159, 0,
+	13, 173, 128, 0, 272, 273, 11, 263, 121, 296,
+	0, 13, 13, 319, 13, 315, 346, 13, 13, 345,
+	364, 370, 11, 371, 374, 379, 20, 380, 382, 0,
+	386, 389, 0, 391, 368, 163, 160, 161, 13, 0,
+	0, 0, 265, 11, 307, 310, 313, 0, 312, 0,
+	0, 0, 372, 384, 0, 0, 392, 0, 164, 171,
+	202, 266, 13, 351, 0, 0, 346, 353, 0, 355,
+	0, 393, 165, 314, 352, 0, 346, 346, 359, 354,
+	390, 394, 346, 357, 358, 356,
 }
-var RubyTok1 = []int{
 
+var RubyTok1 = [...]int8{
 	1,
 }
-var RubyTok2 = []int{
 
+var RubyTok2 = [...]int8{
 	2, 3, 4, 5, 6, 7, 8, 9, 10, 11,
 	12, 13, 14, 15, 16, 17, 18, 19, 20, 21,
 	22, 23, 24, 25, 26, 27, 28, 29, 30, 31,
@@ -950,30 +1308,59 @@ var RubyTok2 = []int{
 	42, 43, 44, 45, 46, 47, 48, 49, 50, 51,
 	52, 53, 54, 55, 56, 57, 58, 59, 60, 61,
 	62, 63, 64, 65, 66, 67, 68, 69, 70, 71,
-	72, 73, 74, 75, 76,
+	72, 73, 74, 75, 76, 77, 78, 79, 80, 81,
+	82, 83, 84, 85, 86,
 }
-var RubyTok3 = []int{
+
+var RubyTok3 = [...]int8{
 	0,
 }
 
+var RubyErrorMessages = [...]struct {
+	state int
+	token int
+	msg   string
+}{}
+
 //line yaccpar:1
 
 /*	parser for yacc output	*/
 
-var RubyDebug = 0
+var (
+	RubyDebug        = 0
+	RubyErrorVerbose = false
+)
 
 type RubyLexer interface {
 	Lex(lval *RubySymType) int
 	Error(s string)
 }
 
-const RubyFlag = -1000
+type RubyParser interface {
+	Parse(RubyLexer) int
+	Lookahead() int
+}
+
+type RubyParserImpl struct {
+	lval  RubySymType
+	stack [RubyInitialStackSize]RubySymType
+	char  int
+}
+
+func (p *RubyParserImpl) Lookahead() int {
+	return p.char
+}
+
+func RubyNewParser() RubyParser {
+	return &RubyParserImpl{}
+}
+
+const RubyFlag = -32768
 
 func RubyTokname(c int) string {
-	// 4 is TOKSTART above
-	if c >= 4 && c-4 < len(RubyToknames) {
-		if RubyToknames[c-4] != "" {
-			return RubyToknames[c-4]
+	if c >= 1 && c-1 < len(RubyToknames) {
+		if RubyToknames[c-1] != "" {
+			return RubyToknames[c-1]
 		}
 	}
 	return __yyfmt__.Sprintf("tok-%v", c)
@@ -988,51 +1375,127 @@ func RubyStatname(s int) string {
 	return __yyfmt__.Sprintf("state-%v", s)
 }
 
-func Rubylex1(lex RubyLexer, lval *RubySymType) int {
-	c := 0
-	char := lex.Lex(lval)
+func RubyErrorMessage(state, lookAhead int) string {
+	const TOKSTART = 4
+
+	if !RubyErrorVerbose {
+		return "syntax error"
+	}
+
+	for _, e := range RubyErrorMessages {
+		if e.state == state && e.token == lookAhead {
+			return "syntax error: " + e.msg
+		}
+	}
+
+	res := "syntax error: unexpected " + RubyTokname(lookAhead)
+
+	// To match Bison, suggest at most four expected tokens.
+	expected := make([]int, 0, 4)
+
+	// Look for shiftable tokens.
+	base := int(RubyPact[state])
+	for tok := TOKSTART; tok-1 < len(RubyToknames); tok++ {
+		if n := base + tok; n >= 0 && n < RubyLast && int(RubyChk[int(RubyAct[n])]) == tok {
+			if len(expected) == cap(expected) {
+				return res
+			}
+			expected = append(expected, tok)
+		}
+	}
+
+	if RubyDef[state] == -2 {
+		i := 0
+		for RubyExca[i] != -1 || int(RubyExca[i+1]) != state {
+			i += 2
+		}
+
+		// Look for tokens that we accept or reduce.
+		for i += 2; RubyExca[i] >= 0; i += 2 {
+			tok := int(RubyExca[i])
+			if tok < TOKSTART || RubyExca[i+1] == 0 {
+				continue
+			}
+			if len(expected) == cap(expected) {
+				return res
+			}
+			expected = append(expected, tok)
+		}
+
+		// If the default action is to accept or reduce, give up.
+		if RubyExca[i+1] != 0 {
+			return res
+		}
+	}
+
+	for i, tok := range expected {
+		if i == 0 {
+			res += ", expecting "
+		} else {
+			res += " or "
+		}
+		res += RubyTokname(tok)
+	}
+	return res
+}
+
+func Rubylex1(lex RubyLexer, lval *RubySymType) (char, token int) {
+	token = 0
+	char = lex.Lex(lval)
 	if char <= 0 {
-		c = RubyTok1[0]
+		token = int(RubyTok1[0])
 		goto out
 	}
 	if char < len(RubyTok1) {
-		c = RubyTok1[char]
+		token = int(RubyTok1[char])
 		goto out
 	}
 	if char >= RubyPrivate {
 		if char < RubyPrivate+len(RubyTok2) {
-			c = RubyTok2[char-RubyPrivate]
+			token = int(RubyTok2[char-RubyPrivate])
 			goto out
 		}
 	}
 	for i := 0; i < len(RubyTok3); i += 2 {
-		c = RubyTok3[i+0]
-		if c == char {
-			c = RubyTok3[i+1]
+		token = int(RubyTok3[i+0])
+		if token == char {
+			token = int(RubyTok3[i+1])
 			goto out
 		}
 	}
 
 out:
-	if c == 0 {
-		c = RubyTok2[1] /* unknown char */
+	if token == 0 {
+		token = int(RubyTok2[1]) /* unknown char */
 	}
 	if RubyDebug >= 3 {
-		__yyfmt__.Printf("lex %s(%d)\n", RubyTokname(c), uint(char))
+		__yyfmt__.Printf("lex %s(%d)\n", RubyTokname(token), uint(char))
 	}
-	return c
+	return char, token
 }
 
 func RubyParse(Rubylex RubyLexer) int {
+	return RubyNewParser().Parse(Rubylex)
+}
+
+func (Rubyrcvr *RubyParserImpl) Parse(Rubylex RubyLexer) int {
 	var Rubyn int
-	var Rubylval RubySymType
 	var RubyVAL RubySymType
-	RubyS := make([]RubySymType, RubyMaxDepth)
+	var RubyDollar []RubySymType
+	_ = RubyDollar // silence set and not used
+	RubyS := Rubyrcvr.stack[:]
 
 	Nerrs := 0   /* number of errors */
 	Errflag := 0 /* error recovery flag */
 	Rubystate := 0
-	Rubychar := -1
+	Rubyrcvr.char = -1
+	Rubytoken := -1 // Rubyrcvr.char translated into internal numbering
+	defer func() {
+		// Make sure we report no lookahead when not parsing.
+		Rubystate = -1
+		Rubyrcvr.char = -1
+		Rubytoken = -1
+	}()
 	Rubyp := -1
 	goto Rubystack
 
@@ -1045,7 +1508,7 @@ ret1:
 Rubystack:
 	/* put a state and value onto the stack */
 	if RubyDebug >= 4 {
-		__yyfmt__.Printf("char %v in %v\n", RubyTokname(Rubychar), RubyStatname(Rubystate))
+		__yyfmt__.Printf("char %v in %v\n", RubyTokname(Rubytoken), RubyStatname(Rubystate))
 	}
 
 	Rubyp++
@@ -1058,21 +1521,22 @@ Rubystack:
 	RubyS[Rubyp].yys = Rubystate
 
 Rubynewstate:
-	Rubyn = RubyPact[Rubystate]
+	Rubyn = int(RubyPact[Rubystate])
 	if Rubyn <= RubyFlag {
 		goto Rubydefault /* simple state */
 	}
-	if Rubychar < 0 {
-		Rubychar = Rubylex1(Rubylex, &Rubylval)
+	if Rubyrcvr.char < 0 {
+		Rubyrcvr.char, Rubytoken = Rubylex1(Rubylex, &Rubyrcvr.lval)
 	}
-	Rubyn += Rubychar
+	Rubyn += Rubytoken
 	if Rubyn < 0 || Rubyn >= RubyLast {
 		goto Rubydefault
 	}
-	Rubyn = RubyAct[Rubyn]
-	if RubyChk[Rubyn] == Rubychar { /* valid shift */
-		Rubychar = -1
-		RubyVAL = Rubylval
+	Rubyn = int(RubyAct[Rubyn])
+	if int(RubyChk[Rubyn]) == Rubytoken { /* valid shift */
+		Rubyrcvr.char = -1
+		Rubytoken = -1
+		RubyVAL = Rubyrcvr.lval
 		Rubystate = Rubyn
 		if Errflag > 0 {
 			Errflag--
@@ -1082,27 +1546,27 @@ Rubynewstate:
 
 Rubydefault:
 	/* default state action */
-	Rubyn = RubyDef[Rubystate]
+	Rubyn = int(RubyDef[Rubystate])
 	if Rubyn == -2 {
-		if Rubychar < 0 {
-			Rubychar = Rubylex1(Rubylex, &Rubylval)
+		if Rubyrcvr.char < 0 {
+			Rubyrcvr.char, Rubytoken = Rubylex1(Rubylex, &Rubyrcvr.lval)
 		}
 
 		/* look through exception table */
 		xi := 0
 		for {
-			if RubyExca[xi+0] == -1 && RubyExca[xi+1] == Rubystate {
+			if RubyExca[xi+0] == -1 && int(RubyExca[xi+1]) == Rubystate {
 				break
 			}
 			xi += 2
 		}
 		for xi += 2; ; xi += 2 {
-			Rubyn = RubyExca[xi+0]
-			if Rubyn < 0 || Rubyn == Rubychar {
+			Rubyn = int(RubyExca[xi+0])
+			if Rubyn < 0 || Rubyn == Rubytoken {
 				break
 			}
 		}
-		Rubyn = RubyExca[xi+1]
+		Rubyn = int(RubyExca[xi+1])
 		if Rubyn < 0 {
 			goto ret0
 		}
@@ -1111,11 +1575,11 @@ Rubydefault:
 		/* error ... attempt to resume parsing */
 		switch Errflag {
 		case 0: /* brand new error */
-			Rubylex.Error("syntax error")
+			Rubylex.Error(RubyErrorMessage(Rubystate, Rubytoken))
 			Nerrs++
 			if RubyDebug >= 1 {
 				__yyfmt__.Printf("%s", RubyStatname(Rubystate))
-				__yyfmt__.Printf(" saw %s\n", RubyTokname(Rubychar))
+				__yyfmt__.Printf(" saw %s\n", RubyTokname(Rubytoken))
 			}
 			fallthrough
 
@@ -1124,10 +1588,10 @@ Rubydefault:
 
 			/* find a state where "error" is a legal shift action */
 			for Rubyp >= 0 {
-				Rubyn = RubyPact[RubyS[Rubyp].yys] + RubyErrCode
+				Rubyn = int(RubyPact[RubyS[Rubyp].yys]) + RubyErrCode
 				if Rubyn >= 0 && Rubyn < RubyLast {
-					Rubystate = RubyAct[Rubyn] /* simulate a shift of "error" */
-					if RubyChk[Rubystate] == RubyErrCode {
+					Rubystate = int(RubyAct[Rubyn]) /* simulate a shift of "error" */
+					if int(RubyChk[Rubystate]) == RubyErrCode {
 						goto Rubystack
 					}
 				}
@@ -1143,12 +1607,13 @@ Rubydefault:
 
 		case 3: /* no shift yet; clobber input char */
 			if RubyDebug >= 2 {
-				__yyfmt__.Printf("error recovery discards %s\n", RubyTokname(Rubychar))
+				__yyfmt__.Printf("error recovery discards %s\n", RubyTokname(Rubytoken))
 			}
-			if Rubychar == RubyEofCode {
+			if Rubytoken == RubyEofCode {
 				goto ret1
 			}
-			Rubychar = -1
+			Rubyrcvr.char = -1
+			Rubytoken = -1
 			goto Rubynewstate /* try again in the same state */
 		}
 	}
@@ -1162,1785 +1627,2575 @@ Rubydefault:
 	Rubypt := Rubyp
 	_ = Rubypt // guard against "declared and not used"
 
-	Rubyp -= RubyR2[Rubyn]
+	Rubyp -= int(RubyR2[Rubyn])
+	// Rubyp is now the index of $0. Perform the default action. Iff the
+	// reduced production is ε, $1 is possibly out of range.
+	if Rubyp+1 >= len(RubyS) {
+		nyys := make([]RubySymType, len(RubyS)*2)
+		copy(nyys, RubyS)
+		RubyS = nyys
+	}
 	RubyVAL = RubyS[Rubyp+1]
 
 	/* consult goto table to find next state */
-	Rubyn = RubyR1[Rubyn]
-	Rubyg := RubyPgo[Rubyn]
+	Rubyn = int(RubyR1[Rubyn])
+	Rubyg := int(RubyPgo[Rubyn])
 	Rubyj := Rubyg + RubyS[Rubyp].yys + 1
 
 	if Rubyj >= RubyLast {
-		Rubystate = RubyAct[Rubyg]
+		Rubystate = int(RubyAct[Rubyg])
 	} else {
-		Rubystate = RubyAct[Rubyj]
-		if RubyChk[Rubystate] != -Rubyn {
-			Rubystate = RubyAct[Rubyg]
+		Rubystate = int(RubyAct[Rubyj])
+		if int(RubyChk[Rubystate]) != -Rubyn {
+			Rubystate = int(RubyAct[Rubyg])
 		}
 	}
 	// dummy call; replaced with literal code
 	switch Rubynt {
 
 	case 1:
-		//line parser.y:224
+		RubyDollar = RubyS[Rubypt-0 : Rubypt+1]
+//line parser.y:266
 		{
 			Statements = []ast.Node{}
 		}
 	case 2:
-		//line parser.y:226
+		RubyDollar = RubyS[Rubypt-1 : Rubypt+1]
+//line parser.y:268
 		{
 		}
 	case 3:
-		//line parser.y:228
+		RubyDollar = RubyS[Rubypt-1 : Rubypt+1]
+//line parser.y:270
 		{
 		}
 	case 4:
-		//line parser.y:230
+		RubyDollar = RubyS[Rubypt-1 : Rubypt+1]
+//line parser.y:272
 		{
 		}
 	case 5:
-		//line parser.y:232
+		RubyDollar = RubyS[Rubypt-3 : Rubypt+1]
+//line parser.y:274
 		{
-			Statements = append(Statements, RubyS[Rubypt-1].genericValue)
+			Statements = append(Statements, RubyDollar[2].genericValue)
 		}
 	case 6:
-		//line parser.y:234
+		RubyDollar = RubyS[Rubypt-3 : Rubypt+1]
+//line parser.y:276
 		{
-			Statements = append(Statements, RubyS[Rubypt-1].genericValue)
+			Statements = append(Statements, RubyDollar[2].genericValue)
 		}
 	case 7:
-		//line parser.y:236
+		RubyDollar = RubyS[Rubypt-3 : Rubypt+1]
+//line parser.y:278
 		{
-			Statements = append(Statements, RubyS[Rubypt-1].genericValue)
+			Statements = append(Statements, RubyDollar[2].genericValue)
 		}
-	case 8:
-		RubyVAL.genericSlice = RubyS[Rubypt-0].genericSlice
-	case 9:
-		RubyVAL.genericSlice = RubyS[Rubypt-0].genericSlice
 	case 10:
-		//line parser.y:242
+		RubyDollar = RubyS[Rubypt-2 : Rubypt+1]
+//line parser.y:284
 		{
 		}
 	case 11:
-		//line parser.y:244
+		RubyDollar = RubyS[Rubypt-0 : Rubypt+1]
+//line parser.y:286
 		{
 		}
 	case 12:
-		//line parser.y:245
+		RubyDollar = RubyS[Rubypt-2 : Rubypt+1]
+//line parser.y:287
 		{
 		}
 	case 13:
-		//line parser.y:247
+		RubyDollar = RubyS[Rubypt-0 : Rubypt+1]
+//line parser.y:290
 		{
+			RubyVAL.genericSlice = ast.Nodes{}
 		}
 	case 14:
-		//line parser.y:248
+		RubyDollar = RubyS[Rubypt-2 : Rubypt+1]
+//line parser.y:292
 		{
 		}
 	case 15:
-		//line parser.y:251
+		RubyDollar = RubyS[Rubypt-2 : Rubypt+1]
+//line parser.y:294
 		{
-			RubyVAL.genericSlice = ast.Nodes{}
 		}
 	case 16:
-		//line parser.y:253
-		{
-		}
-	case 17:
-		//line parser.y:255
-		{
-		}
-	case 18:
-		//line parser.y:257
+		RubyDollar = RubyS[Rubypt-2 : Rubypt+1]
+//line parser.y:296
 		{
-			RubyVAL.genericSlice = append(RubyVAL.genericSlice, RubyS[Rubypt-0].genericValue)
+			RubyVAL.genericSlice = append(RubyVAL.genericSlice, RubyDollar[2].genericValue)
 		}
 	case 19:
-		RubyVAL.genericValue = RubyS[Rubypt-0].genericValue
-	case 20:
-		RubyVAL.genericValue = RubyS[Rubypt-0].genericValue
-	case 21:
-		RubyVAL.genericValue = RubyS[Rubypt-0].genericValue
-	case 22:
-		RubyVAL.genericValue = RubyS[Rubypt-0].genericValue
-	case 23:
-		RubyVAL.genericValue = RubyS[Rubypt-0].genericValue
-	case 24:
-		RubyVAL.genericValue = RubyS[Rubypt-0].genericValue
-	case 25:
-		RubyVAL.genericValue = RubyS[Rubypt-0].genericValue
-	case 26:
-		RubyVAL.genericValue = RubyS[Rubypt-0].genericValue
-	case 27:
-		RubyVAL.genericValue = RubyS[Rubypt-0].genericValue
-	case 28:
-		RubyVAL.genericValue = RubyS[Rubypt-0].genericValue
-	case 29:
-		RubyVAL.genericValue = RubyS[Rubypt-0].genericValue
-	case 30:
-		RubyVAL.genericValue = RubyS[Rubypt-0].genericValue
-	case 31:
-		RubyVAL.genericValue = RubyS[Rubypt-0].genericValue
-	case 32:
-		RubyVAL.genericValue = RubyS[Rubypt-0].genericValue
-	case 33:
-		RubyVAL.genericValue = RubyS[Rubypt-0].genericValue
-	case 34:
-		RubyVAL.genericValue = RubyS[Rubypt-0].genericValue
-	case 35:
-		RubyVAL.genericValue = RubyS[Rubypt-0].genericValue
-	case 36:
-		RubyVAL.genericValue = RubyS[Rubypt-0].genericValue
-	case 37:
-		RubyVAL.genericValue = RubyS[Rubypt-0].genericValue
-	case 38:
-		RubyVAL.genericValue = RubyS[Rubypt-0].genericValue
-	case 39:
-		RubyVAL.genericValue = RubyS[Rubypt-0].genericValue
-	case 40:
-		RubyVAL.genericValue = RubyS[Rubypt-0].genericValue
-	case 41:
-		RubyVAL.genericValue = RubyS[Rubypt-0].genericValue
-	case 42:
-		RubyVAL.genericValue = RubyS[Rubypt-0].genericValue
-	case 43:
-		RubyVAL.genericValue = RubyS[Rubypt-0].genericValue
-	case 44:
-		RubyVAL.genericValue = RubyS[Rubypt-0].genericValue
-	case 45:
-		RubyVAL.genericValue = RubyS[Rubypt-0].genericValue
-	case 46:
-		RubyVAL.genericValue = RubyS[Rubypt-0].genericValue
-	case 47:
-		RubyVAL.genericValue = RubyS[Rubypt-0].genericValue
-	case 48:
-		RubyVAL.genericValue = RubyS[Rubypt-0].genericValue
-	case 49:
-		RubyVAL.genericValue = RubyS[Rubypt-0].genericValue
-	case 50:
-		RubyVAL.genericValue = RubyS[Rubypt-0].genericValue
-	case 51:
-		RubyVAL.genericValue = RubyS[Rubypt-0].genericValue
-	case 52:
-		RubyVAL.genericValue = RubyS[Rubypt-0].genericValue
-	case 53:
-		RubyVAL.genericValue = RubyS[Rubypt-0].genericValue
-	case 54:
-		RubyVAL.genericValue = RubyS[Rubypt-0].genericValue
-	case 55:
-		RubyVAL.genericValue = RubyS[Rubypt-0].genericValue
-	case 56:
-		RubyVAL.genericValue = RubyS[Rubypt-0].genericValue
-	case 57:
-		RubyVAL.genericValue = RubyS[Rubypt-0].genericValue
-	case 58:
-		RubyVAL.genericValue = RubyS[Rubypt-0].genericValue
-	case 59:
-		RubyVAL.genericValue = RubyS[Rubypt-0].genericValue
-	case 60:
-		RubyVAL.genericValue = RubyS[Rubypt-0].genericValue
-	case 61:
-		RubyVAL.genericValue = RubyS[Rubypt-0].genericValue
-	case 62:
-		RubyVAL.genericValue = RubyS[Rubypt-0].genericValue
-	case 63:
-		RubyVAL.genericValue = RubyS[Rubypt-0].genericValue
-	case 64:
-		RubyVAL.genericValue = RubyS[Rubypt-0].genericValue
-	case 65:
-		RubyVAL.genericValue = RubyS[Rubypt-0].genericValue
-	case 66:
-		RubyVAL.genericValue = RubyS[Rubypt-0].genericValue
-	case 67:
-		RubyVAL.genericValue = RubyS[Rubypt-0].genericValue
-	case 68:
-		RubyVAL.genericValue = RubyS[Rubypt-0].genericValue
-	case 69:
-		RubyVAL.genericValue = RubyS[Rubypt-0].genericValue
-	case 70:
-		RubyVAL.genericValue = RubyS[Rubypt-0].genericValue
-	case 71:
-		RubyVAL.genericValue = RubyS[Rubypt-0].genericValue
-	case 72:
-		RubyVAL.genericValue = RubyS[Rubypt-0].genericValue
-	case 73:
-		RubyVAL.genericValue = RubyS[Rubypt-0].genericValue
-	case 74:
-		RubyVAL.genericValue = RubyS[Rubypt-0].genericValue
-	case 75:
-		//line parser.y:269
-		{
-			RubyVAL.genericValue = ast.RescueModifier{Statement: RubyS[Rubypt-2].genericValue, Rescue: RubyS[Rubypt-0].genericValue}
-		}
-	case 76:
-		//line parser.y:272
-		{
-			RubyVAL.genericValue = ast.StarSplat{Value: RubyS[Rubypt-0].genericValue}
-		}
-	case 77:
-		//line parser.y:275
-		{
-			RubyVAL.genericValue = ast.CallExpression{
-				Func: RubyS[Rubypt-3].genericValue.(ast.BareReference),
-				Args: RubyS[Rubypt-1].genericSlice,
-			}
-		}
-	case 78:
-		//line parser.y:282
+		RubyDollar = RubyS[Rubypt-1 : Rubypt+1]
+//line parser.y:300
 		{
-			RubyVAL.genericValue = ast.CallExpression{
-				Func:          RubyS[Rubypt-4].genericValue.(ast.BareReference),
-				Args:          RubyS[Rubypt-2].genericSlice,
-				OptionalBlock: RubyS[Rubypt-0].genericBlock,
+			// a bare raise re-raises the current exception, so it is always a call
+			if ref, ok := RubyDollar[1].genericValue.(ast.BareReference); ok && ref.Name == "raise" {
+				RubyVAL.genericValue = ast.CallExpression{Func: ref, Args: []ast.Node{}}
+			} else {
+				RubyVAL.genericValue = RubyDollar[1].genericValue
 			}
 		}
-	case 79:
-		//line parser.y:290
-		{
-			RubyVAL.genericValue = ast.CallExpression{Func: RubyS[Rubypt-0].genericValue.(ast.BareReference)}
-		}
-	case 80:
-		//line parser.y:294
+	case 32:
+		RubyDollar = RubyS[Rubypt-1 : Rubypt+1]
+//line parser.y:313
 		{
-			RubyVAL.genericValue = ast.CallExpression{
-				Func: RubyS[Rubypt-3].genericValue.(ast.BareReference),
-				Args: RubyS[Rubypt-1].genericSlice,
-			}
+			RubyVAL.genericValue = withPosition(RubyDollar[1].genericValue, RubyDollar[1].pos)
 		}
-	case 81:
-		//line parser.y:301
+	case 57:
+		RubyDollar = RubyS[Rubypt-1 : Rubypt+1]
+//line parser.y:321
 		{
-			RubyVAL.genericValue = ast.CallExpression{
-				Func: RubyS[Rubypt-3].genericValue.(ast.BareReference),
-				Args: RubyS[Rubypt-1].genericSlice,
-			}
+			RubyVAL.genericValue = withPosition(RubyDollar[1].genericValue, RubyDollar[1].pos)
 		}
 	case 82:
-		//line parser.y:308
+		RubyDollar = RubyS[Rubypt-3 : Rubypt+1]
+//line parser.y:326
 		{
-			RubyVAL.genericValue = ast.CallExpression{
-				Func: RubyS[Rubypt-1].genericValue.(ast.BareReference),
-				Args: RubyS[Rubypt-0].genericSlice,
-			}
+			RubyVAL.genericValue = ast.RescueModifier{Statement: RubyDollar[1].genericValue, Rescue: RubyDollar[3].genericValue}
 		}
 	case 83:
-		//line parser.y:315
+		RubyDollar = RubyS[Rubypt-2 : Rubypt+1]
+//line parser.y:329
 		{
-			RubyVAL.genericValue = ast.CallExpression{
-				Func:          RubyS[Rubypt-2].genericValue.(ast.BareReference),
-				Args:          RubyS[Rubypt-1].genericSlice,
-				OptionalBlock: RubyS[Rubypt-0].genericBlock,
-			}
+			RubyVAL.genericValue = ast.StarSplat{Value: RubyDollar[2].genericValue}
 		}
 	case 84:
-		//line parser.y:323
+		RubyDollar = RubyS[Rubypt-2 : Rubypt+1]
+//line parser.y:332
 		{
-			RubyVAL.genericValue = ast.CallExpression{
-				Func:          RubyS[Rubypt-1].genericValue.(ast.BareReference),
-				Args:          []ast.Node{},
-				OptionalBlock: RubyS[Rubypt-0].genericBlock,
-			}
+			RubyVAL.genericValue = ast.DoubleStarSplat{Value: RubyDollar[2].genericValue}
 		}
 	case 85:
-		//line parser.y:331
+		RubyDollar = RubyS[Rubypt-4 : Rubypt+1]
+//line parser.y:335
 		{
 			RubyVAL.genericValue = ast.CallExpression{
-				Target: RubyS[Rubypt-2].genericValue,
-				Func:   RubyS[Rubypt-0].genericValue.(ast.BareReference),
+				Func: RubyDollar[1].genericValue.(ast.BareReference),
+				Args: RubyDollar[3].genericSlice,
 			}
 		}
 	case 86:
-		//line parser.y:338
+		RubyDollar = RubyS[Rubypt-5 : Rubypt+1]
+//line parser.y:342
 		{
 			RubyVAL.genericValue = ast.CallExpression{
-				Target:        RubyS[Rubypt-3].genericValue,
-				Func:          RubyS[Rubypt-1].genericValue.(ast.BareReference),
-				Args:          []ast.Node{},
-				OptionalBlock: RubyS[Rubypt-0].genericBlock,
+				Func:          RubyDollar[1].genericValue.(ast.BareReference),
+				Args:          RubyDollar[3].genericSlice,
+				OptionalBlock: RubyDollar[5].genericBlock,
 			}
 		}
 	case 87:
-		//line parser.y:347
+		RubyDollar = RubyS[Rubypt-1 : Rubypt+1]
+//line parser.y:350
 		{
-			RubyVAL.genericValue = ast.CallExpression{
-				Target:        RubyS[Rubypt-4].genericValue,
-				Func:          RubyS[Rubypt-2].genericValue.(ast.BareReference),
-				Args:          RubyS[Rubypt-1].genericSlice,
-				OptionalBlock: RubyS[Rubypt-0].genericBlock,
-			}
+			RubyVAL.genericValue = ast.CallExpression{Func: RubyDollar[1].genericValue.(ast.BareReference)}
 		}
 	case 88:
-		//line parser.y:356
+		RubyDollar = RubyS[Rubypt-4 : Rubypt+1]
+//line parser.y:354
 		{
 			RubyVAL.genericValue = ast.CallExpression{
-				Target: RubyS[Rubypt-3].genericValue,
-				Func:   RubyS[Rubypt-1].genericValue.(ast.BareReference),
-				Args:   RubyS[Rubypt-0].genericSlice,
+				Func: RubyDollar[1].genericValue.(ast.BareReference),
+				Args: RubyDollar[3].genericSlice,
 			}
 		}
 	case 89:
-		//line parser.y:364
+		RubyDollar = RubyS[Rubypt-4 : Rubypt+1]
+//line parser.y:361
 		{
 			RubyVAL.genericValue = ast.CallExpression{
-				Target: RubyS[Rubypt-2].genericValue,
-				Func:   RubyS[Rubypt-0].genericValue.(ast.BareReference),
-				Args:   []ast.Node{},
+				Func: RubyDollar[1].genericValue.(ast.BareReference),
+				Args: RubyDollar[3].genericSlice,
 			}
 		}
 	case 90:
-		//line parser.y:372
+		RubyDollar = RubyS[Rubypt-2 : Rubypt+1]
+//line parser.y:368
 		{
 			RubyVAL.genericValue = ast.CallExpression{
-				Target:        RubyS[Rubypt-3].genericValue,
-				Func:          RubyS[Rubypt-1].genericValue.(ast.BareReference),
-				Args:          []ast.Node{},
-				OptionalBlock: RubyS[Rubypt-0].genericBlock,
+				Func: RubyDollar[1].genericValue.(ast.BareReference),
+				Args: RubyDollar[2].genericSlice,
 			}
 		}
 	case 91:
-		//line parser.y:381
+		RubyDollar = RubyS[Rubypt-3 : Rubypt+1]
+//line parser.y:375
 		{
-			methodName := RubyS[Rubypt-2].genericValue.(ast.BareReference).Name + "="
 			RubyVAL.genericValue = ast.CallExpression{
-				Func:   ast.BareReference{Name: methodName},
-				Target: RubyS[Rubypt-4].genericValue,
-				Args:   []ast.Node{RubyS[Rubypt-0].genericValue},
+				Func:          RubyDollar[1].genericValue.(ast.BareReference),
+				Args:          RubyDollar[2].genericSlice,
+				OptionalBlock: RubyDollar[3].genericBlock,
 			}
 		}
 	case 92:
-		//line parser.y:392
+		RubyDollar = RubyS[Rubypt-2 : Rubypt+1]
+//line parser.y:383
 		{
 			RubyVAL.genericValue = ast.CallExpression{
-				Func: RubyS[Rubypt-1].genericValue.(ast.BareReference),
-				Args: RubyS[Rubypt-0].genericSlice,
+				Func:          RubyDollar[1].genericValue.(ast.BareReference),
+				Args:          []ast.Node{},
+				OptionalBlock: RubyDollar[2].genericBlock,
 			}
 		}
 	case 93:
-		//line parser.y:399
+		RubyDollar = RubyS[Rubypt-3 : Rubypt+1]
+//line parser.y:391
 		{
 			RubyVAL.genericValue = ast.CallExpression{
-				Func:          RubyS[Rubypt-2].genericValue.(ast.BareReference),
-				Args:          RubyS[Rubypt-1].genericSlice,
-				OptionalBlock: RubyS[Rubypt-0].genericBlock,
+				Target: RubyDollar[1].genericValue,
+				Func:   RubyDollar[3].genericValue.(ast.BareReference),
 			}
 		}
 	case 94:
-		//line parser.y:407
+		RubyDollar = RubyS[Rubypt-4 : Rubypt+1]
+//line parser.y:398
 		{
 			RubyVAL.genericValue = ast.CallExpression{
-				Func:   ast.BareReference{Name: "<"},
-				Target: RubyS[Rubypt-2].genericValue,
-				Args:   []ast.Node{RubyS[Rubypt-0].genericValue},
+				Target:        RubyDollar[1].genericValue,
+				Func:          RubyDollar[3].genericValue.(ast.BareReference),
+				Args:          []ast.Node{},
+				OptionalBlock: RubyDollar[4].genericBlock,
 			}
 		}
 	case 95:
-		//line parser.y:415
+		RubyDollar = RubyS[Rubypt-5 : Rubypt+1]
+//line parser.y:407
 		{
 			RubyVAL.genericValue = ast.CallExpression{
-				Func:   ast.BareReference{Name: "<"},
-				Target: RubyS[Rubypt-2].genericValue,
-				Args:   []ast.Node{RubyS[Rubypt-0].genericValue},
+				Target:        RubyDollar[1].genericValue,
+				Func:          RubyDollar[3].genericValue.(ast.BareReference),
+				Args:          RubyDollar[4].genericSlice,
+				OptionalBlock: RubyDollar[5].genericBlock,
 			}
 		}
 	case 96:
-		//line parser.y:423
+		RubyDollar = RubyS[Rubypt-4 : Rubypt+1]
+//line parser.y:416
 		{
 			RubyVAL.genericValue = ast.CallExpression{
-				Func:   ast.BareReference{Name: ">"},
-				Target: RubyS[Rubypt-2].genericValue,
-				Args:   []ast.Node{RubyS[Rubypt-0].genericValue},
+				Target: RubyDollar[1].genericValue,
+				Func:   RubyDollar[3].genericValue.(ast.BareReference),
+				Args:   RubyDollar[4].genericSlice,
 			}
 		}
 	case 97:
-		//line parser.y:433
+		RubyDollar = RubyS[Rubypt-3 : Rubypt+1]
+//line parser.y:424
 		{
 			RubyVAL.genericValue = ast.CallExpression{
-				Func:   ast.BareReference{Name: "[]"},
-				Target: RubyS[Rubypt-3].genericValue,
-				Args:   []ast.Node{RubyS[Rubypt-1].genericValue},
+				Target: withPosition(RubyDollar[1].genericValue, RubyDollar[1].pos),
+				Func:   RubyDollar[3].genericValue.(ast.BareReference),
+				Args:   []ast.Node{},
 			}
 		}
 	case 98:
-		//line parser.y:441
+		RubyDollar = RubyS[Rubypt-4 : Rubypt+1]
+//line parser.y:432
 		{
 			RubyVAL.genericValue = ast.CallExpression{
-				Func:   ast.BareReference{Name: "[]"},
-				Target: RubyS[Rubypt-3].genericValue,
-				Args:   []ast.Node{RubyS[Rubypt-1].genericValue},
+				Target:        withPosition(RubyDollar[1].genericValue, RubyDollar[1].pos),
+				Func:          RubyDollar[3].genericValue.(ast.BareReference),
+				Args:          []ast.Node{},
+				OptionalBlock: RubyDollar[4].genericBlock,
 			}
 		}
 	case 99:
-		//line parser.y:449
+		RubyDollar = RubyS[Rubypt-4 : Rubypt+1]
+//line parser.y:441
 		{
 			RubyVAL.genericValue = ast.CallExpression{
-				Func:   ast.BareReference{Name: "[]"},
-				Target: RubyS[Rubypt-3].genericValue,
-				Args:   []ast.Node{RubyS[Rubypt-1].genericValue},
+				Target: withPosition(RubyDollar[1].genericValue, RubyDollar[1].pos),
+				Func:   RubyDollar[3].genericValue.(ast.BareReference),
+				Args:   RubyDollar[4].genericSlice,
 			}
 		}
 	case 100:
-		//line parser.y:457
+		RubyDollar = RubyS[Rubypt-5 : Rubypt+1]
+//line parser.y:449
 		{
 			RubyVAL.genericValue = ast.CallExpression{
-				Func:   ast.BareReference{Name: "[]"},
-				Target: RubyS[Rubypt-3].genericValue,
-				Args:   []ast.Node{RubyS[Rubypt-1].genericValue},
+				Target:        withPosition(RubyDollar[1].genericValue, RubyDollar[1].pos),
+				Func:          RubyDollar[3].genericValue.(ast.BareReference),
+				Args:          RubyDollar[4].genericSlice,
+				OptionalBlock: RubyDollar[5].genericBlock,
 			}
 		}
 	case 101:
-		//line parser.y:465
+		RubyDollar = RubyS[Rubypt-5 : Rubypt+1]
+//line parser.y:458
 		{
+			methodName := RubyDollar[3].genericValue.(ast.BareReference).Name + "="
 			RubyVAL.genericValue = ast.CallExpression{
-				Func:   ast.BareReference{Name: "[]"},
-				Target: RubyS[Rubypt-3].genericValue,
-				Args:   []ast.Node{RubyS[Rubypt-1].genericValue},
+				Func:   ast.BareReference{Name: methodName},
+				Target: RubyDollar[1].genericValue,
+				Args:   []ast.Node{RubyDollar[5].genericValue},
 			}
 		}
 	case 102:
-		//line parser.y:473
+		RubyDollar = RubyS[Rubypt-3 : Rubypt+1]
+//line parser.y:467
 		{
 			RubyVAL.genericValue = ast.CallExpression{
-				Func:   ast.BareReference{Name: "[]"},
-				Target: RubyS[Rubypt-3].genericValue,
-				Args:   RubyS[Rubypt-1].genericSlice,
+				Target:         RubyDollar[1].genericValue,
+				Func:           RubyDollar[3].genericValue.(ast.BareReference),
+				SafeNavigation: true,
 			}
 		}
 	case 103:
-		//line parser.y:481
+		RubyDollar = RubyS[Rubypt-4 : Rubypt+1]
+//line parser.y:475
 		{
 			RubyVAL.genericValue = ast.CallExpression{
-				Func:   ast.BareReference{Name: "[]"},
-				Target: RubyS[Rubypt-3].genericValue,
-				Args:   RubyS[Rubypt-1].genericSlice,
+				Target:         RubyDollar[1].genericValue,
+				Func:           RubyDollar[3].genericValue.(ast.BareReference),
+				Args:           RubyDollar[4].genericSlice,
+				SafeNavigation: true,
 			}
 		}
 	case 104:
-		//line parser.y:489
+		RubyDollar = RubyS[Rubypt-4 : Rubypt+1]
+//line parser.y:484
 		{
 			RubyVAL.genericValue = ast.CallExpression{
-				Func:   ast.BareReference{Name: "[]"},
-				Target: RubyS[Rubypt-3].genericValue,
-				Args:   RubyS[Rubypt-1].genericSlice,
+				Target:         RubyDollar[1].genericValue,
+				Func:           RubyDollar[3].genericValue.(ast.BareReference),
+				Args:           []ast.Node{},
+				OptionalBlock:  RubyDollar[4].genericBlock,
+				SafeNavigation: true,
 			}
 		}
 	case 105:
-		//line parser.y:497
+		RubyDollar = RubyS[Rubypt-5 : Rubypt+1]
+//line parser.y:494
 		{
+			methodName := RubyDollar[3].genericValue.(ast.BareReference).Name + "="
 			RubyVAL.genericValue = ast.CallExpression{
-				Func:   ast.BareReference{Name: "[]"},
-				Target: RubyS[Rubypt-3].genericValue,
-				Args:   []ast.Node{RubyS[Rubypt-1].genericValue},
+				Func:           ast.BareReference{Name: methodName},
+				Target:         RubyDollar[1].genericValue,
+				Args:           []ast.Node{RubyDollar[5].genericValue},
+				SafeNavigation: true,
 			}
 		}
 	case 106:
-		//line parser.y:507
+		RubyDollar = RubyS[Rubypt-2 : Rubypt+1]
+//line parser.y:506
 		{
 			RubyVAL.genericValue = ast.CallExpression{
-				Func:   ast.BareReference{Name: "[]="},
-				Target: RubyS[Rubypt-5].genericValue,
-				Args:   []ast.Node{RubyS[Rubypt-3].genericValue, RubyS[Rubypt-0].genericValue},
+				Func: RubyDollar[1].genericValue.(ast.BareReference),
+				Args: RubyDollar[2].genericSlice,
 			}
 		}
 	case 107:
-		//line parser.y:515
+		RubyDollar = RubyS[Rubypt-3 : Rubypt+1]
+//line parser.y:513
 		{
-			if RubyS[Rubypt-0].genericValue == nil {
-				panic("WHAT THE EVER COMPILING FUCK")
-			}
 			RubyVAL.genericValue = ast.CallExpression{
-				Func:   ast.BareReference{Name: "[]="},
-				Target: RubyS[Rubypt-6].genericValue,
-				Args:   []ast.Node{RubyS[Rubypt-4].genericValue, RubyS[Rubypt-0].genericValue},
+				Func:          RubyDollar[1].genericValue.(ast.BareReference),
+				Args:          RubyDollar[2].genericSlice,
+				OptionalBlock: RubyDollar[3].genericBlock,
 			}
 		}
 	case 108:
-		//line parser.y:526
+		RubyDollar = RubyS[Rubypt-3 : Rubypt+1]
+//line parser.y:521
 		{
 			RubyVAL.genericValue = ast.CallExpression{
-				Func:   ast.BareReference{Name: "[]="},
-				Target: RubyS[Rubypt-5].genericValue,
-				Args:   []ast.Node{RubyS[Rubypt-3].genericValue, RubyS[Rubypt-0].genericValue},
+				Func:   ast.BareReference{Name: "<"},
+				Target: RubyDollar[1].genericValue,
+				Args:   []ast.Node{RubyDollar[3].genericValue},
 			}
 		}
 	case 109:
-		//line parser.y:534
+		RubyDollar = RubyS[Rubypt-3 : Rubypt+1]
+//line parser.y:529
 		{
 			RubyVAL.genericValue = ast.CallExpression{
-				Func:   ast.BareReference{Name: "[]="},
-				Target: RubyS[Rubypt-5].genericValue,
-				Args:   []ast.Node{RubyS[Rubypt-3].genericValue, RubyS[Rubypt-0].genericValue},
+				Func:   ast.BareReference{Name: "<"},
+				Target: withPosition(RubyDollar[1].genericValue, RubyDollar[1].pos),
+				Args:   []ast.Node{RubyDollar[3].genericValue},
 			}
 		}
 	case 110:
-		//line parser.y:544
+		RubyDollar = RubyS[Rubypt-3 : Rubypt+1]
+//line parser.y:537
 		{
 			RubyVAL.genericValue = ast.CallExpression{
-				Func:   ast.BareReference{Name: RubyS[Rubypt-2].operator},
-				Target: RubyS[Rubypt-3].genericValue,
-				Args:   []ast.Node{RubyS[Rubypt-0].genericValue},
+				Func:   ast.BareReference{Name: ">"},
+				Target: RubyDollar[1].genericValue,
+				Args:   []ast.Node{RubyDollar[3].genericValue},
 			}
 		}
 	case 111:
-		//line parser.y:554
+		RubyDollar = RubyS[Rubypt-4 : Rubypt+1]
+//line parser.y:547
 		{
-			RubyVAL.genericSlice = RubyS[Rubypt-1].genericSlice
+			RubyVAL.genericValue = ast.CallExpression{
+				Func:   ast.BareReference{Name: "[]"},
+				Target: RubyDollar[1].genericValue,
+				Args:   []ast.Node{RubyDollar[3].genericValue},
+			}
 		}
 	case 112:
-		//line parser.y:556
+		RubyDollar = RubyS[Rubypt-4 : Rubypt+1]
+//line parser.y:555
 		{
-			RubyVAL.genericSlice = append(RubyS[Rubypt-4].genericSlice, RubyS[Rubypt-1].genericValue)
+			RubyVAL.genericValue = ast.CallExpression{
+				Func:   ast.BareReference{Name: "[]"},
+				Target: RubyDollar[1].genericValue,
+				Args:   []ast.Node{RubyDollar[3].genericValue},
+			}
 		}
 	case 113:
-		//line parser.y:558
+		RubyDollar = RubyS[Rubypt-4 : Rubypt+1]
+//line parser.y:563
 		{
-			RubyVAL.genericSlice = RubyS[Rubypt-0].genericSlice
+			RubyVAL.genericValue = ast.CallExpression{
+				Func:   ast.BareReference{Name: "[]"},
+				Target: RubyDollar[1].genericValue,
+				Args:   []ast.Node{RubyDollar[3].genericValue},
+			}
 		}
 	case 114:
-		//line parser.y:560
+		RubyDollar = RubyS[Rubypt-4 : Rubypt+1]
+//line parser.y:571
 		{
-			RubyVAL.genericSlice = append(RubyS[Rubypt-3].genericSlice, RubyS[Rubypt-0].genericValue)
+			RubyVAL.genericValue = ast.CallExpression{
+				Func:   ast.BareReference{Name: "[]"},
+				Target: RubyDollar[1].genericValue,
+				Args:   []ast.Node{withPosition(RubyDollar[3].genericValue, RubyDollar[3].pos)},
+			}
 		}
 	case 115:
-		//line parser.y:563
+		RubyDollar = RubyS[Rubypt-4 : Rubypt+1]
+//line parser.y:579
 		{
-			RubyVAL.genericSlice = append(RubyVAL.genericSlice, RubyS[Rubypt-0].genericValue)
+			RubyVAL.genericValue = ast.CallExpression{
+				Func:   ast.BareReference{Name: "[]"},
+				Target: RubyDollar[1].genericValue,
+				Args:   []ast.Node{withPosition(RubyDollar[3].genericValue, RubyDollar[3].pos)},
+			}
 		}
 	case 116:
-		//line parser.y:565
+		RubyDollar = RubyS[Rubypt-4 : Rubypt+1]
+//line parser.y:587
 		{
-			RubyVAL.genericSlice = append(RubyVAL.genericSlice, RubyS[Rubypt-0].genericValue)
+			RubyVAL.genericValue = ast.CallExpression{
+				Func:   ast.BareReference{Name: "[]"},
+				Target: RubyDollar[1].genericValue,
+				Args:   RubyDollar[3].genericSlice,
+			}
 		}
 	case 117:
-		//line parser.y:567
+		RubyDollar = RubyS[Rubypt-4 : Rubypt+1]
+//line parser.y:595
 		{
-			RubyVAL.genericSlice = ast.Nodes{}
+			RubyVAL.genericValue = ast.CallExpression{
+				Func:   ast.BareReference{Name: "[]"},
+				Target: RubyDollar[1].genericValue,
+				Args:   RubyDollar[3].genericSlice,
+			}
 		}
 	case 118:
-		//line parser.y:569
+		RubyDollar = RubyS[Rubypt-4 : Rubypt+1]
+//line parser.y:603
 		{
-			RubyVAL.genericSlice = append(RubyVAL.genericSlice, RubyS[Rubypt-0].genericValue)
+			RubyVAL.genericValue = ast.CallExpression{
+				Func:   ast.BareReference{Name: "[]"},
+				Target: withPosition(RubyDollar[1].genericValue, RubyDollar[1].pos),
+				Args:   RubyDollar[3].genericSlice,
+			}
 		}
 	case 119:
-		//line parser.y:571
+		RubyDollar = RubyS[Rubypt-4 : Rubypt+1]
+//line parser.y:611
 		{
-			RubyVAL.genericSlice = append(RubyVAL.genericSlice, RubyS[Rubypt-0].genericValue)
+			RubyVAL.genericValue = ast.CallExpression{
+				Func:   ast.BareReference{Name: "[]"},
+				Target: withPosition(RubyDollar[1].genericValue, RubyDollar[1].pos),
+				Args:   []ast.Node{RubyDollar[3].genericValue},
+			}
 		}
 	case 120:
-		//line parser.y:573
+		RubyDollar = RubyS[Rubypt-6 : Rubypt+1]
+//line parser.y:621
 		{
-			RubyVAL.genericSlice = append(RubyVAL.genericSlice, RubyS[Rubypt-0].genericValue)
+			RubyVAL.genericValue = ast.CallExpression{
+				Func:   ast.BareReference{Name: "[]="},
+				Target: RubyDollar[1].genericValue,
+				Args:   []ast.Node{RubyDollar[3].genericValue, RubyDollar[6].genericValue},
+			}
 		}
 	case 121:
-		//line parser.y:575
+		RubyDollar = RubyS[Rubypt-7 : Rubypt+1]
+//line parser.y:629
 		{
-			RubyVAL.genericSlice = append(RubyVAL.genericSlice, RubyS[Rubypt-0].genericValue)
+			if RubyDollar[7].genericValue == nil {
+				panic("WHAT THE EVER COMPILING FUCK")
+			}
+			RubyVAL.genericValue = ast.CallExpression{
+				Func:   ast.BareReference{Name: "[]="},
+				Target: RubyDollar[1].genericValue,
+				Args:   []ast.Node{RubyDollar[3].genericValue, RubyDollar[7].genericValue},
+			}
 		}
 	case 122:
-		//line parser.y:577
+		RubyDollar = RubyS[Rubypt-6 : Rubypt+1]
+//line parser.y:640
 		{
-			RubyVAL.genericSlice = append(RubyVAL.genericSlice, RubyS[Rubypt-0].genericValue)
+			RubyVAL.genericValue = ast.CallExpression{
+				Func:   ast.BareReference{Name: "[]="},
+				Target: RubyDollar[1].genericValue,
+				Args:   []ast.Node{RubyDollar[3].genericValue, RubyDollar[6].genericValue},
+			}
 		}
 	case 123:
-		//line parser.y:579
-		{
-			RubyVAL.genericSlice = append(RubyVAL.genericSlice, RubyS[Rubypt-0].genericValue)
-		}
-	case 124:
-		//line parser.y:582
+		RubyDollar = RubyS[Rubypt-6 : Rubypt+1]
+//line parser.y:648
 		{
 			RubyVAL.genericValue = ast.CallExpression{
-				Func:   ast.BareReference{Name: "to_proc"},
-				Target: RubyS[Rubypt-0].genericValue,
+				Func:   ast.BareReference{Name: "[]="},
+				Target: withPosition(RubyDollar[1].genericValue, RubyDollar[1].pos),
+				Args:   []ast.Node{RubyDollar[3].genericValue, RubyDollar[6].genericValue},
 			}
 		}
-	case 125:
-		//line parser.y:590
-		{
-			RubyVAL.genericSlice = append(RubyVAL.genericSlice, RubyS[Rubypt-0].genericValue)
-		}
 	case 126:
-		//line parser.y:592
+		RubyDollar = RubyS[Rubypt-4 : Rubypt+1]
+//line parser.y:660
 		{
-			RubyVAL.genericSlice = append(RubyVAL.genericSlice, RubyS[Rubypt-0].genericValue)
+			RubyVAL.genericValue = ast.CallExpression{
+				Func:   ast.BareReference{Name: RubyDollar[2].operator},
+				Target: RubyDollar[1].genericValue,
+				Args:   []ast.Node{RubyDollar[4].genericValue},
+			}
 		}
 	case 127:
-		//line parser.y:596
+		RubyDollar = RubyS[Rubypt-3 : Rubypt+1]
+//line parser.y:670
 		{
-			RubyVAL.genericValue = ast.FuncDecl{
-				Name: RubyS[Rubypt-3].genericValue.(ast.BareReference),
-				Args: RubyS[Rubypt-2].genericSlice,
-				Body: RubyS[Rubypt-1].genericSlice,
-			}
+			RubyVAL.genericSlice = RubyDollar[2].genericSlice
 		}
 	case 128:
-		//line parser.y:604
+		RubyDollar = RubyS[Rubypt-6 : Rubypt+1]
+//line parser.y:672
 		{
-			RubyVAL.genericValue = ast.FuncDecl{
-				Name:    RubyS[Rubypt-4].genericValue.(ast.BareReference),
-				Args:    RubyS[Rubypt-3].genericSlice,
-				Body:    RubyS[Rubypt-2].genericSlice,
-				Rescues: RubyS[Rubypt-1].genericSlice,
-			}
+			RubyVAL.genericSlice = append(RubyDollar[2].genericSlice, withPosition(RubyDollar[5].genericValue, RubyDollar[5].pos))
 		}
 	case 129:
-		//line parser.y:613
+		RubyDollar = RubyS[Rubypt-1 : Rubypt+1]
+//line parser.y:674
 		{
-			RubyVAL.genericValue = ast.FuncDecl{
-				Target: RubyS[Rubypt-5].genericValue,
-				Name:   RubyS[Rubypt-3].genericValue.(ast.BareReference),
-				Args:   RubyS[Rubypt-2].genericSlice,
-				Body:   RubyS[Rubypt-1].genericSlice,
-			}
+			RubyVAL.genericSlice = RubyDollar[1].genericSlice
 		}
 	case 130:
-		//line parser.y:622
+		RubyDollar = RubyS[Rubypt-4 : Rubypt+1]
+//line parser.y:676
 		{
-			RubyVAL.genericValue = ast.FuncDecl{
-				Target: RubyS[Rubypt-5].genericValue,
-				Name:   RubyS[Rubypt-3].genericValue.(ast.BareReference),
-				Args:   RubyS[Rubypt-2].genericSlice,
-				Body:   RubyS[Rubypt-1].genericSlice,
-			}
+			RubyVAL.genericSlice = append(RubyDollar[1].genericSlice, withPosition(RubyDollar[4].genericValue, RubyDollar[4].pos))
 		}
 	case 131:
-		//line parser.y:631
+		RubyDollar = RubyS[Rubypt-1 : Rubypt+1]
+//line parser.y:679
 		{
-			RubyVAL.genericValue = ast.FuncDecl{
-				Target:  RubyS[Rubypt-6].genericValue,
-				Name:    RubyS[Rubypt-4].genericValue.(ast.BareReference),
-				Args:    RubyS[Rubypt-3].genericSlice,
-				Body:    RubyS[Rubypt-2].genericSlice,
-				Rescues: RubyS[Rubypt-1].genericSlice,
-			}
+			RubyVAL.genericSlice = append(RubyVAL.genericSlice, RubyDollar[1].genericValue)
 		}
 	case 132:
-		//line parser.y:641
+		RubyDollar = RubyS[Rubypt-1 : Rubypt+1]
+//line parser.y:681
 		{
-			RubyVAL.genericValue = ast.FuncDecl{
-				Target:  RubyS[Rubypt-6].genericValue,
-				Name:    RubyS[Rubypt-4].genericValue.(ast.BareReference),
-				Args:    RubyS[Rubypt-3].genericSlice,
-				Body:    RubyS[Rubypt-2].genericSlice,
-				Rescues: RubyS[Rubypt-1].genericSlice,
-			}
+			RubyVAL.genericSlice = append(RubyVAL.genericSlice, withPosition(RubyDollar[1].genericValue, RubyDollar[1].pos))
 		}
 	case 133:
-		//line parser.y:651
+		RubyDollar = RubyS[Rubypt-3 : Rubypt+1]
+//line parser.y:683
 		{
-			RubyVAL.genericValue = ast.FuncDecl{
-				Name: ast.BareReference{Name: RubyS[Rubypt-3].operator},
-				Args: RubyS[Rubypt-2].genericSlice,
-				Body: RubyS[Rubypt-1].genericSlice,
-			}
+			RubyVAL.genericSlice = append(RubyVAL.genericSlice, RubyDollar[3].genericValue)
 		}
 	case 134:
-		//line parser.y:659
+		RubyDollar = RubyS[Rubypt-3 : Rubypt+1]
+//line parser.y:685
 		{
-			RubyVAL.genericValue = ast.FuncDecl{
-				Name:    ast.BareReference{Name: RubyS[Rubypt-4].operator},
-				Args:    RubyS[Rubypt-3].genericSlice,
-				Body:    RubyS[Rubypt-2].genericSlice,
-				Rescues: RubyS[Rubypt-1].genericSlice,
-			}
+			RubyVAL.genericSlice = append(RubyVAL.genericSlice, withPosition(RubyDollar[3].genericValue, RubyDollar[3].pos))
 		}
 	case 135:
-		//line parser.y:670
+		RubyDollar = RubyS[Rubypt-0 : Rubypt+1]
+//line parser.y:687
 		{
-			RubyVAL.genericSlice = RubyS[Rubypt-0].genericSlice
+			RubyVAL.genericSlice = ast.Nodes{}
 		}
 	case 136:
-		//line parser.y:672
+		RubyDollar = RubyS[Rubypt-1 : Rubypt+1]
+//line parser.y:689
 		{
-			RubyVAL.genericSlice = RubyS[Rubypt-1].genericSlice
+			RubyVAL.genericSlice = append(RubyVAL.genericSlice, RubyDollar[1].genericValue)
 		}
 	case 137:
-		//line parser.y:674
+		RubyDollar = RubyS[Rubypt-1 : Rubypt+1]
+//line parser.y:691
 		{
-			RubyVAL.genericSlice = ast.Nodes{}
+			RubyVAL.genericSlice = append(RubyVAL.genericSlice, withPosition(RubyDollar[1].genericValue, RubyDollar[1].pos))
 		}
 	case 138:
-		//line parser.y:676
+		RubyDollar = RubyS[Rubypt-1 : Rubypt+1]
+//line parser.y:693
 		{
-			RubyVAL.genericSlice = append(RubyVAL.genericSlice, RubyS[Rubypt-0].genericValue)
+			RubyVAL.genericSlice = append(RubyVAL.genericSlice, withPosition(RubyDollar[1].genericValue, RubyDollar[1].pos))
 		}
 	case 139:
-		//line parser.y:678
+		RubyDollar = RubyS[Rubypt-1 : Rubypt+1]
+//line parser.y:695
 		{
-			RubyVAL.genericSlice = append(RubyVAL.genericSlice, RubyS[Rubypt-0].genericValue)
+			RubyVAL.genericSlice = append(RubyVAL.genericSlice, withPosition(RubyDollar[1].genericValue, RubyDollar[1].pos))
 		}
 	case 140:
-		//line parser.y:681
+		RubyDollar = RubyS[Rubypt-1 : Rubypt+1]
+//line parser.y:697
 		{
-			RubyVAL.genericValue = ast.MethodParam{Name: RubyS[Rubypt-0].genericValue.(ast.BareReference)}
+			RubyVAL.genericSlice = append(RubyVAL.genericSlice, withPosition(RubyDollar[1].genericValue, RubyDollar[1].pos))
 		}
 	case 141:
-		//line parser.y:683
+		RubyDollar = RubyS[Rubypt-1 : Rubypt+1]
+//line parser.y:699
 		{
-			RubyVAL.genericValue = ast.MethodParam{Name: RubyS[Rubypt-0].genericValue.(ast.BareReference), IsSplat: true}
+			RubyVAL.genericSlice = append(RubyVAL.genericSlice, withPosition(RubyDollar[1].genericValue, RubyDollar[1].pos))
 		}
 	case 142:
-		//line parser.y:685
+		RubyDollar = RubyS[Rubypt-4 : Rubypt+1]
+//line parser.y:701
 		{
-			RubyVAL.genericValue = ast.MethodParam{Name: RubyS[Rubypt-2].genericValue.(ast.BareReference), DefaultValue: RubyS[Rubypt-0].genericValue}
+			RubyVAL.genericSlice = append(RubyVAL.genericSlice, RubyDollar[4].genericValue)
 		}
 	case 143:
-		//line parser.y:687
+		RubyDollar = RubyS[Rubypt-4 : Rubypt+1]
+//line parser.y:703
 		{
-			RubyVAL.genericValue = ast.MethodParam{Name: RubyS[Rubypt-0].genericValue.(ast.BareReference), IsProc: true}
+			RubyVAL.genericSlice = append(RubyVAL.genericSlice, withPosition(RubyDollar[4].genericValue, RubyDollar[4].pos))
 		}
 	case 144:
-		//line parser.y:691
+		RubyDollar = RubyS[Rubypt-4 : Rubypt+1]
+//line parser.y:705
 		{
-			RubyVAL.genericValue = ast.ClassDecl{
-				Name:      RubyS[Rubypt-2].genericValue.(ast.Class).Name,
-				Namespace: RubyS[Rubypt-2].genericValue.(ast.Class).Namespace,
-				Body:      RubyS[Rubypt-1].genericSlice,
-			}
+			RubyVAL.genericSlice = append(RubyVAL.genericSlice, withPosition(RubyDollar[4].genericValue, RubyDollar[4].pos))
 		}
 	case 145:
-		//line parser.y:699
+		RubyDollar = RubyS[Rubypt-4 : Rubypt+1]
+//line parser.y:707
 		{
-			RubyVAL.genericValue = ast.ClassDecl{
-				Name:       RubyS[Rubypt-4].genericValue.(ast.Class).Name,
-				SuperClass: RubyS[Rubypt-2].genericValue.(ast.Class),
-				Namespace:  RubyS[Rubypt-4].genericValue.(ast.Class).Namespace,
-				Body:       RubyS[Rubypt-1].genericSlice,
-			}
+			RubyVAL.genericSlice = append(RubyVAL.genericSlice, withPosition(RubyDollar[4].genericValue, RubyDollar[4].pos))
 		}
 	case 146:
-		//line parser.y:709
+		RubyDollar = RubyS[Rubypt-4 : Rubypt+1]
+//line parser.y:709
 		{
-			if RubyS[Rubypt-3].operator != "<<" {
-				panic("FREAKOUT :: impossible operator after 'class' keyword (" + RubyS[Rubypt-3].operator + ")")
-			}
-
-			RubyVAL.genericValue = ast.EigenClass{
-				Target: RubyS[Rubypt-2].genericValue,
-				Body:   RubyS[Rubypt-1].genericSlice,
-			}
+			RubyVAL.genericSlice = append(RubyVAL.genericSlice, withPosition(RubyDollar[4].genericValue, RubyDollar[4].pos))
 		}
 	case 147:
-		//line parser.y:721
+		RubyDollar = RubyS[Rubypt-4 : Rubypt+1]
+//line parser.y:711
 		{
-			RubyVAL.genericValue = ast.ModuleDecl{
-				Name:      RubyS[Rubypt-2].genericValue.(ast.Class).Name,
-				Namespace: RubyS[Rubypt-2].genericValue.(ast.Class).Namespace,
-				Body:      RubyS[Rubypt-1].genericSlice,
-			}
+			RubyVAL.genericSlice = append(RubyVAL.genericSlice, withPosition(RubyDollar[4].genericValue, RubyDollar[4].pos))
 		}
 	case 148:
-		//line parser.y:730
+		RubyDollar = RubyS[Rubypt-1 : Rubypt+1]
+//line parser.y:713
 		{
-			RubyVAL.genericValue = ast.Class{
-				Name:              RubyS[Rubypt-0].genericValue.(ast.BareReference).Name,
-				IsGlobalNamespace: false,
+			pairs := []ast.HashKeyValuePair{}
+			for _, node := range RubyDollar[1].genericSlice {
+				pairs = append(pairs, node.(ast.HashKeyValuePair))
 			}
+			RubyVAL.genericSlice = ast.Nodes{ast.Hash{Pairs: pairs}}
 		}
 	case 149:
-		//line parser.y:737
+		RubyDollar = RubyS[Rubypt-4 : Rubypt+1]
+//line parser.y:721
 		{
-			firstPart := RubyS[Rubypt-1].genericValue.(ast.BareReference).Name
-			fullName := strings.Join([]string{firstPart, RubyS[Rubypt-0].genericValue.(string)}, "")
-			pieces := strings.Split(fullName, "::")
-			name := pieces[len(pieces)-1]
-			var namespace []string
-			if len(pieces) > 1 {
-				namespace = pieces[0 : len(pieces)-1]
-			}
-
-			RubyVAL.genericValue = ast.Class{
-				Name:              name,
-				Namespace:         strings.Join(namespace, "::"),
-				IsGlobalNamespace: false,
+			pairs := []ast.HashKeyValuePair{}
+			for _, node := range RubyDollar[4].genericSlice {
+				pairs = append(pairs, node.(ast.HashKeyValuePair))
 			}
+			RubyVAL.genericSlice = append(RubyVAL.genericSlice, ast.Hash{Pairs: pairs})
 		}
 	case 150:
-		//line parser.y:754
+		RubyDollar = RubyS[Rubypt-1 : Rubypt+1]
+//line parser.y:729
 		{
-			pieces := strings.Split(RubyS[Rubypt-0].genericValue.(string), "::")
-			namespace := strings.Join(pieces[:len(pieces)-1], "::")
-			RubyVAL.genericValue = ast.Class{
-				Name:              pieces[len(pieces)-1],
-				Namespace:         strings.TrimPrefix(namespace, "::"),
-				IsGlobalNamespace: true,
+			pairs := []ast.HashKeyValuePair{}
+			for _, node := range RubyDollar[1].genericSlice {
+				pairs = append(pairs, node.(ast.HashKeyValuePair))
 			}
+			RubyVAL.genericSlice = ast.Nodes{ast.Hash{Pairs: pairs}}
 		}
 	case 151:
-		//line parser.y:765
+		RubyDollar = RubyS[Rubypt-4 : Rubypt+1]
+//line parser.y:737
 		{
-			RubyVAL.genericValue = ast.Assignment{
-				LHS: RubyS[Rubypt-2].genericValue,
-				RHS: RubyS[Rubypt-0].genericValue,
+			pairs := []ast.HashKeyValuePair{}
+			for _, node := range RubyDollar[4].genericSlice {
+				pairs = append(pairs, node.(ast.HashKeyValuePair))
 			}
+			RubyVAL.genericSlice = append(RubyVAL.genericSlice, ast.Hash{Pairs: pairs})
 		}
 	case 152:
-		//line parser.y:772
+		RubyDollar = RubyS[Rubypt-2 : Rubypt+1]
+//line parser.y:746
 		{
-			RubyVAL.genericValue = ast.Assignment{LHS: RubyS[Rubypt-2].genericValue, RHS: RubyS[Rubypt-0].genericValue}
+			RubyVAL.genericValue = ast.ProcArg{
+				Value: ast.CallExpression{
+					Func:   ast.BareReference{Name: "to_proc"},
+					Target: RubyDollar[2].genericValue,
+				},
+			}
 		}
 	case 153:
-		//line parser.y:776
+		RubyDollar = RubyS[Rubypt-1 : Rubypt+1]
+//line parser.y:756
 		{
-			RubyVAL.genericValue = ast.Assignment{LHS: RubyS[Rubypt-2].genericValue, RHS: RubyS[Rubypt-0].genericValue}
+			RubyVAL.genericSlice = append(RubyVAL.genericSlice, RubyDollar[1].genericValue)
 		}
 	case 154:
-		//line parser.y:780
+		RubyDollar = RubyS[Rubypt-3 : Rubypt+1]
+//line parser.y:758
 		{
-			RubyVAL.genericValue = ast.Assignment{
-				LHS: RubyS[Rubypt-2].genericValue,
-				RHS: RubyS[Rubypt-0].genericValue,
-			}
+			RubyVAL.genericSlice = append(RubyVAL.genericSlice, RubyDollar[3].genericValue)
 		}
 	case 155:
-		//line parser.y:787
+		RubyDollar = RubyS[Rubypt-3 : Rubypt+1]
+//line parser.y:760
 		{
-			RubyVAL.genericValue = ast.Assignment{
-				LHS: RubyS[Rubypt-2].genericValue,
-				RHS: RubyS[Rubypt-0].genericValue,
-			}
+			RubyVAL.genericSlice = append(RubyVAL.genericSlice, withPosition(RubyDollar[3].genericValue, RubyDollar[3].pos))
 		}
 	case 156:
-		//line parser.y:794
+		RubyDollar = RubyS[Rubypt-5 : Rubypt+1]
+//line parser.y:764
 		{
-			RubyVAL.genericValue = ast.Assignment{
-				LHS: RubyS[Rubypt-2].genericValue,
-				RHS: RubyS[Rubypt-0].genericValue,
+			RubyVAL.genericValue = ast.FuncDecl{
+				Name: RubyDollar[2].genericValue.(ast.BareReference),
+				Args: RubyDollar[3].genericSlice,
+				Body: RubyDollar[4].genericSlice,
 			}
 		}
 	case 157:
-		//line parser.y:801
+		RubyDollar = RubyS[Rubypt-6 : Rubypt+1]
+//line parser.y:772
 		{
-			RubyVAL.genericValue = ast.Assignment{
-				LHS: RubyS[Rubypt-2].genericValue,
-				RHS: RubyS[Rubypt-0].genericValue,
+			RubyVAL.genericValue = ast.FuncDecl{
+				Name:    RubyDollar[2].genericValue.(ast.BareReference),
+				Args:    RubyDollar[3].genericSlice,
+				Body:    RubyDollar[4].genericSlice,
+				Rescues: RubyDollar[5].genericSlice,
 			}
 		}
 	case 158:
-		//line parser.y:809
+		RubyDollar = RubyS[Rubypt-7 : Rubypt+1]
+//line parser.y:781
 		{
-			RubyVAL.genericValue = ast.Assignment{
-				LHS: RubyS[Rubypt-2].genericValue,
-				RHS: RubyS[Rubypt-0].genericValue,
+			RubyVAL.genericValue = ast.FuncDecl{
+				Target: RubyDollar[2].genericValue,
+				Name:   RubyDollar[4].genericValue.(ast.BareReference),
+				Args:   RubyDollar[5].genericSlice,
+				Body:   RubyDollar[6].genericSlice,
 			}
 		}
 	case 159:
-		//line parser.y:816
+		RubyDollar = RubyS[Rubypt-7 : Rubypt+1]
+//line parser.y:790
 		{
-			RubyVAL.genericValue = ast.Assignment{
-				LHS: ast.Array{Nodes: RubyS[Rubypt-2].genericSlice},
-				RHS: ast.Array{Nodes: RubyS[Rubypt-0].genericSlice},
+			RubyVAL.genericValue = ast.FuncDecl{
+				Target: RubyDollar[2].genericValue,
+				Name:   RubyDollar[4].genericValue.(ast.BareReference),
+				Args:   RubyDollar[5].genericSlice,
+				Body:   RubyDollar[6].genericSlice,
 			}
 		}
 	case 160:
-		//line parser.y:824
+		RubyDollar = RubyS[Rubypt-8 : Rubypt+1]
+//line parser.y:799
 		{
-			RubyVAL.genericSlice = []ast.Node{
-				ast.CallExpression{
-					Target: RubyS[Rubypt-8].genericValue,
-					Func:   ast.BareReference{Name: "[]="},
-					Args:   []ast.Node{RubyS[Rubypt-6].genericValue},
-				},
-				ast.CallExpression{
-					Target: RubyS[Rubypt-3].genericValue,
-					Func:   ast.BareReference{Name: "[]="},
-					Args:   []ast.Node{RubyS[Rubypt-1].genericValue},
-				},
+			RubyVAL.genericValue = ast.FuncDecl{
+				Target:  RubyDollar[2].genericValue,
+				Name:    RubyDollar[4].genericValue.(ast.BareReference),
+				Args:    RubyDollar[5].genericSlice,
+				Body:    RubyDollar[6].genericSlice,
+				Rescues: RubyDollar[7].genericSlice,
 			}
 		}
 	case 161:
-		//line parser.y:839
+		RubyDollar = RubyS[Rubypt-8 : Rubypt+1]
+//line parser.y:809
 		{
-			tail := ast.CallExpression{Target: RubyS[Rubypt-3].genericValue, Func: ast.BareReference{Name: "[]="}, Args: []ast.Node{RubyS[Rubypt-1].genericValue}}
-			RubyVAL.genericSlice = append(RubyS[Rubypt-5].genericSlice, tail)
+			RubyVAL.genericValue = ast.FuncDecl{
+				Target:  RubyDollar[2].genericValue,
+				Name:    RubyDollar[4].genericValue.(ast.BareReference),
+				Args:    RubyDollar[5].genericSlice,
+				Body:    RubyDollar[6].genericSlice,
+				Rescues: RubyDollar[7].genericSlice,
+			}
 		}
 	case 162:
-		//line parser.y:845
+		RubyDollar = RubyS[Rubypt-7 : Rubypt+1]
+//line parser.y:819
 		{
-			RubyVAL.genericValue = ast.ConditionalAssignment{
-				LHS: RubyS[Rubypt-2].genericValue,
-				RHS: RubyS[Rubypt-0].genericValue,
+			RubyVAL.genericValue = ast.FuncDecl{
+				Name:   RubyDollar[2].genericValue.(ast.BareReference),
+				Args:   RubyDollar[3].genericSlice,
+				Body:   RubyDollar[4].genericSlice,
+				Ensure: RubyDollar[6].genericSlice,
 			}
 		}
 	case 163:
-		//line parser.y:852
+		RubyDollar = RubyS[Rubypt-8 : Rubypt+1]
+//line parser.y:828
 		{
-			RubyVAL.genericValue = ast.ConditionalAssignment{LHS: RubyS[Rubypt-2].genericValue, RHS: RubyS[Rubypt-0].genericValue}
-		}
+			RubyVAL.genericValue = ast.FuncDecl{
+				Name:    RubyDollar[2].genericValue.(ast.BareReference),
+				Args:    RubyDollar[3].genericSlice,
+				Body:    RubyDollar[4].genericSlice,
+				Rescues: RubyDollar[5].genericSlice,
+				Ensure:  RubyDollar[7].genericSlice,
+			}
+		}
 	case 164:
-		//line parser.y:856
+		RubyDollar = RubyS[Rubypt-9 : Rubypt+1]
+//line parser.y:838
 		{
-			RubyVAL.genericValue = ast.ConditionalAssignment{
-				LHS: RubyS[Rubypt-2].genericValue,
-				RHS: RubyS[Rubypt-0].genericValue,
+			RubyVAL.genericValue = ast.FuncDecl{
+				Target: RubyDollar[2].genericValue,
+				Name:   RubyDollar[4].genericValue.(ast.BareReference),
+				Args:   RubyDollar[5].genericSlice,
+				Body:   RubyDollar[6].genericSlice,
+				Ensure: RubyDollar[8].genericSlice,
 			}
 		}
 	case 165:
-		//line parser.y:863
+		RubyDollar = RubyS[Rubypt-10 : Rubypt+1]
+//line parser.y:848
 		{
-			RubyVAL.genericValue = ast.ConditionalAssignment{
-				LHS: RubyS[Rubypt-2].genericValue,
-				RHS: RubyS[Rubypt-0].genericValue,
+			RubyVAL.genericValue = ast.FuncDecl{
+				Target:  RubyDollar[2].genericValue,
+				Name:    RubyDollar[4].genericValue.(ast.BareReference),
+				Args:    RubyDollar[5].genericSlice,
+				Body:    RubyDollar[6].genericSlice,
+				Rescues: RubyDollar[7].genericSlice,
+				Ensure:  RubyDollar[9].genericSlice,
 			}
 		}
 	case 166:
-		//line parser.y:870
+		RubyDollar = RubyS[Rubypt-5 : Rubypt+1]
+//line parser.y:859
 		{
-			RubyVAL.genericValue = ast.ConditionalAssignment{
-				LHS: RubyS[Rubypt-2].genericValue,
-				RHS: RubyS[Rubypt-0].genericValue,
+			RubyVAL.genericValue = ast.FuncDecl{
+				Name: ast.BareReference{Name: RubyDollar[2].operator},
+				Args: RubyDollar[3].genericSlice,
+				Body: RubyDollar[4].genericSlice,
 			}
 		}
 	case 167:
-		//line parser.y:877
+		RubyDollar = RubyS[Rubypt-6 : Rubypt+1]
+//line parser.y:867
 		{
-			RubyVAL.genericValue = ast.ConditionalAssignment{
-				LHS: RubyS[Rubypt-2].genericValue,
-				RHS: RubyS[Rubypt-0].genericValue,
+			RubyVAL.genericValue = ast.FuncDecl{
+				Name:    ast.BareReference{Name: RubyDollar[2].operator},
+				Args:    RubyDollar[3].genericSlice,
+				Body:    RubyDollar[4].genericSlice,
+				Rescues: RubyDollar[5].genericSlice,
 			}
 		}
 	case 168:
-		//line parser.y:884
+		RubyDollar = RubyS[Rubypt-4 : Rubypt+1]
+//line parser.y:876
 		{
-			RubyVAL.genericValue = ast.ConditionalAssignment{LHS: RubyS[Rubypt-2].genericValue, RHS: RubyS[Rubypt-0].genericValue}
+			RubyVAL.genericValue = ast.FuncDecl{
+				Name: RubyDollar[2].genericValue.(ast.BareReference),
+				Args: ast.Nodes{},
+				Body: []ast.Node{RubyDollar[4].genericValue},
+			}
 		}
 	case 169:
-		//line parser.y:887
+		RubyDollar = RubyS[Rubypt-7 : Rubypt+1]
+//line parser.y:884
 		{
-			RubyVAL.genericValue = ast.GlobalVariable{Name: RubyS[Rubypt-0].genericValue.(ast.BareReference).Name}
+			RubyVAL.genericValue = ast.FuncDecl{
+				Name: RubyDollar[2].genericValue.(ast.BareReference),
+				Args: RubyDollar[4].genericSlice,
+				Body: []ast.Node{RubyDollar[7].genericValue},
+			}
 		}
 	case 170:
-		//line parser.y:889
+		RubyDollar = RubyS[Rubypt-6 : Rubypt+1]
+//line parser.y:892
 		{
-			RubyVAL.genericValue = ast.GlobalVariable{Name: RubyS[Rubypt-0].genericValue.(ast.BareReference).Name}
+			RubyVAL.genericValue = ast.FuncDecl{
+				Target: RubyDollar[2].genericValue,
+				Name:   RubyDollar[4].genericValue.(ast.BareReference),
+				Args:   ast.Nodes{},
+				Body:   []ast.Node{RubyDollar[6].genericValue},
+			}
 		}
 	case 171:
-		//line parser.y:892
+		RubyDollar = RubyS[Rubypt-9 : Rubypt+1]
+//line parser.y:901
 		{
-			RubyVAL.genericValue = ast.InstanceVariable{Name: RubyS[Rubypt-0].genericValue.(ast.BareReference).Name}
+			RubyVAL.genericValue = ast.FuncDecl{
+				Target: RubyDollar[2].genericValue,
+				Name:   RubyDollar[4].genericValue.(ast.BareReference),
+				Args:   RubyDollar[6].genericSlice,
+				Body:   []ast.Node{RubyDollar[9].genericValue},
+			}
 		}
 	case 172:
-		//line parser.y:894
+		RubyDollar = RubyS[Rubypt-1 : Rubypt+1]
+//line parser.y:912
 		{
-			RubyVAL.genericValue = ast.InstanceVariable{Name: RubyS[Rubypt-0].genericValue.(ast.BareReference).Name}
+			RubyVAL.genericSlice = RubyDollar[1].genericSlice
 		}
 	case 173:
-		//line parser.y:897
+		RubyDollar = RubyS[Rubypt-3 : Rubypt+1]
+//line parser.y:914
 		{
-			RubyVAL.genericValue = ast.ClassVariable{Name: RubyS[Rubypt-0].genericValue.(ast.BareReference).Name}
+			RubyVAL.genericSlice = RubyDollar[2].genericSlice
 		}
 	case 174:
-		//line parser.y:899
+		RubyDollar = RubyS[Rubypt-0 : Rubypt+1]
+//line parser.y:916
 		{
-			RubyVAL.genericValue = ast.ClassVariable{Name: RubyS[Rubypt-0].genericValue.(ast.BareReference).Name}
+			RubyVAL.genericSlice = ast.Nodes{}
 		}
 	case 175:
-		//line parser.y:902
+		RubyDollar = RubyS[Rubypt-1 : Rubypt+1]
+//line parser.y:918
 		{
-			RubyVAL.genericValue = ast.Array{Nodes: []ast.Node{RubyS[Rubypt-2].genericValue, RubyS[Rubypt-0].genericValue}}
+			RubyVAL.genericSlice = append(RubyVAL.genericSlice, RubyDollar[1].genericValue)
 		}
 	case 176:
-		//line parser.y:904
+		RubyDollar = RubyS[Rubypt-3 : Rubypt+1]
+//line parser.y:920
 		{
-			RubyVAL.genericValue = ast.Array{Nodes: []ast.Node{RubyS[Rubypt-2].genericValue, RubyS[Rubypt-0].genericValue}}
+			RubyVAL.genericSlice = append(RubyVAL.genericSlice, RubyDollar[3].genericValue)
 		}
 	case 177:
-		//line parser.y:906
+		RubyDollar = RubyS[Rubypt-1 : Rubypt+1]
+//line parser.y:923
 		{
-			RubyVAL.genericValue = ast.Array{Nodes: []ast.Node{RubyS[Rubypt-2].genericValue, RubyS[Rubypt-0].genericValue}}
+			RubyVAL.genericValue = ast.MethodParam{Name: RubyDollar[1].genericValue.(ast.BareReference)}
 		}
 	case 178:
-		//line parser.y:908
+		RubyDollar = RubyS[Rubypt-2 : Rubypt+1]
+//line parser.y:925
 		{
-			RubyVAL.genericValue = ast.Array{Nodes: []ast.Node{RubyS[Rubypt-3].genericValue, ast.StarSplat{Value: RubyS[Rubypt-0].genericValue}}}
+			RubyVAL.genericValue = ast.MethodParam{Name: RubyDollar[2].genericValue.(ast.BareReference), IsSplat: true}
 		}
 	case 179:
-		//line parser.y:911
+		RubyDollar = RubyS[Rubypt-2 : Rubypt+1]
+//line parser.y:927
 		{
-			RubyVAL.genericValue = ast.Array{Nodes: []ast.Node{RubyS[Rubypt-2].genericValue, RubyS[Rubypt-0].genericValue}}
+			RubyVAL.genericValue = ast.MethodParam{Name: RubyDollar[2].genericValue.(ast.BareReference), IsKeywordSplat: true}
 		}
 	case 180:
-		//line parser.y:913
+		RubyDollar = RubyS[Rubypt-3 : Rubypt+1]
+//line parser.y:929
 		{
-			RubyVAL.genericValue = ast.Array{Nodes: []ast.Node{RubyS[Rubypt-2].genericValue, RubyS[Rubypt-0].genericValue}}
+			RubyVAL.genericValue = ast.MethodParam{Name: RubyDollar[1].genericValue.(ast.BareReference), DefaultValue: RubyDollar[3].genericValue}
 		}
 	case 181:
-		//line parser.y:915
+		RubyDollar = RubyS[Rubypt-2 : Rubypt+1]
+//line parser.y:931
 		{
-			RubyVAL.genericValue = ast.Array{Nodes: []ast.Node{RubyS[Rubypt-2].genericValue, RubyS[Rubypt-0].genericValue}}
+			RubyVAL.genericValue = ast.MethodParam{Name: RubyDollar[2].genericValue.(ast.BareReference), IsProc: true}
 		}
 	case 182:
-		//line parser.y:917
+		RubyDollar = RubyS[Rubypt-4 : Rubypt+1]
+//line parser.y:935
 		{
-			RubyVAL.genericValue = ast.Array{Nodes: []ast.Node{RubyS[Rubypt-3].genericValue, ast.StarSplat{Value: RubyS[Rubypt-0].genericValue}}}
+			RubyVAL.genericValue = ast.ClassDecl{
+				Name:      RubyDollar[2].genericValue.(ast.Class).Name,
+				Namespace: RubyDollar[2].genericValue.(ast.Class).Namespace,
+				Body:      RubyDollar[3].genericSlice,
+			}
 		}
 	case 183:
-		//line parser.y:920
+		RubyDollar = RubyS[Rubypt-6 : Rubypt+1]
+//line parser.y:943
 		{
-			RubyVAL.genericValue = ast.Array{Nodes: []ast.Node{RubyS[Rubypt-2].genericValue, RubyS[Rubypt-0].genericValue}}
+			RubyVAL.genericValue = ast.ClassDecl{
+				Name:       RubyDollar[2].genericValue.(ast.Class).Name,
+				SuperClass: RubyDollar[4].genericValue.(ast.Class),
+				Namespace:  RubyDollar[2].genericValue.(ast.Class).Namespace,
+				Body:       RubyDollar[5].genericSlice,
+			}
 		}
 	case 184:
-		//line parser.y:922
+		RubyDollar = RubyS[Rubypt-5 : Rubypt+1]
+//line parser.y:953
 		{
-			RubyVAL.genericValue = ast.Array{Nodes: []ast.Node{RubyS[Rubypt-2].genericValue, RubyS[Rubypt-0].genericValue}}
+			if RubyDollar[2].operator != "<<" {
+				panic("FREAKOUT :: impossible operator after 'class' keyword (" + RubyDollar[2].operator + ")")
+			}
+
+			RubyVAL.genericValue = ast.EigenClass{
+				Target: RubyDollar[3].genericValue,
+				Body:   RubyDollar[4].genericSlice,
+			}
 		}
 	case 185:
-		//line parser.y:924
+		RubyDollar = RubyS[Rubypt-4 : Rubypt+1]
+//line parser.y:965
 		{
-			RubyVAL.genericValue = ast.Array{Nodes: []ast.Node{RubyS[Rubypt-2].genericValue, RubyS[Rubypt-0].genericValue}}
+			RubyVAL.genericValue = ast.ModuleDecl{
+				Name:      RubyDollar[2].genericValue.(ast.Class).Name,
+				Namespace: RubyDollar[2].genericValue.(ast.Class).Namespace,
+				Body:      RubyDollar[3].genericSlice,
+			}
 		}
 	case 186:
-		//line parser.y:926
+		RubyDollar = RubyS[Rubypt-1 : Rubypt+1]
+//line parser.y:974
 		{
-			RubyVAL.genericValue = ast.Array{Nodes: []ast.Node{RubyS[Rubypt-3].genericValue, ast.StarSplat{Value: RubyS[Rubypt-0].genericValue}}}
+			RubyVAL.genericValue = ast.Class{
+				Name:              RubyDollar[1].genericValue.(ast.BareReference).Name,
+				IsGlobalNamespace: false,
+			}
 		}
 	case 187:
-		//line parser.y:929
+		RubyDollar = RubyS[Rubypt-2 : Rubypt+1]
+//line parser.y:981
 		{
-			RubyVAL.genericValue = ast.Array{Nodes: append(RubyVAL.genericValue.(ast.Array).Nodes, RubyS[Rubypt-0].genericValue)}
+			firstPart := RubyDollar[1].genericValue.(ast.BareReference).Name
+			fullName := strings.Join([]string{firstPart, RubyDollar[2].genericValue.(string)}, "")
+			pieces := strings.Split(fullName, "::")
+			name := pieces[len(pieces)-1]
+			var namespace []string
+			if len(pieces) > 1 {
+				namespace = pieces[0 : len(pieces)-1]
+			}
+
+			RubyVAL.genericValue = ast.Class{
+				Name:              name,
+				Namespace:         strings.Join(namespace, "::"),
+				IsGlobalNamespace: false,
+			}
 		}
 	case 188:
-		//line parser.y:931
+		RubyDollar = RubyS[Rubypt-1 : Rubypt+1]
+//line parser.y:998
 		{
-			RubyVAL.genericValue = ast.Array{Nodes: append(RubyVAL.genericValue.(ast.Array).Nodes, RubyS[Rubypt-0].genericValue)}
+			pieces := strings.Split(RubyDollar[1].genericValue.(string), "::")
+			namespace := strings.Join(pieces[:len(pieces)-1], "::")
+			RubyVAL.genericValue = ast.Class{
+				Name:              pieces[len(pieces)-1],
+				Namespace:         strings.TrimPrefix(namespace, "::"),
+				IsGlobalNamespace: true,
+			}
 		}
 	case 189:
-		//line parser.y:933
+		RubyDollar = RubyS[Rubypt-3 : Rubypt+1]
+//line parser.y:1009
 		{
-			RubyVAL.genericValue = ast.Array{Nodes: append(RubyVAL.genericValue.(ast.Array).Nodes, RubyS[Rubypt-0].genericValue)}
+			RubyVAL.genericValue = newAssignment(RubyDollar[1].genericValue, RubyDollar[3].genericValue)
 		}
 	case 190:
-		//line parser.y:935
+		RubyDollar = RubyS[Rubypt-3 : Rubypt+1]
+//line parser.y:1013
 		{
-			RubyVAL.genericValue = ast.Array{Nodes: []ast.Node{RubyS[Rubypt-3].genericValue, ast.StarSplat{Value: RubyS[Rubypt-0].genericValue}}}
+			RubyVAL.genericValue = ast.Assignment{LHS: RubyDollar[1].genericValue, RHS: withPosition(RubyDollar[3].genericValue, RubyDollar[3].pos)}
 		}
 	case 191:
-		//line parser.y:938
+		RubyDollar = RubyS[Rubypt-3 : Rubypt+1]
+//line parser.y:1017
 		{
-			RubyVAL.genericValue = ast.Negation{Target: RubyS[Rubypt-0].genericValue}
+			RubyVAL.genericValue = ast.Assignment{LHS: RubyDollar[1].genericValue, RHS: withPosition(RubyDollar[3].genericValue, RubyDollar[3].pos)}
 		}
 	case 192:
-		//line parser.y:939
+		RubyDollar = RubyS[Rubypt-3 : Rubypt+1]
+//line parser.y:1021
 		{
-			RubyVAL.genericValue = ast.Complement{Target: RubyS[Rubypt-0].genericValue}
+			RubyVAL.genericValue = ast.Assignment{LHS: RubyDollar[1].genericValue, RHS: withPosition(RubyDollar[3].genericValue, RubyDollar[3].pos)}
 		}
 	case 193:
-		//line parser.y:940
+		RubyDollar = RubyS[Rubypt-3 : Rubypt+1]
+//line parser.y:1025
 		{
-			RubyVAL.genericValue = ast.Positive{Target: RubyS[Rubypt-0].genericValue}
+			RubyVAL.genericValue = ast.Assignment{LHS: RubyDollar[1].genericValue, RHS: withPosition(RubyDollar[3].genericValue, RubyDollar[3].pos)}
 		}
 	case 194:
-		//line parser.y:941
+		RubyDollar = RubyS[Rubypt-3 : Rubypt+1]
+//line parser.y:1029
 		{
-			RubyVAL.genericValue = ast.Negative{Target: RubyS[Rubypt-0].genericValue}
+			RubyVAL.genericValue = ast.Assignment{LHS: RubyDollar[1].genericValue, RHS: withPosition(RubyDollar[3].genericValue, RubyDollar[3].pos)}
 		}
 	case 195:
-		//line parser.y:944
+		RubyDollar = RubyS[Rubypt-3 : Rubypt+1]
+//line parser.y:1033
+		{
+			RubyVAL.genericValue = newAssignment(RubyDollar[1].genericValue, RubyDollar[3].genericValue)
+		}
+	case 196:
+		RubyDollar = RubyS[Rubypt-3 : Rubypt+1]
+//line parser.y:1037
+		{
+			RubyVAL.genericValue = newAssignment(RubyDollar[1].genericValue, RubyDollar[3].genericValue)
+		}
+	case 197:
+		RubyDollar = RubyS[Rubypt-3 : Rubypt+1]
+//line parser.y:1041
+		{
+			RubyVAL.genericValue = newAssignment(RubyDollar[1].genericValue, RubyDollar[3].genericValue)
+		}
+	case 198:
+		RubyDollar = RubyS[Rubypt-3 : Rubypt+1]
+//line parser.y:1045
+		{
+			RubyVAL.genericValue = newAssignment(RubyDollar[1].genericValue, RubyDollar[3].genericValue)
+		}
+	case 199:
+		RubyDollar = RubyS[Rubypt-3 : Rubypt+1]
+//line parser.y:1050
+		{
+			// a lone splat is still a list of values to spread across the variables
+			rhs := RubyDollar[3].genericValue
+			if _, ok := rhs.(ast.StarSplat); ok {
+				rhs = ast.Array{Nodes: []ast.Node{rhs}}
+			}
+
+			RubyVAL.genericValue = ast.Assignment{
+				LHS: RubyDollar[1].genericValue,
+				RHS: rhs,
+			}
+		}
+	case 200:
+		RubyDollar = RubyS[Rubypt-5 : Rubypt+1]
+//line parser.y:1063
+		{
+			RubyVAL.genericValue = ast.Assignment{
+				LHS: RubyDollar[1].genericValue,
+				RHS: ast.Array{Nodes: append([]ast.Node{RubyDollar[3].genericValue}, RubyDollar[5].genericSlice...)},
+			}
+		}
+	case 201:
+		RubyDollar = RubyS[Rubypt-3 : Rubypt+1]
+//line parser.y:1070
+		{
+			RubyVAL.genericValue = ast.Assignment{
+				LHS: ast.Array{Nodes: RubyDollar[1].genericSlice},
+				RHS: ast.Array{Nodes: RubyDollar[3].genericSlice},
+			}
+		}
+	case 202:
+		RubyDollar = RubyS[Rubypt-9 : Rubypt+1]
+//line parser.y:1078
+		{
+			RubyVAL.genericSlice = []ast.Node{
+				ast.CallExpression{
+					Target: RubyDollar[1].genericValue,
+					Func:   ast.BareReference{Name: "[]="},
+					Args:   []ast.Node{RubyDollar[3].genericValue},
+				},
+				ast.CallExpression{
+					Target: RubyDollar[6].genericValue,
+					Func:   ast.BareReference{Name: "[]="},
+					Args:   []ast.Node{RubyDollar[8].genericValue},
+				},
+			}
+		}
+	case 203:
+		RubyDollar = RubyS[Rubypt-6 : Rubypt+1]
+//line parser.y:1093
+		{
+			tail := ast.CallExpression{Target: RubyDollar[3].genericValue, Func: ast.BareReference{Name: "[]="}, Args: []ast.Node{RubyDollar[5].genericValue}}
+			RubyVAL.genericSlice = append(RubyDollar[1].genericSlice, tail)
+		}
+	case 204:
+		RubyDollar = RubyS[Rubypt-3 : Rubypt+1]
+//line parser.y:1099
+		{
+			RubyVAL.genericValue = ast.ConditionalAssignment{
+				LHS: RubyDollar[1].genericValue,
+				RHS: RubyDollar[3].genericValue,
+			}
+		}
+	case 205:
+		RubyDollar = RubyS[Rubypt-3 : Rubypt+1]
+//line parser.y:1106
+		{
+			RubyVAL.genericValue = ast.ConditionalAssignment{LHS: RubyDollar[1].genericValue, RHS: withPosition(RubyDollar[3].genericValue, RubyDollar[3].pos)}
+		}
+	case 206:
+		RubyDollar = RubyS[Rubypt-3 : Rubypt+1]
+//line parser.y:1110
+		{
+			RubyVAL.genericValue = ast.ConditionalAssignment{
+				LHS: RubyDollar[1].genericValue,
+				RHS: RubyDollar[3].genericValue,
+			}
+		}
+	case 207:
+		RubyDollar = RubyS[Rubypt-3 : Rubypt+1]
+//line parser.y:1117
+		{
+			RubyVAL.genericValue = ast.ConditionalAssignment{
+				LHS: RubyDollar[1].genericValue,
+				RHS: RubyDollar[3].genericValue,
+			}
+		}
+	case 208:
+		RubyDollar = RubyS[Rubypt-3 : Rubypt+1]
+//line parser.y:1124
+		{
+			RubyVAL.genericValue = ast.ConditionalAssignment{
+				LHS: RubyDollar[1].genericValue,
+				RHS: RubyDollar[3].genericValue,
+			}
+		}
+	case 209:
+		RubyDollar = RubyS[Rubypt-3 : Rubypt+1]
+//line parser.y:1131
+		{
+			RubyVAL.genericValue = ast.ConditionalAssignment{
+				LHS: RubyDollar[1].genericValue,
+				RHS: RubyDollar[3].genericValue,
+			}
+		}
+	case 210:
+		RubyDollar = RubyS[Rubypt-3 : Rubypt+1]
+//line parser.y:1138
+		{
+			RubyVAL.genericValue = ast.ConditionalAssignment{LHS: withPosition(RubyDollar[1].genericValue, RubyDollar[1].pos), RHS: RubyDollar[3].genericValue}
+		}
+	case 211:
+		RubyDollar = RubyS[Rubypt-2 : Rubypt+1]
+//line parser.y:1141
+		{
+			RubyVAL.genericValue = ast.GlobalVariable{Name: RubyDollar[2].genericValue.(ast.BareReference).Name}
+		}
+	case 212:
+		RubyDollar = RubyS[Rubypt-2 : Rubypt+1]
+//line parser.y:1143
+		{
+			RubyVAL.genericValue = ast.GlobalVariable{Name: RubyDollar[2].genericValue.(ast.BareReference).Name}
+		}
+	case 213:
+		RubyDollar = RubyS[Rubypt-2 : Rubypt+1]
+//line parser.y:1146
+		{
+			RubyVAL.genericValue = ast.InstanceVariable{Name: RubyDollar[2].genericValue.(ast.BareReference).Name}
+		}
+	case 214:
+		RubyDollar = RubyS[Rubypt-2 : Rubypt+1]
+//line parser.y:1148
+		{
+			RubyVAL.genericValue = ast.InstanceVariable{Name: RubyDollar[2].genericValue.(ast.BareReference).Name}
+		}
+	case 215:
+		RubyDollar = RubyS[Rubypt-3 : Rubypt+1]
+//line parser.y:1151
+		{
+			RubyVAL.genericValue = ast.ClassVariable{Name: RubyDollar[3].genericValue.(ast.BareReference).Name}
+		}
+	case 216:
+		RubyDollar = RubyS[Rubypt-3 : Rubypt+1]
+//line parser.y:1153
+		{
+			RubyVAL.genericValue = ast.ClassVariable{Name: RubyDollar[3].genericValue.(ast.BareReference).Name}
+		}
+	case 217:
+		RubyDollar = RubyS[Rubypt-3 : Rubypt+1]
+//line parser.y:1156
+		{
+			RubyVAL.genericValue = ast.Array{Nodes: []ast.Node{RubyDollar[1].genericValue, RubyDollar[3].genericValue}}
+		}
+	case 218:
+		RubyDollar = RubyS[Rubypt-3 : Rubypt+1]
+//line parser.y:1158
+		{
+			RubyVAL.genericValue = ast.Array{Nodes: []ast.Node{RubyDollar[1].genericValue, RubyDollar[3].genericValue}}
+		}
+	case 219:
+		RubyDollar = RubyS[Rubypt-3 : Rubypt+1]
+//line parser.y:1160
+		{
+			RubyVAL.genericValue = ast.Array{Nodes: []ast.Node{RubyDollar[1].genericValue, RubyDollar[3].genericValue}}
+		}
+	case 220:
+		RubyDollar = RubyS[Rubypt-4 : Rubypt+1]
+//line parser.y:1162
+		{
+			RubyVAL.genericValue = ast.Array{Nodes: []ast.Node{RubyDollar[1].genericValue, ast.StarSplat{Value: RubyDollar[4].genericValue}}}
+		}
+	case 221:
+		RubyDollar = RubyS[Rubypt-3 : Rubypt+1]
+//line parser.y:1165
+		{
+			RubyVAL.genericValue = ast.Array{Nodes: []ast.Node{RubyDollar[1].genericValue, RubyDollar[3].genericValue}}
+		}
+	case 222:
+		RubyDollar = RubyS[Rubypt-3 : Rubypt+1]
+//line parser.y:1167
+		{
+			RubyVAL.genericValue = ast.Array{Nodes: []ast.Node{RubyDollar[1].genericValue, RubyDollar[3].genericValue}}
+		}
+	case 223:
+		RubyDollar = RubyS[Rubypt-3 : Rubypt+1]
+//line parser.y:1169
+		{
+			RubyVAL.genericValue = ast.Array{Nodes: []ast.Node{RubyDollar[1].genericValue, RubyDollar[3].genericValue}}
+		}
+	case 224:
+		RubyDollar = RubyS[Rubypt-4 : Rubypt+1]
+//line parser.y:1171
+		{
+			RubyVAL.genericValue = ast.Array{Nodes: []ast.Node{RubyDollar[1].genericValue, ast.StarSplat{Value: RubyDollar[4].genericValue}}}
+		}
+	case 225:
+		RubyDollar = RubyS[Rubypt-3 : Rubypt+1]
+//line parser.y:1174
+		{
+			RubyVAL.genericValue = ast.Array{Nodes: []ast.Node{RubyDollar[1].genericValue, RubyDollar[3].genericValue}}
+		}
+	case 226:
+		RubyDollar = RubyS[Rubypt-3 : Rubypt+1]
+//line parser.y:1176
+		{
+			RubyVAL.genericValue = ast.Array{Nodes: []ast.Node{RubyDollar[1].genericValue, RubyDollar[3].genericValue}}
+		}
+	case 227:
+		RubyDollar = RubyS[Rubypt-3 : Rubypt+1]
+//line parser.y:1178
+		{
+			RubyVAL.genericValue = ast.Array{Nodes: []ast.Node{RubyDollar[1].genericValue, RubyDollar[3].genericValue}}
+		}
+	case 228:
+		RubyDollar = RubyS[Rubypt-4 : Rubypt+1]
+//line parser.y:1180
+		{
+			RubyVAL.genericValue = ast.Array{Nodes: []ast.Node{RubyDollar[1].genericValue, ast.StarSplat{Value: RubyDollar[4].genericValue}}}
+		}
+	case 229:
+		RubyDollar = RubyS[Rubypt-3 : Rubypt+1]
+//line parser.y:1183
+		{
+			RubyVAL.genericValue = ast.Array{Nodes: append(RubyVAL.genericValue.(ast.Array).Nodes, RubyDollar[3].genericValue)}
+		}
+	case 230:
+		RubyDollar = RubyS[Rubypt-3 : Rubypt+1]
+//line parser.y:1185
+		{
+			RubyVAL.genericValue = ast.Array{Nodes: append(RubyVAL.genericValue.(ast.Array).Nodes, RubyDollar[3].genericValue)}
+		}
+	case 231:
+		RubyDollar = RubyS[Rubypt-3 : Rubypt+1]
+//line parser.y:1187
+		{
+			RubyVAL.genericValue = ast.Array{Nodes: append(RubyVAL.genericValue.(ast.Array).Nodes, RubyDollar[3].genericValue)}
+		}
+	case 232:
+		RubyDollar = RubyS[Rubypt-4 : Rubypt+1]
+//line parser.y:1189
+		{
+			RubyVAL.genericValue = ast.Array{Nodes: []ast.Node{RubyDollar[1].genericValue, ast.StarSplat{Value: RubyDollar[4].genericValue}}}
+		}
+	case 233:
+		RubyDollar = RubyS[Rubypt-2 : Rubypt+1]
+//line parser.y:1192
+		{
+			RubyVAL.genericValue = ast.Negation{Target: RubyDollar[2].genericValue}
+		}
+	case 234:
+		RubyDollar = RubyS[Rubypt-2 : Rubypt+1]
+//line parser.y:1193
+		{
+			RubyVAL.genericValue = ast.Complement{Target: RubyDollar[2].genericValue}
+		}
+	case 235:
+		RubyDollar = RubyS[Rubypt-2 : Rubypt+1]
+//line parser.y:1194
+		{
+			RubyVAL.genericValue = ast.Positive{Target: RubyDollar[2].genericValue}
+		}
+	case 236:
+		RubyDollar = RubyS[Rubypt-2 : Rubypt+1]
+//line parser.y:1195
+		{
+			RubyVAL.genericValue = ast.Negative{Target: RubyDollar[2].genericValue}
+		}
+	case 237:
+		RubyDollar = RubyS[Rubypt-2 : Rubypt+1]
+//line parser.y:1197
+		{
+			switch number := RubyDollar[2].genericValue.(type) {
+			case ast.ConstantInt:
+				RubyVAL.genericValue = ast.ConstantInt{Value: -number.Value}
+			case ast.ConstantFloat:
+				RubyVAL.genericValue = ast.ConstantFloat{Value: -number.Value}
+			default:
+				RubyVAL.genericValue = ast.Negative{Target: RubyDollar[2].genericValue}
+			}
+		}
+	case 238:
+		RubyDollar = RubyS[Rubypt-5 : Rubypt+1]
+//line parser.y:1209
+		{
+			RubyVAL.genericValue = ast.Negative{
+				Target: ast.CallExpression{
+					Target: RubyDollar[2].genericValue,
+					Func:   ast.BareReference{Name: "**"},
+					Args:   []ast.Node{RubyDollar[5].genericValue},
+				},
+			}
+		}
+	case 239:
+		RubyDollar = RubyS[Rubypt-3 : Rubypt+1]
+//line parser.y:1220
 		{
 			RubyVAL.genericValue = ast.CallExpression{
-				Target: RubyS[Rubypt-2].genericValue,
+				Target: RubyDollar[1].genericValue,
 				Func:   ast.BareReference{Name: "+"},
-				Args:   []ast.Node{RubyS[Rubypt-0].genericValue},
+				Args:   []ast.Node{RubyDollar[3].genericValue},
 			}
 		}
-	case 196:
-		//line parser.y:953
+	case 240:
+		RubyDollar = RubyS[Rubypt-3 : Rubypt+1]
+//line parser.y:1229
 		{
 			RubyVAL.genericValue = ast.CallExpression{
-				Target: RubyS[Rubypt-2].genericValue,
+				Target: RubyDollar[1].genericValue,
 				Func:   ast.BareReference{Name: "-"},
-				Args:   []ast.Node{RubyS[Rubypt-0].genericValue},
+				Args:   []ast.Node{RubyDollar[3].genericValue},
 			}
 		}
-	case 197:
-		//line parser.y:962
+	case 241:
+		RubyDollar = RubyS[Rubypt-3 : Rubypt+1]
+//line parser.y:1238
 		{
 			RubyVAL.genericValue = ast.CallExpression{
-				Target: RubyS[Rubypt-2].genericValue,
+				Target: RubyDollar[1].genericValue,
 				Func:   ast.BareReference{Name: "*"},
-				Args:   []ast.Node{RubyS[Rubypt-0].genericValue},
+				Args:   []ast.Node{RubyDollar[3].genericValue},
 			}
 		}
-	case 198:
-		//line parser.y:971
+	case 242:
+		RubyDollar = RubyS[Rubypt-3 : Rubypt+1]
+//line parser.y:1247
 		{
 			RubyVAL.genericValue = ast.CallExpression{
-				Target: RubyS[Rubypt-2].genericValue,
+				Target: RubyDollar[1].genericValue,
 				Func:   ast.BareReference{Name: "/"},
-				Args:   []ast.Node{RubyS[Rubypt-0].genericValue},
+				Args:   []ast.Node{RubyDollar[3].genericValue},
 			}
 		}
-	case 199:
-		//line parser.y:980
+	case 243:
+		RubyDollar = RubyS[Rubypt-4 : Rubypt+1]
+//line parser.y:1257
+		{
+			RubyVAL.genericValue = ast.CallExpression{
+				Target: RubyDollar[1].genericValue,
+				Func:   ast.BareReference{Name: "**"},
+				Args:   []ast.Node{RubyDollar[4].genericValue},
+			}
+		}
+	case 244:
+		RubyDollar = RubyS[Rubypt-3 : Rubypt+1]
+//line parser.y:1266
 		{
 			RubyVAL.genericValue = ast.CallExpression{
-				Target: RubyS[Rubypt-2].genericValue,
+				Target: RubyDollar[1].genericValue,
 				Func:   ast.BareReference{Name: "&"},
-				Args:   []ast.Node{RubyS[Rubypt-0].genericValue},
+				Args:   []ast.Node{RubyDollar[3].genericValue},
 			}
 		}
-	case 200:
-		//line parser.y:989
+	case 245:
+		RubyDollar = RubyS[Rubypt-3 : Rubypt+1]
+//line parser.y:1275
 		{
 			RubyVAL.genericValue = ast.CallExpression{
-				Target: RubyS[Rubypt-2].genericValue,
+				Target: RubyDollar[1].genericValue,
 				Func:   ast.BareReference{Name: "|"},
-				Args:   []ast.Node{RubyS[Rubypt-0].genericValue},
+				Args:   []ast.Node{RubyDollar[3].genericValue},
 			}
 		}
-	case 201:
-		//line parser.y:997
+	case 246:
+		RubyDollar = RubyS[Rubypt-1 : Rubypt+1]
+//line parser.y:1283
 		{
 			RubyVAL.genericValue = ast.Boolean{Value: true}
 		}
-	case 202:
-		//line parser.y:998
+	case 247:
+		RubyDollar = RubyS[Rubypt-1 : Rubypt+1]
+//line parser.y:1284
 		{
 			RubyVAL.genericValue = ast.Boolean{Value: false}
 		}
-	case 203:
-		//line parser.y:1000
+	case 248:
+		RubyDollar = RubyS[Rubypt-5 : Rubypt+1]
+//line parser.y:1286
 		{
-			RubyVAL.genericValue = ast.Array{Nodes: RubyS[Rubypt-2].genericSlice}
+			RubyVAL.genericValue = ast.Array{Nodes: RubyDollar[3].genericSlice}
 		}
-	case 204:
-		//line parser.y:1002
+	case 249:
+		RubyDollar = RubyS[Rubypt-1 : Rubypt+1]
+//line parser.y:1288
 		{
 			RubyVAL.genericValue = ast.Self{}
 		}
-	case 205:
-		//line parser.y:1003
+	case 250:
+		RubyDollar = RubyS[Rubypt-1 : Rubypt+1]
+//line parser.y:1289
 		{
 			RubyVAL.genericValue = ast.Nil{}
 		}
-	case 206:
-		//line parser.y:1005
+	case 251:
+		RubyDollar = RubyS[Rubypt-0 : Rubypt+1]
+//line parser.y:1291
 		{
 			RubyVAL.genericSlice = ast.Nodes{}
 		}
-	case 207:
-		//line parser.y:1007
+	case 252:
+		RubyDollar = RubyS[Rubypt-1 : Rubypt+1]
+//line parser.y:1293
 		{
-			RubyVAL.genericSlice = append(RubyVAL.genericSlice, RubyS[Rubypt-0].genericValue)
+			RubyVAL.genericSlice = append(RubyVAL.genericSlice, RubyDollar[1].genericValue)
 		}
-	case 208:
-		//line parser.y:1009
+	case 253:
+		RubyDollar = RubyS[Rubypt-1 : Rubypt+1]
+//line parser.y:1295
 		{
-			RubyVAL.genericSlice = append(RubyVAL.genericSlice, RubyS[Rubypt-0].genericValue)
+			RubyVAL.genericSlice = append(RubyVAL.genericSlice, withPosition(RubyDollar[1].genericValue, RubyDollar[1].pos))
 		}
-	case 209:
-		//line parser.y:1011
+	case 254:
+		RubyDollar = RubyS[Rubypt-1 : Rubypt+1]
+//line parser.y:1297
 		{
-			RubyVAL.genericSlice = append(RubyVAL.genericSlice, RubyS[Rubypt-0].genericValue)
+			RubyVAL.genericSlice = append(RubyVAL.genericSlice, withPosition(RubyDollar[1].genericValue, RubyDollar[1].pos))
 		}
-	case 210:
-		//line parser.y:1013
+	case 255:
+		RubyDollar = RubyS[Rubypt-4 : Rubypt+1]
+//line parser.y:1299
 		{
-			RubyVAL.genericSlice = append(RubyVAL.genericSlice, RubyS[Rubypt-0].genericValue)
+			RubyVAL.genericSlice = append(RubyVAL.genericSlice, RubyDollar[4].genericValue)
 		}
-	case 211:
-		//line parser.y:1015
+	case 256:
+		RubyDollar = RubyS[Rubypt-4 : Rubypt+1]
+//line parser.y:1301
 		{
-			RubyVAL.genericSlice = append(RubyVAL.genericSlice, RubyS[Rubypt-0].genericValue)
+			RubyVAL.genericSlice = append(RubyVAL.genericSlice, withPosition(RubyDollar[4].genericValue, RubyDollar[4].pos))
 		}
-	case 212:
-		//line parser.y:1017
+	case 257:
+		RubyDollar = RubyS[Rubypt-4 : Rubypt+1]
+//line parser.y:1303
 		{
-			RubyVAL.genericSlice = append(RubyVAL.genericSlice, RubyS[Rubypt-0].genericValue)
+			RubyVAL.genericSlice = append(RubyVAL.genericSlice, withPosition(RubyDollar[4].genericValue, RubyDollar[4].pos))
 		}
-	case 213:
-		//line parser.y:1020
+	case 258:
+		RubyDollar = RubyS[Rubypt-3 : Rubypt+1]
+//line parser.y:1306
 		{
 			RubyVAL.genericValue = ast.Hash{}
 		}
-	case 214:
-		//line parser.y:1022
+	case 259:
+		RubyDollar = RubyS[Rubypt-5 : Rubypt+1]
+//line parser.y:1308
 		{
 			pairs := []ast.HashKeyValuePair{}
-			for _, node := range RubyS[Rubypt-2].genericSlice {
+			for _, node := range RubyDollar[3].genericSlice {
 				pairs = append(pairs, node.(ast.HashKeyValuePair))
 			}
 			RubyVAL.genericValue = ast.Hash{Pairs: pairs}
 		}
-	case 215:
-		//line parser.y:1030
+	case 260:
+		RubyDollar = RubyS[Rubypt-6 : Rubypt+1]
+//line parser.y:1316
 		{
 			pairs := []ast.HashKeyValuePair{}
-			for _, node := range RubyS[Rubypt-2].genericSlice {
+			for _, node := range RubyDollar[3].genericSlice {
 				pairs = append(pairs, node.(ast.HashKeyValuePair))
 			}
 			RubyVAL.genericValue = ast.Hash{Pairs: pairs}
 		}
-	case 216:
-		//line parser.y:1039
+	case 261:
+		RubyDollar = RubyS[Rubypt-5 : Rubypt+1]
+//line parser.y:1324
 		{
-			if RubyS[Rubypt-1].operator != "=>" {
-				panic("FREAKOUT")
+			pairs := []ast.HashKeyValuePair{}
+			for _, node := range RubyDollar[3].genericSlice {
+				pairs = append(pairs, node.(ast.HashKeyValuePair))
 			}
-			RubyVAL.genericSlice = append(RubyVAL.genericSlice, ast.HashKeyValuePair{Key: RubyS[Rubypt-2].genericValue, Value: RubyS[Rubypt-0].genericValue})
+			RubyVAL.genericValue = ast.Hash{Pairs: pairs}
 		}
-	case 217:
-		//line parser.y:1046
+	case 262:
+		RubyDollar = RubyS[Rubypt-3 : Rubypt+1]
+//line parser.y:1333
 		{
-			if RubyS[Rubypt-2].operator != "=>" {
-				panic("FREAKOUT")
-			}
-			RubyVAL.genericSlice = append(RubyVAL.genericSlice, ast.HashKeyValuePair{Key: RubyS[Rubypt-3].genericValue, Value: RubyS[Rubypt-1].genericValue})
+			RubyVAL.genericSlice = append(RubyVAL.genericSlice, ast.HashKeyValuePair{Key: RubyDollar[1].genericValue, Value: RubyDollar[3].genericValue})
 		}
-	case 218:
-		//line parser.y:1054
+	case 263:
+		RubyDollar = RubyS[Rubypt-6 : Rubypt+1]
+//line parser.y:1337
+		{
+			RubyVAL.genericSlice = append(RubyVAL.genericSlice, ast.HashKeyValuePair{Key: RubyDollar[4].genericValue, Value: RubyDollar[6].genericValue})
+		}
+	case 264:
+		RubyDollar = RubyS[Rubypt-3 : Rubypt+1]
+//line parser.y:1342
 		{
 			RubyVAL.genericSlice = append(RubyVAL.genericSlice, ast.HashKeyValuePair{
-				Key:   ast.Symbol{Name: RubyS[Rubypt-2].genericValue.(ast.BareReference).Name},
-				Value: RubyS[Rubypt-0].genericValue,
+				Key:   ast.Symbol{Name: RubyDollar[1].genericValue.(ast.BareReference).Name},
+				Value: RubyDollar[3].genericValue,
 			})
 		}
-	case 219:
-		//line parser.y:1061
+	case 265:
+		RubyDollar = RubyS[Rubypt-7 : Rubypt+1]
+//line parser.y:1349
 		{
 			RubyVAL.genericSlice = append(RubyVAL.genericSlice, ast.HashKeyValuePair{
-				Key:   ast.Symbol{Name: RubyS[Rubypt-3].genericValue.(ast.BareReference).Name},
-				Value: RubyS[Rubypt-1].genericValue,
+				Key:   ast.Symbol{Name: RubyDollar[4].genericValue.(ast.BareReference).Name},
+				Value: RubyDollar[6].genericValue,
 			})
 		}
-	case 220:
-		//line parser.y:1068
+	case 266:
+		RubyDollar = RubyS[Rubypt-8 : Rubypt+1]
+//line parser.y:1356
 		{
 			RubyVAL.genericSlice = append(RubyVAL.genericSlice, ast.HashKeyValuePair{
-				Key:   ast.Symbol{Name: RubyS[Rubypt-4].genericValue.(ast.BareReference).Name},
-				Value: RubyS[Rubypt-2].genericValue,
+				Key:   ast.Symbol{Name: RubyDollar[4].genericValue.(ast.BareReference).Name},
+				Value: RubyDollar[6].genericValue,
 			})
 		}
-	case 221:
-		//line parser.y:1076
+	case 267:
+		RubyDollar = RubyS[Rubypt-3 : Rubypt+1]
+//line parser.y:1364
 		{
-			RubyVAL.genericBlock = ast.Block{Body: RubyS[Rubypt-1].genericSlice}
+			RubyVAL.genericBlock = newBlockWithoutArgs(RubyDollar[2].genericSlice)
 		}
-	case 222:
-		//line parser.y:1078
+	case 268:
+		RubyDollar = RubyS[Rubypt-4 : Rubypt+1]
+//line parser.y:1366
 		{
-			RubyVAL.genericBlock = ast.Block{Args: RubyS[Rubypt-2].genericSlice, Body: RubyS[Rubypt-1].genericSlice}
+			RubyVAL.genericBlock = ast.Block{Args: RubyDollar[2].genericSlice, Body: RubyDollar[3].genericSlice}
 		}
-	case 223:
-		//line parser.y:1080
+	case 269:
+		RubyDollar = RubyS[Rubypt-5 : Rubypt+1]
+//line parser.y:1368
 		{
-			RubyVAL.genericBlock = ast.Block{Body: RubyS[Rubypt-2].genericSlice}
+			RubyVAL.genericBlock = newBlockWithoutArgs(RubyDollar[3].genericSlice)
 		}
-	case 224:
-		//line parser.y:1082
+	case 270:
+		RubyDollar = RubyS[Rubypt-5 : Rubypt+1]
+//line parser.y:1370
 		{
-			RubyVAL.genericBlock = ast.Block{Args: RubyS[Rubypt-2].genericSlice, Body: RubyS[Rubypt-1].genericSlice}
+			RubyVAL.genericBlock = ast.Block{Args: RubyDollar[3].genericSlice, Body: RubyDollar[4].genericSlice}
 		}
-	case 225:
-		//line parser.y:1084
+	case 271:
+		RubyDollar = RubyS[Rubypt-5 : Rubypt+1]
+//line parser.y:1372
 		{
-			RubyVAL.genericBlock = ast.Block{Body: []ast.Node{RubyS[Rubypt-2].genericValue}}
+			RubyVAL.genericBlock = newBlockWithoutArgs([]ast.Node{withPosition(RubyDollar[3].genericValue, RubyDollar[3].pos)})
 		}
-	case 226:
-		//line parser.y:1086
+	case 272:
+		RubyDollar = RubyS[Rubypt-6 : Rubypt+1]
+//line parser.y:1374
 		{
-			head := []ast.Node{RubyS[Rubypt-3].genericValue}
-			tail := RubyS[Rubypt-2].genericSlice
+			head := []ast.Node{withPosition(RubyDollar[3].genericValue, RubyDollar[3].pos)}
+			tail := RubyDollar[4].genericSlice
 			body := append(head, tail...)
-			RubyVAL.genericBlock = ast.Block{Body: body}
+			RubyVAL.genericBlock = newBlockWithoutArgs(body)
 		}
-	case 227:
-		//line parser.y:1094
+	case 273:
+		RubyDollar = RubyS[Rubypt-6 : Rubypt+1]
+//line parser.y:1381
 		{
-			RubyVAL.genericSlice = RubyS[Rubypt-1].genericSlice
+			RubyVAL.genericBlock = newBlockWithoutArgs(append([]ast.Node{withPosition(RubyDollar[3].genericValue, RubyDollar[3].pos)}, RubyDollar[4].genericSlice...))
 		}
-	case 228:
-		//line parser.y:1096
+	case 274:
+		RubyDollar = RubyS[Rubypt-5 : Rubypt+1]
+//line parser.y:1383
+		{
+			RubyVAL.genericBlock = newBlockWithoutArgs([]ast.Node{RubyDollar[3].genericValue})
+		}
+	case 275:
+		RubyDollar = RubyS[Rubypt-3 : Rubypt+1]
+//line parser.y:1386
+		{
+			RubyVAL.genericSlice = RubyDollar[2].genericSlice
+		}
+	case 276:
+		RubyDollar = RubyS[Rubypt-3 : Rubypt+1]
+//line parser.y:1388
+		{
+			RubyVAL.genericSlice = RubyDollar[2].genericSlice
+		}
+	case 277:
+		RubyDollar = RubyS[Rubypt-1 : Rubypt+1]
+//line parser.y:1391
+		{
+			RubyVAL.genericSlice = ast.Nodes{RubyDollar[1].genericValue}
+		}
+	case 278:
+		RubyDollar = RubyS[Rubypt-3 : Rubypt+1]
+//line parser.y:1393
+		{
+			RubyVAL.genericSlice = append(RubyDollar[1].genericSlice, RubyDollar[3].genericValue)
+		}
+	case 279:
+		RubyDollar = RubyS[Rubypt-3 : Rubypt+1]
+//line parser.y:1395
+		{
+			RubyVAL.genericSlice = append(RubyDollar[1].genericSlice, RubyDollar[3].genericValue)
+		}
+	case 280:
+		RubyDollar = RubyS[Rubypt-3 : Rubypt+1]
+//line parser.y:1397
+		{
+			RubyVAL.genericSlice = append(RubyDollar[1].genericSlice, RubyDollar[3].genericValue)
+		}
+	case 281:
+		RubyDollar = RubyS[Rubypt-3 : Rubypt+1]
+//line parser.y:1400
+		{
+			RubyVAL.genericValue = ast.DestructuredParam{Params: RubyDollar[2].genericSlice}
+		}
+	case 282:
+		RubyDollar = RubyS[Rubypt-0 : Rubypt+1]
+//line parser.y:1402
 		{
 			RubyVAL.genericSlice = ast.Nodes{}
 		}
-	case 229:
-		//line parser.y:1098
+	case 283:
+		RubyDollar = RubyS[Rubypt-1 : Rubypt+1]
+//line parser.y:1404
 		{
-			RubyVAL.genericSlice = append(RubyVAL.genericSlice, RubyS[Rubypt-0].genericValue)
+			RubyVAL.genericSlice = append(RubyVAL.genericSlice, RubyDollar[1].genericValue)
 		}
-	case 230:
-		//line parser.y:1100
+	case 284:
+		RubyDollar = RubyS[Rubypt-3 : Rubypt+1]
+//line parser.y:1406
 		{
-			RubyVAL.genericSlice = append(RubyVAL.genericSlice, RubyS[Rubypt-0].genericValue)
+			RubyVAL.genericSlice = append(RubyVAL.genericSlice, RubyDollar[3].genericValue)
 		}
-	case 231:
-		//line parser.y:1103
+	case 285:
+		RubyDollar = RubyS[Rubypt-4 : Rubypt+1]
+//line parser.y:1409
 		{
 			RubyVAL.genericValue = ast.IfBlock{
-				Condition: RubyS[Rubypt-2].genericValue,
-				Body:      RubyS[Rubypt-1].genericSlice,
+				Condition: RubyDollar[2].genericValue,
+				Body:      RubyDollar[3].genericSlice,
 			}
 		}
-	case 232:
-		//line parser.y:1110
+	case 286:
+		RubyDollar = RubyS[Rubypt-5 : Rubypt+1]
+//line parser.y:1416
 		{
 			RubyVAL.genericValue = ast.IfBlock{
-				Condition: RubyS[Rubypt-3].genericValue,
-				Body:      RubyS[Rubypt-2].genericSlice,
-				Else:      RubyS[Rubypt-1].genericSlice,
+				Condition: RubyDollar[2].genericValue,
+				Body:      RubyDollar[3].genericSlice,
+				Else:      RubyDollar[4].genericSlice,
 			}
 		}
-	case 233:
-		//line parser.y:1118
+	case 287:
+		RubyDollar = RubyS[Rubypt-3 : Rubypt+1]
+//line parser.y:1424
 		{
 			RubyVAL.genericValue = ast.IfBlock{
-				Condition: RubyS[Rubypt-0].genericValue,
-				Body:      []ast.Node{RubyS[Rubypt-2].genericValue},
+				Condition: RubyDollar[3].genericValue,
+				Body:      []ast.Node{RubyDollar[1].genericValue},
 			}
 		}
-	case 234:
-		//line parser.y:1125
+	case 288:
+		RubyDollar = RubyS[Rubypt-3 : Rubypt+1]
+//line parser.y:1431
 		{
 			RubyVAL.genericValue = ast.IfBlock{
-				Condition: RubyS[Rubypt-0].genericValue,
-				Body:      []ast.Node{RubyS[Rubypt-2].genericValue},
+				Condition: RubyDollar[3].genericValue,
+				Body:      []ast.Node{withPosition(RubyDollar[1].genericValue, RubyDollar[1].pos)},
 			}
 		}
-	case 235:
-		//line parser.y:1132
+	case 289:
+		RubyDollar = RubyS[Rubypt-3 : Rubypt+1]
+//line parser.y:1438
 		{
 			RubyVAL.genericValue = ast.IfBlock{
-				Condition: ast.Negation{Target: RubyS[Rubypt-0].genericValue},
-				Body:      []ast.Node{RubyS[Rubypt-2].genericValue},
+				Condition: ast.Negation{Target: RubyDollar[3].genericValue},
+				Body:      []ast.Node{RubyDollar[1].genericValue},
 			}
 		}
-	case 236:
-		//line parser.y:1139
+	case 290:
+		RubyDollar = RubyS[Rubypt-3 : Rubypt+1]
+//line parser.y:1445
 		{
 			RubyVAL.genericValue = ast.IfBlock{
-				Condition: ast.Negation{Target: RubyS[Rubypt-0].genericValue},
-				Body:      ast.Nodes{RubyS[Rubypt-2].genericValue},
+				Condition: ast.Negation{Target: RubyDollar[3].genericValue},
+				Body:      ast.Nodes{withPosition(RubyDollar[1].genericValue, RubyDollar[1].pos)},
 			}
 		}
-	case 237:
-		//line parser.y:1146
+	case 291:
+		RubyDollar = RubyS[Rubypt-3 : Rubypt+1]
+//line parser.y:1452
 		{
 			RubyVAL.genericValue = ast.IfBlock{
-				Condition: ast.Negation{Target: RubyS[Rubypt-0].genericValue},
-				Body:      ast.Nodes{RubyS[Rubypt-2].genericValue},
+				Condition: ast.Negation{Target: RubyDollar[3].genericValue},
+				Body:      ast.Nodes{withPosition(RubyDollar[1].genericValue, RubyDollar[1].pos)},
 			}
 		}
-	case 238:
-		//line parser.y:1153
+	case 292:
+		RubyDollar = RubyS[Rubypt-5 : Rubypt+1]
+//line parser.y:1459
 		{
 			RubyVAL.genericValue = ast.IfBlock{
-				Condition: ast.Negation{Target: RubyS[Rubypt-3].genericValue},
-				Body:      RubyS[Rubypt-1].genericSlice,
+				Condition: ast.Negation{Target: RubyDollar[2].genericValue},
+				Body:      RubyDollar[4].genericSlice,
 			}
 		}
-	case 239:
-		//line parser.y:1160
+	case 293:
+		RubyDollar = RubyS[Rubypt-6 : Rubypt+1]
+//line parser.y:1466
 		{
 			RubyVAL.genericValue = ast.IfBlock{
-				Condition: ast.Negation{Target: RubyS[Rubypt-4].genericValue},
-				Body:      RubyS[Rubypt-2].genericSlice,
-				Else:      RubyS[Rubypt-1].genericSlice,
+				Condition: ast.Negation{Target: RubyDollar[2].genericValue},
+				Body:      RubyDollar[4].genericSlice,
+				Else:      RubyDollar[5].genericSlice,
 			}
 		}
-	case 240:
-		//line parser.y:1168
+	case 294:
+		RubyDollar = RubyS[Rubypt-5 : Rubypt+1]
+//line parser.y:1474
 		{
 			RubyVAL.genericValue = ast.IfBlock{
-				Condition: ast.Negation{Target: RubyS[Rubypt-3].genericValue},
-				Body:      RubyS[Rubypt-1].genericSlice,
+				Condition: ast.Negation{Target: RubyDollar[2].genericValue},
+				Body:      RubyDollar[4].genericSlice,
 			}
 		}
-	case 241:
-		//line parser.y:1175
+	case 295:
+		RubyDollar = RubyS[Rubypt-3 : Rubypt+1]
+//line parser.y:1481
 		{
 			RubyVAL.genericValue = ast.IfBlock{
-				Condition: ast.Negation{Target: RubyS[Rubypt-0].genericValue},
-				Body:      []ast.Node{RubyS[Rubypt-2].genericValue},
+				Condition: ast.Negation{Target: RubyDollar[3].genericValue},
+				Body:      []ast.Node{RubyDollar[1].genericValue},
 			}
 		}
-	case 242:
-		//line parser.y:1184
+	case 296:
+		RubyDollar = RubyS[Rubypt-4 : Rubypt+1]
+//line parser.y:1490
 		{
 			RubyVAL.genericSlice = append(RubyVAL.genericSlice, ast.IfBlock{
-				Condition: RubyS[Rubypt-1].genericValue,
-				Body:      RubyS[Rubypt-0].genericSlice,
+				Condition: RubyDollar[3].genericValue,
+				Body:      RubyDollar[4].genericSlice,
 			})
 		}
-	case 243:
-		//line parser.y:1191
+	case 297:
+		RubyDollar = RubyS[Rubypt-3 : Rubypt+1]
+//line parser.y:1497
 		{
 			RubyVAL.genericSlice = append(RubyVAL.genericSlice, ast.IfBlock{
 				Condition: ast.Boolean{Value: true},
-				Body:      RubyS[Rubypt-0].genericSlice,
+				Body:      RubyDollar[3].genericSlice,
 			})
 		}
-	case 244:
-		//line parser.y:1198
+	case 298:
+		RubyDollar = RubyS[Rubypt-3 : Rubypt+1]
+//line parser.y:1504
 		{
 			RubyVAL.genericSlice = append(RubyVAL.genericSlice, ast.IfBlock{
-				Condition: RubyS[Rubypt-1].genericValue,
-				Body:      RubyS[Rubypt-0].genericSlice,
+				Condition: RubyDollar[2].genericValue,
+				Body:      RubyDollar[3].genericSlice,
 			})
 		}
-	case 245:
-		//line parser.y:1205
+	case 299:
+		RubyDollar = RubyS[Rubypt-2 : Rubypt+1]
+//line parser.y:1511
 		{
 			RubyVAL.genericSlice = append(RubyVAL.genericSlice, ast.IfBlock{
 				Condition: ast.Boolean{Value: true},
-				Body:      RubyS[Rubypt-0].genericSlice,
+				Body:      RubyDollar[2].genericSlice,
 			})
 		}
-	case 246:
-		//line parser.y:1212
+	case 300:
+		RubyDollar = RubyS[Rubypt-0 : Rubypt+1]
+//line parser.y:1518
 		{
+			RubyVAL.genericSlice = ast.Nodes{}
 		}
-	case 247:
-		//line parser.y:1213
+	case 301:
+		RubyDollar = RubyS[Rubypt-2 : Rubypt+1]
+//line parser.y:1519
 		{
-			RubyVAL.genericSlice = append(RubyVAL.genericSlice, RubyS[Rubypt-0].genericValue)
+			RubyVAL.genericSlice = append(RubyVAL.genericSlice, RubyDollar[2].genericValue)
 		}
-	case 248:
-		//line parser.y:1214
+	case 302:
+		RubyDollar = RubyS[Rubypt-2 : Rubypt+1]
+//line parser.y:1520
 		{
 		}
-	case 249:
-		//line parser.y:1217
+	case 303:
+		RubyDollar = RubyS[Rubypt-3 : Rubypt+1]
+//line parser.y:1523
 		{
-			RubyVAL.genericValue = ast.Group{Body: RubyS[Rubypt-1].genericSlice}
+			RubyVAL.genericValue = ast.Group{Body: RubyDollar[2].genericSlice}
 		}
-	case 250:
-		//line parser.y:1220
+	case 304:
+		RubyDollar = RubyS[Rubypt-4 : Rubypt+1]
+//line parser.y:1526
 		{
 			RubyVAL.genericValue = ast.Begin{
-				Body:   RubyS[Rubypt-2].genericSlice,
-				Rescue: RubyS[Rubypt-1].genericSlice,
+				Body:   RubyDollar[2].genericSlice,
+				Rescue: RubyDollar[3].genericSlice,
 			}
 		}
-	case 251:
-		//line parser.y:1227
+	case 305:
+		RubyDollar = RubyS[Rubypt-6 : Rubypt+1]
+//line parser.y:1533
 		{
 			RubyVAL.genericValue = ast.Begin{
-				Body:   RubyS[Rubypt-4].genericSlice,
-				Rescue: RubyS[Rubypt-3].genericSlice,
-				Else:   RubyS[Rubypt-1].genericSlice,
+				Body:   RubyDollar[2].genericSlice,
+				Rescue: RubyDollar[3].genericSlice,
+				Else:   RubyDollar[5].genericSlice,
 			}
 		}
-	case 252:
-		//line parser.y:1236
+	case 306:
+		RubyDollar = RubyS[Rubypt-6 : Rubypt+1]
+//line parser.y:1541
+		{
+			RubyVAL.genericValue = ast.Begin{
+				Body:   RubyDollar[2].genericSlice,
+				Rescue: RubyDollar[3].genericSlice,
+				Ensure: RubyDollar[5].genericSlice,
+			}
+		}
+	case 307:
+		RubyDollar = RubyS[Rubypt-8 : Rubypt+1]
+//line parser.y:1549
+		{
+			RubyVAL.genericValue = ast.Begin{
+				Body:   RubyDollar[2].genericSlice,
+				Rescue: RubyDollar[3].genericSlice,
+				Else:   RubyDollar[5].genericSlice,
+				Ensure: RubyDollar[7].genericSlice,
+			}
+		}
+	case 308:
+		RubyDollar = RubyS[Rubypt-2 : Rubypt+1]
+//line parser.y:1559
 		{
-			RubyVAL.genericValue = ast.Rescue{Body: RubyS[Rubypt-0].genericSlice}
+			RubyVAL.genericValue = ast.Rescue{Body: RubyDollar[2].genericSlice}
 		}
-	case 253:
-		//line parser.y:1238
+	case 309:
+		RubyDollar = RubyS[Rubypt-3 : Rubypt+1]
+//line parser.y:1561
 		{
 			classes := []ast.Class{}
-			for _, class := range RubyS[Rubypt-1].genericSlice {
+			for _, class := range RubyDollar[2].genericSlice {
 				classes = append(classes, class.(ast.Class))
 			}
 			RubyVAL.genericValue = ast.Rescue{
-				Body: RubyS[Rubypt-0].genericSlice,
+				Body: RubyDollar[3].genericSlice,
 				Exception: ast.RescueException{
 					Classes: classes,
 				},
 			}
 		}
-	case 254:
-		//line parser.y:1251
+	case 310:
+		RubyDollar = RubyS[Rubypt-5 : Rubypt+1]
+//line parser.y:1574
 		{
-			if RubyS[Rubypt-2].operator != "=>" {
-				panic("FREAKOUT")
+			classes := []ast.Class{}
+			for _, class := range RubyDollar[2].genericSlice {
+				classes = append(classes, class.(ast.Class))
 			}
 
+			RubyVAL.genericValue = ast.Rescue{
+				Body: RubyDollar[5].genericSlice,
+				Exception: ast.RescueException{
+					Var:     RubyDollar[4].genericValue.(ast.BareReference),
+					Classes: classes,
+				},
+			}
+		}
+	case 311:
+		RubyDollar = RubyS[Rubypt-3 : Rubypt+1]
+//line parser.y:1589
+		{
+			RubyVAL.genericValue = ast.Rescue{
+				Body:      RubyDollar[3].genericSlice,
+				Exception: ast.RescueException{Splat: RubyDollar[2].genericValue},
+			}
+		}
+	case 312:
+		RubyDollar = RubyS[Rubypt-5 : Rubypt+1]
+//line parser.y:1596
+		{
+			RubyVAL.genericValue = ast.Rescue{
+				Body: RubyDollar[5].genericSlice,
+				Exception: ast.RescueException{
+					Var:   RubyDollar[4].genericValue.(ast.BareReference),
+					Splat: RubyDollar[2].genericValue,
+				},
+			}
+		}
+	case 313:
+		RubyDollar = RubyS[Rubypt-5 : Rubypt+1]
+//line parser.y:1606
+		{
 			classes := []ast.Class{}
-			for _, class := range RubyS[Rubypt-3].genericSlice {
+			for _, class := range RubyDollar[2].genericSlice {
 				classes = append(classes, class.(ast.Class))
 			}
 
 			RubyVAL.genericValue = ast.Rescue{
-				Body: RubyS[Rubypt-0].genericSlice,
+				Body: RubyDollar[5].genericSlice,
 				Exception: ast.RescueException{
-					Var:     RubyS[Rubypt-1].genericValue.(ast.BareReference),
 					Classes: classes,
+					Splat:   RubyDollar[4].genericValue,
 				},
 			}
 		}
-	case 255:
-		//line parser.y:1270
+	case 314:
+		RubyDollar = RubyS[Rubypt-7 : Rubypt+1]
+//line parser.y:1621
 		{
-			if RubyS[Rubypt-2].operator != "=>" {
-				panic("FREAKOUT")
+			classes := []ast.Class{}
+			for _, class := range RubyDollar[2].genericSlice {
+				classes = append(classes, class.(ast.Class))
 			}
 
 			RubyVAL.genericValue = ast.Rescue{
-				Body: RubyS[Rubypt-0].genericSlice,
+				Body: RubyDollar[7].genericSlice,
 				Exception: ast.RescueException{
-					Var: RubyS[Rubypt-1].genericValue.(ast.BareReference),
+					Var:     RubyDollar[6].genericValue.(ast.BareReference),
+					Classes: classes,
+					Splat:   RubyDollar[4].genericValue,
 				},
 			}
 		}
-	case 256:
-		//line parser.y:1284
+	case 315:
+		RubyDollar = RubyS[Rubypt-4 : Rubypt+1]
+//line parser.y:1637
 		{
-			RubyVAL.genericSlice = append(RubyVAL.genericSlice, RubyS[Rubypt-0].genericValue)
+			RubyVAL.genericValue = ast.Rescue{
+				Body: RubyDollar[4].genericSlice,
+				Exception: ast.RescueException{
+					Var: RubyDollar[3].genericValue.(ast.BareReference),
+				},
+			}
 		}
-	case 257:
-		//line parser.y:1286
+	case 316:
+		RubyDollar = RubyS[Rubypt-2 : Rubypt+1]
+//line parser.y:1647
 		{
-			RubyVAL.genericSlice = append(RubyVAL.genericSlice, RubyS[Rubypt-0].genericValue)
+			RubyVAL.genericValue = RubyDollar[2].genericValue
 		}
-	case 258:
-		//line parser.y:1289
+	case 317:
+		RubyDollar = RubyS[Rubypt-2 : Rubypt+1]
+//line parser.y:1649
+		{
+			RubyVAL.genericValue = RubyDollar[2].genericValue
+		}
+	case 318:
+		RubyDollar = RubyS[Rubypt-1 : Rubypt+1]
+//line parser.y:1652
+		{
+			RubyVAL.genericSlice = append(RubyVAL.genericSlice, withPosition(RubyDollar[1].genericValue, RubyDollar[1].pos))
+		}
+	case 319:
+		RubyDollar = RubyS[Rubypt-3 : Rubypt+1]
+//line parser.y:1654
+		{
+			RubyVAL.genericSlice = append(RubyVAL.genericSlice, withPosition(RubyDollar[3].genericValue, RubyDollar[3].pos))
+		}
+	case 320:
+		RubyDollar = RubyS[Rubypt-0 : Rubypt+1]
+//line parser.y:1657
 		{
 			RubyVAL.genericSlice = []ast.Node{}
 		}
-	case 259:
-		//line parser.y:1291
+	case 321:
+		RubyDollar = RubyS[Rubypt-2 : Rubypt+1]
+//line parser.y:1659
 		{
-			RubyVAL.genericSlice = append(RubyVAL.genericSlice, RubyS[Rubypt-0].genericValue)
+			RubyVAL.genericSlice = append(RubyVAL.genericSlice, RubyDollar[2].genericValue)
 		}
-	case 260:
-		//line parser.y:1294
+	case 322:
+		RubyDollar = RubyS[Rubypt-1 : Rubypt+1]
+//line parser.y:1662
 		{
-			RubyVAL.genericSlice = append(RubyVAL.genericSlice, RubyS[Rubypt-0].genericValue)
+			RubyVAL.genericSlice = append(RubyVAL.genericSlice, RubyDollar[1].genericValue)
 		}
-	case 261:
-		//line parser.y:1296
+	case 323:
+		RubyDollar = RubyS[Rubypt-2 : Rubypt+1]
+//line parser.y:1664
 		{
-			RubyVAL.genericSlice = append(RubyVAL.genericSlice, RubyS[Rubypt-0].genericValue)
+			RubyVAL.genericSlice = append(RubyVAL.genericSlice, RubyDollar[2].genericValue)
 		}
-	case 262:
-		//line parser.y:1299
+	case 324:
+		RubyDollar = RubyS[Rubypt-4 : Rubypt+1]
+//line parser.y:1667
 		{
-			if len(RubyS[Rubypt-0].genericSlice) == 1 {
-				RubyVAL.genericValue = ast.Yield{Value: RubyS[Rubypt-0].genericSlice[0]}
+			RubyVAL.genericValue = ast.DefinedExpression{Target: RubyDollar[3].genericValue}
+		}
+	case 325:
+		RubyDollar = RubyS[Rubypt-2 : Rubypt+1]
+//line parser.y:1669
+		{
+			RubyVAL.genericValue = ast.DefinedExpression{Target: RubyDollar[2].genericValue}
+		}
+	case 326:
+		RubyDollar = RubyS[Rubypt-2 : Rubypt+1]
+//line parser.y:1672
+		{
+			if len(RubyDollar[2].genericSlice) == 1 {
+				RubyVAL.genericValue = ast.Yield{Value: RubyDollar[2].genericSlice[0]}
 			} else {
-				RubyVAL.genericValue = ast.Yield{Value: RubyS[Rubypt-0].genericSlice}
+				RubyVAL.genericValue = ast.Yield{Value: RubyDollar[2].genericSlice}
 			}
 		}
-	case 263:
-		//line parser.y:1306
+	case 327:
+		RubyDollar = RubyS[Rubypt-1 : Rubypt+1]
+//line parser.y:1679
 		{
 			RubyVAL.genericValue = ast.Yield{}
 		}
-	case 264:
-		//line parser.y:1308
+	case 328:
+		RubyDollar = RubyS[Rubypt-1 : Rubypt+1]
+//line parser.y:1681
 		{
 			RubyVAL.genericValue = ast.Retry{}
 		}
-	case 265:
-		//line parser.y:1311
+	case 329:
+		RubyDollar = RubyS[Rubypt-2 : Rubypt+1]
+//line parser.y:1684
 		{
-			if len(RubyS[Rubypt-0].genericSlice) == 1 {
-				RubyVAL.genericValue = ast.Return{Value: RubyS[Rubypt-0].genericSlice[0]}
+			if len(RubyDollar[2].genericSlice) == 1 {
+				RubyVAL.genericValue = ast.Return{Value: RubyDollar[2].genericSlice[0]}
 			} else {
-				RubyVAL.genericValue = ast.Return{Value: RubyS[Rubypt-0].genericSlice}
+				RubyVAL.genericValue = ast.Return{Value: RubyDollar[2].genericSlice}
 			}
 		}
-	case 266:
-		//line parser.y:1319
+	case 330:
+		RubyDollar = RubyS[Rubypt-1 : Rubypt+1]
+//line parser.y:1692
 		{
 			RubyVAL.genericValue = ast.Return{}
 		}
-	case 267:
-		//line parser.y:1323
+	case 331:
+		RubyDollar = RubyS[Rubypt-1 : Rubypt+1]
+//line parser.y:1696
 		{
 			RubyVAL.genericValue = ast.Next{}
 		}
-	case 268:
-		//line parser.y:1325
+	case 332:
+		RubyDollar = RubyS[Rubypt-3 : Rubypt+1]
+//line parser.y:1698
 		{
-			RubyVAL.genericValue = ast.IfBlock{Condition: RubyS[Rubypt-0].genericValue, Body: []ast.Node{ast.Next{}}}
+			RubyVAL.genericValue = ast.IfBlock{Condition: RubyDollar[3].genericValue, Body: []ast.Node{ast.Next{}}}
 		}
-	case 269:
-		//line parser.y:1327
+	case 333:
+		RubyDollar = RubyS[Rubypt-3 : Rubypt+1]
+//line parser.y:1700
 		{
-			RubyVAL.genericValue = ast.IfBlock{Condition: ast.Negation{Target: RubyS[Rubypt-0].genericValue}, Body: []ast.Node{ast.Next{}}}
+			RubyVAL.genericValue = ast.IfBlock{Condition: ast.Negation{Target: RubyDollar[3].genericValue}, Body: []ast.Node{ast.Next{}}}
 		}
-	case 270:
-		//line parser.y:1331
+	case 334:
+		RubyDollar = RubyS[Rubypt-1 : Rubypt+1]
+//line parser.y:1703
+		{
+			RubyVAL.genericValue = ast.Redo{}
+		}
+	case 335:
+		RubyDollar = RubyS[Rubypt-3 : Rubypt+1]
+//line parser.y:1705
+		{
+			RubyVAL.genericValue = ast.IfBlock{Condition: RubyDollar[3].genericValue, Body: []ast.Node{ast.Redo{}}}
+		}
+	case 336:
+		RubyDollar = RubyS[Rubypt-3 : Rubypt+1]
+//line parser.y:1707
+		{
+			RubyVAL.genericValue = ast.IfBlock{Condition: ast.Negation{Target: RubyDollar[3].genericValue}, Body: []ast.Node{ast.Redo{}}}
+		}
+	case 337:
+		RubyDollar = RubyS[Rubypt-1 : Rubypt+1]
+//line parser.y:1711
 		{
 			RubyVAL.genericValue = ast.Break{}
 		}
-	case 271:
-		//line parser.y:1333
+	case 338:
+		RubyDollar = RubyS[Rubypt-3 : Rubypt+1]
+//line parser.y:1713
 		{
-			RubyVAL.genericValue = ast.IfBlock{Condition: RubyS[Rubypt-0].genericValue, Body: []ast.Node{ast.Break{}}}
+			RubyVAL.genericValue = ast.IfBlock{Condition: RubyDollar[3].genericValue, Body: []ast.Node{ast.Break{}}}
 		}
-	case 272:
-		//line parser.y:1335
+	case 339:
+		RubyDollar = RubyS[Rubypt-3 : Rubypt+1]
+//line parser.y:1715
 		{
-			RubyVAL.genericValue = ast.IfBlock{Condition: ast.Negation{Target: RubyS[Rubypt-0].genericValue}, Body: []ast.Node{ast.Break{}}}
+			RubyVAL.genericValue = ast.IfBlock{Condition: ast.Negation{Target: RubyDollar[3].genericValue}, Body: []ast.Node{ast.Break{}}}
 		}
-	case 273:
-		//line parser.y:1339
+	case 340:
+		RubyDollar = RubyS[Rubypt-5 : Rubypt+1]
+//line parser.y:1719
 		{
 			RubyVAL.genericValue = ast.Ternary{
-				Condition: RubyS[Rubypt-4].genericValue,
-				True:      RubyS[Rubypt-2].genericValue,
-				False:     RubyS[Rubypt-0].genericValue,
+				Condition: RubyDollar[1].genericValue,
+				True:      RubyDollar[3].genericValue,
+				False:     RubyDollar[5].genericValue,
 			}
 		}
-	case 274:
-		//line parser.y:1348
+	case 341:
+		RubyDollar = RubyS[Rubypt-5 : Rubypt+1]
+//line parser.y:1728
 		{
-			RubyVAL.genericValue = ast.Loop{Condition: RubyS[Rubypt-3].genericValue, Body: RubyS[Rubypt-1].genericSlice}
+			RubyVAL.genericValue = ast.Loop{Condition: RubyDollar[2].genericValue, Body: RubyDollar[4].genericSlice}
 		}
-	case 275:
-		//line parser.y:1350
+	case 342:
+		RubyDollar = RubyS[Rubypt-5 : Rubypt+1]
+//line parser.y:1730
 		{
-			RubyVAL.genericValue = ast.Loop{Condition: ast.Negation{Target: RubyS[Rubypt-3].genericValue}, Body: RubyS[Rubypt-1].genericSlice}
+			RubyVAL.genericValue = ast.Loop{Condition: ast.Negation{Target: RubyDollar[2].genericValue}, Body: RubyDollar[4].genericSlice}
 		}
-	case 276:
-		//line parser.y:1352
+	case 343:
+		RubyDollar = RubyS[Rubypt-3 : Rubypt+1]
+//line parser.y:1732
 		{
-			RubyVAL.genericValue = ast.Loop{Condition: RubyS[Rubypt-0].genericValue, Body: []ast.Node{RubyS[Rubypt-2].genericValue}}
+			RubyVAL.genericValue = newModifierLoop(RubyDollar[1].genericValue, RubyDollar[3].genericValue)
 		}
-	case 277:
-		//line parser.y:1355
+	case 344:
+		RubyDollar = RubyS[Rubypt-3 : Rubypt+1]
+//line parser.y:1734
+		{
+			RubyVAL.genericValue = newModifierLoop(RubyDollar[1].genericValue, ast.Negation{Target: RubyDollar[3].genericValue})
+		}
+	case 345:
+		RubyDollar = RubyS[Rubypt-7 : Rubypt+1]
+//line parser.y:1737
+		{
+			RubyVAL.genericValue = ast.ForLoop{Vars: RubyDollar[2].genericSlice, Collection: RubyDollar[4].genericValue, Body: RubyDollar[6].genericSlice}
+		}
+	case 346:
+		RubyDollar = RubyS[Rubypt-0 : Rubypt+1]
+//line parser.y:1740
 		{
 			RubyVAL.genericSlice = ast.Nodes{}
 		}
-	case 278:
-		//line parser.y:1357
+	case 347:
+		RubyDollar = RubyS[Rubypt-2 : Rubypt+1]
+//line parser.y:1742
 		{
 		}
-	case 279:
-		//line parser.y:1359
+	case 348:
+		RubyDollar = RubyS[Rubypt-2 : Rubypt+1]
+//line parser.y:1744
 		{
 		}
-	case 280:
-		//line parser.y:1361
+	case 349:
+		RubyDollar = RubyS[Rubypt-2 : Rubypt+1]
+//line parser.y:1746
 		{
-			RubyVAL.genericSlice = append(RubyVAL.genericSlice, RubyS[Rubypt-0].genericValue)
+			RubyVAL.genericSlice = append(RubyVAL.genericSlice, RubyDollar[2].genericValue)
 		}
-	case 281:
-		//line parser.y:1363
+	case 350:
+		RubyDollar = RubyS[Rubypt-2 : Rubypt+1]
+//line parser.y:1748
 		{
-			RubyVAL.genericSlice = append(RubyVAL.genericSlice, RubyS[Rubypt-0].genericValue)
+			RubyVAL.genericSlice = append(RubyVAL.genericSlice, RubyDollar[2].genericValue)
 		}
-	case 282:
-		//line parser.y:1366
+	case 351:
+		RubyDollar = RubyS[Rubypt-5 : Rubypt+1]
+//line parser.y:1751
 		{
 			RubyVAL.genericValue = ast.IfBlock{
-				Condition: RubyS[Rubypt-3].genericValue,
-				Body:      RubyS[Rubypt-1].genericSlice,
+				Condition: RubyDollar[2].genericValue,
+				Body:      RubyDollar[4].genericSlice,
 			}
 		}
-	case 283:
-		//line parser.y:1373
+	case 352:
+		RubyDollar = RubyS[Rubypt-6 : Rubypt+1]
+//line parser.y:1758
 		{
 			RubyVAL.genericValue = ast.IfBlock{
-				Condition: RubyS[Rubypt-4].genericValue,
-				Body:      RubyS[Rubypt-2].genericSlice,
-				Else:      RubyS[Rubypt-1].genericSlice,
+				Condition: RubyDollar[2].genericValue,
+				Body:      RubyDollar[4].genericSlice,
+				Else:      RubyDollar[5].genericSlice,
 			}
 		}
-	case 284:
-		//line parser.y:1381
+	case 353:
+		RubyDollar = RubyS[Rubypt-5 : Rubypt+1]
+//line parser.y:1766
 		{
 			RubyVAL.genericValue = ast.IfBlock{
-				Condition: ast.Negation{Target: RubyS[Rubypt-3].genericValue},
-				Body:      RubyS[Rubypt-1].genericSlice,
+				Condition: ast.Negation{Target: RubyDollar[2].genericValue},
+				Body:      RubyDollar[4].genericSlice,
 			}
 		}
-	case 285:
-		//line parser.y:1388
+	case 354:
+		RubyDollar = RubyS[Rubypt-6 : Rubypt+1]
+//line parser.y:1773
 		{
 			RubyVAL.genericValue = ast.IfBlock{
-				Condition: ast.Negation{Target: RubyS[Rubypt-4].genericValue},
-				Body:      RubyS[Rubypt-2].genericSlice,
-				Else:      RubyS[Rubypt-1].genericSlice,
+				Condition: ast.Negation{Target: RubyDollar[2].genericValue},
+				Body:      RubyDollar[4].genericSlice,
+				Else:      RubyDollar[5].genericSlice,
 			}
 		}
-	case 286:
-		//line parser.y:1396
+	case 355:
+		RubyDollar = RubyS[Rubypt-5 : Rubypt+1]
+//line parser.y:1781
 		{
 			RubyVAL.genericValue = ast.IfBlock{
-				Condition: ast.Negation{Target: RubyS[Rubypt-3].genericValue},
-				Body:      RubyS[Rubypt-1].genericSlice,
+				Condition: ast.Negation{Target: RubyDollar[2].genericValue},
+				Body:      RubyDollar[4].genericSlice,
 			}
 		}
-	case 287:
-		//line parser.y:1404
+	case 356:
+		RubyDollar = RubyS[Rubypt-4 : Rubypt+1]
+//line parser.y:1789
 		{
 			RubyVAL.genericSlice = append(RubyVAL.genericSlice, ast.IfBlock{
-				Condition: RubyS[Rubypt-1].genericValue,
-				Body:      RubyS[Rubypt-0].genericSlice,
+				Condition: RubyDollar[3].genericValue,
+				Body:      RubyDollar[4].genericSlice,
 			})
 		}
-	case 288:
-		//line parser.y:1411
+	case 357:
+		RubyDollar = RubyS[Rubypt-3 : Rubypt+1]
+//line parser.y:1796
 		{
 			RubyVAL.genericSlice = append(RubyVAL.genericSlice, ast.IfBlock{
 				Condition: ast.Boolean{Value: true},
-				Body:      RubyS[Rubypt-0].genericSlice,
+				Body:      RubyDollar[3].genericSlice,
 			})
 		}
-	case 289:
-		//line parser.y:1418
+	case 358:
+		RubyDollar = RubyS[Rubypt-3 : Rubypt+1]
+//line parser.y:1803
 		{
 			RubyVAL.genericSlice = append(RubyVAL.genericSlice, ast.IfBlock{
-				Condition: RubyS[Rubypt-1].genericValue,
-				Body:      RubyS[Rubypt-0].genericSlice,
+				Condition: RubyDollar[2].genericValue,
+				Body:      RubyDollar[3].genericSlice,
 			})
 		}
-	case 290:
-		//line parser.y:1425
+	case 359:
+		RubyDollar = RubyS[Rubypt-2 : Rubypt+1]
+//line parser.y:1810
 		{
 			RubyVAL.genericSlice = append(RubyVAL.genericSlice, ast.IfBlock{
 				Condition: ast.Boolean{Value: true},
-				Body:      RubyS[Rubypt-0].genericSlice,
+				Body:      RubyDollar[2].genericSlice,
 			})
 		}
-	case 291:
-		//line parser.y:1433
+	case 360:
+		RubyDollar = RubyS[Rubypt-4 : Rubypt+1]
+//line parser.y:1818
 		{
-			RubyVAL.genericValue = ast.WeakLogicalAnd{LHS: RubyS[Rubypt-3].genericValue, RHS: RubyS[Rubypt-0].genericValue}
+			RubyVAL.genericValue = ast.WeakLogicalAnd{LHS: RubyDollar[1].genericValue, RHS: RubyDollar[4].genericValue}
 		}
-	case 292:
-		//line parser.y:1436
+	case 361:
+		RubyDollar = RubyS[Rubypt-4 : Rubypt+1]
+//line parser.y:1821
 		{
-			RubyVAL.genericValue = ast.WeakLogicalOr{LHS: RubyS[Rubypt-3].genericValue, RHS: RubyS[Rubypt-0].genericValue}
+			RubyVAL.genericValue = ast.WeakLogicalOr{LHS: RubyDollar[1].genericValue, RHS: RubyDollar[4].genericValue}
 		}
-	case 293:
-		//line parser.y:1438
+	case 362:
+		RubyDollar = RubyS[Rubypt-2 : Rubypt+1]
+//line parser.y:1823
 		{
-			RubyVAL.genericValue = ast.Lambda{Body: RubyS[Rubypt-0].genericBlock}
+			RubyVAL.genericValue = ast.Lambda{Body: RubyDollar[2].genericBlock}
 		}
-	case 294:
-		//line parser.y:1441
+	case 363:
+		RubyDollar = RubyS[Rubypt-5 : Rubypt+1]
+//line parser.y:1826
 		{
-			RubyVAL.genericValue = ast.SwitchStatement{Condition: RubyS[Rubypt-3].genericValue, Cases: RubyS[Rubypt-1].switchCaseSlice}
+			RubyVAL.genericValue = ast.SwitchStatement{Condition: RubyDollar[2].genericValue, Cases: RubyDollar[4].switchCaseSlice}
 		}
-	case 295:
-		//line parser.y:1443
+	case 364:
+		RubyDollar = RubyS[Rubypt-7 : Rubypt+1]
+//line parser.y:1828
 		{
-			RubyVAL.genericValue = ast.SwitchStatement{Condition: RubyS[Rubypt-5].genericValue, Cases: RubyS[Rubypt-3].switchCaseSlice, Else: RubyS[Rubypt-1].genericSlice}
+			RubyVAL.genericValue = ast.SwitchStatement{Condition: RubyDollar[2].genericValue, Cases: RubyDollar[4].switchCaseSlice, Else: RubyDollar[6].genericSlice}
 		}
-	case 296:
-		//line parser.y:1445
+	case 365:
+		RubyDollar = RubyS[Rubypt-4 : Rubypt+1]
+//line parser.y:1830
 		{
-			RubyVAL.genericValue = ast.SwitchStatement{Cases: RubyS[Rubypt-1].switchCaseSlice}
+			RubyVAL.genericValue = ast.SwitchStatement{Cases: RubyDollar[3].switchCaseSlice}
 		}
-	case 297:
-		//line parser.y:1447
+	case 366:
+		RubyDollar = RubyS[Rubypt-6 : Rubypt+1]
+//line parser.y:1832
 		{
-			RubyVAL.genericValue = ast.SwitchStatement{Cases: RubyS[Rubypt-3].switchCaseSlice, Else: RubyS[Rubypt-1].genericSlice}
+			RubyVAL.genericValue = ast.SwitchStatement{Cases: RubyDollar[3].switchCaseSlice, Else: RubyDollar[5].genericSlice}
 		}
-	case 298:
-		//line parser.y:1450
+	case 367:
+		RubyDollar = RubyS[Rubypt-4 : Rubypt+1]
+//line parser.y:1835
 		{
-			RubyVAL.switchCaseSlice = append(RubyVAL.switchCaseSlice, ast.SwitchCase{Conditions: RubyS[Rubypt-2].genericSlice, Body: RubyS[Rubypt-1].genericSlice})
+			RubyVAL.switchCaseSlice = append(RubyVAL.switchCaseSlice, ast.SwitchCase{Conditions: RubyDollar[2].genericSlice, Body: RubyDollar[3].genericSlice})
 		}
-	case 299:
-		//line parser.y:1452
+	case 368:
+		RubyDollar = RubyS[Rubypt-5 : Rubypt+1]
+//line parser.y:1837
 		{
-			RubyVAL.switchCaseSlice = append(RubyVAL.switchCaseSlice, ast.SwitchCase{Conditions: RubyS[Rubypt-2].genericSlice, Body: RubyS[Rubypt-1].genericSlice})
+			RubyVAL.switchCaseSlice = append(RubyVAL.switchCaseSlice, ast.SwitchCase{Conditions: RubyDollar[3].genericSlice, Body: RubyDollar[4].genericSlice})
 		}
-	case 300:
-		//line parser.y:1454
+	case 369:
+		RubyDollar = RubyS[Rubypt-5 : Rubypt+1]
+//line parser.y:1840
 		{
-			RubyVAL.genericValue = ast.Range{Start: RubyS[Rubypt-2].genericValue, End: RubyS[Rubypt-0].genericValue}
+			RubyVAL.genericValue = ast.PatternMatch{Condition: RubyDollar[2].genericValue, Cases: RubyDollar[4].patternCaseSlice}
 		}
-	case 301:
-		//line parser.y:1457
+	case 370:
+		RubyDollar = RubyS[Rubypt-7 : Rubypt+1]
+//line parser.y:1842
+		{
+			RubyVAL.genericValue = ast.PatternMatch{Condition: RubyDollar[2].genericValue, Cases: RubyDollar[4].patternCaseSlice, Else: RubyDollar[6].genericSlice}
+		}
+	case 371:
+		RubyDollar = RubyS[Rubypt-4 : Rubypt+1]
+//line parser.y:1845
+		{
+			RubyVAL.patternCaseSlice = append(RubyVAL.patternCaseSlice, ast.PatternCase{Pattern: RubyDollar[2].genericValue, Body: RubyDollar[3].genericSlice})
+		}
+	case 372:
+		RubyDollar = RubyS[Rubypt-5 : Rubypt+1]
+//line parser.y:1847
+		{
+			RubyVAL.patternCaseSlice = append(RubyVAL.patternCaseSlice, ast.PatternCase{Pattern: RubyDollar[3].genericValue, Body: RubyDollar[4].genericSlice})
+		}
+	case 374:
+		RubyDollar = RubyS[Rubypt-3 : Rubypt+1]
+//line parser.y:1851
+		{
+			RubyVAL.genericValue = ast.PatternBinding{Pattern: RubyDollar[1].genericValue, Name: RubyDollar[3].genericValue.(ast.BareReference)}
+		}
+	case 379:
+		RubyDollar = RubyS[Rubypt-3 : Rubypt+1]
+//line parser.y:1857
+		{
+			RubyVAL.genericValue = ast.Range{Start: RubyDollar[1].genericValue, End: RubyDollar[3].genericValue}
+		}
+	case 380:
+		RubyDollar = RubyS[Rubypt-3 : Rubypt+1]
+//line parser.y:1859
+		{
+			RubyVAL.genericValue = ast.Range{Start: RubyDollar[1].genericValue, End: RubyDollar[3].genericValue, Exclusive: true}
+		}
+	case 381:
+		RubyDollar = RubyS[Rubypt-2 : Rubypt+1]
+//line parser.y:1862
+		{
+			RubyVAL.genericValue = ast.ArrayPattern{Elements: []ast.Node{}}
+		}
+	case 382:
+		RubyDollar = RubyS[Rubypt-3 : Rubypt+1]
+//line parser.y:1864
+		{
+			RubyVAL.genericValue = newArrayPattern(RubyDollar[2].genericSlice)
+		}
+	case 383:
+		RubyDollar = RubyS[Rubypt-1 : Rubypt+1]
+//line parser.y:1867
+		{
+			RubyVAL.genericSlice = ast.Nodes{RubyDollar[1].genericValue}
+		}
+	case 384:
+		RubyDollar = RubyS[Rubypt-3 : Rubypt+1]
+//line parser.y:1869
+		{
+			RubyVAL.genericSlice = append(RubyDollar[1].genericSlice, RubyDollar[3].genericValue)
+		}
+	case 386:
+		RubyDollar = RubyS[Rubypt-2 : Rubypt+1]
+//line parser.y:1873
+		{
+			RubyVAL.genericValue = ast.StarSplat{Value: RubyDollar[2].genericValue}
+		}
+	case 387:
+		RubyDollar = RubyS[Rubypt-1 : Rubypt+1]
+//line parser.y:1875
+		{
+			RubyVAL.genericValue = ast.StarSplat{}
+		}
+	case 388:
+		RubyDollar = RubyS[Rubypt-2 : Rubypt+1]
+//line parser.y:1878
+		{
+			RubyVAL.genericValue = ast.HashPattern{Pairs: []ast.HashPatternPair{}}
+		}
+	case 389:
+		RubyDollar = RubyS[Rubypt-3 : Rubypt+1]
+//line parser.y:1880
+		{
+			RubyVAL.genericValue = ast.HashPattern{Pairs: RubyDollar[2].hashPatternPairs}
+		}
+	case 390:
+		RubyDollar = RubyS[Rubypt-6 : Rubypt+1]
+//line parser.y:1882
+		{
+			RubyVAL.genericValue = ast.HashPattern{Pairs: RubyDollar[2].hashPatternPairs, Rest: RubyDollar[5].genericValue}
+		}
+	case 391:
+		RubyDollar = RubyS[Rubypt-2 : Rubypt+1]
+//line parser.y:1885
+		{
+			RubyVAL.hashPatternPairs = append(RubyVAL.hashPatternPairs, ast.HashPatternPair{Key: ast.Symbol{Name: RubyDollar[1].genericValue.(ast.BareReference).Name}})
+		}
+	case 392:
+		RubyDollar = RubyS[Rubypt-3 : Rubypt+1]
+//line parser.y:1887
+		{
+			RubyVAL.hashPatternPairs = append(RubyVAL.hashPatternPairs, ast.HashPatternPair{Key: ast.Symbol{Name: RubyDollar[1].genericValue.(ast.BareReference).Name}, Value: RubyDollar[3].genericValue})
+		}
+	case 393:
+		RubyDollar = RubyS[Rubypt-4 : Rubypt+1]
+//line parser.y:1889
+		{
+			RubyVAL.hashPatternPairs = append(RubyDollar[1].hashPatternPairs, ast.HashPatternPair{Key: ast.Symbol{Name: RubyDollar[3].genericValue.(ast.BareReference).Name}})
+		}
+	case 394:
+		RubyDollar = RubyS[Rubypt-5 : Rubypt+1]
+//line parser.y:1891
+		{
+			RubyVAL.hashPatternPairs = append(RubyDollar[1].hashPatternPairs, ast.HashPatternPair{Key: ast.Symbol{Name: RubyDollar[3].genericValue.(ast.BareReference).Name}, Value: RubyDollar[5].genericValue})
+		}
+	case 395:
+		RubyDollar = RubyS[Rubypt-3 : Rubypt+1]
+//line parser.y:1893
+		{
+			RubyVAL.genericValue = ast.Range{Start: RubyDollar[1].genericValue, End: RubyDollar[3].genericValue}
+		}
+	case 396:
+		RubyDollar = RubyS[Rubypt-3 : Rubypt+1]
+//line parser.y:1894
+		{
+			RubyVAL.genericValue = ast.Range{Start: RubyDollar[1].genericValue, End: RubyDollar[3].genericValue, Exclusive: true}
+		}
+	case 397:
+		RubyDollar = RubyS[Rubypt-2 : Rubypt+1]
+//line parser.y:1895
+		{
+			RubyVAL.genericValue = ast.Range{Start: RubyDollar[1].genericValue}
+		}
+	case 398:
+		RubyDollar = RubyS[Rubypt-2 : Rubypt+1]
+//line parser.y:1896
+		{
+			RubyVAL.genericValue = ast.Range{Start: RubyDollar[1].genericValue, Exclusive: true}
+		}
+	case 399:
+		RubyDollar = RubyS[Rubypt-2 : Rubypt+1]
+//line parser.y:1897
+		{
+			RubyVAL.genericValue = ast.Range{End: RubyDollar[2].genericValue}
+		}
+	case 400:
+		RubyDollar = RubyS[Rubypt-2 : Rubypt+1]
+//line parser.y:1898
+		{
+			RubyVAL.genericValue = ast.Range{End: RubyDollar[2].genericValue, Exclusive: true}
+		}
+	case 401:
+		RubyDollar = RubyS[Rubypt-3 : Rubypt+1]
+//line parser.y:1901
 		{
-			RubyVAL.genericValue = ast.Alias{To: RubyS[Rubypt-1].genericValue.(ast.Symbol), From: RubyS[Rubypt-0].genericValue.(ast.Symbol)}
+			RubyVAL.genericValue = ast.Alias{To: RubyDollar[2].genericValue.(ast.Symbol), From: RubyDollar[3].genericValue.(ast.Symbol)}
 		}
 	}
 	goto Rubystack /* stack new state and value */
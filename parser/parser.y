@@ -20,9 +20,17 @@ var Statements []ast.Node
   genericSlice    ast.Nodes
   stringSlice     []string
   switchCaseSlice []ast.SwitchCase
+  patternCaseSlice []ast.PatternCase
+  hashPatternPairs []ast.HashPatternPair
+
+  // where the first token of a symbol started, which goyacc carries over
+  // from $1 to $$ before running each action
+  pos             ast.Position
 }
 
 %token <operator> OPERATOR
+%token <operator> POW
+%token <genericValue> HASHROCKET // the => between a hash key and its value
 
 // any non-terminal which returns a value needs a type, which is
 // really a field name in the above union struct
@@ -60,11 +68,13 @@ var Statements []ast.Node
 %token <genericValue> RETRY
 %token <genericValue> RETURN
 %token <genericValue> YIELD
+%token <genericValue> DEFINED
 %token <genericValue> AND
 %token <genericValue> OR
 %token <genericValue> LAMBDA
 %token <genericValue> CASE
 %token <genericValue> WHEN
+%token <genericValue> IN
 %token <genericValue> ALIAS
 %token <genericValue> SELF
 %token <genericValue> NIL
@@ -85,9 +95,12 @@ var Statements []ast.Node
 
 %token <genericValue> BINARY_MINUS
 %token <genericValue> UNARY_MINUS
+%token <genericValue> UNARY_MINUS_NUM // a unary minus right before a number
 
 %token <genericValue> STAR
+%token <genericValue> DOUBLESTAR
 %token <genericValue> RANGE
+%token <genericValue> EXCLUSIVE_RANGE
 
 %token <genericValue> OR_EQUALS
 
@@ -97,6 +110,7 @@ var Statements []ast.Node
 %token <genericValue> SEMICOLON
 %token <genericValue> COLON
 %token <genericValue> DOT
+%token <genericValue> SAFE_NAV
 %token <genericValue> PIPE          // "|"
 %token <genericValue> SLASH         // "/"
 %token <genericValue> AMPERSAND     // "&"
@@ -110,6 +124,8 @@ var Statements []ast.Node
 %token <genericValue> ATSIGN        // "@"
 %token <genericValue> FILE_CONST_REF // __FILE__
 %token <genericValue> LINE_CONST_REF // __LINE__
+%token <genericValue> DIR_CONST_REF // __dir__
+%token <genericValue> METHOD_CONST_REF // __method__
 %token <genericValue> EOF
 
 /*
@@ -121,6 +137,7 @@ var Statements []ast.Node
  // single nodes
 %type <genericValue> nil
 %type <genericValue> expr
+%type <genericValue> unpositioned_expr
 %type <genericValue> true
 %type <genericValue> self
 %type <genericValue> hash
@@ -137,20 +154,26 @@ var Statements []ast.Node
 %type <genericValue> if_block
 %type <genericValue> proc_arg
 %type <genericValue> splat_arg
+%type <genericValue> double_splat_arg
 %type <genericValue> assignment
 %type <genericValue> multiple_assignment;
 %type <genericValue> begin_block
 %type <genericValue> single_node
+%type <genericValue> unpositioned_single_node
 %type <genericValue> simple_node
 %type <genericValue> class_variable
 %type <genericValue> call_expression
 %type <genericValue> operator_expression;
+%type <operator> operator_method_name
 %type <genericValue> method_declaration
 %type <genericValue> yield_expression
+%type <genericValue> for_loop
+%type <genericValue> defined_expression
 %type <genericValue> retry_expression;
 %type <genericValue> return_expression
 %type <genericValue> break_expression;
 %type <genericValue> next_expression;
+%type <genericValue> redo_expression;
 %type <genericValue> binary_expression
 %type <genericValue> class_declaration
 %type <genericValue> eigenclass_declaration
@@ -163,6 +186,17 @@ var Statements []ast.Node
 %type <switchCaseSlice> switch_cases;
 %type <genericValue> switch_statement;
 
+// pattern matching
+%type <genericValue> pattern_match
+%type <patternCaseSlice> pattern_cases
+%type <genericValue> pattern
+%type <genericValue> pattern_primary
+%type <genericValue> pattern_element
+%type <genericSlice> pattern_elements
+%type <genericValue> array_pattern
+%type <genericValue> hash_pattern
+%type <hashPatternPairs> hash_pattern_pairs
+
 %type <genericValue> logical_or;
 %type <genericValue> logical_and;
 
@@ -186,6 +220,7 @@ var Statements []ast.Node
 %type <genericValue> binary_subtraction    // 2 - 3
 %type <genericValue> binary_multiplication // 2 * 3
 %type <genericValue> binary_division       // 2 / 3
+%type <genericValue> exponentiation        // 2 ** 3
 %type <genericValue> bitwise_and           // 2 & 5
 %type <genericValue> bitwise_or            // 2 | 5
 
@@ -193,6 +228,7 @@ var Statements []ast.Node
 %type <genericSlice> list
 %type <genericSlice> lines
 %type <genericSlice> rescues
+%type <genericValue> rescue_splat
 %type <genericSlice> call_args
 %type <genericSlice> block_args
 %type <genericSlice> elsif_block
@@ -203,6 +239,8 @@ var Statements []ast.Node
 %type <genericSlice> optional_rescues
 %type <genericSlice> nodes_with_commas
 %type <genericSlice> comma_delimited_refs
+%type <genericSlice> block_params
+%type <genericValue> destructured_param
 %type <genericSlice> comma_delimited_nodes
 %type <genericSlice> symbol_key_value_pairs
 %type <genericSlice> nonempty_nodes_with_commas
@@ -212,9 +250,13 @@ var Statements []ast.Node
 %type <genericSlice> nodes_with_commas_and_optional_newlines
 
 // misc
-%type <genericValue> optional_comma
 %type <genericValue> optional_newlines
 
+// ** binds tighter than the other binary operators (and unary minus), so
+// those are declared a level below it. They stay right associative among
+// themselves, which is how every conflict between them was already resolved.
+%right OPERATOR STAR SLASH BINARY_PLUS BINARY_MINUS UNARY_MINUS LESSTHAN GREATERTHAN AMPERSAND PIPE CARET RANGE EXCLUSIVE_RANGE AND OR RESCUE
+%right POW
 %left DOT
 %left QUESTIONMARK
 
@@ -241,9 +283,6 @@ capture_list : /* empty */
 | capture_list EOF
   { };
 
-optional_comma : /* empty */ { }
-| COMMA { };
-
 optional_newlines : /* empty */ { }
 | optional_newlines NEWLINE { }
 
@@ -256,14 +295,32 @@ list : /* empty */
 | list expr
 {  $$ = append($$, $2) };
 
-simple_node : SYMBOL | NODE | REF | CAPITAL_REF | instance_variable | class_variable | global | true | false | LINE_CONST_REF | FILE_CONST_REF | self | nil;
+simple_node : SYMBOL | NODE
+| REF
+  {
+    // a bare raise re-raises the current exception, so it is always a call
+    if ref, ok := $1.(ast.BareReference); ok && ref.Name == "raise" {
+      $$ = ast.CallExpression{Func: ref, Args: []ast.Node{}}
+    } else {
+      $$ = $1
+    }
+  }
+| CAPITAL_REF | instance_variable | class_variable | global | true | false | LINE_CONST_REF | FILE_CONST_REF | DIR_CONST_REF | METHOD_CONST_REF | self | nil;
+
+// every node is positioned at its first token as it is reduced, so nested
+// nodes (call targets and args, assignment values, ...) carry one too
+single_node : unpositioned_single_node
+  { $$ = withPosition($1, $<pos>1) };
 
 // e.g.: not a complex set of tokens (e.g.: call expression)
-single_node : simple_node | array | hash | class_name_with_modules | call_expression | operator_expression | group | lambda | negation | complement | positive | negative | splat_arg | logical_and | logical_or | binary_expression;
+unpositioned_single_node : simple_node | array | hash | class_name_with_modules | call_expression | operator_expression | group | lambda | negation | complement | positive | negative | splat_arg | logical_and | logical_or | binary_expression | defined_expression;
 
-binary_expression : binary_addition | binary_subtraction | binary_multiplication | binary_division | bitwise_and | bitwise_or;
+binary_expression : binary_addition | binary_subtraction | binary_multiplication | binary_division | exponentiation | bitwise_and | bitwise_or;
 
-expr : single_node | method_declaration | class_declaration | module_declaration | eigenclass_declaration | assignment | multiple_assignment | conditional_assignment | if_block | begin_block | yield_expression | while_loop | switch_statement | return_expression | break_expression | next_expression | rescue_modifier | range | retry_expression | ternary | alias;
+expr : unpositioned_expr
+  { $$ = withPosition($1, $<pos>1) };
+
+unpositioned_expr : single_node | method_declaration | class_declaration | module_declaration | eigenclass_declaration | assignment | multiple_assignment | conditional_assignment | if_block | begin_block | yield_expression | while_loop | for_loop | switch_statement | pattern_match | return_expression | break_expression | next_expression | redo_expression | rescue_modifier | range | retry_expression | ternary | alias;
 
 rescue_modifier : single_node RESCUE single_node
   { $$ = ast.RescueModifier{Statement: $1, Rescue: $3} };
@@ -271,6 +328,9 @@ rescue_modifier : single_node RESCUE single_node
 splat_arg : STAR single_node
   { $$ = ast.StarSplat{Value: $2} };
 
+double_splat_arg : DOUBLESTAR single_node
+  { $$ = ast.DoubleStarSplat{Value: $2} };
+
 call_expression : REF LPAREN nodes_with_commas RPAREN
   {
     $$ = ast.CallExpression{
@@ -363,7 +423,7 @@ call_expression : REF LPAREN nodes_with_commas RPAREN
 | group DOT REF
   {
     $$ = ast.CallExpression{
-      Target: $1,
+      Target: withPosition($1, $<pos>1),
       Func: $3.(ast.BareReference),
       Args: []ast.Node{},
     }
@@ -371,12 +431,29 @@ call_expression : REF LPAREN nodes_with_commas RPAREN
 | group DOT REF block
   {
     $$ = ast.CallExpression{
-      Target: $1,
+      Target: withPosition($1, $<pos>1),
       Func: $3.(ast.BareReference),
       Args: []ast.Node{},
       OptionalBlock: $4,
     }
   }
+| group DOT REF call_args
+  {
+    $$ = ast.CallExpression{
+      Target: withPosition($1, $<pos>1),
+      Func: $3.(ast.BareReference),
+      Args: $4,
+    }
+  }
+| group DOT REF call_args block
+  {
+    $$ = ast.CallExpression{
+      Target: withPosition($1, $<pos>1),
+      Func: $3.(ast.BareReference),
+      Args: $4,
+      OptionalBlock: $5,
+    }
+  }
 | single_node DOT REF EQUALTO expr
   {
     methodName := $3.(ast.BareReference).Name + "="
@@ -386,6 +463,43 @@ call_expression : REF LPAREN nodes_with_commas RPAREN
       Args: []ast.Node{$5},
     }
   }
+| single_node SAFE_NAV REF
+  {
+    $$ = ast.CallExpression{
+      Target: $1,
+      Func: $3.(ast.BareReference),
+      SafeNavigation: true,
+    }
+  }
+| single_node SAFE_NAV REF call_args
+  {
+    $$ = ast.CallExpression{
+      Target: $1,
+      Func: $3.(ast.BareReference),
+      Args: $4,
+      SafeNavigation: true,
+    }
+  }
+| single_node SAFE_NAV REF block
+  {
+    $$ = ast.CallExpression{
+      Target: $1,
+      Func: $3.(ast.BareReference),
+      Args: []ast.Node{},
+      OptionalBlock: $4,
+      SafeNavigation: true,
+    }
+  }
+| single_node SAFE_NAV REF EQUALTO expr
+  {
+    methodName := $3.(ast.BareReference).Name + "="
+    $$ = ast.CallExpression{
+      Func: ast.BareReference{Name: methodName},
+      Target: $1,
+      Args: []ast.Node{$5},
+      SafeNavigation: true,
+    }
+  }
 
 // e.g.: `puts 'whatever' do ; end;` or with_a_block { puts 'foo' }
 | REF nodes_with_commas
@@ -415,7 +529,7 @@ call_expression : REF LPAREN nodes_with_commas RPAREN
   {
     $$ = ast.CallExpression{
       Func: ast.BareReference{Name: "<"},
-      Target: $1,
+      Target: withPosition($1, $<pos>1),
       Args: []ast.Node{$3},
     }
   }
@@ -458,7 +572,7 @@ call_expression : REF LPAREN nodes_with_commas RPAREN
     $$ = ast.CallExpression{
       Func: ast.BareReference{Name: "[]"},
       Target: $1,
-      Args: []ast.Node{$3},
+      Args: []ast.Node{withPosition($3, $<pos>3)},
     }
   }
 | CAPITAL_REF LBRACKET range RBRACKET
@@ -466,7 +580,7 @@ call_expression : REF LPAREN nodes_with_commas RPAREN
     $$ = ast.CallExpression{
       Func: ast.BareReference{Name: "[]"},
       Target: $1,
-      Args: []ast.Node{$3},
+      Args: []ast.Node{withPosition($3, $<pos>3)},
     }
   }
 | REF LBRACKET nonempty_nodes_with_commas RBRACKET
@@ -489,7 +603,7 @@ call_expression : REF LPAREN nodes_with_commas RPAREN
   {
     $$ = ast.CallExpression{
       Func: ast.BareReference{Name: "[]"},
-      Target: $1,
+      Target: withPosition($1, $<pos>1),
       Args: $3,
     }
   }
@@ -497,7 +611,7 @@ call_expression : REF LPAREN nodes_with_commas RPAREN
   {
     $$ = ast.CallExpression{
       Func: ast.BareReference{Name: "[]"},
-      Target: $1,
+      Target: withPosition($1, $<pos>1),
       Args: []ast.Node{$3},
     }
   }
@@ -534,12 +648,14 @@ call_expression : REF LPAREN nodes_with_commas RPAREN
   {
     $$ = ast.CallExpression{
       Func: ast.BareReference{Name: "[]="},
-      Target: $1,
+      Target: withPosition($1, $<pos>1),
       Args: []ast.Node{$3, $6},
     }
   };
 
 
+operator_method_name : OPERATOR | POW;
+
 operator_expression : single_node OPERATOR optional_newlines single_node
   {
     $$ = ast.CallExpression{
@@ -553,36 +669,86 @@ operator_expression : single_node OPERATOR optional_newlines single_node
 call_args : LPAREN nodes_with_commas RPAREN
   { $$ = $2 }
 | LPAREN nodes_with_commas COMMA optional_newlines proc_arg RPAREN
-  { $$ = append($2, $5) }
+  { $$ = append($2, withPosition($5, $<pos>5)) }
 | nonempty_nodes_with_commas
   { $$ = $1 }
 | nonempty_nodes_with_commas COMMA optional_newlines proc_arg
-  { $$ = append($1, $4) };
+  { $$ = append($1, withPosition($4, $<pos>4)) };
 
 comma_delimited_nodes : single_node
   { $$ = append($$, $1) }
+| range
+  { $$ = append($$, withPosition($1, $<pos>1)) }
 | comma_delimited_nodes COMMA single_node
-  { $$ = append($$, $3) };
+  { $$ = append($$, $3) }
+| comma_delimited_nodes COMMA range
+  { $$ = append($$, withPosition($3, $<pos>3)) };
 
 nodes_with_commas : /* empty */ { $$ = ast.Nodes{} }
 | single_node
   { $$ = append($$, $1) }
 | assignment
-  { $$ = append($$, $1) }
+  { $$ = append($$, withPosition($1, $<pos>1)) }
 | proc_arg
-  { $$ = append($$, $1) }
+  { $$ = append($$, withPosition($1, $<pos>1)) }
+| ternary
+  { $$ = append($$, withPosition($1, $<pos>1)) }
+| range
+  { $$ = append($$, withPosition($1, $<pos>1)) }
+| double_splat_arg
+  { $$ = append($$, withPosition($1, $<pos>1)) }
 | nodes_with_commas COMMA optional_newlines single_node
   { $$ = append($$, $4) }
 | nodes_with_commas COMMA optional_newlines assignment
-  { $$ = append($$, $4) }
+  { $$ = append($$, withPosition($4, $<pos>4)) }
 | nodes_with_commas COMMA optional_newlines proc_arg
-  { $$ = append($$, $4) };
+  { $$ = append($$, withPosition($4, $<pos>4)) }
+| nodes_with_commas COMMA optional_newlines ternary
+  { $$ = append($$, withPosition($4, $<pos>4)) }
+| nodes_with_commas COMMA optional_newlines range
+  { $$ = append($$, withPosition($4, $<pos>4)) }
+| nodes_with_commas COMMA optional_newlines double_splat_arg
+  { $$ = append($$, withPosition($4, $<pos>4)) }
+| symbol_key_value_pairs
+  {
+    pairs := []ast.HashKeyValuePair{}
+    for _, node := range $1 {
+      pairs = append(pairs, node.(ast.HashKeyValuePair))
+    }
+    $$ = ast.Nodes{ast.Hash{Pairs: pairs}}
+  }
+| nodes_with_commas COMMA optional_newlines symbol_key_value_pairs
+  {
+    pairs := []ast.HashKeyValuePair{}
+    for _, node := range $4 {
+      pairs = append(pairs, node.(ast.HashKeyValuePair))
+    }
+    $$ = append($$, ast.Hash{Pairs: pairs})
+  }
+| key_value_pairs
+  {
+    pairs := []ast.HashKeyValuePair{}
+    for _, node := range $1 {
+      pairs = append(pairs, node.(ast.HashKeyValuePair))
+    }
+    $$ = ast.Nodes{ast.Hash{Pairs: pairs}}
+  }
+| nodes_with_commas COMMA optional_newlines key_value_pairs
+  {
+    pairs := []ast.HashKeyValuePair{}
+    for _, node := range $4 {
+      pairs = append(pairs, node.(ast.HashKeyValuePair))
+    }
+    $$ = append($$, ast.Hash{Pairs: pairs})
+  };
 
 proc_arg : ProcArg single_node
   {
-    $$ = ast.CallExpression{
-      Func: ast.BareReference{Name: "to_proc"},
-      Target: $2,
+    $$ = ast.ProcArg{
+      Value: ast.CallExpression{
+        Func: ast.BareReference{Name: "to_proc"},
+        Target: $2,
+      },
     }
   };
 
@@ -590,6 +756,8 @@ nonempty_nodes_with_commas : single_node
   { $$ = append($$, $1); }
 | nonempty_nodes_with_commas COMMA single_node
   { $$ = append($$, $3); }
+| nonempty_nodes_with_commas COMMA double_splat_arg
+  { $$ = append($$, withPosition($3, $<pos>3)); }
 
 
 method_declaration : DEF REF method_args list END
@@ -647,7 +815,47 @@ method_declaration : DEF REF method_args list END
       Rescues: $7,
     }
   }
-| DEF OPERATOR method_args list END
+| DEF REF method_args list ENSURE list END
+  {
+		$$ = ast.FuncDecl{
+			Name: $2.(ast.BareReference),
+      Args: $3,
+			Body: $4,
+      Ensure: $6,
+    }
+  }
+| DEF REF method_args list rescues ENSURE list END
+  {
+		$$ = ast.FuncDecl{
+			Name: $2.(ast.BareReference),
+      Args: $3,
+			Body: $4,
+      Rescues: $5,
+      Ensure: $7,
+    }
+  }
+| DEF self DOT REF method_args list ENSURE list END
+  {
+		$$ = ast.FuncDecl{
+      Target: $2,
+			Name: $4.(ast.BareReference),
+      Args: $5,
+			Body: $6,
+      Ensure: $8,
+    }
+  }
+| DEF self DOT REF method_args list rescues ENSURE list END
+  {
+		$$ = ast.FuncDecl{
+      Target: $2,
+			Name: $4.(ast.BareReference),
+      Args: $5,
+			Body: $6,
+      Rescues: $7,
+      Ensure: $9,
+    }
+  }
+| DEF operator_method_name method_args list END
   {
 		$$ = ast.FuncDecl{
 			Name: ast.BareReference{Name: $2},
@@ -655,7 +863,7 @@ method_declaration : DEF REF method_args list END
       Body: $4,
     }
   }
-| DEF OPERATOR method_args list rescues END
+| DEF operator_method_name method_args list rescues END
   {
 		$$ = ast.FuncDecl{
 			Name: ast.BareReference{Name: $2},
@@ -663,6 +871,40 @@ method_declaration : DEF REF method_args list END
       Body: $4,
       Rescues: $5,
     }
+  }
+| DEF REF EQUALTO expr
+  {
+		$$ = ast.FuncDecl{
+			Name: $2.(ast.BareReference),
+      Args: ast.Nodes{},
+			Body: []ast.Node{$4},
+    }
+  }
+| DEF REF LPAREN comma_delimited_args_with_default_values RPAREN EQUALTO expr
+  {
+		$$ = ast.FuncDecl{
+			Name: $2.(ast.BareReference),
+      Args: $4,
+			Body: []ast.Node{$7},
+    }
+  }
+| DEF self DOT REF EQUALTO expr
+  {
+		$$ = ast.FuncDecl{
+      Target: $2,
+			Name: $4.(ast.BareReference),
+      Args: ast.Nodes{},
+			Body: []ast.Node{$6},
+    }
+  }
+| DEF self DOT REF LPAREN comma_delimited_args_with_default_values RPAREN EQUALTO expr
+  {
+		$$ = ast.FuncDecl{
+      Target: $2,
+			Name: $4.(ast.BareReference),
+      Args: $6,
+			Body: []ast.Node{$9},
+    }
   };
 
 
@@ -681,6 +923,8 @@ default_value_arg : REF
   { $$ = ast.MethodParam{Name: $1.(ast.BareReference)} }
 | STAR REF
   { $$ = ast.MethodParam{Name: $2.(ast.BareReference), IsSplat: true} }
+| DOUBLESTAR REF
+  { $$ = ast.MethodParam{Name: $2.(ast.BareReference), IsKeywordSplat: true} }
 | REF EQUALTO single_node
   { $$ = ast.MethodParam{Name: $1.(ast.BareReference), DefaultValue: $3} }
 | ProcArg REF
@@ -763,53 +1007,63 @@ class_name_with_modules : CAPITAL_REF
 
 assignment : REF EQUALTO single_node
   {
-    $$ = ast.Assignment{
-      LHS: $1,
-      RHS: $3,
-    }
+    $$ = newAssignment($1, $3)
   }
 | REF EQUALTO rescue_modifier
   {
-    $$ = ast.Assignment{LHS: $1, RHS: $3}
+    $$ = ast.Assignment{LHS: $1, RHS: withPosition($3, $<pos>3)}
   }
 | REF EQUALTO ternary
   {
-     $$ = ast.Assignment{LHS: $1, RHS: $3}
+     $$ = ast.Assignment{LHS: $1, RHS: withPosition($3, $<pos>3)}
+  }
+| REF EQUALTO range
+  {
+     $$ = ast.Assignment{LHS: $1, RHS: withPosition($3, $<pos>3)}
+  }
+| REF EQUALTO switch_statement
+  {
+     $$ = ast.Assignment{LHS: $1, RHS: withPosition($3, $<pos>3)}
+  }
+| REF EQUALTO begin_block
+  {
+     $$ = ast.Assignment{LHS: $1, RHS: withPosition($3, $<pos>3)}
   }
 | CAPITAL_REF EQUALTO expr
   {
-    $$ = ast.Assignment{
-      LHS: $1,
-      RHS: $3,
-    }
+    $$ = newAssignment($1, $3)
   }
 | instance_variable EQUALTO expr
   {
-    $$ = ast.Assignment{
-      LHS: $1,
-      RHS: $3,
-    }
+    $$ = newAssignment($1, $3)
   }
 | class_variable EQUALTO expr
   {
-    $$ = ast.Assignment{
-      LHS: $1,
-      RHS: $3,
-    }
+    $$ = newAssignment($1, $3)
   }
 | global EQUALTO expr
   {
+    $$ = newAssignment($1, $3)
+  };
+
+multiple_assignment : assignable_variables EQUALTO single_node
+  {
+    // a lone splat is still a list of values to spread across the variables
+    rhs := $3
+    if _, ok := rhs.(ast.StarSplat); ok {
+      rhs = ast.Array{Nodes: []ast.Node{rhs}}
+    }
+
     $$ = ast.Assignment{
       LHS: $1,
-      RHS: $3,
+      RHS: rhs,
     }
-  };
-
-multiple_assignment : assignable_variables EQUALTO expr
+  }
+| assignable_variables EQUALTO single_node COMMA comma_delimited_nodes
   {
     $$ = ast.Assignment{
       LHS: $1,
-      RHS: $3,
+      RHS: ast.Array{Nodes: append([]ast.Node{$3}, $5...)},
     }
   }
 | two_or_more_call_expressions EQUALTO two_or_more_call_expressions
@@ -850,7 +1104,7 @@ conditional_assignment : REF OR_EQUALS single_node
   }
 | REF OR_EQUALS ternary
   {
-     $$ = ast.ConditionalAssignment{LHS: $1, RHS: $3}
+     $$ = ast.ConditionalAssignment{LHS: $1, RHS: withPosition($3, $<pos>3)}
   }
 | CAPITAL_REF OR_EQUALS expr
   {
@@ -881,7 +1135,7 @@ conditional_assignment : REF OR_EQUALS single_node
     }
   }
 | call_expression OR_EQUALS expr
-  { $$ = ast.ConditionalAssignment{LHS: $1, RHS: $3} };
+  { $$ = ast.ConditionalAssignment{LHS: withPosition($1, $<pos>1), RHS: $3} };
 
 global : DOLLARSIGN REF
   { $$ = ast.GlobalVariable{Name: $2.(ast.BareReference).Name} }
@@ -938,7 +1192,29 @@ assignable_variables : REF COMMA REF
 negation : BANG expr { $$ = ast.Negation{Target: $2} };
 complement : COMPLEMENT expr { $$ = ast.Complement{Target: $2} };
 positive : UNARY_PLUS single_node { $$ = ast.Positive{Target: $2} };
-negative : UNARY_MINUS single_node { $$ = ast.Negative{Target: $2} };
+negative : UNARY_MINUS single_node { $$ = ast.Negative{Target: $2} }
+| UNARY_MINUS_NUM NODE
+  {
+    switch number := $2.(type) {
+    case ast.ConstantInt:
+      $$ = ast.ConstantInt{Value: -number.Value}
+    case ast.ConstantFloat:
+      $$ = ast.ConstantFloat{Value: -number.Value}
+    default:
+      $$ = ast.Negative{Target: $2}
+    }
+  }
+// like ruby, -2 ** 2 is -(2 ** 2) even though the minus is part of the literal
+| UNARY_MINUS_NUM NODE POW optional_newlines single_node
+  {
+    $$ = ast.Negative{
+      Target: ast.CallExpression{
+        Target: $2,
+        Func: ast.BareReference{Name: "**"},
+        Args: []ast.Node{$5},
+      },
+    }
+  };
 
 binary_addition : single_node BINARY_PLUS single_node
   {
@@ -976,6 +1252,16 @@ binary_division : single_node SLASH single_node
     }
   };
 
+// right associative, so 2 ** 3 ** 2 is 2 ** (3 ** 2)
+exponentiation : single_node POW optional_newlines single_node
+  {
+    $$ = ast.CallExpression{
+      Target: $1,
+      Func: ast.BareReference{Name: "**"},
+      Args: []ast.Node{$4},
+    }
+  };
+
 bitwise_and: single_node AMPERSAND single_node
   {
     $$ = ast.CallExpression{
@@ -1006,15 +1292,15 @@ nodes_with_commas_and_optional_newlines : /* empty */ { $$ = ast.Nodes{} }
 | single_node
   { $$ = append($$, $1) }
 | assignment
-  { $$ = append($$, $1) }
+  { $$ = append($$, withPosition($1, $<pos>1)) }
 | proc_arg
-  { $$ = append($$, $1) }
+  { $$ = append($$, withPosition($1, $<pos>1)) }
 | nodes_with_commas_and_optional_newlines COMMA optional_newlines single_node
   { $$ = append($$, $4) }
 | nodes_with_commas_and_optional_newlines COMMA optional_newlines assignment
-  { $$ = append($$, $4) }
+  { $$ = append($$, withPosition($4, $<pos>4)) }
 | nodes_with_commas_and_optional_newlines COMMA optional_newlines proc_arg
-  { $$ = append($$, $4) };
+  { $$ = append($$, withPosition($4, $<pos>4)) };
 
 hash : LBRACE optional_newlines RBRACE
   { $$ = ast.Hash{} }
@@ -1026,6 +1312,14 @@ hash : LBRACE optional_newlines RBRACE
     }
     $$ = ast.Hash{Pairs: pairs}
   }
+| LBRACE optional_newlines key_value_pairs COMMA optional_newlines RBRACE
+  {
+    pairs := []ast.HashKeyValuePair{}
+    for _, node := range $3 {
+      pairs = append(pairs, node.(ast.HashKeyValuePair))
+    }
+    $$ = ast.Hash{Pairs: pairs}
+  }
 | LBRACE optional_newlines symbol_key_value_pairs optional_newlines RBRACE
   {
     pairs := []ast.HashKeyValuePair{}
@@ -1035,18 +1329,12 @@ hash : LBRACE optional_newlines RBRACE
     $$ = ast.Hash{Pairs: pairs}
   };
 
-key_value_pairs : single_node OPERATOR expr
+key_value_pairs : single_node HASHROCKET expr
   {
-    if $2 != "=>" {
-      panic("FREAKOUT")
-        }
     $$ = append($$, ast.HashKeyValuePair{Key: $1, Value: $3})
   }
-| key_value_pairs COMMA optional_newlines single_node OPERATOR expr optional_comma
+| key_value_pairs COMMA optional_newlines single_node HASHROCKET expr
   {
-    if $5 != "=>" {
-      panic("FREAKOUT")
-    }
     $$ = append($$, ast.HashKeyValuePair{Key: $4, Value: $6})
   };
 
@@ -1073,26 +1361,44 @@ symbol_key_value_pairs : REF COLON single_node
   };
 
 block : DO list END
-  { $$ = ast.Block{Body: $2} }
+  { $$ = newBlockWithoutArgs($2) }
 | DO block_args list END
   { $$ = ast.Block{Args: $2, Body: $3} }
 | LBRACE optional_newlines list optional_newlines RBRACE
-  { $$ = ast.Block{Body: $3} }
+  { $$ = newBlockWithoutArgs($3) }
 | LBRACE optional_newlines block_args list RBRACE
   { $$ = ast.Block{Args: $3, Body: $4} }
 | LBRACE optional_newlines call_expression optional_newlines RBRACE
-  { $$ = ast.Block{Body: []ast.Node{$3}} };
+  { $$ = newBlockWithoutArgs([]ast.Node{withPosition($3, $<pos>3)}) };
 | LBRACE optional_newlines call_expression list optional_newlines RBRACE
   {
-      head := []ast.Node{$3}
+      head := []ast.Node{withPosition($3, $<pos>3)}
       tail := $4
       body := append(head, tail...)
-    $$ = ast.Block{Body: body}
-  };
+    $$ = newBlockWithoutArgs(body)
+  }
+| LBRACE optional_newlines assignment list optional_newlines RBRACE
+  { $$ = newBlockWithoutArgs(append([]ast.Node{withPosition($3, $<pos>3)}, $4...)) }
+| LBRACE optional_newlines single_node optional_newlines RBRACE
+  { $$ = newBlockWithoutArgs([]ast.Node{$3}) };
 
 block_args : PIPE comma_delimited_refs PIPE
+  { $$ = $2 }
+| PIPE block_params PIPE
   { $$ = $2 };
 
+block_params : destructured_param
+  { $$ = ast.Nodes{$1} }
+| comma_delimited_refs COMMA destructured_param
+  { $$ = append($1, $3) }
+| block_params COMMA REF
+  { $$ = append($1, $3) }
+| block_params COMMA destructured_param
+  { $$ = append($1, $3) };
+
+destructured_param : LPAREN comma_delimited_refs RPAREN
+  { $$ = ast.DestructuredParam{Params: $2} };
+
 comma_delimited_refs : /* empty */ { $$ = ast.Nodes{} }
 | REF
   { $$ = append($$, $1); }
@@ -1125,7 +1431,7 @@ if_block : IF expr list END
   {
     $$ = ast.IfBlock{
       Condition: $3,
-      Body: []ast.Node{$1},
+      Body: []ast.Node{withPosition($1, $<pos>1)},
     }
   }
 | single_node UNLESS expr
@@ -1139,14 +1445,14 @@ if_block : IF expr list END
   {
     $$ = ast.IfBlock{
       Condition: ast.Negation{Target: $3},
-      Body: ast.Nodes{$1},
+      Body: ast.Nodes{withPosition($1, $<pos>1)},
     }
   }
 | assignment UNLESS expr
   {
     $$ = ast.IfBlock{
       Condition: ast.Negation{Target: $3},
-      Body: ast.Nodes{$1},
+      Body: ast.Nodes{withPosition($1, $<pos>1)},
     }
   }
 | UNLESS expr NEWLINE list END
@@ -1209,7 +1515,7 @@ elsif_block : elsif_block ELSIF expr list
     })
       };
 
-lines : /* empty */ { }
+lines : /* empty */ { $$ = ast.Nodes{} }
 | lines expr { $$ = append($$, $2) }
 | lines SEMICOLON { };
 
@@ -1230,6 +1536,23 @@ begin_block : BEGIN list optional_rescues END
       Rescue: $3,
       Else: $5,
     }
+  }
+| BEGIN list optional_rescues ENSURE list END
+  {
+    $$ = ast.Begin{
+      Body: $2,
+      Rescue: $3,
+      Ensure: $5,
+    }
+  }
+| BEGIN list optional_rescues ELSE list ENSURE list END
+  {
+    $$ = ast.Begin{
+      Body: $2,
+      Rescue: $3,
+      Else: $5,
+      Ensure: $7,
+    }
   };
 
 rescue : RESCUE list
@@ -1247,12 +1570,40 @@ rescue : RESCUE list
       },
     }
   }
-| RESCUE comma_delimited_class_names OPERATOR REF list
+| RESCUE comma_delimited_class_names HASHROCKET REF list
   {
-    if $3 != "=>" {
-      panic("FREAKOUT")
+    classes := []ast.Class{}
+    for _, class := range $2 {
+      classes = append(classes, class.(ast.Class))
     }
 
+    $$ = ast.Rescue{
+      Body: $5,
+      Exception: ast.RescueException{
+        Var: $4.(ast.BareReference),
+        Classes: classes,
+      },
+    }
+  }
+| RESCUE rescue_splat list
+  {
+    $$ = ast.Rescue{
+      Body: $3,
+      Exception: ast.RescueException{Splat: $2},
+    }
+  }
+| RESCUE rescue_splat HASHROCKET REF list
+  {
+    $$ = ast.Rescue{
+      Body: $5,
+      Exception: ast.RescueException{
+        Var: $4.(ast.BareReference),
+        Splat: $2,
+      },
+    }
+  }
+| RESCUE comma_delimited_class_names COMMA rescue_splat list
+  {
     classes := []ast.Class{}
     for _, class := range $2 {
       classes = append(classes, class.(ast.Class))
@@ -1261,17 +1612,29 @@ rescue : RESCUE list
     $$ = ast.Rescue{
       Body: $5,
       Exception: ast.RescueException{
-        Var: $4.(ast.BareReference),
         Classes: classes,
+        Splat: $4,
       },
     }
   }
-| RESCUE OPERATOR REF list
+| RESCUE comma_delimited_class_names COMMA rescue_splat HASHROCKET REF list
   {
-    if $2 != "=>" {
-      panic("FREAKOUT")
+    classes := []ast.Class{}
+    for _, class := range $2 {
+      classes = append(classes, class.(ast.Class))
     }
 
+    $$ = ast.Rescue{
+      Body: $7,
+      Exception: ast.RescueException{
+        Var: $6.(ast.BareReference),
+        Classes: classes,
+        Splat: $4,
+      },
+    }
+  }
+| RESCUE HASHROCKET REF list
+  {
     $$ = ast.Rescue{
       Body: $4,
       Exception: ast.RescueException{
@@ -1280,10 +1643,15 @@ rescue : RESCUE list
     }
   };
 
+rescue_splat : STAR CAPITAL_REF
+  { $$ = $2 }
+| STAR REF
+  { $$ = $2 };
+
 comma_delimited_class_names : class_name_with_modules
-  { $$ = append($$, $1) }
+  { $$ = append($$, withPosition($1, $<pos>1)) }
 | comma_delimited_class_names COMMA class_name_with_modules
-  { $$ = append($$, $3) };
+  { $$ = append($$, withPosition($3, $<pos>3)) };
 
 optional_rescues : /* empty */
   { $$ = []ast.Node{} }
@@ -1295,6 +1663,11 @@ rescues : rescue
 | rescues rescue
   { $$ = append($$, $2) };
 
+defined_expression : DEFINED LPAREN expr RPAREN
+  { $$ = ast.DefinedExpression{Target: $3} }
+| DEFINED single_node
+  { $$ = ast.DefinedExpression{Target: $2} };
+
 yield_expression : YIELD comma_delimited_nodes
   {
     if len($2) == 1 {
@@ -1326,6 +1699,13 @@ next_expression : NEXT
 | NEXT UNLESS expr
   { $$ = ast.IfBlock{Condition: ast.Negation{Target: $3}, Body: []ast.Node{ast.Next{}}} };
 
+redo_expression : REDO
+  { $$ = ast.Redo{} }
+| REDO IF expr
+  { $$ = ast.IfBlock{Condition: $3, Body: []ast.Node{ast.Redo{}}} }
+| REDO UNLESS expr
+  { $$ = ast.IfBlock{Condition: ast.Negation{Target: $3}, Body: []ast.Node{ast.Redo{}}} };
+
 
 break_expression: BREAK
   { $$ = ast.Break{} }
@@ -1349,7 +1729,12 @@ while_loop : WHILE expr NEWLINE loop_expressions END
 | UNTIL expr NEWLINE loop_expressions END
   { $$ = ast.Loop{Condition: ast.Negation{Target:$2}, Body: $4} }
 | expr WHILE expr
-  { $$ = ast.Loop{Condition: $3, Body: []ast.Node{$1}} };
+  { $$ = newModifierLoop($1, $3) }
+| expr UNTIL expr
+  { $$ = newModifierLoop($1, ast.Negation{Target: $3}) };
+
+for_loop : FOR comma_delimited_refs IN expr NEWLINE loop_expressions END
+  { $$ = ast.ForLoop{Vars: $2, Collection: $4, Body: $6} };
 
 loop_expressions : /* empty */
   { $$ = ast.Nodes{} }
@@ -1451,7 +1836,66 @@ switch_cases : WHEN comma_delimited_nodes list optional_newlines
 | switch_cases WHEN comma_delimited_nodes list optional_newlines
   { $$ = append($$, ast.SwitchCase{Conditions: $3, Body: $4}) };
 
-range : single_node RANGE single_node { $$ = ast.Range{Start: $1, End: $3} };
+pattern_match : CASE single_node optional_newlines pattern_cases END
+  { $$ = ast.PatternMatch{Condition: $2, Cases: $4} }
+| CASE single_node optional_newlines pattern_cases ELSE list END
+  { $$ = ast.PatternMatch{Condition: $2, Cases: $4, Else: $6} };
+
+pattern_cases : IN pattern list optional_newlines
+  { $$ = append($$, ast.PatternCase{Pattern: $2, Body: $3}) }
+| pattern_cases IN pattern list optional_newlines
+  { $$ = append($$, ast.PatternCase{Pattern: $3, Body: $4}) };
+
+pattern : pattern_primary
+| pattern_primary HASHROCKET REF
+  {
+    $$ = ast.PatternBinding{Pattern: $1, Name: $3.(ast.BareReference)}
+  };
+
+pattern_primary : simple_node | class_name_with_modules | array_pattern | hash_pattern
+| simple_node RANGE simple_node
+  { $$ = ast.Range{Start: $1, End: $3} }
+| simple_node EXCLUSIVE_RANGE simple_node
+  { $$ = ast.Range{Start: $1, End: $3, Exclusive: true} };
+
+array_pattern : LBRACKET RBRACKET
+  { $$ = ast.ArrayPattern{Elements: []ast.Node{}} }
+| LBRACKET pattern_elements RBRACKET
+  { $$ = newArrayPattern($2) };
+
+pattern_elements : pattern_element
+  { $$ = ast.Nodes{$1} }
+| pattern_elements COMMA pattern_element
+  { $$ = append($1, $3) };
+
+pattern_element : pattern
+| STAR REF
+  { $$ = ast.StarSplat{Value: $2} }
+| STAR
+  { $$ = ast.StarSplat{} };
+
+hash_pattern : LBRACE RBRACE
+  { $$ = ast.HashPattern{Pairs: []ast.HashPatternPair{}} }
+| LBRACE hash_pattern_pairs RBRACE
+  { $$ = ast.HashPattern{Pairs: $2} }
+| LBRACE hash_pattern_pairs COMMA DOUBLESTAR REF RBRACE
+  { $$ = ast.HashPattern{Pairs: $2, Rest: $5} };
+
+hash_pattern_pairs : REF COLON
+  { $$ = append($$, ast.HashPatternPair{Key: ast.Symbol{Name: $1.(ast.BareReference).Name}}) }
+| REF COLON pattern
+  { $$ = append($$, ast.HashPatternPair{Key: ast.Symbol{Name: $1.(ast.BareReference).Name}, Value: $3}) }
+| hash_pattern_pairs COMMA REF COLON
+  { $$ = append($1, ast.HashPatternPair{Key: ast.Symbol{Name: $3.(ast.BareReference).Name}}) }
+| hash_pattern_pairs COMMA REF COLON pattern
+  { $$ = append($1, ast.HashPatternPair{Key: ast.Symbol{Name: $3.(ast.BareReference).Name}, Value: $5}) };
+
+range : single_node RANGE single_node { $$ = ast.Range{Start: $1, End: $3} }
+| single_node EXCLUSIVE_RANGE single_node { $$ = ast.Range{Start: $1, End: $3, Exclusive: true} }
+| single_node RANGE { $$ = ast.Range{Start: $1} }
+| single_node EXCLUSIVE_RANGE { $$ = ast.Range{Start: $1, Exclusive: true} }
+| RANGE single_node { $$ = ast.Range{End: $2} }
+| EXCLUSIVE_RANGE single_node { $$ = ast.Range{End: $2, Exclusive: true} };
 
 alias : ALIAS SYMBOL SYMBOL
   { $$ = ast.Alias{To: $2.(ast.Symbol), From: $3.(ast.Symbol)} };
@@ -47,6 +47,24 @@ var _ = Describe("goyacc parser", func() {
 			})
 		})
 
+		Describe("parsing integers with underscores and radix prefixes", func() {
+			BeforeEach(func() {
+				lexer = parser.NewLexer("[1_000, 0xff, 0b10, 0o755, 0d99]")
+			})
+
+			It("returns ConstantInts with the values they represent", func() {
+				Expect(parser.Statements).To(Equal([]ast.Node{
+					ast.Array{Nodes: []ast.Node{
+						ast.ConstantInt{Value: 1000},
+						ast.ConstantInt{Value: 255},
+						ast.ConstantInt{Value: 2},
+						ast.ConstantInt{Value: 493},
+						ast.ConstantInt{Value: 99},
+					}},
+				}))
+			})
+		})
+
 		Describe("parsing a float", func() {
 			BeforeEach(func() {
 				lexer = parser.NewLexer("123.4567")
@@ -59,6 +77,23 @@ var _ = Describe("goyacc parser", func() {
 			})
 		})
 
+		Describe("parsing floats in scientific notation or with underscores", func() {
+			BeforeEach(func() {
+				lexer = parser.NewLexer("[1e3, 2.5e-3, 6.022E23, 1_234.567_8]")
+			})
+
+			It("returns ConstantFloats with the values they represent", func() {
+				Expect(parser.Statements).To(Equal([]ast.Node{
+					ast.Array{Nodes: []ast.Node{
+						ast.ConstantFloat{Value: 1000.0},
+						ast.ConstantFloat{Value: 0.0025},
+						ast.ConstantFloat{Value: 6.022e23},
+						ast.ConstantFloat{Value: 1234.5678},
+					}},
+				}))
+			})
+		})
+
 		Describe("backtics", func() {
 			BeforeEach(func() {
 				lexer = parser.NewLexer("`echo 'this wont work on windows'`")
@@ -166,6 +201,30 @@ var _ = Describe("goyacc parser", func() {
 				}
 			})
 
+			Describe("escaped character literals", func() {
+				for escape, character := range map[string]string{
+					`?\n`: "\n",
+					`?\t`: "\t",
+					`?\s`: " ",
+					`?\\`: "\\",
+					`?\0`: "\x00",
+				} {
+					func(escape, character string) {
+						Context(escape, func() {
+							BeforeEach(func() {
+								lexer = parser.NewLexer(escape)
+							})
+
+							It("parses as the escaped character", func() {
+								Expect(parser.Statements).To(Equal([]ast.Node{
+									ast.CharacterLiteral{Value: character},
+								}))
+							})
+						})
+					}(escape, character)
+				}
+			})
+
 			Describe("heredoc", func() {
 				Context("as an arg to a method call", func() {
 					BeforeEach(func() {
@@ -198,6 +257,59 @@ EOS
 					})
 				})
 
+				Context("with a single-quoted identifier", func() {
+					BeforeEach(func() {
+						lexer = parser.NewLexer(`
+<<-'EOS'
+  a #{b}
+  EOS
+`)
+					})
+
+					It("returns a simple string, without interpolation", func() {
+						Expect(parser.Statements).To(Equal([]ast.Node{
+							ast.SimpleString{Value: "  a #{b}"},
+						}))
+					})
+				})
+
+				Context("with a double-quoted identifier", func() {
+					BeforeEach(func() {
+						lexer = parser.NewLexer(`
+<<-"EOS"
+  a #{b}
+  EOS
+`)
+					})
+
+					It("returns an interpolated string", func() {
+						Expect(parser.Statements).To(Equal([]ast.Node{
+							ast.InterpolatedString{Value: "  a #{b}"},
+						}))
+					})
+				})
+
+				Context("with a quoted identifier that no line terminates", func() {
+					BeforeEach(func() {
+						lexer = parser.NewLexer("x <<'b'\nx <<\"c\"")
+					})
+
+					It("appends the string instead", func() {
+						Expect(parser.Statements).To(Equal([]ast.Node{
+							ast.CallExpression{
+								Target: ast.BareReference{Name: "x"},
+								Func:   ast.BareReference{Name: "<<"},
+								Args:   []ast.Node{ast.SimpleString{Value: "b"}},
+							},
+							ast.CallExpression{
+								Target: ast.BareReference{Name: "x"},
+								Func:   ast.BareReference{Name: "<<"},
+								Args:   []ast.Node{ast.InterpolatedString{Value: "c"}},
+							},
+						}))
+					})
+				})
+
 				Context("with a dash", func() {
 					BeforeEach(func() {
 						lexer = parser.NewLexer(`
@@ -371,6 +483,60 @@ FOO
 					})
 				})
 			})
+
+			Context("__dir__", func() {
+				BeforeEach(func() {
+					lexer = parser.NewLexer("__dir__")
+				})
+
+				It("returns a directory name reference", func() {
+					Expect(parser.Statements).To(Equal([]ast.Node{
+						ast.DirNameConstReference{},
+					}))
+				})
+
+				Describe("as an object", func() {
+					BeforeEach(func() {
+						lexer = parser.NewLexer("__dir__.length")
+					})
+
+					It("can have methods called on it", func() {
+						Expect(parser.Statements).To(Equal([]ast.Node{
+							ast.CallExpression{
+								Target: ast.DirNameConstReference{},
+								Func:   ast.BareReference{Name: "length"},
+							},
+						}))
+					})
+				})
+			})
+
+			Context("__method__", func() {
+				BeforeEach(func() {
+					lexer = parser.NewLexer("__method__")
+				})
+
+				It("returns a method name reference", func() {
+					Expect(parser.Statements).To(Equal([]ast.Node{
+						ast.MethodNameConstReference{},
+					}))
+				})
+
+				Describe("as an object", func() {
+					BeforeEach(func() {
+						lexer = parser.NewLexer("__method__.to_s")
+					})
+
+					It("can have methods called on it", func() {
+						Expect(parser.Statements).To(Equal([]ast.Node{
+							ast.CallExpression{
+								Target: ast.MethodNameConstReference{},
+								Func:   ast.BareReference{Name: "to_s"},
+							},
+						}))
+					})
+				})
+			})
 		})
 
 		Describe("case statements", func() {
@@ -494,6 +660,185 @@ end
 					}))
 				})
 			})
+
+			Context("assigned to a variable, with ranges to compare against", func() {
+				BeforeEach(func() {
+					lexer = parser.NewLexer(`
+size = case n
+when 1..3, 5..6
+  'small'
+end
+`)
+				})
+
+				It("should be parsed as an assignment of an ast.SwitchStatement", func() {
+					Expect(parser.Statements).To(Equal([]ast.Node{
+						ast.Assignment{
+							LHS: ast.BareReference{Name: "size"},
+							RHS: ast.SwitchStatement{
+								Condition: ast.BareReference{Name: "n"},
+								Cases: []ast.SwitchCase{
+									ast.SwitchCase{
+										Conditions: []ast.Node{
+											ast.Range{Start: ast.ConstantInt{Value: 1}, End: ast.ConstantInt{Value: 3}},
+											ast.Range{Start: ast.ConstantInt{Value: 5}, End: ast.ConstantInt{Value: 6}},
+										},
+										Body: []ast.Node{ast.SimpleString{Value: "small"}},
+									},
+								},
+							},
+						},
+					}))
+				})
+			})
+
+			Context("with then after each condition", func() {
+				BeforeEach(func() {
+					lexer = parser.NewLexer(`
+case number
+when 1 then 'one'
+when 2 then 'two'
+end
+`)
+				})
+
+				It("parses the same as when the body is on its own line", func() {
+					Expect(parser.Statements).To(Equal([]ast.Node{
+						ast.SwitchStatement{
+							Condition: ast.BareReference{Name: "number"},
+							Cases: []ast.SwitchCase{
+								ast.SwitchCase{
+									Conditions: []ast.Node{ast.ConstantInt{Value: 1}},
+									Body:       []ast.Node{ast.SimpleString{Value: "one"}},
+								},
+								ast.SwitchCase{
+									Conditions: []ast.Node{ast.ConstantInt{Value: 2}},
+									Body:       []ast.Node{ast.SimpleString{Value: "two"}},
+								},
+							},
+						},
+					}))
+				})
+			})
+		})
+
+		Describe("case statements with patterns", func() {
+			Context("with array patterns", func() {
+				BeforeEach(func() {
+					lexer = parser.NewLexer(`
+case point
+in [x, 0]
+  x
+in [Integer => first, *rest]
+  rest
+in [*, 42, *post]
+  post
+in []
+  :empty
+end
+`)
+				})
+
+				It("should be parsed as an ast.PatternMatch", func() {
+					Expect(parser.Statements).To(Equal([]ast.Node{
+						ast.PatternMatch{
+							Condition: ast.BareReference{Name: "point"},
+							Cases: []ast.PatternCase{
+								{
+									Pattern: ast.ArrayPattern{Elements: []ast.Node{
+										ast.BareReference{Name: "x"},
+										ast.ConstantInt{Value: 0},
+									}},
+									Body: []ast.Node{ast.BareReference{Name: "x"}},
+								},
+								{
+									Pattern: ast.ArrayPattern{Elements: []ast.Node{
+										ast.PatternBinding{
+											Pattern: ast.BareReference{Name: "Integer"},
+											Name:    ast.BareReference{Name: "first"},
+										},
+										ast.StarSplat{Value: ast.BareReference{Name: "rest"}},
+									}},
+									Body: []ast.Node{ast.BareReference{Name: "rest"}},
+								},
+								{
+									Pattern: ast.FindPattern{
+										Pre:    ast.StarSplat{},
+										Middle: []ast.Node{ast.ConstantInt{Value: 42}},
+										Post:   ast.StarSplat{Value: ast.BareReference{Name: "post"}},
+									},
+									Body: []ast.Node{ast.BareReference{Name: "post"}},
+								},
+								{
+									Pattern: ast.ArrayPattern{Elements: []ast.Node{}},
+									Body:    []ast.Node{ast.Symbol{Name: "empty"}},
+								},
+							},
+						},
+					}))
+				})
+			})
+
+			Context("with hash patterns, bindings and an else", func() {
+				BeforeEach(func() {
+					lexer = parser.NewLexer(`
+case config
+in {name: String => name, age:}
+  name
+in {debug:, **rest}
+  rest
+in Integer => n
+  n
+in 1..5
+  :small
+else
+  nil
+end
+`)
+				})
+
+				It("should be parsed as an ast.PatternMatch", func() {
+					Expect(parser.Statements).To(Equal([]ast.Node{
+						ast.PatternMatch{
+							Condition: ast.BareReference{Name: "config"},
+							Cases: []ast.PatternCase{
+								{
+									Pattern: ast.HashPattern{Pairs: []ast.HashPatternPair{
+										{
+											Key: ast.Symbol{Name: "name"},
+											Value: ast.PatternBinding{
+												Pattern: ast.BareReference{Name: "String"},
+												Name:    ast.BareReference{Name: "name"},
+											},
+										},
+										{Key: ast.Symbol{Name: "age"}},
+									}},
+									Body: []ast.Node{ast.BareReference{Name: "name"}},
+								},
+								{
+									Pattern: ast.HashPattern{
+										Pairs: []ast.HashPatternPair{{Key: ast.Symbol{Name: "debug"}}},
+										Rest:  ast.BareReference{Name: "rest"},
+									},
+									Body: []ast.Node{ast.BareReference{Name: "rest"}},
+								},
+								{
+									Pattern: ast.PatternBinding{
+										Pattern: ast.BareReference{Name: "Integer"},
+										Name:    ast.BareReference{Name: "n"},
+									},
+									Body: []ast.Node{ast.BareReference{Name: "n"}},
+								},
+								{
+									Pattern: ast.Range{Start: ast.ConstantInt{Value: 1}, End: ast.ConstantInt{Value: 5}},
+									Body:    []ast.Node{ast.Symbol{Name: "small"}},
+								},
+							},
+							Else: []ast.Node{ast.Nil{}},
+						},
+					}))
+				})
+			})
 		})
 
 		Describe("procs", func() {
@@ -523,6 +868,31 @@ end
 		})
 
 		Describe("call expressions", func() {
+			Context("with safe navigation", func() {
+				BeforeEach(func() {
+					lexer = parser.NewLexer(`
+user&.name
+user&.name = 'alice'
+`)
+				})
+
+				It("marks the call expressions as safely navigated", func() {
+					Expect(parser.Statements).To(Equal([]ast.Node{
+						ast.CallExpression{
+							Target:         ast.BareReference{Name: "user"},
+							Func:           ast.BareReference{Name: "name"},
+							SafeNavigation: true,
+						},
+						ast.CallExpression{
+							Target:         ast.BareReference{Name: "user"},
+							Func:           ast.BareReference{Name: "name="},
+							Args:           []ast.Node{ast.SimpleString{Value: "alice"}},
+							SafeNavigation: true,
+						},
+					}))
+				})
+			})
+
 			Context("with a value that should be converted to a proc", func() {
 				BeforeEach(func() {
 					lexer = parser.NewLexer("describe(&blocks); explain(&:it_well)")
@@ -533,18 +903,22 @@ end
 						ast.CallExpression{
 							Func: ast.BareReference{Name: "describe"},
 							Args: []ast.Node{
-								ast.CallExpression{
-									Target: ast.BareReference{Name: "blocks"},
-									Func:   ast.BareReference{Name: "to_proc"},
+								ast.ProcArg{
+									Value: ast.CallExpression{
+										Target: ast.BareReference{Name: "blocks"},
+										Func:   ast.BareReference{Name: "to_proc"},
+									},
 								},
 							},
 						},
 						ast.CallExpression{
 							Func: ast.BareReference{Name: "explain"},
 							Args: []ast.Node{
-								ast.CallExpression{
-									Target: ast.Symbol{Name: "it_well"},
-									Func:   ast.BareReference{Name: "to_proc"},
+								ast.ProcArg{
+									Value: ast.CallExpression{
+										Target: ast.Symbol{Name: "it_well"},
+										Func:   ast.BareReference{Name: "to_proc"},
+									},
 								},
 							},
 						},
@@ -608,9 +982,11 @@ method_with_lots_of_args('foo',
 								ast.SimpleString{Value: "foo"},
 								ast.SimpleString{Value: "bar"},
 								ast.SimpleString{Value: "baz"},
-								ast.CallExpression{
-									Func:   ast.BareReference{Name: "to_proc"},
-									Target: ast.BareReference{Name: "buz"},
+								ast.ProcArg{
+									Value: ast.CallExpression{
+										Func:   ast.BareReference{Name: "to_proc"},
+										Target: ast.BareReference{Name: "buz"},
+									},
 								},
 							},
 						},
@@ -681,6 +1057,29 @@ end
 				})
 			})
 
+			Context("with arguments passed to a call expression targeting a group", func() {
+				BeforeEach(func() {
+					lexer = parser.NewLexer(`('a'..'z').include?('m')`)
+				})
+
+				It("is parsed as a call expression", func() {
+					Expect(parser.Statements).To(Equal([]ast.Node{
+						ast.CallExpression{
+							Target: ast.Group{
+								Body: []ast.Node{
+									ast.Range{
+										Start: ast.SimpleString{Value: "a"},
+										End:   ast.SimpleString{Value: "z"},
+									},
+								},
+							},
+							Func: ast.BareReference{Name: "include?"},
+							Args: []ast.Node{ast.SimpleString{Value: "m"}},
+						},
+					}))
+				})
+			})
+
 			Context("with args and a block split across newlines", func() {
 				BeforeEach(func() {
 					lexer = parser.NewLexer(`
@@ -728,9 +1127,11 @@ end
 								Args: []ast.Node{
 									ast.SimpleString{Value: "foo"},
 									ast.SimpleString{Value: "bar"},
-									ast.CallExpression{
-										Func:   ast.BareReference{Name: "to_proc"},
-										Target: ast.BareReference{Name: "baz"},
+									ast.ProcArg{
+										Value: ast.CallExpression{
+											Func:   ast.BareReference{Name: "to_proc"},
+											Target: ast.BareReference{Name: "baz"},
+										},
 									},
 								},
 							},
@@ -750,9 +1151,11 @@ end
 								Args: []ast.Node{
 									ast.SimpleString{Value: "foo"},
 									ast.SimpleString{Value: "bar"},
-									ast.CallExpression{
-										Target: ast.BareReference{Name: "baz"},
-										Func:   ast.BareReference{Name: "to_proc"},
+									ast.ProcArg{
+										Value: ast.CallExpression{
+											Target: ast.BareReference{Name: "baz"},
+											Func:   ast.BareReference{Name: "to_proc"},
+										},
 									},
 								},
 							},
@@ -1021,23 +1424,28 @@ ARGV.shift
 				})
 			})
 
-			Context("with a call expression as an argument", func() {
+			Context("with keyword arguments inside parens", func() {
 				BeforeEach(func() {
-					lexer = parser.NewLexer("$:.unshift File.expand_path('../../lib', __FILE__)")
+					lexer = parser.NewLexer("deck.shuffle(1, random: rng, tries: 2)")
 				})
 
-				It("applies the correct args to each call expression", func() {
+				It("collects the trailing key-value pairs into a hash", func() {
 					Expect(parser.Statements).To(Equal([]ast.Node{
 						ast.CallExpression{
-							Func:   ast.BareReference{Name: "unshift"},
-							Target: ast.GlobalVariable{Name: ":"},
+							Target: ast.BareReference{Name: "deck"},
+							Func:   ast.BareReference{Name: "shuffle"},
 							Args: []ast.Node{
-								ast.CallExpression{
-									Target: ast.BareReference{Name: "File"}, // TODO: should be a class
-									Func:   ast.BareReference{Name: "expand_path"},
-									Args: []ast.Node{
-										ast.SimpleString{Value: "../../lib"},
-										ast.FileNameConstReference{},
+								ast.ConstantInt{Value: 1},
+								ast.Hash{
+									Pairs: []ast.HashKeyValuePair{
+										{
+											Key:   ast.Symbol{Name: "random"},
+											Value: ast.BareReference{Name: "rng"},
+										},
+										{
+											Key:   ast.Symbol{Name: "tries"},
+											Value: ast.ConstantInt{Value: 2},
+										},
 									},
 								},
 							},
@@ -1046,16 +1454,73 @@ ARGV.shift
 				})
 			})
 
-			Context("very nested call expressions", func() {
+			Context("with arguments split across lines inside the parens", func() {
 				BeforeEach(func() {
-					lexer = parser.NewLexer("$:.unshift File.expand_path(File.dirname(__FILE__) + '/../lib')")
+					lexer = parser.NewLexer(`
+puts(subtotal
+       + tax,
+     items
+       .size)
+`)
 				})
 
-				It("is parsed with the correct args on the correct methods", func() {
+				It("treats the newlines as insignificant", func() {
 					Expect(parser.Statements).To(Equal([]ast.Node{
 						ast.CallExpression{
-							Target: ast.GlobalVariable{Name: ":"},
-							Func:   ast.BareReference{Name: "unshift"},
+							Func: ast.BareReference{Name: "puts"},
+							Args: []ast.Node{
+								ast.CallExpression{
+									Target: ast.BareReference{Name: "subtotal"},
+									Func:   ast.BareReference{Name: "+"},
+									Args: []ast.Node{
+										ast.BareReference{Name: "tax"},
+									},
+								},
+								ast.CallExpression{
+									Target: ast.BareReference{Name: "items"},
+									Func:   ast.BareReference{Name: "size"},
+								},
+							},
+						},
+					}))
+				})
+			})
+
+			Context("with a call expression as an argument", func() {
+				BeforeEach(func() {
+					lexer = parser.NewLexer("$:.unshift File.expand_path('../../lib', __FILE__)")
+				})
+
+				It("applies the correct args to each call expression", func() {
+					Expect(parser.Statements).To(Equal([]ast.Node{
+						ast.CallExpression{
+							Func:   ast.BareReference{Name: "unshift"},
+							Target: ast.GlobalVariable{Name: ":"},
+							Args: []ast.Node{
+								ast.CallExpression{
+									Target: ast.BareReference{Name: "File"}, // TODO: should be a class
+									Func:   ast.BareReference{Name: "expand_path"},
+									Args: []ast.Node{
+										ast.SimpleString{Value: "../../lib"},
+										ast.FileNameConstReference{},
+									},
+								},
+							},
+						},
+					}))
+				})
+			})
+
+			Context("very nested call expressions", func() {
+				BeforeEach(func() {
+					lexer = parser.NewLexer("$:.unshift File.expand_path(File.dirname(__FILE__) + '/../lib')")
+				})
+
+				It("is parsed with the correct args on the correct methods", func() {
+					Expect(parser.Statements).To(Equal([]ast.Node{
+						ast.CallExpression{
+							Target: ast.GlobalVariable{Name: ":"},
+							Func:   ast.BareReference{Name: "unshift"},
 							Args: []ast.Node{
 								ast.CallExpression{
 									Target: ast.BareReference{Name: "File"},
@@ -1098,7 +1563,78 @@ ARGV.shift
 			})
 		})
 
+		Describe("keyword splat", func() {
+			Context("in a call expression", func() {
+				BeforeEach(func() {
+					lexer = parser.NewLexer(`
+foo(1, **options)
+bar 2, **options
+`)
+				})
+
+				It("marks the argument for hash deferencing", func() {
+					Expect(parser.Statements).To(Equal([]ast.Node{
+						ast.CallExpression{
+							Func: ast.BareReference{Name: "foo"},
+							Args: []ast.Node{
+								ast.ConstantInt{Value: 1},
+								ast.DoubleStarSplat{Value: ast.BareReference{Name: "options"}},
+							},
+						},
+						ast.CallExpression{
+							Func: ast.BareReference{Name: "bar"},
+							Args: []ast.Node{
+								ast.ConstantInt{Value: 2},
+								ast.DoubleStarSplat{Value: ast.BareReference{Name: "options"}},
+							},
+						},
+					}))
+				})
+			})
+		})
+
 		Describe("method definitions", func() {
+			Context("without an end", func() {
+				BeforeEach(func() {
+					lexer = parser.NewLexer(`
+def foo = 5
+def bar(x) = x + 1
+def baz=(value)
+end
+`)
+				})
+
+				It("uses the single expression as the body", func() {
+					Expect(parser.Statements).To(Equal([]ast.Node{
+						ast.FuncDecl{
+							Name: ast.BareReference{Name: "foo"},
+							Args: []ast.Node{},
+							Body: []ast.Node{ast.ConstantInt{Value: 5}},
+						},
+						ast.FuncDecl{
+							Name: ast.BareReference{Name: "bar"},
+							Args: []ast.Node{
+								ast.MethodParam{Name: ast.BareReference{Name: "x"}},
+							},
+							Body: []ast.Node{
+								ast.CallExpression{
+									Target: ast.BareReference{Name: "x"},
+									Func:   ast.BareReference{Name: "+"},
+									Args:   []ast.Node{ast.ConstantInt{Value: 1}},
+								},
+							},
+						},
+						ast.FuncDecl{
+							Name: ast.BareReference{Name: "baz="},
+							Args: []ast.Node{
+								ast.MethodParam{Name: ast.BareReference{Name: "value"}},
+							},
+							Body: []ast.Node{},
+						},
+					}))
+				})
+			})
+
 			Context("for setter methods", func() {
 				BeforeEach(func() {
 					lexer = parser.NewLexer(`
@@ -1219,6 +1755,31 @@ end
 				})
 			})
 
+			Context("with keyword splat-args", func() {
+				BeforeEach(func() {
+					lexer = parser.NewLexer(`
+def configure(name, **options)
+end
+`)
+				})
+
+				It("marks the param as collecting keywords", func() {
+					Expect(parser.Statements).To(Equal([]ast.Node{
+						ast.FuncDecl{
+							Name: ast.BareReference{Name: "configure"},
+							Args: []ast.Node{
+								ast.MethodParam{Name: ast.BareReference{Name: "name"}},
+								ast.MethodParam{
+									Name:           ast.BareReference{Name: "options"},
+									IsKeywordSplat: true,
+								},
+							},
+							Body: []ast.Node{},
+						},
+					}))
+				})
+			})
+
 			Context("with a named proc parameter", func() {
 				BeforeEach(func() {
 					lexer = parser.NewLexer(`
@@ -1265,7 +1826,10 @@ end
 							Name: ast.BareReference{Name: "foo!"},
 							Args: []ast.Node{},
 							Body: []ast.Node{
-								ast.BareReference{Name: "raise"},
+								ast.CallExpression{
+									Func: ast.BareReference{Name: "raise"},
+									Args: []ast.Node{},
+								},
 							},
 						},
 						ast.FuncDecl{
@@ -1315,7 +1879,7 @@ end
 				It("returns a function declaration with the default values set", func() {
 					Expect(parser.Statements).To(Equal([]ast.Node{
 						ast.FuncDecl{
-							Name: ast.BareReference{"foo"},
+							Name: ast.BareReference{Name: "foo"},
 							Args: []ast.Node{
 								ast.MethodParam{
 									Name:         ast.BareReference{Name: "a"},
@@ -1773,6 +2337,33 @@ foo  \
 			})
 		})
 
+		Describe("chaining calls with a dot leading the next line", func() {
+			BeforeEach(func() {
+				lexer = parser.NewLexer(`
+collection
+  .map { |item| item }
+  .to_a
+`)
+			})
+
+			It("is parsed as nested call expressions", func() {
+				Expect(parser.Statements).To(Equal([]ast.Node{
+					ast.CallExpression{
+						Target: ast.CallExpression{
+							Target: ast.BareReference{Name: "collection"},
+							Func:   ast.BareReference{Name: "map"},
+							Args:   []ast.Node{},
+							OptionalBlock: ast.Block{
+								Args: []ast.Node{ast.BareReference{Name: "item"}},
+								Body: []ast.Node{ast.BareReference{Name: "item"}},
+							},
+						},
+						Func: ast.BareReference{Name: "to_a"},
+					},
+				}))
+			})
+		})
+
 		Describe("conditional assignment", func() {
 			BeforeEach(func() {
 				lexer = parser.NewLexer(`
@@ -1914,6 +2505,85 @@ HASH['second_key'] = [:something]
 				})
 			})
 
+			Context("to multiple variables from a list of values", func() {
+				BeforeEach(func() {
+					lexer = parser.NewLexer("a, b = 1, 2")
+				})
+
+				It("collects the values into an array", func() {
+					Expect(parser.Statements).To(Equal([]ast.Node{
+						ast.Assignment{
+							LHS: ast.Array{
+								Nodes: []ast.Node{
+									ast.BareReference{Name: "a"},
+									ast.BareReference{Name: "b"},
+								},
+							},
+							RHS: ast.Array{
+								Nodes: []ast.Node{
+									ast.ConstantInt{Value: 1},
+									ast.ConstantInt{Value: 2},
+								},
+							},
+						},
+					}))
+				})
+			})
+
+			Context("to multiple variables from a splat", func() {
+				BeforeEach(func() {
+					lexer = parser.NewLexer("x, *y = *some_array")
+				})
+
+				It("wraps the splat in an array of values", func() {
+					Expect(parser.Statements).To(Equal([]ast.Node{
+						ast.Assignment{
+							LHS: ast.Array{
+								Nodes: []ast.Node{
+									ast.BareReference{Name: "x"},
+									ast.StarSplat{Value: ast.BareReference{Name: "y"}},
+								},
+							},
+							RHS: ast.Array{
+								Nodes: []ast.Node{
+									ast.StarSplat{Value: ast.BareReference{Name: "some_array"}},
+								},
+							},
+						},
+					}))
+				})
+			})
+
+			Context("to multiple variables, with a trailing condition", func() {
+				BeforeEach(func() {
+					lexer = parser.NewLexer("a, b = x, y if cond")
+				})
+
+				It("applies the condition to the whole assignment", func() {
+					Expect(parser.Statements).To(Equal([]ast.Node{
+						ast.IfBlock{
+							Condition: ast.BareReference{Name: "cond"},
+							Body: []ast.Node{
+								ast.Assignment{
+									LHS: ast.Array{
+										Nodes: []ast.Node{
+											ast.BareReference{Name: "a"},
+											ast.BareReference{Name: "b"},
+										},
+									},
+									RHS: ast.Array{
+										Nodes: []ast.Node{
+											ast.BareReference{Name: "x"},
+											ast.BareReference{Name: "y"},
+										},
+									},
+								},
+							},
+						},
+					}))
+				})
+			})
+
 			Context("to multiple instance or class variables", func() {
 				BeforeEach(func() {
 					lexer = parser.NewLexer(`
@@ -2023,7 +2693,7 @@ false
 				It("returns a Negation expression", func() {
 					Expect(parser.Statements).To(Equal([]ast.Node{
 						ast.Negation{
-							ast.Negation{
+							Target: ast.Negation{
 								Target: ast.Boolean{Value: true},
 							},
 						},
@@ -2039,7 +2709,7 @@ false
 				It("returns a Complement expression", func() {
 					Expect(parser.Statements).To(Equal([]ast.Node{
 						ast.Complement{
-							ast.Complement{
+							Target: ast.Complement{
 								Target: ast.Boolean{Value: false},
 							},
 						},
@@ -2055,7 +2725,7 @@ false
 				It("returns a Positive expression", func() {
 					Expect(parser.Statements).To(Equal([]ast.Node{
 						ast.Positive{
-							ast.Positive{
+							Target: ast.Positive{
 								Target: ast.BareReference{Name: "foo"},
 							},
 						},
@@ -2071,8 +2741,38 @@ false
 				It("returns a Negative expression", func() {
 					Expect(parser.Statements).To(Equal([]ast.Node{
 						ast.Negative{
-							ast.Negative{
-								Target: ast.ConstantFloat{Value: 867.5309},
+							Target: ast.ConstantFloat{Value: -867.5309},
+						},
+					}))
+				})
+			})
+
+			Describe("unary minus before a number literal", func() {
+				BeforeEach(func() {
+					lexer = parser.NewLexer("-5.abs")
+				})
+
+				It("is part of the literal the method is called on", func() {
+					Expect(parser.Statements).To(Equal([]ast.Node{
+						ast.CallExpression{
+							Target: ast.ConstantInt{Value: -5},
+							Func:   ast.BareReference{Name: "abs"},
+						},
+					}))
+				})
+			})
+
+			Describe("unary minus before a method call", func() {
+				BeforeEach(func() {
+					lexer = parser.NewLexer("-x.abs")
+				})
+
+				It("negates the result of the call", func() {
+					Expect(parser.Statements).To(Equal([]ast.Node{
+						ast.Negative{
+							Target: ast.CallExpression{
+								Target: ast.BareReference{Name: "x"},
+								Func:   ast.BareReference{Name: "abs"},
 							},
 						},
 					}))
@@ -2153,6 +2853,25 @@ false
 				})
 			})
 
+			Describe("consecutive groups", func() {
+				BeforeEach(func() {
+					lexer = parser.NewLexer("b = (3)\nc = (4)")
+				})
+
+				It("gives each group only its own statements", func() {
+					Expect(parser.Statements).To(Equal([]ast.Node{
+						ast.Assignment{
+							LHS: ast.BareReference{Name: "b"},
+							RHS: ast.Group{Body: []ast.Node{ast.ConstantInt{Value: 3}}},
+						},
+						ast.Assignment{
+							LHS: ast.BareReference{Name: "c"},
+							RHS: ast.Group{Body: []ast.Node{ast.ConstantInt{Value: 4}}},
+						},
+					}))
+				})
+			})
+
 			Describe("%", func() {
 				BeforeEach(func() {
 					lexer = parser.NewLexer("321 % 123")
@@ -2185,46 +2904,126 @@ false
 				})
 			})
 
-			Describe("<< and >>", func() {
+			Describe("* between a reference and a number", func() {
 				BeforeEach(func() {
-					lexer = parser.NewLexer(`
-321 << 123
-555 >> 666
-`)
+					lexer = parser.NewLexer("x * 2\nx *2")
 				})
 
-				It("returns a shovel call expression", func() {
+				It("multiplies when followed by a space, but splats otherwise", func() {
 					Expect(parser.Statements).To(Equal([]ast.Node{
 						ast.CallExpression{
-							Target: ast.ConstantInt{Value: 321},
-							Func:   ast.BareReference{Name: "<<"},
-							Args:   []ast.Node{ast.ConstantInt{Value: 123}},
+							Target: ast.BareReference{Name: "x"},
+							Func:   ast.BareReference{Name: "*"},
+							Args:   []ast.Node{ast.ConstantInt{Value: 2}},
 						},
 						ast.CallExpression{
-							Target: ast.ConstantInt{Value: 555},
-							Func:   ast.BareReference{Name: ">>"},
-							Args:   []ast.Node{ast.ConstantInt{Value: 666}},
+							Func: ast.BareReference{Name: "x"},
+							Args: []ast.Node{ast.StarSplat{Value: ast.ConstantInt{Value: 2}}},
 						},
 					}))
 				})
 			})
 
-			Describe("bitwise binary operators", func() {
+			Describe("** with unary minus", func() {
 				BeforeEach(func() {
-					lexer = parser.NewLexer(`
-1 & 0
-1 | 0
-1 ^ 5
-File.lchmod mode & 01777, path
-`)
+					lexer = parser.NewLexer("-2 ** 2")
 				})
 
-				It("returns a call expressions for those methods", func() {
+				It("negates the result of the exponentiation", func() {
 					Expect(parser.Statements).To(Equal([]ast.Node{
-						ast.CallExpression{
-							Target: ast.ConstantInt{Value: 1},
-							Func:   ast.BareReference{Name: "&"},
-							Args:   []ast.Node{ast.ConstantInt{Value: 0}},
+						ast.Negative{
+							Target: ast.CallExpression{
+								Target: ast.ConstantInt{Value: 2},
+								Func:   ast.BareReference{Name: "**"},
+								Args:   []ast.Node{ast.ConstantInt{Value: 2}},
+							},
+						},
+					}))
+				})
+			})
+
+			Describe("chained **", func() {
+				BeforeEach(func() {
+					lexer = parser.NewLexer("2 ** 3 ** 2")
+				})
+
+				It("is right associative", func() {
+					Expect(parser.Statements).To(Equal([]ast.Node{
+						ast.CallExpression{
+							Target: ast.ConstantInt{Value: 2},
+							Func:   ast.BareReference{Name: "**"},
+							Args: []ast.Node{
+								ast.CallExpression{
+									Target: ast.ConstantInt{Value: 3},
+									Func:   ast.BareReference{Name: "**"},
+									Args:   []ast.Node{ast.ConstantInt{Value: 2}},
+								},
+							},
+						},
+					}))
+				})
+			})
+
+			Describe("** followed by another operator", func() {
+				BeforeEach(func() {
+					lexer = parser.NewLexer("2 ** 3 * 4")
+				})
+
+				It("exponentiates before multiplying", func() {
+					Expect(parser.Statements).To(Equal([]ast.Node{
+						ast.CallExpression{
+							Target: ast.CallExpression{
+								Target: ast.ConstantInt{Value: 2},
+								Func:   ast.BareReference{Name: "**"},
+								Args:   []ast.Node{ast.ConstantInt{Value: 3}},
+							},
+							Func: ast.BareReference{Name: "*"},
+							Args: []ast.Node{ast.ConstantInt{Value: 4}},
+						},
+					}))
+				})
+			})
+
+			Describe("<< and >>", func() {
+				BeforeEach(func() {
+					lexer = parser.NewLexer(`
+321 << 123
+555 >> 666
+`)
+				})
+
+				It("returns a shovel call expression", func() {
+					Expect(parser.Statements).To(Equal([]ast.Node{
+						ast.CallExpression{
+							Target: ast.ConstantInt{Value: 321},
+							Func:   ast.BareReference{Name: "<<"},
+							Args:   []ast.Node{ast.ConstantInt{Value: 123}},
+						},
+						ast.CallExpression{
+							Target: ast.ConstantInt{Value: 555},
+							Func:   ast.BareReference{Name: ">>"},
+							Args:   []ast.Node{ast.ConstantInt{Value: 666}},
+						},
+					}))
+				})
+			})
+
+			Describe("bitwise binary operators", func() {
+				BeforeEach(func() {
+					lexer = parser.NewLexer(`
+1 & 0
+1 | 0
+1 ^ 5
+File.lchmod mode & 01777, path
+`)
+				})
+
+				It("returns a call expressions for those methods", func() {
+					Expect(parser.Statements).To(Equal([]ast.Node{
+						ast.CallExpression{
+							Target: ast.ConstantInt{Value: 1},
+							Func:   ast.BareReference{Name: "&"},
+							Args:   []ast.Node{ast.ConstantInt{Value: 0}},
 						},
 						ast.CallExpression{
 							Target: ast.ConstantInt{Value: 1},
@@ -2330,6 +3129,34 @@ File.lchmod mode & 01777, path
 					})
 				})
 
+				Context("on the right side of an assignment", func() {
+					BeforeEach(func() {
+						lexer = parser.NewLexer(`
+x = a or b
+@y = c and d
+`)
+					})
+
+					It("binds more loosely than the assignment", func() {
+						Expect(parser.Statements).To(Equal([]ast.Node{
+							ast.WeakLogicalOr{
+								LHS: ast.Assignment{
+									LHS: ast.BareReference{Name: "x"},
+									RHS: ast.BareReference{Name: "a"},
+								},
+								RHS: ast.BareReference{Name: "b"},
+							},
+							ast.WeakLogicalAnd{
+								LHS: ast.Assignment{
+									LHS: ast.InstanceVariable{Name: "y"},
+									RHS: ast.BareReference{Name: "c"},
+								},
+								RHS: ast.BareReference{Name: "d"},
+							},
+						}))
+					})
+				})
+
 				Context("with complex types on the left and right side", func() {
 					BeforeEach(func() {
 						lexer = parser.NewLexer(`retrieve(:features)[feature] || false`)
@@ -2398,6 +3225,22 @@ File.lchmod mode & 01777, path
 		})
 
 		Describe("arrays", func() {
+			Context("with splatted elements", func() {
+				BeforeEach(func() {
+					lexer = parser.NewLexer("[*a, b, *c]")
+				})
+
+				It("keeps each splat as an element of the Array", func() {
+					Expect(parser.Statements).To(Equal([]ast.Node{
+						ast.Array{Nodes: []ast.Node{
+							ast.StarSplat{Value: ast.BareReference{Name: "a"}},
+							ast.BareReference{Name: "b"},
+							ast.StarSplat{Value: ast.BareReference{Name: "c"}},
+						}},
+					}))
+				})
+			})
+
 			Context("with newlines between elements", func() {
 				BeforeEach(func() {
 					lexer = parser.NewLexer(`[1,2,3,
@@ -2583,6 +3426,63 @@ File.lchmod mode & 01777, path
 				})
 			})
 
+			Context("with hashrocket pairs as the last args to a method", func() {
+				BeforeEach(func() {
+					lexer = parser.NewLexer("foo(1, :a => 2)")
+				})
+
+				It("passes the pairs as a Hash", func() {
+					Expect(parser.Statements).To(Equal([]ast.Node{
+						ast.CallExpression{
+							Func: ast.BareReference{Name: "foo"},
+							Args: []ast.Node{
+								ast.ConstantInt{Value: 1},
+								ast.Hash{
+									Pairs: []ast.HashKeyValuePair{
+										{Key: ast.Symbol{Name: "a"}, Value: ast.ConstantInt{Value: 2}},
+									},
+								},
+							},
+						},
+					}))
+				})
+			})
+
+			Context("with more than two hashrocket pairs", func() {
+				BeforeEach(func() {
+					lexer = parser.NewLexer("{:a => 1, :b => 2, :c => 3}")
+				})
+
+				It("returns a Hash node with every pair", func() {
+					Expect(parser.Statements).To(Equal([]ast.Node{
+						ast.Hash{
+							Pairs: []ast.HashKeyValuePair{
+								{Key: ast.Symbol{Name: "a"}, Value: ast.ConstantInt{Value: 1}},
+								{Key: ast.Symbol{Name: "b"}, Value: ast.ConstantInt{Value: 2}},
+								{Key: ast.Symbol{Name: "c"}, Value: ast.ConstantInt{Value: 3}},
+							},
+						},
+					}))
+				})
+			})
+
+			Context("with a trailing comma after the last hashrocket pair", func() {
+				BeforeEach(func() {
+					lexer = parser.NewLexer("{:a => 1, :b => 2,}")
+				})
+
+				It("returns a Hash node with every pair", func() {
+					Expect(parser.Statements).To(Equal([]ast.Node{
+						ast.Hash{
+							Pairs: []ast.HashKeyValuePair{
+								{Key: ast.Symbol{Name: "a"}, Value: ast.ConstantInt{Value: 1}},
+								{Key: ast.Symbol{Name: "b"}, Value: ast.ConstantInt{Value: 2}},
+							},
+						},
+					}))
+				})
+			})
+
 			Describe("assigning a value via a setter", func() {
 				BeforeEach(func() {
 					lexer = parser.NewLexer("Sharpware.nasality = 'cladosiphonic-capillitial'")
@@ -2675,6 +3575,19 @@ foo: bar,
 			})
 		})
 
+		Describe("special globals named by punctuation", func() {
+			BeforeEach(func() {
+				lexer = parser.NewLexer(`$"; $!`)
+			})
+
+			It("should be parsed as a GlobalVariable", func() {
+				Expect(parser.Statements).To(Equal([]ast.Node{
+					ast.GlobalVariable{Name: `"`},
+					ast.GlobalVariable{Name: "!"},
+				}))
+			})
+		})
+
 		Describe("instance variables", func() {
 			BeforeEach(func() {
 				lexer = parser.NewLexer(`
@@ -2853,6 +3766,60 @@ end
 			})
 		})
 
+		Describe("the 'defined?' keyword", func() {
+			Context("with parens", func() {
+				BeforeEach(func() {
+					lexer = parser.NewLexer("defined?(x)")
+				})
+
+				It("is parsed as a DefinedExpression", func() {
+					Expect(parser.Statements).To(Equal([]ast.Node{
+						ast.DefinedExpression{Target: ast.BareReference{Name: "x"}},
+					}))
+				})
+			})
+
+			Context("without parens", func() {
+				BeforeEach(func() {
+					lexer = parser.NewLexer("defined? @y")
+				})
+
+				It("is parsed as a DefinedExpression", func() {
+					Expect(parser.Statements).To(Equal([]ast.Node{
+						ast.DefinedExpression{Target: ast.InstanceVariable{Name: "y"}},
+					}))
+				})
+			})
+
+			Context("describing a constant", func() {
+				BeforeEach(func() {
+					lexer = parser.NewLexer("defined?(SomeConst)")
+				})
+
+				It("is parsed as a DefinedExpression", func() {
+					Expect(parser.Statements).To(Equal([]ast.Node{
+						ast.DefinedExpression{Target: ast.BareReference{Name: "SomeConst"}},
+					}))
+				})
+			})
+
+			Context("followed by an operator", func() {
+				BeforeEach(func() {
+					lexer = parser.NewLexer("defined?(x) && x")
+				})
+
+				It("only describes what is inside its parens", func() {
+					Expect(parser.Statements).To(Equal([]ast.Node{
+						ast.CallExpression{
+							Target: ast.DefinedExpression{Target: ast.BareReference{Name: "x"}},
+							Func:   ast.BareReference{Name: "&&"},
+							Args:   []ast.Node{ast.BareReference{Name: "x"}},
+						},
+					}))
+				})
+			})
+		})
+
 		Describe("the retry keyword", func() {
 			BeforeEach(func() {
 				lexer = parser.NewLexer(`
@@ -3006,6 +3973,83 @@ end
 				})
 			})
 
+			Context("with numbered params", func() {
+				BeforeEach(func() {
+					lexer = parser.NewLexer("pairs.map { _1 + _2 }")
+				})
+
+				It("records the highest numbered param referenced", func() {
+					Expect(parser.Statements).To(Equal([]ast.Node{
+						ast.CallExpression{
+							Target: ast.BareReference{Name: "pairs"},
+							Func:   ast.BareReference{Name: "map"},
+							Args:   []ast.Node{},
+							OptionalBlock: ast.Block{
+								Body: []ast.Node{
+									ast.CallExpression{
+										Target: ast.BareReference{Name: "_1"},
+										Func:   ast.BareReference{Name: "+"},
+										Args:   []ast.Node{ast.BareReference{Name: "_2"}},
+									},
+								},
+								ImplicitArgCount: 2,
+							},
+						},
+					}))
+				})
+			})
+
+			Context("without params, using an operator", func() {
+				BeforeEach(func() {
+					lexer = parser.NewLexer("[1].map { x * 2 }")
+				})
+
+				It("is parsed as a block rather than a hash", func() {
+					Expect(parser.Statements).To(Equal([]ast.Node{
+						ast.CallExpression{
+							Target: ast.Array{Nodes: []ast.Node{ast.ConstantInt{Value: 1}}},
+							Func:   ast.BareReference{Name: "map"},
+							Args:   []ast.Node{},
+							OptionalBlock: ast.Block{
+								Body: []ast.Node{
+									ast.CallExpression{
+										Target: ast.BareReference{Name: "x"},
+										Func:   ast.BareReference{Name: "*"},
+										Args:   []ast.Node{ast.ConstantInt{Value: 2}},
+									},
+								},
+							},
+						},
+					}))
+				})
+			})
+
+			Context("with a numbered param and an operator", func() {
+				BeforeEach(func() {
+					lexer = parser.NewLexer("[1].map { _1 * 2 }")
+				})
+
+				It("is parsed as a block rather than a hash", func() {
+					Expect(parser.Statements).To(Equal([]ast.Node{
+						ast.CallExpression{
+							Target: ast.Array{Nodes: []ast.Node{ast.ConstantInt{Value: 1}}},
+							Func:   ast.BareReference{Name: "map"},
+							Args:   []ast.Node{},
+							OptionalBlock: ast.Block{
+								Body: []ast.Node{
+									ast.CallExpression{
+										Target: ast.BareReference{Name: "_1"},
+										Func:   ast.BareReference{Name: "*"},
+										Args:   []ast.Node{ast.ConstantInt{Value: 2}},
+									},
+								},
+								ImplicitArgCount: 1,
+							},
+						},
+					}))
+				})
+			})
+
 			Context("with args", func() {
 				BeforeEach(func() {
 					lexer = parser.NewLexer(`
@@ -3033,6 +4077,36 @@ end
 				})
 			})
 
+			Context("with args destructured in parens", func() {
+				BeforeEach(func() {
+					lexer = parser.NewLexer(`
+pairs.each_with_object({}) do |(key, value), memo|
+  key
+end
+`)
+				})
+
+				It("is parsed as an ast.Block with an ast.DestructuredParam", func() {
+					Expect(parser.Statements).To(Equal([]ast.Node{
+						ast.CallExpression{
+							Target: ast.BareReference{Name: "pairs"},
+							Func:   ast.BareReference{Name: "each_with_object"},
+							Args:   []ast.Node{ast.Hash{}},
+							OptionalBlock: ast.Block{
+								Args: []ast.Node{
+									ast.DestructuredParam{Params: []ast.Node{
+										ast.BareReference{Name: "key"},
+										ast.BareReference{Name: "value"},
+									}},
+									ast.BareReference{Name: "memo"},
+								},
+								Body: []ast.Node{ast.BareReference{Name: "key"}},
+							},
+						},
+					}))
+				})
+			})
+
 			Context("with curly braces", func() {
 				BeforeEach(func() {
 					lexer = parser.NewLexer("with.a_block {|foo| puts foo}")
@@ -3057,9 +4131,131 @@ end
 					}))
 				})
 			})
-		})
 
-		Describe("ranges", func() {
+			Context("with curly braces and a body that starts with an assignment", func() {
+				BeforeEach(func() {
+					lexer = parser.NewLexer("[1].each { x = 2 }")
+				})
+
+				It("is parsed as an ast.Block without args", func() {
+					Expect(parser.Statements).To(Equal([]ast.Node{
+						ast.CallExpression{
+							Target: ast.Array{Nodes: []ast.Node{ast.ConstantInt{Value: 1}}},
+							Func:   ast.BareReference{Name: "each"},
+							Args:   []ast.Node{},
+							OptionalBlock: ast.Block{
+								Body: []ast.Node{
+									ast.Assignment{
+										LHS: ast.BareReference{Name: "x"},
+										RHS: ast.ConstantInt{Value: 2},
+									},
+								},
+							},
+						},
+					}))
+				})
+			})
+		})
+
+		Describe("ranges", func() {
+			Context("that exclude their end", func() {
+				BeforeEach(func() {
+					lexer = parser.NewLexer("1...10")
+				})
+
+				It("should be parsed as an exclusive Range", func() {
+					Expect(parser.Statements).To(Equal([]ast.Node{
+						ast.Range{
+							Start:     ast.ConstantInt{Value: 1},
+							End:       ast.ConstantInt{Value: 10},
+							Exclusive: true,
+						},
+					}))
+				})
+			})
+
+			Context("of strings that exclude their end", func() {
+				BeforeEach(func() {
+					lexer = parser.NewLexer("'a'...'z'")
+				})
+
+				It("should be parsed as an exclusive Range", func() {
+					Expect(parser.Statements).To(Equal([]ast.Node{
+						ast.Range{
+							Start:     ast.SimpleString{Value: "a"},
+							End:       ast.SimpleString{Value: "z"},
+							Exclusive: true,
+						},
+					}))
+				})
+			})
+
+			Context("without an end", func() {
+				BeforeEach(func() {
+					lexer = parser.NewLexer("1..")
+				})
+
+				It("should be parsed as a Range with no End", func() {
+					Expect(parser.Statements).To(Equal([]ast.Node{
+						ast.Range{Start: ast.ConstantInt{Value: 1}},
+					}))
+				})
+			})
+
+			Context("without a beginning", func() {
+				BeforeEach(func() {
+					lexer = parser.NewLexer("..5")
+				})
+
+				It("should be parsed as a Range with no Start", func() {
+					Expect(parser.Statements).To(Equal([]ast.Node{
+						ast.Range{End: ast.ConstantInt{Value: 5}},
+					}))
+				})
+			})
+
+			Context("without an end, excluding the end", func() {
+				BeforeEach(func() {
+					lexer = parser.NewLexer("1...")
+				})
+
+				It("should be parsed as an exclusive Range with no End", func() {
+					Expect(parser.Statements).To(Equal([]ast.Node{
+						ast.Range{Start: ast.ConstantInt{Value: 1}, Exclusive: true},
+					}))
+				})
+			})
+
+			Context("without a beginning, excluding the end", func() {
+				BeforeEach(func() {
+					lexer = parser.NewLexer("...5")
+				})
+
+				It("should be parsed as an exclusive Range with no Start", func() {
+					Expect(parser.Statements).To(Equal([]ast.Node{
+						ast.Range{End: ast.ConstantInt{Value: 5}, Exclusive: true},
+					}))
+				})
+			})
+
+			Context("without an end, used to slice", func() {
+				BeforeEach(func() {
+					lexer = parser.NewLexer("name[1..]")
+				})
+
+				It("should be parsed as a call to [] with the Range", func() {
+					Expect(parser.Statements).To(Equal([]ast.Node{
+						ast.CallExpression{
+							Target: ast.BareReference{Name: "name"},
+							Func:   ast.BareReference{Name: "[]"},
+							Args: []ast.Node{
+								ast.Range{Start: ast.ConstantInt{Value: 1}},
+							},
+						},
+					}))
+				})
+			})
+
 			Context("of integers", func() {
 				BeforeEach(func() {
 					lexer = parser.NewLexer("-1..-5")
@@ -3068,8 +4264,36 @@ end
 				It("should be parsed as a Range", func() {
 					Expect(parser.Statements).To(Equal([]ast.Node{
 						ast.Range{
-							Start: ast.Negative{Target: ast.ConstantInt{Value: 1}},
-							End:   ast.Negative{Target: ast.ConstantInt{Value: 5}},
+							Start: ast.ConstantInt{Value: -1},
+							End:   ast.ConstantInt{Value: -5},
+						},
+					}))
+				})
+			})
+
+			Context("with call expressions as endpoints", func() {
+				BeforeEach(func() {
+					lexer = parser.NewLexer("range = (lo.to_i)..hi.to_i")
+				})
+
+				It("should be parsed as a Range", func() {
+					Expect(parser.Statements).To(Equal([]ast.Node{
+						ast.Assignment{
+							LHS: ast.BareReference{Name: "range"},
+							RHS: ast.Range{
+								Start: ast.Group{
+									Body: []ast.Node{
+										ast.CallExpression{
+											Target: ast.BareReference{Name: "lo"},
+											Func:   ast.BareReference{Name: "to_i"},
+										},
+									},
+								},
+								End: ast.CallExpression{
+									Target: ast.BareReference{Name: "hi"},
+									Func:   ast.BareReference{Name: "to_i"},
+								},
+							},
 						},
 					}))
 				})
@@ -3088,6 +4312,99 @@ end
 					}))
 				})
 			})
+
+			Describe("for regular expressions with flags", func() {
+				BeforeEach(func() {
+					lexer = parser.NewLexer("%r{a/b}mx")
+				})
+
+				It("keeps the flags alongside the pattern", func() {
+					Expect(parser.Statements).To(Equal([]ast.Node{
+						ast.Regex{Value: "a/b", Flags: "mx"},
+					}))
+				})
+			})
+
+			Describe("for arrays of words", func() {
+				BeforeEach(func() {
+					lexer = parser.NewLexer(`
+%w(a b c)
+%w[foo   bar
+  baz]
+%w{one\ word}
+%w<>
+`)
+				})
+
+				It("splits the words on whitespace into an ast.Array of strings", func() {
+					Expect(parser.Statements).To(Equal([]ast.Node{
+						ast.Array{Nodes: []ast.Node{
+							ast.SimpleString{Value: "a"},
+							ast.SimpleString{Value: "b"},
+							ast.SimpleString{Value: "c"},
+						}},
+						ast.Array{Nodes: []ast.Node{
+							ast.SimpleString{Value: "foo"},
+							ast.SimpleString{Value: "bar"},
+							ast.SimpleString{Value: "baz"},
+						}},
+						ast.Array{Nodes: []ast.Node{
+							ast.SimpleString{Value: "one word"},
+						}},
+						ast.Array{Nodes: []ast.Node{}},
+					}))
+				})
+			})
+
+			Describe("for arrays of symbols", func() {
+				BeforeEach(func() {
+					lexer = parser.NewLexer(`
+%i(one two)
+%i[one two]
+%i{one two}
+%i<one two>
+`)
+				})
+
+				It("returns an ast.Array of symbols for each delimiter", func() {
+					symbols := ast.Array{Nodes: []ast.Node{
+						ast.Symbol{Name: "one"},
+						ast.Symbol{Name: "two"},
+					}}
+					Expect(parser.Statements).To(Equal([]ast.Node{symbols, symbols, symbols, symbols}))
+				})
+			})
+
+			Describe("for arrays of interpolated symbols", func() {
+				BeforeEach(func() {
+					lexer = parser.NewLexer(`%I[plain @#{name} with\ space]`)
+				})
+
+				It("keeps the interpolation template in the symbol name", func() {
+					Expect(parser.Statements).To(Equal([]ast.Node{
+						ast.Array{Nodes: []ast.Node{
+							ast.Symbol{Name: "plain"},
+							ast.Symbol{Name: "@#{name}"},
+							ast.Symbol{Name: "with space"},
+						}},
+					}))
+				})
+			})
+
+			Describe("for arrays of interpolated words", func() {
+				BeforeEach(func() {
+					lexer = parser.NewLexer(`%W[hello #{name}]`)
+				})
+
+				It("returns an ast.Array of interpolated strings", func() {
+					Expect(parser.Statements).To(Equal([]ast.Node{
+						ast.Array{Nodes: []ast.Node{
+							ast.InterpolatedString{Value: "hello"},
+							ast.InterpolatedString{Value: "#{name}"},
+						}},
+					}))
+				})
+			})
 		})
 
 		Describe("regex literals", func() {
@@ -3100,9 +4417,72 @@ end
 					ast.Regex{Value: "^foo.*bar$"},
 				}))
 			})
+
+			Context("with trailing flags", func() {
+				BeforeEach(func() {
+					lexer = parser.NewLexer("/^foo$/im")
+				})
+
+				It("keeps the flags alongside the pattern", func() {
+					Expect(parser.Statements).To(Equal([]ast.Node{
+						ast.Regex{Value: "^foo$", Flags: "im"},
+					}))
+				})
+			})
+
+			Context("with interpolation", func() {
+				BeforeEach(func() {
+					lexer = parser.NewLexer(`/\A#{prefix}-\d+\z/`)
+				})
+
+				It("keeps the template verbatim and marks it as interpolated", func() {
+					Expect(parser.Statements).To(Equal([]ast.Node{
+						ast.Regex{Value: `\A#{prefix}-\d+\z`, Interpolated: true},
+					}))
+				})
+			})
+
+			Context("with a slash inside the interpolation", func() {
+				BeforeEach(func() {
+					lexer = parser.NewLexer("/#{a / b}/")
+				})
+
+				It("does not end the regex early", func() {
+					Expect(parser.Statements).To(Equal([]ast.Node{
+						ast.Regex{Value: "#{a / b}", Interpolated: true},
+					}))
+				})
+			})
+
+			Context("without interpolation", func() {
+				BeforeEach(func() {
+					lexer = parser.NewLexer("/plain/")
+				})
+
+				It("is not marked as interpolated", func() {
+					Expect(parser.Statements).To(Equal([]ast.Node{
+						ast.Regex{Value: "plain", Interpolated: false},
+					}))
+				})
+			})
 		})
 
 		Describe("unless", func() {
+			Context("with then on a single line", func() {
+				BeforeEach(func() {
+					lexer = parser.NewLexer("unless done then retry_it end")
+				})
+
+				It("is parsed as a negated IfBlock", func() {
+					Expect(parser.Statements).To(Equal([]ast.Node{
+						ast.IfBlock{
+							Condition: ast.Negation{Target: ast.BareReference{Name: "done"}},
+							Body:      []ast.Node{ast.BareReference{Name: "retry_it"}},
+						},
+					}))
+				})
+			})
+
 			Context("at the end of an expression", func() {
 				BeforeEach(func() {
 					lexer = parser.NewLexer("5 unless false")
@@ -3111,7 +4491,7 @@ end
 				It("is parsed as an IfBlock", func() {
 					Expect(parser.Statements).To(Equal([]ast.Node{
 						ast.IfBlock{
-							Condition: ast.Negation{Target: ast.Boolean{false}},
+							Condition: ast.Negation{Target: ast.Boolean{Value: false}},
 							Body:      []ast.Node{ast.ConstantInt{Value: 5}},
 						},
 					}))
@@ -3130,7 +4510,7 @@ end
 					Expect(parser.Statements).To(Equal([]ast.Node{
 						ast.IfBlock{
 							Condition: ast.Negation{
-								ast.CallExpression{
+								Target: ast.CallExpression{
 									Target: ast.CallExpression{
 										Target: ast.BareReference{Name: "target"},
 										Func:   ast.BareReference{Name: "[]"},
@@ -3198,6 +4578,26 @@ end
 		})
 
 		Describe("if else blocks", func() {
+			Context("with then on a single line", func() {
+				BeforeEach(func() {
+					lexer = parser.NewLexer("if false then puts 'Romanize-whereover' end")
+				})
+
+				It("is parsed the same as the multi-line form", func() {
+					Expect(parser.Statements).To(Equal([]ast.Node{
+						ast.IfBlock{
+							Condition: ast.Boolean{Value: false},
+							Body: []ast.Node{
+								ast.CallExpression{
+									Func: ast.BareReference{Name: "puts"},
+									Args: []ast.Node{ast.SimpleString{Value: "Romanize-whereover"}},
+								},
+							},
+						},
+					}))
+				})
+			})
+
 			Context("without an else", func() {
 				BeforeEach(func() {
 					lexer = parser.NewLexer(`
@@ -3381,6 +4781,41 @@ end
 			})
 		})
 
+		Describe("rescuing a splatted list of classes", func() {
+			BeforeEach(func() {
+				lexer = parser.NewLexer(`
+begin
+rescue *NETWORK_ERRORS => e
+rescue Timeout, *others
+end
+`)
+			})
+
+			It("records the splatted expression alongside any named classes", func() {
+				Expect(parser.Statements).To(Equal([]ast.Node{
+					ast.Begin{
+						Body: []ast.Node{},
+						Rescue: []ast.Node{
+							ast.Rescue{
+								Body: []ast.Node{},
+								Exception: ast.RescueException{
+									Var:   ast.BareReference{Name: "e"},
+									Splat: ast.BareReference{Name: "NETWORK_ERRORS"},
+								},
+							},
+							ast.Rescue{
+								Body: []ast.Node{},
+								Exception: ast.RescueException{
+									Classes: []ast.Class{{Name: "Timeout"}},
+									Splat:   ast.BareReference{Name: "others"},
+								},
+							},
+						},
+					},
+				}))
+			})
+		})
+
 		Describe("rescuing without a class, and capturing the exception thrown", func() {
 			BeforeEach(func() {
 				lexer = parser.NewLexer(`
@@ -3490,7 +4925,146 @@ end
 								},
 								Body: []ast.Node{
 									ast.CallExpression{
-										Func: ast.BareReference{Name: "baz"},
+										Func: ast.BareReference{Name: "baz"},
+										Args: []ast.Node{},
+									},
+								},
+							},
+						},
+					},
+				}))
+			})
+		})
+
+		Describe("begin assigned to a variable", func() {
+			BeforeEach(func() {
+				lexer = parser.NewLexer(`
+value = begin
+  foo()
+rescue
+  bar()
+end
+`)
+			})
+
+			It("is parsed as an assignment of the begin block", func() {
+				Expect(parser.Statements).To(Equal([]ast.Node{
+					ast.Assignment{
+						LHS: ast.BareReference{Name: "value"},
+						RHS: ast.Begin{
+							Body: []ast.Node{
+								ast.CallExpression{Func: ast.BareReference{Name: "foo"}, Args: []ast.Node{}},
+							},
+							Rescue: []ast.Node{
+								ast.Rescue{
+									Body: []ast.Node{
+										ast.CallExpression{Func: ast.BareReference{Name: "bar"}, Args: []ast.Node{}},
+									},
+								},
+							},
+						},
+					},
+				}))
+			})
+		})
+
+		Describe("begin with an ensure", func() {
+			Context("after a rescue", func() {
+				BeforeEach(func() {
+					lexer = parser.NewLexer(`
+begin
+  foo()
+rescue
+  bar()
+ensure
+  baz()
+end
+`)
+				})
+
+				It("records the ensure statements", func() {
+					Expect(parser.Statements).To(Equal([]ast.Node{
+						ast.Begin{
+							Body: []ast.Node{
+								ast.CallExpression{Func: ast.BareReference{Name: "foo"}, Args: []ast.Node{}},
+							},
+							Rescue: []ast.Node{
+								ast.Rescue{
+									Body: []ast.Node{
+										ast.CallExpression{Func: ast.BareReference{Name: "bar"}, Args: []ast.Node{}},
+									},
+								},
+							},
+							Ensure: []ast.Node{
+								ast.CallExpression{Func: ast.BareReference{Name: "baz"}, Args: []ast.Node{}},
+							},
+						},
+					}))
+				})
+			})
+
+			Context("without a rescue", func() {
+				BeforeEach(func() {
+					lexer = parser.NewLexer(`
+begin
+  foo()
+ensure
+  baz()
+end
+`)
+				})
+
+				It("records the ensure statements", func() {
+					Expect(parser.Statements).To(Equal([]ast.Node{
+						ast.Begin{
+							Body: []ast.Node{
+								ast.CallExpression{Func: ast.BareReference{Name: "foo"}, Args: []ast.Node{}},
+							},
+							Rescue: []ast.Node{},
+							Ensure: []ast.Node{
+								ast.CallExpression{Func: ast.BareReference{Name: "baz"}, Args: []ast.Node{}},
+							},
+						},
+					}))
+				})
+			})
+		})
+
+		Describe("a method that re-raises from a rescue", func() {
+			BeforeEach(func() {
+				lexer = parser.NewLexer(`
+def samsonic_obey
+  raise 'whoopsie-daisy'
+rescue Nope
+  puts 'unlikely'
+  raise
+end
+`)
+			})
+
+			It("parses the bare raise as a call without args", func() {
+				Expect(parser.Statements).To(Equal([]ast.Node{
+					ast.FuncDecl{
+						Name: ast.BareReference{Name: "samsonic_obey"},
+						Args: []ast.Node{},
+						Body: []ast.Node{
+							ast.CallExpression{
+								Func: ast.BareReference{Name: "raise"},
+								Args: []ast.Node{ast.SimpleString{Value: "whoopsie-daisy"}},
+							},
+						},
+						Rescues: []ast.Node{
+							ast.Rescue{
+								Exception: ast.RescueException{
+									Classes: []ast.Class{{Name: "Nope"}},
+								},
+								Body: []ast.Node{
+									ast.CallExpression{
+										Func: ast.BareReference{Name: "puts"},
+										Args: []ast.Node{ast.SimpleString{Value: "unlikely"}},
+									},
+									ast.CallExpression{
+										Func: ast.BareReference{Name: "raise"},
 										Args: []ast.Node{},
 									},
 								},
@@ -3501,7 +5075,109 @@ end
 			})
 		})
 
+		Describe("a method with an ensure at the end", func() {
+			Context("without a rescue", func() {
+				BeforeEach(func() {
+					lexer = parser.NewLexer(`
+def close_quietly
+  close()
+ensure
+  log()
+end
+`)
+				})
+
+				It("records the ensure statements on the method", func() {
+					Expect(parser.Statements).To(Equal([]ast.Node{
+						ast.FuncDecl{
+							Name: ast.BareReference{Name: "close_quietly"},
+							Args: []ast.Node{},
+							Body: []ast.Node{
+								ast.CallExpression{Func: ast.BareReference{Name: "close"}, Args: []ast.Node{}},
+							},
+							Ensure: []ast.Node{
+								ast.CallExpression{Func: ast.BareReference{Name: "log"}, Args: []ast.Node{}},
+							},
+						},
+					}))
+				})
+			})
+
+			Context("after a rescue", func() {
+				BeforeEach(func() {
+					lexer = parser.NewLexer(`
+def self.close_quietly
+  close()
+rescue IOError
+  retry_later()
+ensure
+  log()
+end
+`)
+				})
+
+				It("records both the rescue and ensure statements on the method", func() {
+					Expect(parser.Statements).To(Equal([]ast.Node{
+						ast.FuncDecl{
+							Target: ast.Self{},
+							Name:   ast.BareReference{Name: "close_quietly"},
+							Args:   []ast.Node{},
+							Body: []ast.Node{
+								ast.CallExpression{Func: ast.BareReference{Name: "close"}, Args: []ast.Node{}},
+							},
+							Rescues: []ast.Node{
+								ast.Rescue{
+									Exception: ast.RescueException{
+										Classes: []ast.Class{{Name: "IOError"}},
+									},
+									Body: []ast.Node{
+										ast.CallExpression{Func: ast.BareReference{Name: "retry_later"}, Args: []ast.Node{}},
+									},
+								},
+							},
+							Ensure: []ast.Node{
+								ast.CallExpression{Func: ast.BareReference{Name: "log"}, Args: []ast.Node{}},
+							},
+						},
+					}))
+				})
+			})
+		})
+
 		Describe("ternary ?", func() {
+			Context("with a comparison operator in the condition", func() {
+				BeforeEach(func() {
+					lexer = parser.NewLexer("sort(x.size <=> y.size ? x : y)")
+				})
+
+				It("is parsed without requiring parens around the condition", func() {
+					Expect(parser.Statements).To(Equal([]ast.Node{
+						ast.CallExpression{
+							Func: ast.BareReference{Name: "sort"},
+							Args: []ast.Node{
+								ast.Ternary{
+									Condition: ast.CallExpression{
+										Target: ast.CallExpression{
+											Target: ast.BareReference{Name: "x"},
+											Func:   ast.BareReference{Name: "size"},
+										},
+										Func: ast.BareReference{Name: "<=>"},
+										Args: []ast.Node{
+											ast.CallExpression{
+												Target: ast.BareReference{Name: "y"},
+												Func:   ast.BareReference{Name: "size"},
+											},
+										},
+									},
+									True:  ast.BareReference{Name: "x"},
+									False: ast.BareReference{Name: "y"},
+								},
+							},
+						},
+					}))
+				})
+			})
+
 			Context("as the right hand side of an assignment expression", func() {
 				BeforeEach(func() {
 					lexer = parser.NewLexer(`
@@ -3566,6 +5242,49 @@ s = short ? short.dup : "  "
 					}))
 				})
 			})
+
+			Context("with a method called on the false branch", func() {
+				BeforeEach(func() {
+					lexer = parser.NewLexer("cond ? a : b.to_s")
+				})
+
+				It("binds the method call to the false branch alone", func() {
+					Expect(parser.Statements).To(Equal([]ast.Node{
+						ast.Ternary{
+							Condition: ast.BareReference{Name: "cond"},
+							True:      ast.BareReference{Name: "a"},
+							False: ast.CallExpression{
+								Target: ast.BareReference{Name: "b"},
+								Func:   ast.BareReference{Name: "to_s"},
+							},
+						},
+					}))
+				})
+			})
+
+			Context("in parens with a method called on it", func() {
+				BeforeEach(func() {
+					lexer = parser.NewLexer("(cond ? a : b).to_s")
+				})
+
+				It("calls the method on the result of the whole ternary", func() {
+					Expect(parser.Statements).To(Equal([]ast.Node{
+						ast.CallExpression{
+							Target: ast.Group{
+								Body: []ast.Node{
+									ast.Ternary{
+										Condition: ast.BareReference{Name: "cond"},
+										True:      ast.BareReference{Name: "a"},
+										False:     ast.BareReference{Name: "b"},
+									},
+								},
+							},
+							Func: ast.BareReference{Name: "to_s"},
+							Args: []ast.Node{},
+						},
+					}))
+				})
+			})
 		})
 
 		Describe("semicolons", func() {
@@ -3670,7 +5389,7 @@ end
 						Args: []ast.Node{},
 						Body: []ast.Node{
 							ast.IfBlock{
-								Condition: ast.Negation{ast.InstanceVariable{Name: "value"}},
+								Condition: ast.Negation{Target: ast.InstanceVariable{Name: "value"}},
 								Body: []ast.Node{
 									ast.Assignment{
 										LHS: ast.InstanceVariable{Name: "value"},
@@ -3712,6 +5431,113 @@ end
 				})
 			})
 
+			Context("with a redo statement", func() {
+				BeforeEach(func() {
+					lexer = parser.NewLexer(`
+5.times do
+  redo
+end
+`)
+				})
+
+				It("is parsed as a block, but the 'redo' keyword is valid here", func() {
+					Expect(parser.Statements).To(Equal([]ast.Node{
+						ast.CallExpression{
+							Target: ast.ConstantInt{Value: 5},
+							Func:   ast.BareReference{Name: "times"},
+							Args:   []ast.Node{},
+							OptionalBlock: ast.Block{
+								Body: []ast.Node{ast.Redo{}},
+							},
+						},
+					}))
+				})
+			})
+
+			Context("with a redo statement and a trailing condition", func() {
+				BeforeEach(func() {
+					lexer = parser.NewLexer(`
+5.times do
+  redo if retrying
+end
+`)
+				})
+
+				It("wraps the redo in an if block", func() {
+					Expect(parser.Statements).To(Equal([]ast.Node{
+						ast.CallExpression{
+							Target: ast.ConstantInt{Value: 5},
+							Func:   ast.BareReference{Name: "times"},
+							Args:   []ast.Node{},
+							OptionalBlock: ast.Block{
+								Body: []ast.Node{
+									ast.IfBlock{
+										Condition: ast.BareReference{Name: "retrying"},
+										Body:      []ast.Node{ast.Redo{}},
+									},
+								},
+							},
+						},
+					}))
+				})
+			})
+
+			Context("with a for statement", func() {
+				BeforeEach(func() {
+					lexer = parser.NewLexer(`
+for i in 1..5
+  puts i
+end
+`)
+				})
+
+				It("is parsed as a ForLoop", func() {
+					Expect(parser.Statements).To(Equal([]ast.Node{
+						ast.ForLoop{
+							Vars: []ast.Node{ast.BareReference{Name: "i"}},
+							Collection: ast.Range{
+								Start: ast.ConstantInt{Value: 1},
+								End:   ast.ConstantInt{Value: 5},
+							},
+							Body: []ast.Node{
+								ast.CallExpression{
+									Func: ast.BareReference{Name: "puts"},
+									Args: []ast.Node{ast.BareReference{Name: "i"}},
+								},
+							},
+						},
+					}))
+				})
+			})
+
+			Context("with a for statement over several vars and a do", func() {
+				BeforeEach(func() {
+					lexer = parser.NewLexer(`
+for key, value in pairs do
+  puts key
+end
+`)
+				})
+
+				It("is parsed as a ForLoop with each var", func() {
+					Expect(parser.Statements).To(Equal([]ast.Node{
+						ast.ForLoop{
+							Vars: []ast.Node{
+								ast.BareReference{Name: "key"},
+								ast.BareReference{Name: "value"},
+							},
+							Collection: ast.BareReference{Name: "pairs"},
+							Body: []ast.Node{
+								ast.CallExpression{
+									Func: ast.BareReference{Name: "puts"},
+									Args: []ast.Node{ast.BareReference{Name: "key"}},
+								},
+							},
+						},
+					}))
+				})
+			})
+
 			Context("with an until statement", func() {
 				BeforeEach(func() {
 					lexer = parser.NewLexer(`
@@ -3775,6 +5601,37 @@ end while true
 										Rescue: []ast.Node{},
 									},
 								},
+								DoWhile: true,
+							},
+						}))
+					})
+				})
+
+				Context("with until at the end of a begin block", func() {
+					BeforeEach(func() {
+						lexer = parser.NewLexer(`
+begin
+  puts 'whaaat'
+end until false
+`)
+					})
+
+					It("is a loop with a negated condition", func() {
+						Expect(parser.Statements).To(Equal([]ast.Node{
+							ast.Loop{
+								Condition: ast.Negation{Target: ast.Boolean{Value: false}},
+								Body: []ast.Node{
+									ast.Begin{
+										Body: []ast.Node{
+											ast.CallExpression{
+												Func: ast.BareReference{Name: "puts"},
+												Args: []ast.Node{ast.SimpleString{Value: "whaaat"}},
+											},
+										},
+										Rescue: []ast.Node{},
+									},
+								},
+								DoWhile: true,
 							},
 						}))
 					})
@@ -3991,6 +5848,78 @@ func.with_a_block { |foo | puts foo.inspect    } # all the comments # yep
 		})
 	})
 
+	Describe("source positions", func() {
+		BeforeEach(func() {
+			lexer = parser.NewPositionedLexer(`x = 1
+
+def foo(a)
+  puts a
+  a.bar(2)
+end
+  foo 3
+`)
+		})
+
+		JustBeforeEach(func() {
+			Expect(parser.RubyParse(lexer)).To(BeSuccessful())
+			Expect(lexer.(*parser.ConcreteStatefulRubyLexer).LastError).ToNot(HaveOccurred())
+		})
+
+		It("records the line and column each statement starts at", func() {
+			Expect(parser.Statements).To(HaveLen(3))
+			Expect(parser.Statements[0].(ast.Positioned).Pos()).To(Equal(ast.Position{Line: 1, Column: 1, Offset: 0}))
+			Expect(parser.Statements[1].(ast.Positioned).Pos()).To(Equal(ast.Position{Line: 3, Column: 1, Offset: 7}))
+			Expect(parser.Statements[2].(ast.Positioned).Pos()).To(Equal(ast.Position{Line: 7, Column: 3, Offset: 44}))
+		})
+
+		It("records the lines of statements in a method body", func() {
+			body := parser.Statements[1].(ast.FuncDecl).Body
+			Expect(body).To(HaveLen(2))
+			Expect(body[0].(ast.Positioned).Pos().Line).To(Equal(4))
+			Expect(body[1].(ast.Positioned).Pos().Line).To(Equal(5))
+		})
+
+		It("records where literals and references start", func() {
+			call := parser.Statements[1].(ast.FuncDecl).Body[1].(ast.CallExpression)
+			Expect(call.Target.(ast.Positioned).Pos()).To(Equal(ast.Position{Line: 5, Column: 3, Offset: 29}))
+			Expect(call.Args[0].(ast.Positioned).Pos()).To(Equal(ast.Position{Line: 5, Column: 9, Offset: 35}))
+		})
+
+		It("records where nested expressions start", func() {
+			assignment := parser.Statements[0].(ast.Assignment)
+			Expect(assignment.RHS.(ast.Positioned).Pos()).To(Equal(ast.Position{Line: 1, Column: 5, Offset: 4}))
+
+			call := parser.Statements[2].(ast.CallExpression)
+			Expect(call.Args[0].(ast.Positioned).Pos()).To(Equal(ast.Position{Line: 7, Column: 7, Offset: 48}))
+		})
+
+		Context("with nested calls and blocks", func() {
+			BeforeEach(func() {
+				lexer = parser.NewPositionedLexer(`x = foo.bar(baz(1))
+list.each { |y| puts y.to_s }
+`)
+			})
+
+			It("records where call targets, args and block bodies start", func() {
+				call := parser.Statements[0].(ast.Assignment).RHS.(ast.CallExpression)
+				Expect(call.Pos()).To(Equal(ast.Position{Line: 1, Column: 5, Offset: 4}))
+				Expect(call.Target.(ast.Positioned).Pos()).To(Equal(ast.Position{Line: 1, Column: 5, Offset: 4}))
+				Expect(call.Args[0].(ast.Positioned).Pos()).To(Equal(ast.Position{Line: 1, Column: 13, Offset: 12}))
+				Expect(call.Args[0].(ast.CallExpression).Args[0].(ast.Positioned).Pos()).To(Equal(ast.Position{Line: 1, Column: 17, Offset: 16}))
+
+				body := parser.Statements[1].(ast.CallExpression).OptionalBlock.Body
+				Expect(body[0].(ast.Positioned).Pos()).To(Equal(ast.Position{Line: 2, Column: 17, Offset: 36}))
+				Expect(body[0].(ast.CallExpression).Args[0].(ast.Positioned).Pos()).To(Equal(ast.Position{Line: 2, Column: 22, Offset: 41}))
+			})
+		})
+
+		It("leaves positions unset when parsed without tracking them", func() {
+			parser.Statements = make([]ast.Node, 0)
+			Expect(parser.RubyParse(parser.NewLexer("x = 1\nfoo 3\n"))).To(BeSuccessful())
+			Expect(parser.Statements[1].(ast.Positioned).Pos()).To(Equal(ast.Position{}))
+		})
+	})
+
 	Describe("invalid ruby", func() {
 		JustBeforeEach(func() {
 			Expect(parser.RubyParse(lexer)).ToNot(BeSuccessful())
@@ -4010,6 +5939,46 @@ end
 			})
 		})
 
+		Context("given a hex literal without any digits", func() {
+			BeforeEach(func() {
+				lexer = parser.NewLexer("x = 0x")
+			})
+
+			It("fails with an error describing the literal", func() {
+				Expect(lexer.(*parser.ConcreteStatefulRubyLexer).LastError.Error()).To(ContainSubstring("numeric literal without digits: '0x'"))
+			})
+		})
+
+		Context("given a float with a trailing dot and no fraction", func() {
+			BeforeEach(func() {
+				lexer = parser.NewLexer("x = 5.")
+			})
+
+			It("fails to parse", func() {
+				Expect(parser.Statements).To(BeEmpty())
+			})
+		})
+
+		Context("given a float with an underscore next to its dot", func() {
+			BeforeEach(func() {
+				lexer = parser.NewLexer("1_.5")
+			})
+
+			It("fails with an error describing the literal", func() {
+				Expect(lexer.(*parser.ConcreteStatefulRubyLexer).LastError.Error()).To(ContainSubstring("misplaced '_' in number: '1_.5'"))
+			})
+		})
+
+		Context("given an integer with consecutive underscores", func() {
+			BeforeEach(func() {
+				lexer = parser.NewLexer("1__000")
+			})
+
+			It("fails to parse", func() {
+				Expect(parser.Statements).To(BeEmpty())
+			})
+		})
+
 		PContext("when the 'next' keyword is outside of a loop or block", func() {
 			BeforeEach(func() {
 				lexer = parser.NewLexer("next")
@@ -0,0 +1,17 @@
+package parser
+
+import "github.com/grubby/grubby/ast"
+
+// an array pattern with a splat at either end, e.g. [*, 42, *post],
+// searches the array for its middle elements instead of matching every one
+func newArrayPattern(elements ast.Nodes) ast.Node {
+	if len(elements) > 2 {
+		pre, preIsSplat := elements[0].(ast.StarSplat)
+		post, postIsSplat := elements[len(elements)-1].(ast.StarSplat)
+		if preIsSplat && postIsSplat {
+			return ast.FindPattern{Pre: pre, Middle: elements[1 : len(elements)-1], Post: post}
+		}
+	}
+
+	return ast.ArrayPattern{Elements: elements}
+}
@@ -1,10 +1,22 @@
 package parser
 
+import "strings"
+
 func lexPercentSign(l StatefulRubyLexer) stateFn {
 	stringType := tokenTypeDoubleQuoteString
-	if l.accept("r") {
+	switch {
+	case l.accept("r"):
 		stringType = tokenTypeRegex
 		l.moveCurrentTokenStartIndex(1)
+	case l.accept("w"):
+		stringType = tokenTypeWordArray
+		l.moveCurrentTokenStartIndex(1)
+	case l.accept("W"):
+		stringType = tokenTypeInterpolatedWordArray
+		l.moveCurrentTokenStartIndex(1)
+	case l.accept("iI"):
+		stringType = tokenTypeSymbolArray
+		l.moveCurrentTokenStartIndex(1)
 	}
 
 	if l.accept("`~!@#$%^&*-_=+()[]{}<>\\|;:'\",./?") {
@@ -15,8 +27,16 @@ func lexPercentSign(l StatefulRubyLexer) stateFn {
 		for {
 			prev = r
 			switch r = l.next(); {
+			case r == '#' && prev != '\\' && stringType == tokenTypeRegex:
+				if l.accept("{") {
+					lexUntilClosingMatchingBraces('{', '}')(l)
+				}
 			case string(r) == delimiter && prev != '\\':
 				l.backup()
+				if stringType == tokenTypeRegex {
+					emitRegex(l)
+					return lexSomething
+				}
 				l.emit(stringType)
 				l.next()
 				l.ignore() // ignore closing delimiter
@@ -47,3 +67,36 @@ func closingDelimiter(openingDelimiter string) string {
 		return openingDelimiter
 	}
 }
+
+// splits the body of a %w, %W, %i or %I literal into its words. Words are separated
+// by runs of whitespace, unless the whitespace is escaped with a backslash.
+func splitWords(body string) []string {
+	words := []string{}
+	var word []rune
+	inWord := false
+
+	runes := []rune(body)
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		switch {
+		case r == '\\' && i+1 < len(runes) && strings.ContainsRune(" \t\n", runes[i+1]):
+			i++
+			word = append(word, runes[i])
+			inWord = true
+		case strings.ContainsRune(" \t\n", r):
+			if inWord {
+				words = append(words, string(word))
+				word, inWord = nil, false
+			}
+		default:
+			word = append(word, r)
+			inWord = true
+		}
+	}
+
+	if inWord {
+		words = append(words, string(word))
+	}
+
+	return words
+}
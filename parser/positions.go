@@ -0,0 +1,27 @@
+package parser
+
+import (
+	"reflect"
+
+	"github.com/grubby/grubby/ast"
+)
+
+// withPosition records pos in a node that hasn't been given a position yet.
+// Nodes are values, so this returns an updated copy rather than changing the
+// node in place.
+func withPosition(node ast.Node, pos ast.Position) ast.Node {
+	value := reflect.ValueOf(node)
+	if pos == (ast.Position{}) || value.Kind() != reflect.Struct {
+		return node
+	}
+
+	positioned, ok := node.(ast.Positioned)
+	if !ok || positioned.Pos() != (ast.Position{}) {
+		return node
+	}
+
+	copied := reflect.New(value.Type()).Elem()
+	copied.Set(value)
+	copied.FieldByName("Position").Set(reflect.ValueOf(pos))
+	return copied.Interface()
+}
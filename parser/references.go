@@ -51,6 +51,10 @@ func lexReference(l StatefulRubyLexer) stateFn {
 		l.emit(tokenType__FILE__)
 	case "__LINE__":
 		l.emit(tokenType__LINE__)
+	case "__dir__":
+		l.emit(tokenType__dir__)
+	case "__method__":
+		l.emit(tokenType__method__)
 	case "__ENCODING__":
 		l.emit(tokenType__ENCODING__)
 	case "for":
@@ -87,6 +91,16 @@ func lexReference(l StatefulRubyLexer) stateFn {
 		l.emit(tokenTypeCASE)
 	case "when":
 		l.emit(tokenTypeWHEN)
+	case "in":
+		l.emit(tokenTypeIN)
+	case "then":
+		// the optional then after a condition or pattern does the same
+		// job as the newline that usually follows it
+		if l.lastToken().typ == tokenTypeDot {
+			l.emit(tokenTypeReference)
+		} else {
+			l.emit(tokenTypeNewline)
+		}
 	case "self":
 		l.emit(tokenTypeSELF)
 	case "nil":
@@ -109,7 +123,11 @@ func lexReference(l StatefulRubyLexer) stateFn {
 		r, _ := utf8.DecodeRuneInString(l.slice(l.startIndex(), l.startIndex()+1))
 
 		if l.accept("?!") {
-			l.emit(tokenTypeMethodName)
+			if l.currentSlice() == "defined?" {
+				l.emit(tokenTypeDEFINED)
+			} else {
+				l.emit(tokenTypeMethodName)
+			}
 		} else if unicode.IsUpper(r) {
 			l.emit(tokenTypeCapitalizedReference)
 		} else {
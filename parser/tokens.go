@@ -0,0 +1,142 @@
+package parser
+
+import (
+	"fmt"
+
+	"github.com/grubby/grubby/ast"
+)
+
+// Token is a single token read from ruby source, for tools such as syntax
+// highlighters that need the token stream without parsing it
+type Token struct {
+	// the kind of token, e.g. "keyword", "integer", "string" or "operator"
+	Type  string
+	Value string
+
+	// where the value starts, which is after the quote of a string literal
+	// or the colon of a symbol
+	Pos ast.Position
+}
+
+var tokenTypeNames = map[tokenType]string{
+	tokenTypeInteger:                 "integer",
+	tokenTypeFloat:                   "float",
+	tokenTypeString:                  "string",
+	tokenTypeDoubleQuoteString:       "string",
+	tokenTypeSubshell:                "subshell",
+	tokenTypeRegex:                   "regex",
+	tokenTypeWordArray:               "word_array",
+	tokenTypeInterpolatedWordArray:   "word_array",
+	tokenTypeSymbolArray:             "symbol_array",
+	tokenTypeCharacter:               "character",
+	tokenTypeSymbol:                  "symbol",
+	tokenTypeReference:               "identifier",
+	tokenTypeMethodName:              "identifier",
+	tokenTypeCapitalizedReference:    "constant",
+	tokenTypeNamespaceResolvedModule: "constant",
+	tokenTypeGlobal:                  "global",
+	tokenTypeNewline:                 "newline",
+	tokenTypeSemicolon:               "newline",
+	tokenTypeProcArg:                 "proc_arg",
+
+	tokenTypeLParen:         "punctuation",
+	tokenTypeRParen:         "punctuation",
+	tokenTypeLBracket:       "punctuation",
+	tokenTypeRBracket:       "punctuation",
+	tokenTypeLBrace:         "punctuation",
+	tokenTypeRBrace:         "punctuation",
+	tokenTypeComma:          "punctuation",
+	tokenTypeColon:          "punctuation",
+	tokenTypeDot:            "punctuation",
+	tokenTypeSafeNavigation: "punctuation",
+	tokenTypePipe:           "punctuation",
+	tokenTypeQuestionMark:   "punctuation",
+
+	tokenTypeLessThan:         "operator",
+	tokenTypeGreaterThan:      "operator",
+	tokenTypeEqual:            "operator",
+	tokenTypeBang:             "operator",
+	tokenTypeTilde:            "operator",
+	tokenTypeUnaryPlus:        "operator",
+	tokenTypeBinaryPlus:       "operator",
+	tokenTypeUnaryMinus:       "operator",
+	tokenTypeUnaryMinusNumber: "operator",
+	tokenTypeBinaryMinus:      "operator",
+	tokenTypeStar:             "operator",
+	tokenTypeDoubleStar:       "operator",
+	tokenTypeDollarSign:       "operator",
+	tokenTypeAtSign:           "operator",
+	tokenTypeRange:            "operator",
+	tokenTypeExclusiveRange:   "operator",
+	tokenTypeOrEquals:         "operator",
+	tokenTypeForwardSlash:     "operator",
+	tokenTypeAmpersand:        "operator",
+	tokenTypeOperator:         "operator",
+
+	tokenTypeDEF:          "keyword",
+	tokenTypeDO:           "keyword",
+	tokenTypeEND:          "keyword",
+	tokenTypeIF:           "keyword",
+	tokenTypeELSE:         "keyword",
+	tokenTypeELSIF:        "keyword",
+	tokenTypeUNLESS:       "keyword",
+	tokenTypeCLASS:        "keyword",
+	tokenTypeMODULE:       "keyword",
+	tokenTypeTRUE:         "keyword",
+	tokenTypeFALSE:        "keyword",
+	tokenTypeSELF:         "keyword",
+	tokenTypeNIL:          "keyword",
+	tokenTypeFOR:          "keyword",
+	tokenTypeWHILE:        "keyword",
+	tokenTypeUNTIL:        "keyword",
+	tokenTypeBEGIN:        "keyword",
+	tokenTypeRESCUE:       "keyword",
+	tokenTypeENSURE:       "keyword",
+	tokenTypeBREAK:        "keyword",
+	tokenTypeNEXT:         "keyword",
+	tokenTypeREDO:         "keyword",
+	tokenTypeRETRY:        "keyword",
+	tokenTypeRETURN:       "keyword",
+	tokenTypeYIELD:        "keyword",
+	tokenTypeDEFINED:      "keyword",
+	tokenTypeAND:          "keyword",
+	tokenTypeOR:           "keyword",
+	tokenTypeLAMBDA:       "keyword",
+	tokenTypeCASE:         "keyword",
+	tokenTypeWHEN:         "keyword",
+	tokenTypeIN:           "keyword",
+	tokenTypeALIAS:        "keyword",
+	tokenType__FILE__:     "keyword",
+	tokenType__LINE__:     "keyword",
+	tokenType__dir__:      "keyword",
+	tokenType__method__:   "keyword",
+	tokenType__ENCODING__: "keyword",
+}
+
+// Tokenize lexes the input without parsing it, returning every token up to
+// the end of the input. Lexing stops at the first input that can't be read
+// as a token, returning the tokens before it along with an error.
+func Tokenize(input string) ([]Token, error) {
+	lexer := NewLexer(input).(*ConcreteStatefulRubyLexer)
+
+	tokens := []Token{}
+	var err error
+	for t := range lexer.tokens {
+		if err != nil || t.typ == tokenTypeEOF {
+			continue
+		}
+
+		if t.typ == tokenTypeError {
+			err = fmt.Errorf("unexpected input at line %d, column %d: %q", t.pos.Line, t.pos.Column, t.value)
+			continue
+		}
+
+		tokens = append(tokens, Token{
+			Type:  tokenTypeNames[t.typ],
+			Value: t.value,
+			Pos:   t.pos,
+		})
+	}
+
+	return tokens, err
+}
@@ -0,0 +1,67 @@
+package parser_test
+
+import (
+	"github.com/grubby/grubby/ast"
+	"github.com/grubby/grubby/parser"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Tokenize", func() {
+	It("returns keywords, references and punctuation", func() {
+		tokens, err := parser.Tokenize("def foo(a)\nend")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(tokens).To(Equal([]parser.Token{
+			{Type: "keyword", Value: "def", Pos: ast.Position{Line: 1, Column: 1, Offset: 0}},
+			{Type: "identifier", Value: "foo", Pos: ast.Position{Line: 1, Column: 5, Offset: 4}},
+			{Type: "punctuation", Value: "(", Pos: ast.Position{Line: 1, Column: 8, Offset: 7}},
+			{Type: "identifier", Value: "a", Pos: ast.Position{Line: 1, Column: 9, Offset: 8}},
+			{Type: "punctuation", Value: ")", Pos: ast.Position{Line: 1, Column: 10, Offset: 9}},
+			{Type: "newline", Value: "\n", Pos: ast.Position{Line: 1, Column: 11, Offset: 10}},
+			{Type: "keyword", Value: "end", Pos: ast.Position{Line: 2, Column: 1, Offset: 11}},
+		}))
+	})
+
+	It("returns string and number literals", func() {
+		tokens, err := parser.Tokenize(`puts 'hi', "there", 42, 3.5`)
+		Expect(err).ToNot(HaveOccurred())
+
+		types := []string{}
+		values := []string{}
+		for _, token := range tokens {
+			types = append(types, token.Type)
+			values = append(values, token.Value)
+		}
+
+		Expect(types).To(Equal([]string{"identifier", "string", "punctuation", "string", "punctuation", "integer", "punctuation", "float"}))
+		Expect(values).To(Equal([]string{"puts", "hi", ",", "there", ",", "42", ",", "3.5"}))
+	})
+
+	It("returns operators", func() {
+		tokens, err := parser.Tokenize("x = 2 ** 3 + 1 >= y")
+		Expect(err).ToNot(HaveOccurred())
+
+		operators := []string{}
+		for _, token := range tokens {
+			if token.Type == "operator" {
+				operators = append(operators, token.Value)
+			}
+		}
+
+		Expect(operators).To(Equal([]string{"=", "**", "+", ">="}))
+	})
+
+	It("records the line each token starts on", func() {
+		tokens, err := parser.Tokenize("if x\n  :sym\nend\n")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(tokens[3]).To(Equal(parser.Token{Type: "symbol", Value: "sym", Pos: ast.Position{Line: 2, Column: 4, Offset: 8}}))
+		Expect(tokens[5].Pos.Line).To(Equal(3))
+	})
+
+	It("returns an error for input it can't lex", func() {
+		tokens, err := parser.Tokenize("x = `ls")
+		Expect(err).To(MatchError(`unexpected input at line 1, column 6: "ls"`))
+		Expect(tokens).To(HaveLen(2))
+	})
+})
@@ -1,15 +1,38 @@
 package parser
 
+import "strings"
+
 const whitespace = " \t"
 const newline = "\n"
 
 func lexNewlines(l StatefulRubyLexer) stateFn {
 	for l.accept(newline) {
-		l.emit(tokenTypeNewline)
+		if l.newlinesAreSignificant() && !nextLineStartsWithDot(l) {
+			l.emit(tokenTypeNewline)
+		} else {
+			l.ignore()
+		}
 	}
 	return lexSomething
 }
 
+// a line starting with .method (or &.method) continues the call chain on the
+// line before it, so the newline between them does not end the expression
+func nextLineStartsWithDot(l StatefulRubyLexer) bool {
+	index := l.currentIndex()
+	for index < l.lengthOfInput() && strings.ContainsAny(l.slice(index, index+1), whitespace+newline) {
+		index++
+	}
+
+	if index+1 < l.lengthOfInput() && l.slice(index, index+2) == "&." {
+		return true
+	}
+
+	// .. and ... start a beginless range instead
+	return index < l.lengthOfInput() && l.slice(index, index+1) == "." &&
+		(index+1 == l.lengthOfInput() || l.slice(index+1, index+2) != ".")
+}
+
 func lexWhiteSpaceIncludingNewlineAndComments(l StatefulRubyLexer) stateFn {
 	// accept any whitespace we might currently have at the end of the line
 	l.acceptRun(whitespace)
@@ -0,0 +1,40 @@
+package testhelpers
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/grubby/grubby/interpreter/vm"
+	"github.com/grubby/grubby/interpreter/vm/builtins"
+)
+
+// creates a file named counter.rb that records each time it is loaded
+// by adding to the $loads global, and returns the directory it lives in
+func SetupLoadPathWithAFileThatCountsLoads(vm vm.VM) string {
+	tempPath, err := ioutil.TempDir("", "")
+	if err != nil {
+		panic(err)
+	}
+
+	err = os.Mkdir(filepath.Join(tempPath, "nested"), 0700)
+	if err != nil {
+		panic(err)
+	}
+
+	err = ioutil.WriteFile(filepath.Join(tempPath, "counter.rb"), []byte(`
+$loads.unshift(:loaded)
+`), 0600)
+
+	if err != nil {
+		panic(err)
+	}
+
+	loadPathGlobal, err := vm.Get("LOAD_PATH")
+	if err != nil {
+		panic(err)
+	}
+
+	loadPathGlobal.(*builtins.Array).Append(builtins.NewString(tempPath, vm, vm))
+	return tempPath
+}
@@ -1,7 +1,7 @@
 package testhelpers
 
 import (
-	"bufio"
+	"io/ioutil"
 	"os"
 
 	. "github.com/onsi/gomega"
@@ -18,10 +18,9 @@ func SwapStdout(block func()) string {
 	os.Stdout = out
 
 	block()
+	out.Close()
 
-	reader := bufio.NewReader(in)
-	str, err := reader.ReadString('\n')
-
+	output, err := ioutil.ReadAll(in)
 	Expect(err).ToNot(HaveOccurred())
-	return str
+	return string(output)
 }